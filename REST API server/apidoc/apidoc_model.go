@@ -0,0 +1,193 @@
+// Package apidoc parses the REST endpoints and domain model annotations into an API model
+//
+// The endpoints are annotated with @Service, @Path, @RequestHeader, @ResourceGroup (endpoint structure) and with @Http,
+// @PathParam, @QueryParam, @BodyParam and @Return (handler methods). The domain model is annotated with @Entity, @Data,
+// @Enum and @EnumValuesFor. The API model is used to generate the OpenAPI document and the client libraries.
+package apidoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Api is the API model: the REST services (endpoints) and the domain model types
+type Api struct {
+	Services []*Service       // List of REST services (endpoints)
+	Types    map[string]*Type // Domain model types by name
+	Problems []string         // List of problems found while parsing (e.g. routes without annotations, unknown types)
+}
+
+// Service is a REST endpoint (group of REST methods)
+type Service struct {
+	Name     string    // Service name (@Service)
+	Type     string    // Endpoint structure name
+	Path     string    // Base path of the service (without the API version prefix)
+	Versions []string  // API versions of the service (@ApiVersion), the last one is the latest version
+	Group    string    // Resource group name (@ResourceGroup)
+	Doc      string    // Service description
+	Headers  []Param   // Request headers (@RequestHeader)
+	Methods  []*Method // List of the service REST methods
+}
+
+// VersionPath returns the full path of the service in the API version (e.g. /v2/users), unversioned services ignore the version
+func (s *Service) VersionPath(version string) string {
+	if len(s.Versions) == 0 || len(version) == 0 {
+		return s.Path
+	}
+	return joinPath("/"+version, s.Path)
+}
+
+// LatestVersion returns the latest API version of the service (empty for unversioned service)
+func (s *Service) LatestVersion() string {
+	if len(s.Versions) == 0 {
+		return ""
+	}
+	return s.Versions[len(s.Versions)-1]
+}
+
+// Method is a single REST method (route handler)
+type Method struct {
+	Name        string   // Handler function name
+	Doc         string   // Method description
+	HttpMethod  string   // HTTP verb
+	Path        string   // Method path relative to the service path (@Http) using {param} notation
+	PathParams  []Param  // Path parameters (@PathParam)
+	QueryParams []Param  // Query string parameters (@QueryParam)
+	BodyParams  []Param  // Body parameters (@BodyParam)
+	Return      *TypeRef // Return type (@Return)
+	Routes      []Route  // Actual routes of the handler (from RestEntries)
+}
+
+// Route is an actual route registered by the endpoint
+type Route struct {
+	HttpMethod string // HTTP verb
+	Path       string // Full path using {param} notation
+}
+
+// Param is a method parameter or a request header
+type Param struct {
+	Name        string   // Parameter name
+	Type        *TypeRef // Parameter type
+	Description string   // Parameter description
+}
+
+// TypeKind is the kind of domain model type
+type TypeKind string
+
+const (
+	KindEntity TypeKind = "entity" // Persistent entity (@Entity)
+	KindData   TypeKind = "data"   // Data structure (@Data)
+	KindEnum   TypeKind = "enum"   // Enum (@Enum)
+)
+
+// Type is a domain model type
+type Type struct {
+	Name    string      // Type name
+	Kind    TypeKind    // Type kind: entity | data | enum
+	Package string      // Model package folder (e.g. entities, common, enums)
+	Table   string      // Entity table name (@Entity)
+	Doc     string      // Type description
+	Fields  []Field     // Structure fields (entity / data)
+	Values  []EnumValue // Enum values (enum)
+}
+
+// IsFlags returns true for enums of flags combination (e.g. UserRoleFlag)
+func (t *Type) IsFlags() bool {
+	return t.Kind == KindEnum && strings.HasSuffix(t.Name, "Flag")
+}
+
+// Field is a structure field
+type Field struct {
+	Name string   // Go field name
+	Json string   // Json field name
+	Doc  string   // Field description
+	Type *TypeRef // Field type
+}
+
+// EnumValue is a single enum value
+type EnumValue struct {
+	Name  string // Value name
+	Value int    // Numeric value
+	Doc   string // Value description
+}
+
+// TypeRef is a reference to a type, including arrays, maps and generic types (e.g. []string, EntityResponse<User>)
+type TypeRef struct {
+	Name  string     // Type name (for arrays: the element type name, for maps: map)
+	Array bool       // Array of the type
+	Args  []*TypeRef // Generic type arguments (for maps: key and value)
+}
+
+// String returns the type reference in the annotation notation
+func (t *TypeRef) String() string {
+	result := t.Name
+	if len(t.Args) > 0 {
+		args := make([]string, 0, len(t.Args))
+		for _, arg := range t.Args {
+			args = append(args, arg.String())
+		}
+		result = fmt.Sprintf("%s<%s>", t.Name, strings.Join(args, ","))
+	}
+	if t.Array {
+		result = "[]" + result
+	}
+	return result
+}
+
+// Elem returns the element type of array type reference
+func (t *TypeRef) Elem() *TypeRef {
+	return &TypeRef{Name: t.Name, Args: t.Args}
+}
+
+// ParseTypeRef parses type reference annotation (e.g. string, []UserTypeCode, EntityResponse<TimeSeries<float64>>)
+func ParseTypeRef(value string) (*TypeRef, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return nil, fmt.Errorf("empty type")
+	}
+
+	ref := &TypeRef{}
+	if strings.HasPrefix(value, "[]") {
+		ref.Array = true
+		value = strings.TrimSpace(value[2:])
+	}
+
+	idx := strings.Index(value, "<")
+	if idx < 0 {
+		ref.Name = value
+		return ref, nil
+	}
+	if !strings.HasSuffix(value, ">") {
+		return nil, fmt.Errorf("invalid type: %s", value)
+	}
+
+	ref.Name = strings.TrimSpace(value[:idx])
+	for _, arg := range splitArgs(value[idx+1 : len(value)-1]) {
+		argRef, err := ParseTypeRef(arg)
+		if err != nil {
+			return nil, err
+		}
+		ref.Args = append(ref.Args, argRef)
+	}
+	return ref, nil
+}
+
+// Split generic type arguments by comma (not including commas of nested generic types)
+func splitArgs(value string) []string {
+	result := make([]string, 0)
+	depth, start := 0, 0
+	for i, r := range value {
+		switch r {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				result = append(result, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(result, value[start:])
+}
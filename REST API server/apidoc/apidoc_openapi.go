@@ -0,0 +1,321 @@
+package apidoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OpenAPI version of the generated document
+const openApiVersion = "3.1.0"
+
+// Security schemes by the request header name (@RequestHeader)
+var securitySchemes = map[string]map[string]any{
+	"X-API-KEY":     {"type": "apiKey", "in": "header", "name": "X-API-KEY", "description": "The key to identify the application"},
+	"Authorization": {"type": "apiKey", "in": "header", "name": "X-ACCESS-TOKEN", "description": "The access token of the logged-in user (renewed in the X-ACCESS-TOKEN response header)"},
+}
+
+// OpenAPI creates the OpenAPI 3.1 document of the API
+func (a *Api) OpenAPI(title, version string) map[string]any {
+
+	doc := map[string]any{
+		"openapi": openApiVersion,
+		"info":    map[string]any{"title": title, "version": version},
+		"servers": []any{map[string]any{"url": "/"}},
+	}
+
+	tags := make([]any, 0)
+	paths := make(map[string]any)
+	for _, svc := range a.Services {
+		tag := svc.Group
+		if len(tag) == 0 {
+			tag = svc.Name
+		}
+		tags = append(tags, map[string]any{"name": tag, "description": svc.Doc})
+
+		for _, method := range svc.Methods {
+			if len(method.HttpMethod) == 0 {
+				continue
+			}
+			path := joinPath(svc.Path, method.Path)
+			item, ok := paths[path].(map[string]any)
+			if !ok {
+				item = make(map[string]any)
+				paths[path] = item
+			}
+			if servers := versionServers(svc); servers != nil {
+				item["servers"] = servers
+			}
+			item[strings.ToLower(method.HttpMethod)] = a.operation(svc, method, tag)
+		}
+	}
+	doc["tags"] = tags
+	doc["paths"] = paths
+
+	schemas := make(map[string]any)
+	for _, name := range a.TypeNames() {
+		schemas[name] = a.typeSchema(a.Types[name])
+	}
+	schemas["ErrorResponse"] = objectSchema(map[string]any{
+		"code":  map[string]any{"type": "integer", "description": "Error code (0 for success)"},
+		"error": map[string]any{"type": "string", "description": "Error message"},
+	})
+
+	schemes := make(map[string]any)
+	for header, scheme := range securitySchemes {
+		schemes[schemeName(header)] = scheme
+	}
+	doc["components"] = map[string]any{"schemas": schemas, "securitySchemes": schemes}
+	return doc
+}
+
+// OpenAPIJson creates the OpenAPI 3.1 document of the API as formatted json
+func (a *Api) OpenAPIJson(title, version string) ([]byte, error) {
+	return json.MarshalIndent(a.OpenAPI(title, version), "", "  ")
+}
+
+// Create the operation object of the method
+func (a *Api) operation(svc *Service, method *Method, tag string) map[string]any {
+
+	summary, description, _ := strings.Cut(method.Doc, "\n")
+	op := map[string]any{
+		"operationId": fmt.Sprintf("%s.%s", svc.Name, method.Name),
+		"summary":     summary,
+		"tags":        []any{tag},
+	}
+	if len(description) > 0 {
+		op["description"] = description
+	}
+
+	params := make([]any, 0)
+	for _, p := range method.PathParams {
+		params = append(params, map[string]any{"name": p.Name, "in": "path", "required": true, "description": p.Description, "schema": a.schema(p.Type)})
+	}
+	for _, p := range method.QueryParams {
+		param := map[string]any{"name": p.Name, "in": "query", "description": p.Description, "schema": a.schema(p.Type)}
+		if p.Type.Array {
+			// Array values are provided as comma separated list or by repeating the parameter
+			param["style"], param["explode"] = "form", false
+		}
+		params = append(params, param)
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+
+	if body := a.requestBody(method); body != nil {
+		op["requestBody"] = body
+	}
+
+	security := make([]any, 0)
+	for _, header := range svc.Headers {
+		if _, ok := securitySchemes[header.Name]; ok {
+			security = append(security, map[string]any{schemeName(header.Name): []any{}})
+		}
+	}
+	op["security"] = security
+
+	errorContent := map[string]any{"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/ErrorResponse"}}}
+	op["responses"] = map[string]any{
+		"200": a.response(method.Return),
+		"400": map[string]any{"description": "Invalid request parameters", "content": errorContent},
+		"500": map[string]any{"description": "Service error", "content": errorContent},
+	}
+	return op
+}
+
+// Create the request body object of the method body parameters
+func (a *Api) requestBody(method *Method) map[string]any {
+	if len(method.BodyParams) == 0 {
+		return nil
+	}
+
+	body := method.BodyParams[0]
+	if body.Type.Name == "file" {
+		return map[string]any{
+			"description": body.Description,
+			"content": map[string]any{
+				"multipart/form-data": map[string]any{"schema": objectSchema(map[string]any{
+					body.Name: map[string]any{"type": "string", "format": "binary"},
+				})},
+				"application/octet-stream": map[string]any{"schema": map[string]any{"type": "string", "format": "binary"}},
+			},
+		}
+	}
+	return map[string]any{
+		"description": body.Description,
+		"required":    true,
+		"content":     map[string]any{"application/json": map[string]any{"schema": a.schema(body.Type)}},
+	}
+}
+
+// Create the success response object of the return type
+func (a *Api) response(ret *TypeRef) map[string]any {
+	if ret == nil {
+		return map[string]any{"description": "Success"}
+	}
+	if ret.Name == "file" {
+		return map[string]any{
+			"description": "File content",
+			"content":     map[string]any{"application/octet-stream": map[string]any{"schema": map[string]any{"type": "string", "format": "binary"}}},
+		}
+	}
+	if ret.Name == "stream" {
+		return map[string]any{
+			"description": ret.String() + " (Server-Sent Events, or WebSocket json messages on upgrade request)",
+			"content":     map[string]any{"text/event-stream": map[string]any{"schema": a.argSchema(ret, 0)}},
+		}
+	}
+	return map[string]any{
+		"description": ret.String(),
+		"content":     map[string]any{"application/json": map[string]any{"schema": a.schema(ret)}},
+	}
+}
+
+// Create the schema of a domain model type
+func (a *Api) typeSchema(t *Type) map[string]any {
+	if t.Kind == KindEnum {
+		names := make([]any, 0, len(t.Values))
+		values := make([]any, 0, len(t.Values))
+		lines := []string{t.Doc}
+		for _, v := range t.Values {
+			names = append(names, v.Name)
+			values = append(values, v.Value)
+			lines = append(lines, fmt.Sprintf("* %d - %s: %s", v.Value, v.Name, v.Doc))
+		}
+		schema := map[string]any{"type": "integer", "description": strings.Join(lines, "\n"), "x-enum-varnames": names}
+		if !t.IsFlags() {
+			schema["enum"] = values
+		}
+		return schema
+	}
+
+	props := make(map[string]any)
+	for _, field := range t.Fields {
+		schema := a.schema(field.Type)
+		if len(field.Doc) > 0 {
+			schema = withDescription(schema, field.Doc)
+		}
+		props[field.Json] = schema
+	}
+	schema := objectSchema(props)
+	schema["description"] = t.Doc
+	return schema
+}
+
+// Create the schema of a type reference
+func (a *Api) schema(ref *TypeRef) map[string]any {
+	if ref.Array {
+		return map[string]any{"type": "array", "items": a.schema(ref.Elem())}
+	}
+
+	switch ref.Name {
+	case "string":
+		return map[string]any{"type": "string"}
+	case "bool":
+		return map[string]any{"type": "boolean"}
+	case "int", "int32":
+		return map[string]any{"type": "integer", "format": "int32"}
+	case "int64":
+		return map[string]any{"type": "integer", "format": "int64"}
+	case "float32", "float64":
+		return map[string]any{"type": "number"}
+	case "Timestamp":
+		return map[string]any{"type": "integer", "format": "int64", "description": "Epoch milliseconds timestamp"}
+	case "Json", "any":
+		return map[string]any{"type": "object"}
+	case "file":
+		return map[string]any{"type": "string", "format": "binary"}
+	case "map":
+		if len(ref.Args) == 2 {
+			return map[string]any{"type": "object", "additionalProperties": a.schema(ref.Args[1])}
+		}
+		return map[string]any{"type": "object"}
+	case "ActionResponse":
+		return responseSchema(map[string]any{
+			"key":  map[string]any{"type": "string", "description": "The entity key (Id)"},
+			"data": map[string]any{"type": "string", "description": "Additional data"},
+		})
+	case "EntityResponse":
+		return responseSchema(map[string]any{"entity": a.argSchema(ref, 0)})
+	case "EntitiesResponse":
+		return responseSchema(map[string]any{
+			"page":  map[string]any{"type": "integer", "description": "Current page (Bulk) number"},
+			"size":  map[string]any{"type": "integer", "description": "Size of page (items in bulk)"},
+			"pages": map[string]any{"type": "integer", "description": "Total number of pages"},
+			"total": map[string]any{"type": "integer", "description": "Total number of items in the query"},
+			"list":  map[string]any{"type": "array", "items": a.argSchema(ref, 0)},
+		})
+	case "TimeFrame":
+		return objectSchema(map[string]any{"from": a.schema(&TypeRef{Name: "Timestamp"}), "to": a.schema(&TypeRef{Name: "Timestamp"})})
+	case "TimeSeries":
+		point := objectSchema(map[string]any{"timestamp": a.schema(&TypeRef{Name: "Timestamp"}), "value": a.argSchema(ref, 0)})
+		return objectSchema(map[string]any{
+			"name":   map[string]any{"type": "string", "description": "Name of the time series"},
+			"range":  a.schema(&TypeRef{Name: "TimeFrame"}),
+			"values": map[string]any{"type": "array", "items": point},
+		})
+	}
+
+	if _, known := a.Types[ref.Name]; known {
+		return map[string]any{"$ref": "#/components/schemas/" + ref.Name}
+	}
+	return map[string]any{"type": "object"}
+}
+
+// Get the schema of generic type argument
+func (a *Api) argSchema(ref *TypeRef, idx int) map[string]any {
+	if idx < len(ref.Args) {
+		return a.schema(ref.Args[idx])
+	}
+	return map[string]any{"type": "object"}
+}
+
+// Add description to schema, references are wrapped since sibling keywords of $ref are ignored by some viewers
+func withDescription(schema map[string]any, description string) map[string]any {
+	if _, isRef := schema["$ref"]; isRef {
+		return map[string]any{"allOf": []any{schema}, "description": description}
+	}
+	if existing, ok := schema["description"].(string); ok && len(existing) > 0 {
+		description = description + " (" + existing + ")"
+	}
+	schema["description"] = description
+	return schema
+}
+
+// Create object schema with the provided properties
+func objectSchema(props map[string]any) map[string]any {
+	return map[string]any{"type": "object", "properties": props}
+}
+
+// Create schema of REST response: the common response fields (code, error) and the provided properties
+func responseSchema(props map[string]any) map[string]any {
+	return map[string]any{"allOf": []any{
+		map[string]any{"$ref": "#/components/schemas/ErrorResponse"},
+		objectSchema(props),
+	}}
+}
+
+// Create the path level servers of versioned service: the version prefixes (latest version first)
+func versionServers(svc *Service) []any {
+	if len(svc.Versions) == 0 {
+		return nil
+	}
+	servers := make([]any, 0, len(svc.Versions))
+	for i := len(svc.Versions) - 1; i >= 0; i-- {
+		description := "API " + svc.Versions[i]
+		if i == len(svc.Versions)-1 {
+			description += " (latest)"
+		}
+		servers = append(servers, map[string]any{"url": "/" + svc.Versions[i], "description": description})
+	}
+	return servers
+}
+
+// Get the security scheme name of the request header
+func schemeName(header string) string {
+	if header == "Authorization" {
+		return "accessToken"
+	}
+	return "apiKey"
+}
@@ -0,0 +1,660 @@
+package apidoc
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Folders of the REST endpoints and the domain model (relative to the module root)
+var (
+	endpointFolders = []string{"rest", "rest/user"}
+	modelFolders    = []string{"model/common", "model/entities", "model/enums"}
+)
+
+// Types of the common library (yaaf-common) used by the domain model and the REST methods
+var builtinTypes = map[string]bool{
+	"string": true, "bool": true, "int": true, "int32": true, "int64": true, "float32": true, "float64": true,
+	"any": true, "Json": true, "Timestamp": true, "file": true, "stream": true, "map": true,
+	"EntityResponse": true, "EntitiesResponse": true, "ActionResponse": true, "TimeSeries": true, "TimeFrame": true,
+}
+
+// Fields of the common library base entity (BaseEntityEx) embedded in all the entities
+var baseEntityFields = []Field{
+	{Name: "Id", Json: "id", Doc: "Unique object Id", Type: &TypeRef{Name: "string"}},
+	{Name: "CreatedOn", Json: "createdOn", Doc: "When the object was created [Epoch milliseconds Timestamp]", Type: &TypeRef{Name: "Timestamp"}},
+	{Name: "UpdatedOn", Json: "updatedOn", Doc: "When the object was last updated [Epoch milliseconds Timestamp]", Type: &TypeRef{Name: "Timestamp"}},
+	{Name: "Flag", Json: "flag", Doc: "Entity status flag (e.g. -1 = deleted)", Type: &TypeRef{Name: "int64"}},
+	{Name: "Props", Json: "props", Doc: "List of custom properties", Type: &TypeRef{Name: "Json"}},
+}
+
+// Parse the REST endpoints and the domain model of the module in the root folder
+func Parse(root string) (*Api, error) {
+	api := &Api{Services: make([]*Service, 0), Types: make(map[string]*Type), Problems: make([]string, 0)}
+
+	for _, folder := range modelFolders {
+		files, err := parseFolder(filepath.Join(root, folder))
+		if err != nil {
+			return nil, err
+		}
+		api.parseModel(filepath.Base(folder), files)
+	}
+
+	for _, folder := range endpointFolders {
+		files, err := parseFolder(filepath.Join(root, folder))
+		if err != nil {
+			return nil, err
+		}
+		api.parseEndpoints(folder, files)
+	}
+
+	sort.Slice(api.Services, func(i, j int) bool { return api.Services[i].Path < api.Services[j].Path })
+	api.validate()
+	return api, nil
+}
+
+// TypeNames returns the sorted names of the domain model types
+func (a *Api) TypeNames() []string {
+	names := make([]string, 0, len(a.Types))
+	for name := range a.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse all the go files (excluding tests) of a folder
+func parseFolder(folder string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*ast.File, 0)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, er := parser.ParseFile(fset, filepath.Join(folder, name), nil, parser.ParseComments)
+		if er != nil {
+			return nil, er
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// region Annotations --------------------------------------------------------------------------------------------------
+
+// annotations of a declaration: the description lines and the list of annotation values by name
+type annotations struct {
+	doc    string
+	values map[string][]string
+}
+
+// Parse the doc comment to description and annotations (lines in the format: @Name: value)
+func parseAnnotations(group *ast.CommentGroup) annotations {
+	result := annotations{values: make(map[string][]string)}
+	if group == nil {
+		return result
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(group.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			if len(line) > 0 {
+				lines = append(lines, line)
+			}
+			continue
+		}
+		name, value, _ := strings.Cut(line[1:], ":")
+		name = strings.TrimSpace(name)
+		result.values[name] = append(result.values[name], strings.TrimSpace(value))
+	}
+	result.doc = strings.Join(lines, "\n")
+	return result
+}
+
+func (a annotations) has(name string) bool {
+	_, ok := a.values[name]
+	return ok
+}
+
+func (a annotations) get(name string) string {
+	if list := a.values[name]; len(list) > 0 {
+		return list[0]
+	}
+	return ""
+}
+
+// Parse comma separated list annotation (e.g. @ApiVersion: v1, v2)
+func (a annotations) list(name string) []string {
+	result := make([]string, 0)
+	for _, item := range strings.Split(a.get(name), ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Parse parameter annotations in the format: name | type | description
+func (a annotations) params(name string) ([]Param, error) {
+	result := make([]Param, 0)
+	for _, value := range a.values[name] {
+		parts := strings.SplitN(value, "|", 3)
+		for len(parts) < 3 {
+			parts = append(parts, "")
+		}
+		ref, err := ParseTypeRef(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("@%s: %s: %v", name, value, err)
+		}
+		result = append(result, Param{Name: strings.TrimSpace(parts[0]), Type: ref, Description: strings.TrimSpace(parts[2])})
+	}
+	return result, nil
+}
+
+// Parse header annotations in the format: name | description
+func (a annotations) headers(name string) []Param {
+	result := make([]Param, 0)
+	for _, value := range a.values[name] {
+		header, description, _ := strings.Cut(value, "|")
+		result = append(result, Param{Name: strings.TrimSpace(header), Type: &TypeRef{Name: "string"}, Description: strings.TrimSpace(description)})
+	}
+	return result
+}
+
+// endregion
+
+// region Model parser -------------------------------------------------------------------------------------------------
+
+// Parse the annotated types of a model package
+func (a *Api) parseModel(pkg string, files []*ast.File) {
+
+	enumValues := make(map[string][]EnumValue)
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc
+				if doc == nil {
+					doc = gen.Doc
+				}
+				ann := parseAnnotations(doc)
+
+				switch {
+				case ann.has("Entity"):
+					a.addStructType(pkg, ts, ann, KindEntity)
+				case ann.has("Data"):
+					a.addStructType(pkg, ts, ann, KindData)
+				case ann.has("Enum"):
+					a.Types[ts.Name.Name] = &Type{Name: ts.Name.Name, Kind: KindEnum, Package: pkg, Doc: ann.doc}
+				case ann.has("EnumValuesFor"):
+					if st, isStruct := ts.Type.(*ast.StructType); isStruct {
+						enumValues[ann.get("EnumValuesFor")] = parseEnumValues(st)
+					}
+				}
+			}
+		}
+	}
+
+	for name, values := range enumValues {
+		if t, ok := a.Types[name]; ok {
+			t.Values = values
+		} else {
+			a.problem("enum values for unknown enum %s", name)
+		}
+	}
+}
+
+// Add entity or data structure type
+func (a *Api) addStructType(pkg string, ts *ast.TypeSpec, ann annotations, kind TypeKind) {
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok {
+		a.problem("type %s is annotated as @%s but it is not a structure", ts.Name.Name, kind)
+		return
+	}
+	t := &Type{Name: ts.Name.Name, Kind: kind, Package: pkg, Table: ann.get("Entity"), Doc: ann.doc}
+	for _, field := range st.Fields.List {
+		ref := exprTypeRef(field.Type)
+		if len(field.Names) == 0 {
+			// Embedded structure
+			if ref.Name == "BaseEntityEx" || ref.Name == "BaseEntity" {
+				t.Fields = append(t.Fields, baseEntityFields...)
+			} else {
+				a.problem("type %s embeds unsupported type %s", t.Name, ref)
+			}
+			continue
+		}
+		jsonName := fieldJsonName(field)
+		if jsonName == "-" {
+			continue
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			json := jsonName
+			if len(json) == 0 {
+				json = name.Name
+			}
+			t.Fields = append(t.Fields, Field{Name: name.Name, Json: json, Doc: fieldDoc(field), Type: ref})
+		}
+	}
+	a.Types[t.Name] = t
+}
+
+// Parse the values of enum from the enum values structure (fields with value tag)
+func parseEnumValues(st *ast.StructType) []EnumValue {
+	values := make([]EnumValue, 0)
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) == 0 {
+			continue
+		}
+		tag, _ := strconv.Unquote(field.Tag.Value)
+		value, err := strconv.Atoi(reflect.StructTag(tag).Get("value"))
+		if err != nil {
+			continue
+		}
+		values = append(values, EnumValue{Name: field.Names[0].Name, Value: value, Doc: fieldDoc(field)})
+	}
+	sort.SliceStable(values, func(i, j int) bool { return values[i].Value < values[j].Value })
+	return values
+}
+
+// Get the json name of the field from the json tag
+func fieldJsonName(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, _ := strconv.Unquote(field.Tag.Value)
+	name, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	return name
+}
+
+// Get the field description from the trailing comment or the doc comment
+func fieldDoc(field *ast.Field) string {
+	if field.Comment != nil {
+		return strings.TrimSpace(field.Comment.Text())
+	}
+	if field.Doc != nil {
+		return strings.TrimSpace(field.Doc.Text())
+	}
+	return ""
+}
+
+// Convert go type expression to type reference
+func exprTypeRef(expr ast.Expr) *TypeRef {
+	switch v := expr.(type) {
+	case *ast.Ident:
+		return &TypeRef{Name: v.Name}
+	case *ast.SelectorExpr:
+		return &TypeRef{Name: v.Sel.Name}
+	case *ast.StarExpr:
+		return exprTypeRef(v.X)
+	case *ast.ArrayType:
+		elem := exprTypeRef(v.Elt)
+		if elem.Array {
+			// Nested arrays are described as arrays of any
+			return &TypeRef{Name: "any", Array: true}
+		}
+		elem.Array = true
+		return elem
+	case *ast.MapType:
+		return &TypeRef{Name: "map", Args: []*TypeRef{exprTypeRef(v.Key), exprTypeRef(v.Value)}}
+	case *ast.IndexExpr:
+		ref := exprTypeRef(v.X)
+		ref.Args = []*TypeRef{exprTypeRef(v.Index)}
+		return ref
+	}
+	return &TypeRef{Name: "any"}
+}
+
+// endregion
+
+// region Endpoints parser ---------------------------------------------------------------------------------------------
+
+// Parse the annotated endpoints of a REST package
+func (a *Api) parseEndpoints(folder string, files []*ast.File) {
+
+	consts := packageConstants(files)
+	services := make(map[string]*Service)
+	funcs := make(map[string]map[string]*ast.FuncDecl)
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				if d.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range d.Specs {
+					ts := spec.(*ast.TypeSpec)
+					doc := ts.Doc
+					if doc == nil {
+						doc = d.Doc
+					}
+					if ann := parseAnnotations(doc); ann.has("Service") {
+						services[ts.Name.Name] = &Service{
+							Name:     ann.get("Service"),
+							Type:     ts.Name.Name,
+							Group:    ann.get("ResourceGroup"),
+							Doc:      ann.doc,
+							Headers:  ann.headers("RequestHeader"),
+							Versions: ann.list("ApiVersion"),
+							Methods:  make([]*Method, 0),
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if recv := receiverType(d); len(recv) > 0 {
+					if funcs[recv] == nil {
+						funcs[recv] = make(map[string]*ast.FuncDecl)
+					}
+					funcs[recv][d.Name.Name] = d
+				}
+			}
+		}
+	}
+
+	for typeName, methods := range funcs {
+		if _, isEndpoint := methods["RestEntries"]; !isEndpoint {
+			continue
+		}
+		svc, ok := services[typeName]
+		if !ok {
+			a.problem("%s/%s: endpoint has no @Service annotation", folder, typeName)
+			svc = &Service{Name: typeName, Type: typeName, Methods: make([]*Method, 0)}
+		}
+		a.parseService(svc, methods, consts)
+		a.Services = append(a.Services, svc)
+	}
+}
+
+// Parse the service routes and the handler methods annotations
+func (a *Api) parseService(svc *Service, funcs map[string]*ast.FuncDecl, consts map[string]string) {
+
+	if pathFunc, ok := funcs["Path"]; ok {
+		svc.Path = evalPath(pathFunc, consts)
+	}
+
+	methods := make(map[string]*Method)
+	for _, entry := range restEntries(funcs["RestEntries"]) {
+		method, exists := methods[entry.handler]
+		if !exists {
+			method = &Method{Name: entry.handler}
+			methods[entry.handler] = method
+			svc.Methods = append(svc.Methods, method)
+			a.parseMethod(svc, method, funcs[entry.handler])
+		}
+		method.Routes = append(method.Routes, Route{HttpMethod: entry.method, Path: joinPath(svc.Path, toTemplate(entry.path))})
+	}
+
+	sort.SliceStable(svc.Methods, func(i, j int) bool {
+		if svc.Methods[i].Path == svc.Methods[j].Path {
+			return svc.Methods[i].HttpMethod < svc.Methods[j].HttpMethod
+		}
+		return svc.Methods[i].Path < svc.Methods[j].Path
+	})
+}
+
+// Parse the handler annotations
+func (a *Api) parseMethod(svc *Service, method *Method, decl *ast.FuncDecl) {
+	if decl == nil {
+		a.problem("%s.%s: handler not found", svc.Type, method.Name)
+		return
+	}
+
+	ann := parseAnnotations(decl.Doc)
+	method.Doc = ann.doc
+
+	if verb, path, ok := strings.Cut(ann.get("Http"), " "); ok {
+		method.HttpMethod = strings.ToUpper(strings.TrimSpace(verb))
+		method.Path = strings.TrimSpace(path)
+	}
+
+	var err error
+	if method.PathParams, err = ann.params("PathParam"); err != nil {
+		a.problem("%s.%s: %v", svc.Type, method.Name, err)
+	}
+	if method.QueryParams, err = ann.params("QueryParam"); err != nil {
+		a.problem("%s.%s: %v", svc.Type, method.Name, err)
+	}
+	if method.BodyParams, err = ann.params("BodyParam"); err != nil {
+		a.problem("%s.%s: %v", svc.Type, method.Name, err)
+	}
+	if ret := ann.get("Return"); len(ret) > 0 {
+		if method.Return, err = ParseTypeRef(ret); err != nil {
+			a.problem("%s.%s: @Return: %v", svc.Type, method.Name, err)
+		}
+	}
+}
+
+// restEntry is a single RestEntry composite literal
+type restEntry struct {
+	method, handler, path string
+}
+
+// Extract the RestEntry literals from the RestEntries function (commented out entries are ignored)
+func restEntries(decl *ast.FuncDecl) []restEntry {
+	result := make([]restEntry, 0)
+	if decl == nil {
+		return result
+	}
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		entry := restEntry{}
+		for _, elt := range lit.Elts {
+			kv, isKv := elt.(*ast.KeyValueExpr)
+			if !isKv {
+				continue
+			}
+			key, _ := kv.Key.(*ast.Ident)
+			if key == nil {
+				continue
+			}
+			switch key.Name {
+			case "Method":
+				if sel, isSel := kv.Value.(*ast.SelectorExpr); isSel {
+					entry.method = strings.ToUpper(strings.TrimPrefix(sel.Sel.Name, "Method"))
+				} else if bl, isLit := kv.Value.(*ast.BasicLit); isLit {
+					entry.method, _ = strconv.Unquote(bl.Value)
+				}
+			case "Handler":
+				if sel, isSel := kv.Value.(*ast.SelectorExpr); isSel {
+					entry.handler = sel.Sel.Name
+				}
+			case "Path":
+				if bl, isLit := kv.Value.(*ast.BasicLit); isLit {
+					entry.path, _ = strconv.Unquote(bl.Value)
+				}
+			}
+		}
+		if len(entry.method) > 0 && len(entry.handler) > 0 {
+			result = append(result, entry)
+			return false
+		}
+		return true
+	})
+	return result
+}
+
+// Get the name of the method receiver type
+func receiverType(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return ""
+	}
+	return exprTypeRef(decl.Recv.List[0].Type).Name
+}
+
+// Collect the string constants of the package
+func packageConstants(files []*ast.File) map[string]string {
+	result := make(map[string]string)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if i < len(vs.Values) {
+						if bl, isLit := vs.Values[i].(*ast.BasicLit); isLit && bl.Kind == token.STRING {
+							result[name.Name], _ = strconv.Unquote(bl.Value)
+						}
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Evaluate the string expression returned by the Path function (string literals, constants and concatenation)
+func evalPath(decl *ast.FuncDecl, consts map[string]string) string {
+	var eval func(expr ast.Expr) string
+	eval = func(expr ast.Expr) string {
+		switch v := expr.(type) {
+		case *ast.BasicLit:
+			s, _ := strconv.Unquote(v.Value)
+			return s
+		case *ast.Ident:
+			return consts[v.Name]
+		case *ast.BinaryExpr:
+			return eval(v.X) + eval(v.Y)
+		case *ast.ParenExpr:
+			return eval(v.X)
+		}
+		return ""
+	}
+
+	result := ""
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if ret, ok := n.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			result = eval(ret.Results[0])
+			return false
+		}
+		return true
+	})
+	return result
+}
+
+// Convert gin route path parameters (:id, *path) to template notation ({id})
+func toTemplate(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") || strings.HasPrefix(part, "*") {
+			parts[i] = "{" + part[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// Join the service path and the method path, the trailing slash is removed (except for the root path)
+func joinPath(base, path string) string {
+	result := strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(path, "/")
+	if len(result) > 1 {
+		result = strings.TrimSuffix(result, "/")
+	}
+	return result
+}
+
+// endregion
+
+// region Validation ---------------------------------------------------------------------------------------------------
+
+// Validate that all the routes are annotated and that all the referenced types are known
+func (a *Api) validate() {
+	for _, svc := range a.Services {
+		for _, method := range svc.Methods {
+			a.validateMethod(svc, method)
+		}
+	}
+	for _, name := range a.TypeNames() {
+		for _, field := range a.Types[name].Fields {
+			a.validateType(fmt.Sprintf("%s.%s", name, field.Name), field.Type)
+		}
+	}
+}
+
+// Validate the method annotations against its routes
+func (a *Api) validateMethod(svc *Service, method *Method) {
+	where := fmt.Sprintf("%s.%s", svc.Type, method.Name)
+	if len(method.HttpMethod) == 0 {
+		for _, route := range method.Routes {
+			a.problem("%s: route %s %s has no @Http annotation", where, route.HttpMethod, route.Path)
+		}
+		return
+	}
+
+	annotated := joinPath(svc.Path, method.Path)
+	for _, route := range method.Routes {
+		if route.HttpMethod != method.HttpMethod || route.Path != annotated {
+			a.problem("%s: route %s %s does not match the annotation @Http: %s %s", where, route.HttpMethod, route.Path, method.HttpMethod, method.Path)
+		}
+	}
+
+	declared := make(map[string]bool)
+	for _, param := range method.PathParams {
+		declared[param.Name] = true
+		if !strings.Contains(annotated, "{"+param.Name+"}") {
+			a.problem("%s: path parameter %s is not part of the path %s", where, param.Name, annotated)
+		}
+	}
+	for _, part := range strings.Split(annotated, "/") {
+		if name := strings.Trim(part, "{}"); len(name) == len(part)-2 && !declared[name] {
+			a.problem("%s: path parameter %s has no @PathParam annotation", where, name)
+		}
+	}
+	if method.Return == nil {
+		a.problem("%s: missing @Return annotation", where)
+	}
+
+	for _, list := range [][]Param{method.PathParams, method.QueryParams, method.BodyParams} {
+		for _, param := range list {
+			a.validateType(fmt.Sprintf("%s(%s)", where, param.Name), param.Type)
+		}
+	}
+	if method.Return != nil {
+		a.validateType(where+" @Return", method.Return)
+	}
+}
+
+// Validate that the type reference and its arguments are known types
+func (a *Api) validateType(where string, ref *TypeRef) {
+	if ref == nil {
+		return
+	}
+	if _, known := a.Types[ref.Name]; !known && !builtinTypes[ref.Name] {
+		a.problem("%s: unknown type %s", where, ref.Name)
+	}
+	for _, arg := range ref.Args {
+		a.validateType(where, arg)
+	}
+}
+
+func (a *Api) problem(format string, args ...any) {
+	a.Problems = append(a.Problems, fmt.Sprintf(format, args...))
+}
+
+// endregion
@@ -0,0 +1,127 @@
+package apidoc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Endpoint source with annotated and not annotated routes
+const testEndpoint = `package rest
+
+import "net/http"
+
+// ItemsEndPoint Items services
+// @Service: ItemsService
+// @Path: /items
+type ItemsEndPoint struct {
+	BaseEndPoint
+}
+
+func (h *ItemsEndPoint) Path() string {
+	return "/items"
+}
+
+func (h *ItemsEndPoint) RestEntries() (restEntries []RestEntry) {
+	return []RestEntry{
+		{Method: http.MethodGet, Handler: h.get, Path: "/:id"},
+		{Method: http.MethodDelete, Handler: h.delete, Path: "/:id"},
+		{Method: http.MethodPost, Handler: h.create, Path: "/"},
+	}
+}
+
+// Get item by id
+// @Http: GET /{id}
+// @PathParam: id | string | item ID
+// @Return: EntityResponse<Item>
+func (h *ItemsEndPoint) get(c *gin.Context) {}
+
+// Delete item (not annotated)
+func (h *ItemsEndPoint) delete(c *gin.Context) {}
+
+// Create item (annotation does not match the route)
+// @Http: PUT /
+// @BodyParam: body | Item | item to add
+// @Return: EntityResponse<Item>
+func (h *ItemsEndPoint) create(c *gin.Context) {}
+`
+
+// Domain model source of the test endpoint
+const testModel = `package model
+
+// Item entity
+// @Entity: item
+type Item struct {
+	Name string ` + "`json:\"name\"`" + ` // Item name
+}
+`
+
+// Create module tree in a temporary folder with the test endpoint and model
+func newTestModule(t *testing.T) string {
+	root := t.TempDir()
+	for _, folder := range append(endpointFolders, modelFolders...) {
+		if err := os.MkdirAll(filepath.Join(root, folder), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		"rest/user/items_endpoint.go": testEndpoint,
+		"model/entities/Item.go":      testModel,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestParseReportsAnnotationProblems(t *testing.T) {
+	api, err := Parse(newTestModule(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"ItemsEndPoint.delete: route DELETE /items/{id} has no @Http annotation",
+		"ItemsEndPoint.create: route POST /items does not match the annotation @Http: PUT /",
+	}
+	if len(api.Problems) != len(expected) {
+		t.Errorf("expected %d problems but got %d: %s", len(expected), len(api.Problems), strings.Join(api.Problems, "; "))
+	}
+	for _, problem := range expected {
+		found := false
+		for _, p := range api.Problems {
+			found = found || p == problem
+		}
+		if !found {
+			t.Errorf("missing problem: %s", problem)
+		}
+	}
+}
+
+func TestParseAnnotatedRoute(t *testing.T) {
+	api, err := Parse(newTestModule(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(api.Services) != 1 {
+		t.Fatalf("expected 1 service but got %d", len(api.Services))
+	}
+
+	svc := api.Services[0]
+	if svc.Name != "ItemsService" || svc.Path != "/items" {
+		t.Errorf("unexpected service %s %s", svc.Name, svc.Path)
+	}
+	for _, method := range svc.Methods {
+		if method.Name != "get" {
+			continue
+		}
+		if method.HttpMethod != "GET" || method.Path != "/{id}" || method.Return.String() != "EntityResponse<Item>" {
+			t.Errorf("unexpected method %s %s %s", method.HttpMethod, method.Path, method.Return)
+		}
+		return
+	}
+	t.Error("method get not found")
+}
@@ -0,0 +1,404 @@
+package apidoc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Header of the generated protobuf definitions file
+const protoHeader = "// Code generated by cmd/protogen from the model and endpoints annotations. DO NOT EDIT.\n"
+
+// Well known protobuf types used by the definitions
+const (
+	protoStruct = ".google.protobuf.Struct"
+	protoValue  = ".google.protobuf.Value"
+	protoEmpty  = ".google.protobuf.Empty"
+)
+
+// Query parameters of the REST find method which are not part of the gRPC find request (the results are streamed)
+var protoSkipParams = map[string]bool{"page": true, "size": true, "fields": true}
+
+// Service methods exposed by gRPC by the REST handler name (in the order of the generated RPCs)
+var protoMethods = []string{"create", "update", "delete", "get", "find"}
+
+// Source code info path elements (see descriptor.proto)
+const (
+	pathMessage      = 4
+	pathEnum         = 5
+	pathService      = 6
+	pathMessageField = 2
+	pathEnumValue    = 2
+	pathMethod       = 2
+)
+
+// region Protobuf definitions -----------------------------------------------------------------------------------------
+
+// Proto creates the protobuf definitions of the gRPC API: one service for each REST service with entity CRUD methods
+// (create, update, delete, get and server-streaming find), the messages of the entities and their data types and enums.
+// The enum values are prefixed by the enum name (protobuf scoping), flags enums are int64 fields, Json is Struct and
+// Timestamp is int64 (epoch milliseconds). The fields are numbered by the model declaration order
+func (a *Api) Proto(file, pkg, goPackage string) *descriptorpb.FileDescriptorProto {
+	b := &protoBuilder{api: a, pkg: pkg, types: make(map[string]bool), imports: make(map[string]bool)}
+	b.fd = &descriptorpb.FileDescriptorProto{
+		Name:           proto.String(file),
+		Package:        proto.String(pkg),
+		Syntax:         proto.String("proto3"),
+		Options:        &descriptorpb.FileOptions{GoPackage: proto.String(goPackage)},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+	}
+
+	b.fd.MessageType = append(b.fd.MessageType, &descriptorpb.DescriptorProto{
+		Name:  proto.String("IdRequest"),
+		Field: []*descriptorpb.FieldDescriptorProto{b.field("id", 1, &TypeRef{Name: "string"})},
+	})
+	b.comment("Request of entity by ID", pathMessage, 0)
+	b.comment("Entity ID", pathMessage, 0, pathMessageField, 0)
+
+	for _, svc := range a.Services {
+		b.service(svc)
+	}
+
+	// Model types referenced by the services (sorted for deterministic output)
+	for len(b.pending) > 0 {
+		sort.Strings(b.pending)
+		name := b.pending[0]
+		b.pending = b.pending[1:]
+		if t := a.Types[name]; t.Kind == KindEnum {
+			b.enum(t)
+		} else {
+			b.message(t)
+		}
+	}
+
+	for imp := range b.imports {
+		b.fd.Dependency = append(b.fd.Dependency, imp)
+	}
+	sort.Strings(b.fd.Dependency)
+	return b.fd
+}
+
+// Builder of the protobuf file descriptor
+type protoBuilder struct {
+	api     *Api
+	pkg     string
+	fd      *descriptorpb.FileDescriptorProto
+	types   map[string]bool // Model types added to the pending list
+	pending []string        // Model types to add as messages / enums
+	imports map[string]bool // Imported well known types files
+}
+
+// Add service of the REST service entity CRUD methods (services without CRUD methods are skipped)
+func (b *protoBuilder) service(svc *Service) {
+	methods := make(map[string]*Method)
+	for _, m := range svc.Methods {
+		methods[m.Name] = m
+	}
+
+	entity := ""
+	for _, name := range protoMethods {
+		if m, exists := methods[name]; exists && m.Return != nil && len(m.Return.Args) == 1 {
+			entity = m.Return.Args[0].Name
+			break
+		}
+	}
+	if t, known := b.api.Types[entity]; !known || t.Kind != KindEntity {
+		return
+	}
+	entityType := b.typeName(entity)
+	label := strings.ToLower(strings.Join(splitWords(entity), " "))
+
+	sd := &descriptorpb.ServiceDescriptorProto{Name: proto.String(svc.Name)}
+	serviceIdx := len(b.fd.Service)
+	b.comment(fmt.Sprintf("%s manages the %s entities (same service as the REST %s endpoints)", svc.Name, label, svc.Path), pathService, serviceIdx)
+
+	addMethod := func(name, input, output, doc string, streaming bool) {
+		b.comment(doc, pathService, serviceIdx, pathMethod, len(sd.Method))
+		md := &descriptorpb.MethodDescriptorProto{Name: proto.String(name), InputType: proto.String(input), OutputType: proto.String(output)}
+		if streaming {
+			md.ServerStreaming = proto.Bool(true)
+		}
+		sd.Method = append(sd.Method, md)
+	}
+
+	for _, name := range protoMethods {
+		m, exists := methods[name]
+		if !exists {
+			continue
+		}
+		switch name {
+		case "create":
+			addMethod("Create", entityType, entityType, fmt.Sprintf("Create a new %s", label), false)
+		case "update":
+			addMethod("Update", entityType, entityType, fmt.Sprintf("Update existing %s", label), false)
+		case "delete":
+			b.imports["google/protobuf/empty.proto"] = true
+			addMethod("Delete", b.localName("IdRequest"), protoEmpty, fmt.Sprintf("Delete %s by ID", label), false)
+		case "get":
+			addMethod("Get", b.localName("IdRequest"), entityType, fmt.Sprintf("Get %s by ID", label), false)
+		case "find":
+			request := "Find" + strings.TrimSuffix(svc.Name, "Service") + "Request"
+			b.findRequest(request, label, m)
+			addMethod("Find", b.localName(request), entityType, fmt.Sprintf("Find %s entities by query, all the matching entities are streamed (no pagination)", label), true)
+		}
+	}
+	b.fd.Service = append(b.fd.Service, sd)
+}
+
+// Add find request message of the REST find method query parameters
+func (b *protoBuilder) findRequest(name, label string, method *Method) {
+	idx := len(b.fd.MessageType)
+	msg := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+	b.comment(fmt.Sprintf("Query of %s entities", label), pathMessage, idx)
+	for _, param := range method.QueryParams {
+		if protoSkipParams[param.Name] {
+			continue
+		}
+		b.comment(param.Description, pathMessage, idx, pathMessageField, len(msg.Field))
+		msg.Field = append(msg.Field, b.field(param.Name, int32(len(msg.Field)+1), param.Type))
+	}
+	b.fd.MessageType = append(b.fd.MessageType, msg)
+}
+
+// Add message of entity or data type
+func (b *protoBuilder) message(t *Type) {
+	idx := len(b.fd.MessageType)
+	msg := &descriptorpb.DescriptorProto{Name: proto.String(t.Name)}
+	b.comment(t.Doc, pathMessage, idx)
+	for i, f := range t.Fields {
+		b.comment(f.Doc, pathMessage, idx, pathMessageField, i)
+		msg.Field = append(msg.Field, b.field(f.Json, int32(i+1), f.Type))
+	}
+	b.fd.MessageType = append(b.fd.MessageType, msg)
+}
+
+// Add enum, the values are prefixed by the enum name and the zero value is first (added when not defined)
+func (b *protoBuilder) enum(t *Type) {
+	idx := len(b.fd.EnumType)
+	prefix := strings.ToUpper(snakeCase(t.Name)) + "_"
+	ed := &descriptorpb.EnumDescriptorProto{Name: proto.String(t.Name)}
+	b.comment(t.Doc, pathEnum, idx)
+
+	values := append([]EnumValue{}, t.Values...)
+	sort.SliceStable(values, func(i, j int) bool { return values[i].Value == 0 && values[j].Value != 0 })
+	if len(values) == 0 || values[0].Value != 0 {
+		values = append([]EnumValue{{Name: "UNSPECIFIED", Value: 0, Doc: "Unspecified value"}}, values...)
+	}
+	for i, v := range values {
+		b.comment(v.Doc, pathEnum, idx, pathEnumValue, i)
+		ed.Value = append(ed.Value, &descriptorpb.EnumValueDescriptorProto{Name: proto.String(prefix + v.Name), Number: proto.Int32(int32(v.Value))})
+	}
+	b.fd.EnumType = append(b.fd.EnumType, ed)
+}
+
+// Create field of type reference, the field name is snake case of the json name (json name is kept)
+func (b *protoBuilder) field(jsonName string, number int32, ref *TypeRef) *descriptorpb.FieldDescriptorProto {
+	fd := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(snakeCase(jsonName)),
+		JsonName: proto.String(jsonName),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if ref.Array {
+		fd.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		ref = ref.Elem()
+	}
+
+	scalar := func(t descriptorpb.FieldDescriptorProto_Type) {
+		fd.Type = t.Enum()
+	}
+	named := func(t descriptorpb.FieldDescriptorProto_Type, name string) {
+		fd.Type, fd.TypeName = t.Enum(), proto.String(name)
+	}
+
+	switch ref.Name {
+	case "string":
+		scalar(descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	case "bool":
+		scalar(descriptorpb.FieldDescriptorProto_TYPE_BOOL)
+	case "int32":
+		scalar(descriptorpb.FieldDescriptorProto_TYPE_INT32)
+	case "int", "int64", "Timestamp":
+		scalar(descriptorpb.FieldDescriptorProto_TYPE_INT64)
+	case "float32":
+		scalar(descriptorpb.FieldDescriptorProto_TYPE_FLOAT)
+	case "float64":
+		scalar(descriptorpb.FieldDescriptorProto_TYPE_DOUBLE)
+	case "Json", "map":
+		b.imports["google/protobuf/struct.proto"] = true
+		named(descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, protoStruct)
+	default:
+		t, known := b.api.Types[ref.Name]
+		switch {
+		case !known:
+			b.imports["google/protobuf/struct.proto"] = true
+			named(descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, protoValue)
+		case t.IsFlags():
+			scalar(descriptorpb.FieldDescriptorProto_TYPE_INT64)
+		case t.Kind == KindEnum:
+			named(descriptorpb.FieldDescriptorProto_TYPE_ENUM, b.typeName(t.Name))
+		default:
+			named(descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, b.typeName(t.Name))
+		}
+	}
+	return fd
+}
+
+// Get the full name of model type, the type is added to the pending types
+func (b *protoBuilder) typeName(name string) string {
+	if !b.types[name] {
+		b.types[name] = true
+		b.pending = append(b.pending, name)
+	}
+	return b.localName(name)
+}
+
+// Get the full name of message in the package
+func (b *protoBuilder) localName(name string) string {
+	return "." + b.pkg + "." + name
+}
+
+// Add leading comment of the declaration path
+func (b *protoBuilder) comment(doc string, path ...int) {
+	if doc = strings.TrimSpace(doc); len(doc) == 0 {
+		return
+	}
+	location := make([]int32, 0, len(path))
+	for _, p := range path {
+		location = append(location, int32(p))
+	}
+	b.fd.SourceCodeInfo.Location = append(b.fd.SourceCodeInfo.Location, &descriptorpb.SourceCodeInfo_Location{
+		Path:            location,
+		Span:            []int32{0, 0, 0},
+		LeadingComments: proto.String(" " + strings.ReplaceAll(doc, "\n", "\n ") + "\n"),
+	})
+}
+
+// endregion
+
+// region Protobuf text ------------------------------------------------------------------------------------------------
+
+// ProtoText renders the protobuf file descriptor as .proto source (including the leading comments)
+func ProtoText(fd *descriptorpb.FileDescriptorProto) []byte {
+	comments := make(map[string]string)
+	for _, loc := range fd.GetSourceCodeInfo().GetLocation() {
+		comments[fmt.Sprint(loc.GetPath())] = loc.GetLeadingComments()
+	}
+	writeComment := func(sb *strings.Builder, indent string, path ...int32) {
+		text, exists := comments[fmt.Sprint(path)]
+		if !exists {
+			return
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+			sb.WriteString(indent + "//" + line + "\n")
+		}
+	}
+	pkg := "." + fd.GetPackage() + "."
+
+	sb := &strings.Builder{}
+	sb.WriteString(protoHeader + "\n")
+	sb.WriteString(fmt.Sprintf("syntax = \"%s\";\n\npackage %s;\n\n", fd.GetSyntax(), fd.GetPackage()))
+	for _, imp := range fd.GetDependency() {
+		sb.WriteString(fmt.Sprintf("import \"%s\";\n", imp))
+	}
+	sb.WriteString(fmt.Sprintf("\noption go_package = \"%s\";\n", fd.GetOptions().GetGoPackage()))
+
+	for i, sd := range fd.GetService() {
+		sb.WriteString("\n")
+		writeComment(sb, "", pathService, int32(i))
+		sb.WriteString(fmt.Sprintf("service %s {\n", sd.GetName()))
+		for j, md := range sd.GetMethod() {
+			writeComment(sb, "  ", pathService, int32(i), pathMethod, int32(j))
+			stream := ""
+			if md.GetServerStreaming() {
+				stream = "stream "
+			}
+			sb.WriteString(fmt.Sprintf("  rpc %s(%s) returns (%s%s);\n", md.GetName(), protoRef(md.GetInputType(), pkg), stream, protoRef(md.GetOutputType(), pkg)))
+		}
+		sb.WriteString("}\n")
+	}
+
+	for i, msg := range fd.GetMessageType() {
+		sb.WriteString("\n")
+		writeComment(sb, "", pathMessage, int32(i))
+		sb.WriteString(fmt.Sprintf("message %s {\n", msg.GetName()))
+		for j, f := range msg.GetField() {
+			writeComment(sb, "  ", pathMessage, int32(i), pathMessageField, int32(j))
+			label := ""
+			if f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+				label = "repeated "
+			}
+			typeName := strings.ToLower(strings.TrimPrefix(f.GetType().String(), "TYPE_"))
+			if len(f.GetTypeName()) > 0 {
+				typeName = protoRef(f.GetTypeName(), pkg)
+			}
+			jsonName := ""
+			if f.GetJsonName() != protoJsonName(f.GetName()) {
+				jsonName = fmt.Sprintf(" [json_name = \"%s\"]", f.GetJsonName())
+			}
+			sb.WriteString(fmt.Sprintf("  %s%s %s = %d%s;\n", label, typeName, f.GetName(), f.GetNumber(), jsonName))
+		}
+		sb.WriteString("}\n")
+	}
+
+	for i, ed := range fd.GetEnumType() {
+		sb.WriteString("\n")
+		writeComment(sb, "", pathEnum, int32(i))
+		sb.WriteString(fmt.Sprintf("enum %s {\n", ed.GetName()))
+		for j, v := range ed.GetValue() {
+			writeComment(sb, "  ", pathEnum, int32(i), pathEnumValue, int32(j))
+			sb.WriteString(fmt.Sprintf("  %s = %d;\n", v.GetName(), v.GetNumber()))
+		}
+		sb.WriteString("}\n")
+	}
+	return []byte(sb.String())
+}
+
+// Get the type reference in the .proto source: local types without the package, well known types without the dot
+func protoRef(name, pkg string) string {
+	if strings.HasPrefix(name, pkg) {
+		return strings.TrimPrefix(name, pkg)
+	}
+	return strings.TrimPrefix(name, ".")
+}
+
+// Get the default json name of protobuf field name (lower camel case)
+func protoJsonName(name string) string {
+	sb := strings.Builder{}
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r, upper = unicode.ToUpper(r), false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// Convert camel case name to snake case (e.g. createdOn -> created_on, AccountTypeCode -> account_type_code)
+func snakeCase(name string) string {
+	return strings.ToLower(strings.Join(splitWords(name), "_"))
+}
+
+// Split camel case name to words (e.g. UsersGroup -> Users, Group)
+func splitWords(name string) (words []string) {
+	start := 0
+	runes := []rune(name)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
+
+// endregion
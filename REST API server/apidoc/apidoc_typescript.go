@@ -0,0 +1,497 @@
+package apidoc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Header of all the generated TypeScript files
+const tsHeader = "// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.\n\n"
+
+// TypeScript creates the TypeScript / Angular client library files: the model interfaces and enums (one file per model
+// package), the REST responses, the base REST client and one Angular service per endpoint.
+// The result is a map of relative file path -> content, the output is deterministic (sorted, no timestamps)
+func (a *Api) TypeScript() map[string][]byte {
+	files := make(map[string][]byte)
+
+	packages := make(map[string][]*Type)
+	for _, name := range a.TypeNames() {
+		t := a.Types[name]
+		packages[t.Package] = append(packages[t.Package], t)
+	}
+
+	exports := []string{"./model/responses", "./rest-api.client"}
+	for pkg, types := range packages {
+		files["model/"+pkg+".ts"] = []byte(a.tsModelFile(pkg, types))
+		exports = append(exports, "./model/"+pkg)
+	}
+	files["model/responses.ts"] = []byte(tsHeader + tsResponses)
+	files["rest-api.client.ts"] = []byte(tsHeader + tsRestClient)
+
+	for _, svc := range a.Services {
+		name := kebabCase(strings.TrimSuffix(svc.Name, "Service")) + ".service"
+		files["services/"+name+".ts"] = []byte(a.tsServiceFile(svc))
+		exports = append(exports, "./services/"+name)
+	}
+
+	sort.Strings(exports)
+	index := strings.Builder{}
+	index.WriteString(tsHeader)
+	for _, export := range exports {
+		index.WriteString(fmt.Sprintf("export * from '%s';\n", export))
+	}
+	files["index.ts"] = []byte(index.String())
+	return files
+}
+
+// region Model --------------------------------------------------------------------------------------------------------
+
+// Create model package file: interfaces of entities / data structures and enum objects
+func (a *Api) tsModelFile(pkg string, types []*Type) string {
+	body := strings.Builder{}
+	imports := make(map[string]map[string]bool)
+
+	for _, t := range types {
+		body.WriteString("\n")
+		if t.Kind == KindEnum {
+			a.tsEnum(&body, t)
+			continue
+		}
+		writeJsDoc(&body, "", t.Doc)
+		body.WriteString(fmt.Sprintf("export interface %s {\n", t.Name))
+		for _, field := range t.Fields {
+			writeJsDoc(&body, "  ", field.Doc)
+			body.WriteString(fmt.Sprintf("  %s: %s;\n", field.Json, a.tsType(field.Type, pkg, imports)))
+		}
+		body.WriteString("}\n")
+	}
+	return tsHeader + strings.TrimPrefix(tsImports(imports, "./")+body.String(), "\n")
+}
+
+// Write enum type, values object and names map
+func (a *Api) tsEnum(sb *strings.Builder, t *Type) {
+	writeJsDoc(sb, "", t.Doc)
+	sb.WriteString(fmt.Sprintf("export type %s = number;\n\n", t.Name))
+
+	sb.WriteString(fmt.Sprintf("/** %s values by name */\n", t.Name))
+	sb.WriteString(fmt.Sprintf("export const %s = {\n", pluralName(t.Name)))
+	for _, v := range t.Values {
+		writeJsDoc(sb, "  ", v.Doc)
+		sb.WriteString(fmt.Sprintf("  %s: %d,\n", v.Name, v.Value))
+	}
+	sb.WriteString("} as const;\n\n")
+
+	sb.WriteString(fmt.Sprintf("/** %s names by value */\n", t.Name))
+	sb.WriteString(fmt.Sprintf("export const %sNames: { [value: number]: string } = {\n", t.Name))
+	for _, v := range t.Values {
+		sb.WriteString(fmt.Sprintf("  %d: '%s',\n", v.Value, v.Name))
+	}
+	sb.WriteString("};\n")
+}
+
+// Get the TypeScript type of type reference, the imports of the model types from other packages are collected
+func (a *Api) tsType(ref *TypeRef, pkg string, imports map[string]map[string]bool) string {
+	if ref.Array {
+		elem := a.tsType(ref.Elem(), pkg, imports)
+		if strings.ContainsAny(elem, " |") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	}
+
+	switch ref.Name {
+	case "string":
+		return "string"
+	case "bool":
+		return "boolean"
+	case "int", "int32", "int64", "float32", "float64", "Timestamp":
+		return "number"
+	case "Json":
+		return "{ [key: string]: any }"
+	case "any":
+		return "any"
+	case "file":
+		return "Blob"
+	case "stream":
+		if len(ref.Args) == 1 {
+			return a.tsType(ref.Args[0], pkg, imports)
+		}
+		return "any"
+	case "map":
+		if len(ref.Args) == 2 {
+			return fmt.Sprintf("{ [key: string]: %s }", a.tsType(ref.Args[1], pkg, imports))
+		}
+		return "{ [key: string]: any }"
+	}
+
+	name := ref.Name
+	if t, known := a.Types[name]; known && t.Package != pkg {
+		addImport(imports, "model/"+t.Package, name)
+	} else if !known && builtinTypes[name] {
+		addImport(imports, "model/responses", name)
+	}
+	if len(ref.Args) > 0 {
+		args := make([]string, 0, len(ref.Args))
+		for _, arg := range ref.Args {
+			args = append(args, a.tsType(arg, pkg, imports))
+		}
+		name = fmt.Sprintf("%s<%s>", name, strings.Join(args, ", "))
+	}
+	return name
+}
+
+// endregion
+
+// region Services -----------------------------------------------------------------------------------------------------
+
+// Create Angular service file of the endpoint
+func (a *Api) tsServiceFile(svc *Service) string {
+	body := strings.Builder{}
+	imports := make(map[string]map[string]bool)
+	addImport(imports, "@angular/core", "Injectable")
+	addImport(imports, "rxjs", "Observable")
+	addImport(imports, "rest-api.client", "RestApiClient")
+
+	body.WriteString("\n")
+	writeJsDoc(&body, "", svc.Doc)
+	body.WriteString("@Injectable({ providedIn: 'root' })\n")
+	body.WriteString(fmt.Sprintf("export class %s {\n\n", svc.Name))
+	body.WriteString("  constructor(private api: RestApiClient) {}\n")
+
+	for _, method := range svc.Methods {
+		if len(method.HttpMethod) == 0 {
+			continue
+		}
+		body.WriteString("\n")
+		a.tsServiceMethod(&body, svc, method, imports)
+	}
+	body.WriteString("}\n")
+
+	return tsHeader + tsImports(imports, "../") + body.String()
+}
+
+// Write single service method
+func (a *Api) tsServiceMethod(sb *strings.Builder, svc *Service, method *Method, imports map[string]map[string]bool) {
+
+	args := make([]string, 0)
+	docs := []string{method.Doc}
+	for _, p := range method.PathParams {
+		args = append(args, fmt.Sprintf("%s: %s", p.Name, a.tsType(p.Type, "", imports)))
+		docs = append(docs, fmt.Sprintf("@param %s %s", p.Name, p.Description))
+	}
+
+	body := "undefined"
+	if len(method.BodyParams) > 0 {
+		p := method.BodyParams[0]
+		args = append(args, fmt.Sprintf("%s: %s", p.Name, a.tsType(p.Type, "", imports)))
+		docs = append(docs, fmt.Sprintf("@param %s %s", p.Name, p.Description))
+		body = p.Name
+		if p.Type.Name == "file" {
+			body = fmt.Sprintf("this.api.formData('%s', %s)", p.Name, p.Name)
+		}
+	}
+
+	params := "undefined"
+	if len(method.QueryParams) > 0 {
+		fields := make([]string, 0, len(method.QueryParams))
+		for _, p := range method.QueryParams {
+			fields = append(fields, fmt.Sprintf("%s?: %s", p.Name, a.tsType(p.Type, "", imports)))
+			docs = append(docs, fmt.Sprintf("@param params.%s %s", p.Name, p.Description))
+		}
+		args = append(args, fmt.Sprintf("params?: { %s }", strings.Join(fields, "; ")))
+		params = "params"
+	}
+
+	returnType, responseType := "Blob", "blob"
+	if method.Return != nil && method.Return.Name != "file" {
+		returnType, responseType = a.tsType(method.Return, "", imports), "json"
+	}
+
+	// The client uses the latest API version of the service
+	fullPath := joinPath(svc.VersionPath(svc.LatestVersion()), method.Path)
+	path := "'" + fullPath + "'"
+	if len(method.PathParams) > 0 {
+		path = "`" + fullPath + "`"
+		for _, p := range method.PathParams {
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", "${encodeURIComponent("+p.Name+")}")
+		}
+	}
+
+	writeJsDoc(sb, "  ", strings.Join(docs, "\n"))
+	sb.WriteString(fmt.Sprintf("  %s(%s): Observable<%s> {\n", method.Name, strings.Join(args, ", "), returnType))
+	if method.Return != nil && method.Return.Name == "stream" {
+		// Server-Sent Events stream (GET only)
+		sb.WriteString(fmt.Sprintf("    return this.api.events<%s>(%s, %s);\n", returnType, path, params))
+	} else {
+		sb.WriteString(fmt.Sprintf("    return this.api.request<%s>('%s', %s, %s, %s, '%s');\n", returnType, method.HttpMethod, path, params, body, responseType))
+	}
+	sb.WriteString("  }\n")
+}
+
+// endregion
+
+// region Helpers ------------------------------------------------------------------------------------------------------
+
+// Add named import from module (relative path of generated file or package name)
+func addImport(imports map[string]map[string]bool, module, name string) {
+	if imports[module] == nil {
+		imports[module] = make(map[string]bool)
+	}
+	imports[module][name] = true
+}
+
+// Create sorted import statements, generated modules are resolved relative to the importing file folder
+func tsImports(imports map[string]map[string]bool, prefix string) string {
+	modules := make([]string, 0, len(imports))
+	for module := range imports {
+		modules = append(modules, module)
+	}
+	// External modules first, then the generated modules
+	sort.Slice(modules, func(i, j int) bool {
+		if isExternal(modules[i]) != isExternal(modules[j]) {
+			return isExternal(modules[i])
+		}
+		return modules[i] < modules[j]
+	})
+
+	sb := strings.Builder{}
+	for _, module := range modules {
+		names := make([]string, 0, len(imports[module]))
+		for name := range imports[module] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		path := module
+		if !isExternal(module) {
+			path = relativeModule(prefix, module)
+		}
+		sb.WriteString(fmt.Sprintf("import { %s } from '%s';\n", strings.Join(names, ", "), path))
+	}
+	return sb.String()
+}
+
+// Check if the module is an external package (e.g. @angular/core, rxjs) and not a generated module
+func isExternal(module string) bool {
+	return strings.HasPrefix(module, "@") || module == "rxjs"
+}
+
+// Resolve generated module path relative to the importing file folder (./ for model files, ../ for services)
+func relativeModule(prefix, module string) string {
+	if prefix == "./" {
+		return "./" + strings.TrimPrefix(module, "model/")
+	}
+	return prefix + module
+}
+
+// Write JSDoc comment
+func writeJsDoc(sb *strings.Builder, indent, doc string) {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(doc, "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			lines = append(lines, strings.ReplaceAll(line, "*/", "* /"))
+		}
+	}
+	switch len(lines) {
+	case 0:
+		return
+	case 1:
+		sb.WriteString(fmt.Sprintf("%s/** %s */\n", indent, lines[0]))
+	default:
+		sb.WriteString(indent + "/**\n")
+		for _, line := range lines {
+			sb.WriteString(fmt.Sprintf("%s * %s\n", indent, line))
+		}
+		sb.WriteString(indent + " */\n")
+	}
+}
+
+// Convert PascalCase name to kebab-case (e.g. AuditLogs -> audit-logs)
+func kebabCase(name string) string {
+	sb := strings.Builder{}
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			sb.WriteRune('-')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+// Get the name of the enum values object, same as the Go enum values (e.g. UserStatusCode -> UserStatusCodes)
+func pluralName(name string) string {
+	return name + "s"
+}
+
+// endregion
+
+// region Static files -------------------------------------------------------------------------------------------------
+
+// REST responses of the common library
+const tsResponses = `/** Common fields of all the REST responses */
+export interface BaseRestResponse {
+  /** Error code (0 for success) */
+  code: number;
+  /** Error message */
+  error?: string;
+}
+
+/** Response with a single entity */
+export interface EntityResponse<T> extends BaseRestResponse {
+  entity: T;
+}
+
+/** Response with a page of entities */
+export interface EntitiesResponse<T> extends BaseRestResponse {
+  /** Current page (Bulk) number */
+  page: number;
+  /** Size of page (items in bulk) */
+  size: number;
+  /** Total number of pages */
+  pages: number;
+  /** Total number of items in the query */
+  total: number;
+  /** List of objects in the current result set */
+  list: T[];
+}
+
+/** Response of action with no return data (e.g. delete) */
+export interface ActionResponse extends BaseRestResponse {
+  /** The entity key (Id) */
+  key?: string;
+  /** Additional data */
+  data?: string;
+}
+
+/** Time range [Epoch milliseconds Timestamp] */
+export interface TimeFrame {
+  from: number;
+  to: number;
+}
+
+/** Time series data point */
+export interface TimeDataPoint<T> {
+  timestamp: number;
+  value: T;
+}
+
+/** Time series */
+export interface TimeSeries<T> {
+  name: string;
+  range: TimeFrame;
+  values: TimeDataPoint<T>[];
+}
+`
+
+// Base REST client: sends the API key and access token headers and keeps the renewed access token
+const tsRestClient = `import { HttpClient, HttpHeaders, HttpParams, HttpResponse } from '@angular/common/http';
+import { Inject, Injectable, InjectionToken } from '@angular/core';
+import { Observable } from 'rxjs';
+import { map } from 'rxjs/operators';
+
+/** REST API client configuration */
+export interface RestApiConfig {
+  /** Base URL of the REST API server (e.g. https://api.example.com) */
+  baseUrl: string;
+  /** The key to identify the application (X-API-KEY header) */
+  apiKey: string;
+  /** Storage of the access token (default: sessionStorage) */
+  storage?: Storage;
+}
+
+/** Injection token of the REST API client configuration */
+export const REST_API_CONFIG = new InjectionToken<RestApiConfig>('REST_API_CONFIG');
+
+const ACCESS_TOKEN_KEY = 'rest-api.access-token';
+
+/**
+ * Base REST client used by all the services: adds the X-API-KEY and X-ACCESS-TOKEN headers to each request
+ * and keeps the renewed access token returned by the server in the X-ACCESS-TOKEN response header
+ */
+@Injectable({ providedIn: 'root' })
+export class RestApiClient {
+
+  constructor(private http: HttpClient, @Inject(REST_API_CONFIG) private config: RestApiConfig) {}
+
+  /** Current access token (empty when not logged-in) */
+  get accessToken(): string {
+    return this.storage.getItem(ACCESS_TOKEN_KEY) || '';
+  }
+
+  set accessToken(token: string) {
+    if (token) {
+      this.storage.setItem(ACCESS_TOKEN_KEY, token);
+    } else {
+      this.storage.removeItem(ACCESS_TOKEN_KEY);
+    }
+  }
+
+  /** Send request and return the response body, array query params are sent as comma separated values */
+  request<T>(method: string, path: string, params?: { [name: string]: any }, body?: any, responseType: 'json' | 'blob' = 'json'): Observable<T> {
+    let httpParams = new HttpParams();
+    for (const [name, value] of Object.entries(params || {})) {
+      if (value !== undefined && value !== null) {
+        httpParams = httpParams.set(name, Array.isArray(value) ? value.join(',') : String(value));
+      }
+    }
+
+    let headers = new HttpHeaders({ 'X-API-KEY': this.config.apiKey, 'X-TIMEZONE-OFFSET': String(new Date().getTimezoneOffset()) });
+    if (this.accessToken) {
+      headers = headers.set('X-ACCESS-TOKEN', this.accessToken);
+    }
+
+    return this.http.request(method, this.config.baseUrl + path, { body, headers, params: httpParams, observe: 'response', responseType: responseType as any }).pipe(
+      map((response: HttpResponse<any>) => {
+        const token = response.headers.get('X-ACCESS-TOKEN');
+        if (token) {
+          this.accessToken = token;
+        }
+        return response.body as T;
+      })
+    );
+  }
+
+  /**
+   * Subscribe to Server-Sent Events stream, the API key and access token are sent as query params since EventSource
+   * can't set request headers. The browser reconnects on connection loss and resumes from the last event ID
+   */
+  events<T>(path: string, params?: { [name: string]: any }): Observable<T> {
+    return new Observable<T>(subscriber => {
+      const query = new URLSearchParams({ apiKey: this.config.apiKey });
+      if (this.accessToken) {
+        query.set('accessToken', this.accessToken);
+      }
+      for (const [name, value] of Object.entries(params || {})) {
+        if (value !== undefined && value !== null) {
+          query.set(name, Array.isArray(value) ? value.join(',') : String(value));
+        }
+      }
+
+      const source = new EventSource(this.config.baseUrl + path + '?' + query.toString());
+      source.onmessage = (event: MessageEvent) => subscriber.next(JSON.parse(event.data) as T);
+      source.onerror = () => {
+        // Closed by the browser (e.g. rejected request), otherwise the browser reconnects
+        if (source.readyState === EventSource.CLOSED) {
+          subscriber.error(new Error('event stream closed'));
+        }
+      };
+      return () => source.close();
+    });
+  }
+
+  /** Create multipart form data with a single file field */
+  formData(name: string, file: Blob): FormData {
+    const data = new FormData();
+    data.append(name, file);
+    return data;
+  }
+
+  private get storage(): Storage {
+    return this.config.storage || sessionStorage;
+  }
+}
+`
+
+// endregion
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// AccountsClient is the client of the accounts endpoint
+type AccountsClient struct {
+	resource[*Account]
+}
+
+// AccountsFindParams are the accounts query parameters
+type AccountsFindParams struct {
+	Search string              // Filter by free text search (using * wildcard)
+	Filter string              // Filter expression (e.g. status in (ACTIVE,BLOCKED) and name like 'acme*')
+	Status []AccountStatusCode // Filter by status(es)
+	Sort   string              // Sort descriptor (field name with suffix +/- for sort order)
+	Page   int                 // Page number for pagination
+	Size   int                 // Page size: number of items per page
+	Fields []string            // Sparse fieldset: list of fields (json paths) to include in the results
+}
+
+func (p AccountsFindParams) values() url.Values {
+	query := url.Values{}
+	setString(query, "search", p.Search)
+	setString(query, "filter", p.Filter)
+	setEnums(query, "status", p.Status)
+	setString(query, "sort", p.Sort)
+	setInt(query, "page", p.Page)
+	setInt(query, "size", p.Size)
+	setStrings(query, "fields", p.Fields)
+	return query
+}
+
+// Find accounts by query
+func (r *AccountsClient) Find(ctx context.Context, p AccountsFindParams) (*EntitiesResponse[*Account], error) {
+	return r.find(ctx, p.values())
+}
+
+// Export accounts by query to the writer in the provided format (csv, ndjson or xlsx), page and size are ignored
+func (r *AccountsClient) Export(ctx context.Context, format string, p AccountsFindParams, w io.Writer) error {
+	p.Page, p.Size = 0, 0
+	return r.export(ctx, format, p.values(), w)
+}
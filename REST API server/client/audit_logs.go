@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+)
+
+// AuditLogsClient is the client of the audit logs endpoint (audit log entries can not be updated or deleted)
+type AuditLogsClient struct {
+	r resource[*AuditLog]
+}
+
+// AuditLogsFindParams are the audit logs query parameters
+type AuditLogsFindParams struct {
+	From     Timestamp // From timestamp (absolute epoch milliseconds or relative to now when negative)
+	To       Timestamp // To timestamp (absolute epoch milliseconds or relative to now when negative)
+	UserId   string    // Filter by User ID
+	Action   string    // Filter by action
+	ItemType string    // Filter by item type
+	ItemId   string    // Filter by item ID
+	ItemName string    // Filter by item name
+	Search   string    // Filter by free search text
+	Filter   string    // Filter expression (e.g. action = 'Delete' and not itemType in ('user','account'))
+	Sort     string    // Sort descriptor (field name with suffix +/- for sort order)
+	Page     int       // Page number for pagination
+	Size     int       // Page size: number of items per page
+	Fields   []string  // Sparse fieldset: list of fields (json paths) to include in the results
+}
+
+func (p AuditLogsFindParams) values() url.Values {
+	query := url.Values{}
+	setTimestamp(query, "from", p.From)
+	setTimestamp(query, "to", p.To)
+	setString(query, "userId", p.UserId)
+	setString(query, "action", p.Action)
+	setString(query, "itemType", p.ItemType)
+	setString(query, "itemId", p.ItemId)
+	setString(query, "itemName", p.ItemName)
+	setString(query, "search", p.Search)
+	setString(query, "filter", p.Filter)
+	setString(query, "sort", p.Sort)
+	setInt(query, "page", p.Page)
+	setInt(query, "size", p.Size)
+	setStrings(query, "fields", p.Fields)
+	return query
+}
+
+// Get returns a single audit log entry by id, fields is an optional list of fields (json paths) to include in the result
+func (a *AuditLogsClient) Get(ctx context.Context, id string, fields ...string) (*AuditLog, error) {
+	return a.r.Get(ctx, id, fields...)
+}
+
+// Create creates new audit log entry
+func (a *AuditLogsClient) Create(ctx context.Context, entity *AuditLog) (*AuditLog, error) {
+	return a.r.Create(ctx, entity)
+}
+
+// Find audit log entries by query
+func (a *AuditLogsClient) Find(ctx context.Context, p AuditLogsFindParams) (*EntitiesResponse[*AuditLog], error) {
+	return a.r.find(ctx, p.values())
+}
+
+// Export audit log entries by query to the writer in the provided format (csv, ndjson or xlsx), page and size are ignored
+func (a *AuditLogsClient) Export(ctx context.Context, format string, p AuditLogsFindParams, w io.Writer) error {
+	p.Page, p.Size = 0, 0
+	return a.r.export(ctx, format, p.values(), w)
+}
+
+// Histogram returns the audit log actions count over time, fields are ignored
+func (a *AuditLogsClient) Histogram(ctx context.Context, p AuditLogsFindParams) (*TimeSeries[float64], error) {
+	p.Fields = nil
+	result := &EntityResponse[*TimeSeries[float64]]{}
+	if err := a.r.c.call(ctx, http.MethodGet, a.r.path+"/histogram", p.values(), nil, result); err != nil {
+		return nil, err
+	}
+	return result.Entity, nil
+}
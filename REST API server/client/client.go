@@ -0,0 +1,307 @@
+// Package client is a typed Go client of the REST API
+//
+// The client uses the domain model types (model/entities, model/enums, model/common) and the common REST responses,
+// it sends the X-API-KEY and X-ACCESS-TOKEN headers, keeps the access token renewed by the server (X-ACCESS-TOKEN
+// response header), retries idempotent calls on transient failures and returns typed errors (*ApiError).
+//
+// Usage:
+//
+//	c := client.NewClient("https://api.example.com", apiKey)
+//	if _, err := c.User().Authorize(ctx, "admin@example.com"); err != nil { ... }
+//	users, err := c.Users().Find(ctx, client.UsersFindParams{Status: []UserStatusCode{UserStatusCodes.ACTIVE}})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+)
+
+// Default API version of the user endpoints (latest version)
+const defaultApiVersion = "v2"
+
+// Default retry policy of idempotent calls
+const (
+	defaultRetries = 3
+	defaultBackoff = 200 * time.Millisecond
+)
+
+// region Client structure and factory method --------------------------------------------------------------------------
+
+// Client is the REST API client, safe for concurrent use
+type Client struct {
+	baseUrl    string
+	apiKey     string
+	version    string
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+
+	mu    sync.RWMutex
+	token string
+}
+
+// NewClient factory method
+func NewClient(baseUrl, apiKey string) *Client {
+	return &Client{
+		baseUrl:    strings.TrimSuffix(baseUrl, "/"),
+		apiKey:     apiKey,
+		version:    defaultApiVersion,
+		httpClient: &http.Client{Timeout: time.Minute},
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+	}
+}
+
+// endregion
+
+// region Client fluent API configuration ------------------------------------------------------------------------------
+
+// WithHttpClient sets the underlying HTTP client (e.g. custom transport or timeout)
+func (c *Client) WithHttpClient(httpClient *http.Client) *Client {
+	c.httpClient = httpClient
+	return c
+}
+
+// WithApiVersion sets the API version of the user endpoints (e.g. v1), the default is the latest version
+func (c *Client) WithApiVersion(version string) *Client {
+	c.version = version
+	return c
+}
+
+// WithRetries sets the number of retries of idempotent calls and the initial backoff (doubled on each retry)
+func (c *Client) WithRetries(retries int, backoff time.Duration) *Client {
+	c.retries = retries
+	c.backoff = backoff
+	return c
+}
+
+// WithToken sets the access token (e.g. token of service account)
+func (c *Client) WithToken(token string) *Client {
+	c.SetToken(token)
+	return c
+}
+
+// Token returns the current access token (the latest token renewed by the server)
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// SetToken sets the current access token
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// endregion
+
+// region Client endpoints ---------------------------------------------------------------------------------------------
+
+// Accounts returns the accounts endpoint client
+func (c *Client) Accounts() *AccountsClient {
+	return &AccountsClient{resource[*Account]{c: c, path: c.versionPath("/accounts")}}
+}
+
+// AuditLogs returns the audit logs endpoint client
+func (c *Client) AuditLogs() *AuditLogsClient {
+	return &AuditLogsClient{resource[*AuditLog]{c: c, path: c.versionPath("/audit_logs")}}
+}
+
+// Contacts returns the contacts endpoint client
+func (c *Client) Contacts() *ContactsClient {
+	return &ContactsClient{resource[*Contact]{c: c, path: c.versionPath("/contacts")}}
+}
+
+// Groups returns the users groups endpoint client
+func (c *Client) Groups() *GroupsClient {
+	return &GroupsClient{resource[*UsersGroup]{c: c, path: c.versionPath("/groups")}}
+}
+
+// Outbox returns the transactional outbox endpoint client
+func (c *Client) Outbox() *OutboxClient {
+	return &OutboxClient{c: c, path: c.versionPath("/outbox")}
+}
+
+// User returns the user (login) endpoint client
+func (c *Client) User() *UserClient {
+	return &UserClient{c: c, path: c.versionPath("/user")}
+}
+
+// Users returns the users endpoint client
+func (c *Client) Users() *UsersClient {
+	return &UsersClient{resource[*User]{c: c, path: c.versionPath("/users")}}
+}
+
+// Webhooks returns the webhooks endpoint client
+func (c *Client) Webhooks() *WebhooksClient {
+	return &WebhooksClient{resource[*Webhook]{c: c, path: c.versionPath("/webhooks")}}
+}
+
+// Version returns the API version (health check)
+func (c *Client) Version(ctx context.Context) (string, error) {
+	result := &ActionResponse{}
+	if err := c.call(ctx, http.MethodGet, "/", nil, nil, result); err != nil {
+		return "", err
+	}
+	return result.Data, nil
+}
+
+// Get the full path of the versioned endpoint (e.g. /v2/users)
+func (c *Client) versionPath(path string) string {
+	return "/" + c.version + path
+}
+
+// endregion
+
+// region Client request processing ------------------------------------------------------------------------------------
+
+// Call the REST method with json body and decode the json response into the result
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	var content []byte
+	if body != nil {
+		var err error
+		if content, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+	}
+
+	return c.callRaw(ctx, method, path, query, content, "application/json", result)
+}
+
+// Call the REST method with raw body of the provided content type and decode the json response into the result
+func (c *Client) callRaw(ctx context.Context, method, path string, query url.Values, content []byte, contentType string, result any) error {
+	resp, err := c.send(ctx, method, path, query, content, contentType)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if content, err = io.ReadAll(resp.Body); err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+
+	// Successful HTTP status may still include an error code in the response
+	br := &BaseRestResponse{}
+	if err = json.Unmarshal(content, br); err != nil {
+		return &ApiError{Method: method, Path: path, StatusCode: resp.StatusCode, Message: fmt.Sprintf("invalid response: %s", err)}
+	}
+	if br.Code != 0 || len(br.Error) > 0 {
+		return &ApiError{Method: method, Path: path, StatusCode: resp.StatusCode, Code: br.Code, Message: br.Error}
+	}
+	if err = json.Unmarshal(content, result); err != nil {
+		return &ApiError{Method: method, Path: path, StatusCode: resp.StatusCode, Message: fmt.Sprintf("invalid response: %s", err)}
+	}
+	return nil
+}
+
+// Send the request (with retries of idempotent methods) and return the successful response, the caller must close the body
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body []byte, contentType string) (*http.Response, error) {
+	target := c.baseUrl + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	attempts := 1
+	if isIdempotent(method) && c.retries > 0 {
+		attempts += c.retries
+	}
+
+	backoff := c.backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendOnce(ctx, method, target, body, contentType)
+		if err == nil && resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+
+		retry := attempt < attempts && ctx.Err() == nil && (err != nil || isTransient(resp.StatusCode))
+		delay := backoff
+		if err == nil {
+			if !retry {
+				return nil, newApiError(method, path, resp)
+			}
+			delay = max(delay, retryAfter(resp))
+			_ = resp.Body.Close()
+		} else if !retry {
+			return nil, fmt.Errorf("%s %s failed: %w", method, path, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+			backoff *= 2
+		}
+	}
+}
+
+// Send single HTTP request and keep the renewed access token
+func (c *Client) sendOnce(ctx context.Context, method, target string, body []byte, contentType string) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("X-API-KEY", c.apiKey)
+	if token := c.Token(); len(token) > 0 {
+		req.Header.Set("X-ACCESS-TOKEN", token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if token := resp.Header.Get("X-ACCESS-TOKEN"); len(token) > 0 && resp.StatusCode == http.StatusOK {
+		c.SetToken(token)
+	}
+	return resp, nil
+}
+
+// Check if the HTTP method is idempotent (safe to retry)
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// Get the delay requested by the Retry-After header in seconds (e.g. rate limit), zero if not provided
+func retryAfter(resp *http.Response) time.Duration {
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return 0
+}
+
+// Check if the HTTP status is a transient failure (worth to retry)
+func isTransient(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// endregion
@@ -0,0 +1,238 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/client"
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rest"
+	usr "github.com/go-yaaf/yaaf-examples/rest-api/rest/user"
+	"github.com/go-yaaf/yaaf-examples/rest-api/utils"
+)
+
+const (
+	adminEmail   = "admin@example.com"
+	blockedEmail = "blocked@example.com"
+)
+
+// Start the REST server in-process with the in-memory database and the test users, returns the server URL and API key
+func newTestServer(t *testing.T) (string, string) {
+	t.Helper()
+
+	hub := common.NewServiceHub()
+	users := []struct {
+		email  string
+		status UserStatusCode
+	}{
+		{adminEmail, UserStatusCodes.ACTIVE},
+		{blockedEmail, UserStatusCodes.BLOCKED},
+	}
+	for _, u := range users {
+		user := NewUser().(*User)
+		user.Id, user.Email, user.Name, user.Type, user.Status = u.email, u.email, u.email, UserTypeCodes.SYSADMIN, u.status
+		if _, err := hub.Database.Insert(user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := config.GetConfig()
+	server := rest.NewRESTServer(cfg)
+	for _, version := range usr.NewUserApiVersions(cfg) {
+		server.AddApiVersion(version, usr.NewListOfUserRestEndPoints(hub)...)
+	}
+
+	ts := httptest.NewServer(server.Handler())
+	t.Cleanup(ts.Close)
+
+	apiKey, err := utils.TokenUtils().CreateApiKey("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts.URL, apiKey
+}
+
+// Create client authorized as the system administrator
+func newAdminClient(t *testing.T, url, apiKey string) *client.Client {
+	t.Helper()
+	c := client.NewClient(url, apiKey).WithRetries(0, 0)
+	if _, err := c.User().Authorize(context.Background(), adminEmail); err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	return c
+}
+
+func TestAuthorizeAndTokenRenewal(t *testing.T) {
+	url, apiKey := newTestServer(t)
+	ctx := context.Background()
+	c := client.NewClient(url, apiKey)
+
+	user, err := c.User().Authorize(ctx, adminEmail)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	if user.Email != adminEmail || len(c.Token()) == 0 {
+		t.Fatalf("expected user %s with access token, got %s (token: %q)", adminEmail, user.Email, c.Token())
+	}
+
+	// Replace the token by a short-lived token of the same subject, the server renews it on the next call
+	td, err := utils.TokenUtils().ParseToken(c.Token())
+	if err != nil {
+		t.Fatal(err)
+	}
+	td.ExpiresIn = int64(Now() + 60*1000)
+	short, err := utils.TokenUtils().CreateToken(td)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetToken(short)
+
+	if _, err = c.Users().Find(ctx, client.UsersFindParams{Page: 1, Size: 10}); err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if c.Token() == short {
+		t.Fatal("expected the access token to be renewed")
+	}
+	renewed, err := utils.TokenUtils().ParseToken(c.Token())
+	if err != nil {
+		t.Fatalf("renewed token: %v", err)
+	}
+	if renewed.SubjectId != td.SubjectId || renewed.ExpiresIn <= td.ExpiresIn {
+		t.Errorf("unexpected renewed token: subject %s expires in %d", renewed.SubjectId, renewed.ExpiresIn)
+	}
+}
+
+func TestContactsCrud(t *testing.T) {
+	url, apiKey := newTestServer(t)
+	ctx := context.Background()
+	contacts := newAdminClient(t, url, apiKey).Contacts()
+
+	contact := NewContact().(*Contact)
+	contact.Name, contact.Email, contact.Mobile = "John Smith", "john@example.com", "054-123 4567"
+	created, err := contacts.Create(ctx, contact)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if len(created.Id) == 0 || created.Mobile != "0541234567" {
+		t.Errorf("unexpected created contact: id %q mobile %q", created.Id, created.Mobile)
+	}
+
+	fetched, err := contacts.Get(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if fetched.Name != contact.Name || fetched.Email != contact.Email {
+		t.Errorf("unexpected contact: %s %s", fetched.Name, fetched.Email)
+	}
+
+	fetched.Name = "John A. Smith"
+	if _, err = contacts.Update(ctx, fetched); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	found, err := contacts.Find(ctx, client.ContactsFindParams{Search: "John*", Page: 1, Size: 10})
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if found.Total != 1 || len(found.List) != 1 || found.List[0].Name != "John A. Smith" {
+		t.Errorf("expected the updated contact, got total %d: %v", found.Total, found.List)
+	}
+
+	if err = contacts.Delete(ctx, created.Id); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if found, err = contacts.Find(ctx, client.ContactsFindParams{Page: 1, Size: 10}); err != nil {
+		t.Fatalf("find: %v", err)
+	} else if found.Total != 0 {
+		t.Errorf("expected no contacts after delete, got %d", found.Total)
+	}
+}
+
+func TestErrorMapping(t *testing.T) {
+	url, apiKey := newTestServer(t)
+	ctx := context.Background()
+	admin := newAdminClient(t, url, apiKey)
+
+	tests := []struct {
+		name   string
+		call   func() error
+		status int
+		check  func(*client.ApiError) bool
+	}{
+		{"invalid api key", func() error {
+			_, err := client.NewClient(url, "invalid").User().Authorize(ctx, adminEmail)
+			return err
+		}, http.StatusForbidden, (*client.ApiError).IsForbidden},
+		{"missing token", func() error {
+			_, err := client.NewClient(url, apiKey).Contacts().Find(ctx, client.ContactsFindParams{})
+			return err
+		}, http.StatusUnauthorized, (*client.ApiError).IsUnauthorized},
+		{"blocked user", func() error {
+			_, err := client.NewClient(url, apiKey).User().Authorize(ctx, blockedEmail)
+			return err
+		}, http.StatusUnauthorized, (*client.ApiError).IsUnauthorized},
+		{"invalid filter", func() error {
+			_, err := admin.Contacts().Find(ctx, client.ContactsFindParams{Filter: "unknown = 1"})
+			return err
+		}, http.StatusBadRequest, (*client.ApiError).IsBadRequest},
+		{"unknown api version", func() error {
+			_, err := client.NewClient(url, apiKey).WithToken(admin.Token()).WithApiVersion("v9").Users().Find(ctx, client.UsersFindParams{})
+			return err
+		}, http.StatusNotFound, (*client.ApiError).IsNotFound},
+		{"missing entity", func() error {
+			_, err := admin.Contacts().Get(ctx, "missing")
+			return err
+		}, http.StatusInternalServerError, func(e *client.ApiError) bool { return len(e.Message) > 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			apiErr := client.AsApiError(err)
+			if apiErr == nil {
+				t.Fatalf("expected *ApiError, got %v", err)
+			}
+			if apiErr.StatusCode != tt.status || !tt.check(apiErr) {
+				t.Errorf("unexpected error: %v", apiErr)
+			}
+		})
+	}
+}
+
+func TestRetryTransientFailure(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":0,"data":"1.0.0"}`))
+	}))
+	defer ts.Close()
+
+	c := client.NewClient(ts.URL, "").WithRetries(3, time.Millisecond)
+	if version, err := c.Version(context.Background()); err != nil || version != "1.0.0" {
+		t.Fatalf("expected version after retries, got %q: %v", version, err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+
+	// Not idempotent calls are not retried
+	calls.Store(0)
+	if _, err := c.User().Authorize(context.Background(), adminEmail); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+}
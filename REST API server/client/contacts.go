@@ -0,0 +1,98 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// ContactsClient is the client of the contacts endpoint
+type ContactsClient struct {
+	resource[*Contact]
+}
+
+// ContactsFindParams are the contacts query parameters
+type ContactsFindParams struct {
+	Search string       // Filter by free text search (using * wildcard)
+	Filter string       // Filter expression (e.g. address.city = 'London' or name like 'john*')
+	Status []StatusCode // Filter by status(es)
+	Sort   string       // Sort descriptor (field name with suffix +/- for sort order)
+	Page   int          // Page number for pagination
+	Size   int          // Page size: number of items per page
+	Fields []string     // Sparse fieldset: list of fields (json paths) to include in the results
+}
+
+func (p ContactsFindParams) values() url.Values {
+	query := url.Values{}
+	setString(query, "search", p.Search)
+	setString(query, "filter", p.Filter)
+	setEnums(query, "status", p.Status)
+	setString(query, "sort", p.Sort)
+	setInt(query, "page", p.Page)
+	setInt(query, "size", p.Size)
+	setStrings(query, "fields", p.Fields)
+	return query
+}
+
+// Find contacts by query
+func (r *ContactsClient) Find(ctx context.Context, p ContactsFindParams) (*EntitiesResponse[*Contact], error) {
+	return r.find(ctx, p.values())
+}
+
+// Export contacts by query to the writer in the provided format (csv, ndjson or xlsx), page and size are ignored
+func (r *ContactsClient) Export(ctx context.Context, format string, p ContactsFindParams, w io.Writer) error {
+	p.Page, p.Size = 0, 0
+	return r.export(ctx, format, p.values(), w)
+}
+
+// ContactsImportParams are the contacts import parameters
+type ContactsImportParams struct {
+	Format    string            // File format: csv | vcard (default: by the file name extension or csv)
+	Mapping   map[string]string // CSV column mapping: contact field (json path) -> column header, other fields are mapped by name
+	AccountId string            // Related billing account of the imported contacts
+	DryRun    bool              // Validate the file and return the report without saving the contacts
+}
+
+// Import contacts from CSV or vCard file, run in dry-run mode first to get the validation report and then commit
+func (r *ContactsClient) Import(ctx context.Context, fileName string, file io.Reader, p ContactsImportParams) (*ImportReport, error) {
+	query := url.Values{}
+	setString(query, "format", p.Format)
+	setString(query, "accountId", p.AccountId)
+	setBool(query, "dryRun", p.DryRun)
+	if len(p.Mapping) > 0 {
+		mapping, err := json.Marshal(p.Mapping)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mapping: %w", err)
+		}
+		query.Set("mapping", string(mapping))
+	}
+
+	// The file is sent as multipart form (buffered to support retries)
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+	if err = form.Close(); err != nil {
+		return nil, err
+	}
+
+	result := &EntityResponse[*ImportReport]{}
+	if err = r.c.callRaw(ctx, http.MethodPost, r.path+"/import", query, body.Bytes(), form.FormDataContentType(), result); err != nil {
+		return nil, err
+	}
+	return result.Entity, nil
+}
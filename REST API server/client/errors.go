@@ -0,0 +1,72 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Maximum size of error response body to read
+const maxErrorBody = 64 * 1024
+
+// ApiError is the error returned by the REST API: non-success HTTP status or error code in the response
+type ApiError struct {
+	Method     string // HTTP method of the failed call
+	Path       string // Path of the failed call
+	StatusCode int    // HTTP status code
+	Code       int    // Error code of the response (0 when not provided)
+	Message    string // Error message
+}
+
+// Error implements the error interface
+func (e *ApiError) Error() string {
+	return fmt.Sprintf("%s %s failed [%d]: %s", e.Method, e.Path, e.StatusCode, e.Message)
+}
+
+// IsBadRequest returns true if the request parameters are invalid
+func (e *ApiError) IsBadRequest() bool {
+	return e.StatusCode == http.StatusBadRequest
+}
+
+// IsUnauthorized returns true if the access token is missing, invalid or expired
+func (e *ApiError) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+// IsForbidden returns true if the API key is invalid or the action is not permitted
+func (e *ApiError) IsForbidden() bool {
+	return e.StatusCode == http.StatusForbidden
+}
+
+// IsNotFound returns true if the route does not exist
+func (e *ApiError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// AsApiError returns the *ApiError of the error chain (or nil)
+func AsApiError(err error) *ApiError {
+	var apiError *ApiError
+	if errors.As(err, &apiError) {
+		return apiError
+	}
+	return nil
+}
+
+// Create error of non-success response: the error message is taken from json error response or from the response body
+func newApiError(method, path string, resp *http.Response) *ApiError {
+	defer func() { _ = resp.Body.Close() }()
+
+	result := &ApiError{Method: method, Path: path, StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	er := &BaseRestResponse{}
+	if err := json.Unmarshal(content, er); err == nil && len(er.Error) > 0 {
+		result.Code, result.Message = er.Code, er.Error
+	} else if text := strings.TrimSpace(string(content)); len(text) > 0 {
+		result.Message = text
+	}
+	return result
+}
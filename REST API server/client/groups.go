@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+)
+
+// GroupsClient is the client of the users groups endpoint
+type GroupsClient struct {
+	resource[*UsersGroup]
+}
+
+// GroupsFindParams are the users groups query parameters
+type GroupsFindParams struct {
+	Search string   // Filter by free text search (using * wildcard)
+	Filter string   // Filter expression (e.g. members = 'user@org.io')
+	Sort   string   // Sort descriptor (field name with suffix +/- for sort order)
+	Page   int      // Page number for pagination
+	Size   int      // Page size: number of items per page
+	Fields []string // Sparse fieldset: list of fields (json paths) to include in the results
+}
+
+func (p GroupsFindParams) values() url.Values {
+	query := url.Values{}
+	setString(query, "search", p.Search)
+	setString(query, "filter", p.Filter)
+	setString(query, "sort", p.Sort)
+	setInt(query, "page", p.Page)
+	setInt(query, "size", p.Size)
+	setStrings(query, "fields", p.Fields)
+	return query
+}
+
+// Find users groups by query
+func (r *GroupsClient) Find(ctx context.Context, p GroupsFindParams) (*EntitiesResponse[*UsersGroup], error) {
+	return r.find(ctx, p.values())
+}
+
+// Export users groups by query to the writer in the provided format (csv, ndjson or xlsx), page and size are ignored
+func (r *GroupsClient) Export(ctx context.Context, format string, p GroupsFindParams, w io.Writer) error {
+	p.Page, p.Size = 0, 0
+	return r.export(ctx, format, p.values(), w)
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// OutboxClient is the client of the transactional outbox endpoint (system administrator only)
+type OutboxClient struct {
+	c    *Client
+	path string
+}
+
+// OutboxFindParams are the outbox entries query parameters
+type OutboxFindParams struct {
+	Topic  string             // Filter by topic (using * wildcard)
+	Status []OutboxStatusCode // Filter by status(s)
+	Stuck  bool               // Filter stuck entries: waiting longer than the stuck threshold
+	Sort   string             // Sort descriptor (field name with suffix +/- for sort order)
+	Page   int                // Page number for pagination
+	Size   int                // Page size: number of items per page
+}
+
+// Find outbox entries by query
+func (r *OutboxClient) Find(ctx context.Context, p OutboxFindParams) (*EntitiesResponse[*OutboxEntry], error) {
+	query := url.Values{}
+	setString(query, "topic", p.Topic)
+	setEnums(query, "status", p.Status)
+	setBool(query, "stuck", p.Stuck)
+	setString(query, "sort", p.Sort)
+	setInt(query, "page", p.Page)
+	setInt(query, "size", p.Size)
+
+	result := &EntitiesResponse[*OutboxEntry]{}
+	if err := r.c.call(ctx, http.MethodGet, r.path, query, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Get a single outbox entry by id
+func (r *OutboxClient) Get(ctx context.Context, id string) (*OutboxEntry, error) {
+	result := &EntityResponse[*OutboxEntry]{}
+	if err := r.c.call(ctx, http.MethodGet, r.path+"/"+url.PathEscape(id), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result.Entity, nil
+}
+
+// Retry schedules the outbox entry for immediate relay
+func (r *OutboxClient) Retry(ctx context.Context, id string) (*OutboxEntry, error) {
+	result := &EntityResponse[*OutboxEntry]{}
+	if err := r.c.call(ctx, http.MethodPost, r.path+"/"+url.PathEscape(id)+"/retry", nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result.Entity, nil
+}
+
+// Stats gets the state of the outbox (pending, stuck and failed entries)
+func (r *OutboxClient) Stats(ctx context.Context) (*OutboxStats, error) {
+	result := &EntityResponse[*OutboxStats]{}
+	if err := r.c.call(ctx, http.MethodGet, r.path+"/stats", nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result.Entity, nil
+}
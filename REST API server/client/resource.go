@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"github.com/go-yaaf/yaaf-common/rest"
+)
+
+// Common REST responses
+type (
+	BaseRestResponse           = rest.BaseRestResponse
+	ActionResponse             = rest.ActionResponse
+	EntityResponse[T Entity]   = rest.EntityResponse[T]
+	EntitiesResponse[T Entity] = rest.EntitiesResponse[T]
+)
+
+// Export file formats
+const (
+	ExportCSV    = "csv"
+	ExportNDJSON = "ndjson"
+	ExportXLSX   = "xlsx"
+)
+
+// region Generic entity resource --------------------------------------------------------------------------------------
+
+// resource implements the common REST methods of entity endpoint (new, get, create, update, delete, find, export)
+type resource[T Entity] struct {
+	c    *Client
+	path string
+}
+
+// New returns new and empty entity template
+func (r resource[T]) New(ctx context.Context) (T, error) {
+	return r.entity(ctx, http.MethodPost, r.path+"/new", nil, nil)
+}
+
+// Get returns a single entity by id, fields is an optional list of fields (json paths) to include in the result
+func (r resource[T]) Get(ctx context.Context, id string, fields ...string) (T, error) {
+	query := url.Values{}
+	setStrings(query, "fields", fields)
+	return r.entity(ctx, http.MethodGet, r.path+"/"+url.PathEscape(id), query, nil)
+}
+
+// Create creates new entity and returns the created entity
+func (r resource[T]) Create(ctx context.Context, entity T) (T, error) {
+	return r.entity(ctx, http.MethodPost, r.path, nil, entity)
+}
+
+// Update updates existing entity and returns the updated entity
+func (r resource[T]) Update(ctx context.Context, entity T) (T, error) {
+	return r.entity(ctx, http.MethodPut, r.path, nil, entity)
+}
+
+// Delete deletes entity by id
+func (r resource[T]) Delete(ctx context.Context, id string) error {
+	return r.c.call(ctx, http.MethodDelete, r.path+"/"+url.PathEscape(id), nil, nil, &ActionResponse{})
+}
+
+// Call method returning single entity
+func (r resource[T]) entity(ctx context.Context, method, path string, query url.Values, body any) (T, error) {
+	result := &EntityResponse[T]{}
+	if err := r.c.call(ctx, method, path, query, body, result); err != nil {
+		var empty T
+		return empty, err
+	}
+	return result.Entity, nil
+}
+
+// Find entities by the query parameters
+func (r resource[T]) find(ctx context.Context, query url.Values) (*EntitiesResponse[T], error) {
+	result := &EntitiesResponse[T]{}
+	if err := r.c.call(ctx, http.MethodGet, r.path, query, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Export entities by the query parameters to the writer in the provided format (csv, ndjson or xlsx)
+func (r resource[T]) export(ctx context.Context, format string, query url.Values, w io.Writer) error {
+	setString(query, "format", format)
+	resp, err := r.c.send(ctx, http.MethodGet, r.path+"/export", query, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if _, err = io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to read export file: %w", err)
+	}
+	return nil
+}
+
+// endregion
+
+// region Query parameters helpers -------------------------------------------------------------------------------------
+
+// Set string parameter (if not empty)
+func setString(query url.Values, name, value string) {
+	if len(value) > 0 {
+		query.Set(name, value)
+	}
+}
+
+// Set int parameter (if not zero)
+func setInt(query url.Values, name string, value int) {
+	if value != 0 {
+		query.Set(name, strconv.Itoa(value))
+	}
+}
+
+// Set timestamp parameter (if not zero)
+func setTimestamp(query url.Values, name string, value Timestamp) {
+	if value != 0 {
+		query.Set(name, strconv.FormatInt(int64(value), 10))
+	}
+}
+
+// Set bool parameter
+func setBool(query url.Values, name string, value bool) {
+	query.Set(name, strconv.FormatBool(value))
+}
+
+// Set array parameter as comma separated list (if not empty)
+func setStrings(query url.Values, name string, values []string) {
+	if len(values) > 0 {
+		query.Set(name, strings.Join(values, ","))
+	}
+}
+
+// Set enum array parameter as comma separated list of numeric values (if not empty)
+func setEnums[E ~int](query url.Values, name string, values []E) {
+	list := make([]string, 0, len(values))
+	for _, v := range values {
+		list = append(list, strconv.Itoa(int(v)))
+	}
+	setStrings(query, name, list)
+}
+
+// endregion
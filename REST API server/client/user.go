@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+)
+
+// UserClient is the client of the user registration and login endpoint
+type UserClient struct {
+	c    *Client
+	path string
+}
+
+// Authorize user by email, the access token returned by the server is kept by the client for the next calls
+func (u *UserClient) Authorize(ctx context.Context, email string) (*User, error) {
+	result := &EntityResponse[*User]{}
+	if err := u.c.call(ctx, http.MethodPost, u.path+"/authorize", nil, &LoginParams{Email: email}, result); err != nil {
+		return nil, err
+	}
+	return result.Entity, nil
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// UsersClient is the client of the users endpoint
+type UsersClient struct {
+	resource[*User]
+}
+
+// UsersFindParams are the users query parameters
+type UsersFindParams struct {
+	Search string           // Filter by free text search (using * wildcard)
+	Filter string           // Filter expression (e.g. type = USER and (status = PENDING or lastSignIn < '2024-01-01'))
+	Type   []UserTypeCode   // Filter by type(s)
+	Status []UserStatusCode // Filter by status(es)
+	Sort   string           // Sort descriptor (field name with suffix +/- for sort order)
+	Page   int              // Page number for pagination
+	Size   int              // Page size: number of items per page
+	Fields []string         // Sparse fieldset: list of fields (json paths) to include in the results
+}
+
+func (p UsersFindParams) values() url.Values {
+	query := url.Values{}
+	setString(query, "search", p.Search)
+	setString(query, "filter", p.Filter)
+	setEnums(query, "type", p.Type)
+	setEnums(query, "status", p.Status)
+	setString(query, "sort", p.Sort)
+	setInt(query, "page", p.Page)
+	setInt(query, "size", p.Size)
+	setStrings(query, "fields", p.Fields)
+	return query
+}
+
+// Find users by query
+func (r *UsersClient) Find(ctx context.Context, p UsersFindParams) (*EntitiesResponse[*User], error) {
+	return r.find(ctx, p.values())
+}
+
+// Export users by query to the writer in the provided format (csv, ndjson or xlsx), page and size are ignored
+func (r *UsersClient) Export(ctx context.Context, format string, p UsersFindParams, w io.Writer) error {
+	p.Page, p.Size = 0, 0
+	return r.export(ctx, format, p.values(), w)
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// WebhooksClient is the client of the webhooks endpoint (system administrator only)
+type WebhooksClient struct {
+	resource[*Webhook]
+}
+
+// WebhooksFindParams are the webhooks query parameters
+type WebhooksFindParams struct {
+	Search    string   // Filter by free text search (using * wildcard)
+	AccountId string   // Filter by account
+	Sort      string   // Sort descriptor (field name with suffix +/- for sort order)
+	Page      int      // Page number for pagination
+	Size      int      // Page size: number of items per page
+	Fields    []string // Sparse fieldset: list of fields (json paths) to include in the results
+}
+
+func (p WebhooksFindParams) values() url.Values {
+	query := url.Values{}
+	setString(query, "search", p.Search)
+	setString(query, "accountId", p.AccountId)
+	setString(query, "sort", p.Sort)
+	setInt(query, "page", p.Page)
+	setInt(query, "size", p.Size)
+	setStrings(query, "fields", p.Fields)
+	return query
+}
+
+// Find webhooks by query
+func (r *WebhooksClient) Find(ctx context.Context, p WebhooksFindParams) (*EntitiesResponse[*Webhook], error) {
+	return r.find(ctx, p.values())
+}
+
+// WebhookDeliveriesFindParams are the webhook delivery history query parameters
+type WebhookDeliveriesFindParams struct {
+	Status []DeliveryStatusCode // Filter by status(s)
+	Sort   string               // Sort descriptor (field name with suffix +/- for sort order)
+	Page   int                  // Page number for pagination
+	Size   int                  // Page size: number of items per page
+}
+
+// Deliveries gets the delivery history of the webhook
+func (r *WebhooksClient) Deliveries(ctx context.Context, webhookId string, p WebhookDeliveriesFindParams) (*EntitiesResponse[*WebhookDelivery], error) {
+	query := url.Values{}
+	setEnums(query, "status", p.Status)
+	setString(query, "sort", p.Sort)
+	setInt(query, "page", p.Page)
+	setInt(query, "size", p.Size)
+
+	result := &EntitiesResponse[*WebhookDelivery]{}
+	if err := r.c.call(ctx, http.MethodGet, r.path+"/"+url.PathEscape(webhookId)+"/deliveries", query, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Delivery gets a single webhook delivery by id
+func (r *WebhooksClient) Delivery(ctx context.Context, id string) (*WebhookDelivery, error) {
+	result := &EntityResponse[*WebhookDelivery]{}
+	if err := r.c.call(ctx, http.MethodGet, r.path+"/deliveries/"+url.PathEscape(id), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result.Entity, nil
+}
+
+// Redeliver schedules the webhook delivery for immediate attempt
+func (r *WebhooksClient) Redeliver(ctx context.Context, id string) (*WebhookDelivery, error) {
+	result := &EntityResponse[*WebhookDelivery]{}
+	if err := r.c.call(ctx, http.MethodPost, r.path+"/deliveries/"+url.PathEscape(id)+"/redeliver", nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result.Entity, nil
+}
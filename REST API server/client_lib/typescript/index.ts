@@ -0,0 +1,18 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+export * from './model/common';
+export * from './model/entities';
+export * from './model/enums';
+export * from './model/responses';
+export * from './rest-api.client';
+export * from './services/accounts.service';
+export * from './services/audit-logs.service';
+export * from './services/contacts.service';
+export * from './services/events.service';
+export * from './services/groups.service';
+export * from './services/health.service';
+export * from './services/outbox.service';
+export * from './services/user.service';
+export * from './services/users.service';
+export * from './services/versions.service';
+export * from './services/webhooks.service';
@@ -0,0 +1,182 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { ImportStatusCode, UserStatusCode, UserTypeCode } from './enums';
+
+/** Address model represents an address */
+export interface Address {
+  /** Street address */
+  street: string;
+  /** City */
+  city: string;
+  /** State (if applicable) */
+  state: string;
+  /** Local zip code (postal cod) */
+  zipCode: string;
+  /** Country name */
+  country: string;
+}
+
+/** ApiVersionInfo model represents a version of the REST API and its routes (route table for documentation) */
+export interface ApiVersionInfo {
+  /** Version name, used as the path prefix (e.g. v2), empty for unversioned routes */
+  version: string;
+  /** When the version was deprecated [RFC3339] (empty for active version) */
+  deprecated: string;
+  /** When the version is going to be removed [RFC3339] (empty if not scheduled) */
+  sunset: string;
+  /** Link to the migration guide (for deprecated version) */
+  link: string;
+  /** List of the version routes */
+  routes: RouteInfo[];
+}
+
+/** DependencyStatus model represents the status of a single service dependency (e.g. database) */
+export interface DependencyStatus {
+  /** Dependency name: database | datacache */
+  name: string;
+  /** Dependency status: UP | DOWN */
+  status: string;
+  /** Check latency in milliseconds */
+  latency: number;
+  /** Error message (if the dependency is down) */
+  error: string;
+}
+
+/**
+ * EntityEvent model represents an entity change (create, update or delete) published by the services, the events are
+ * streamed to the subscribers of the events endpoint
+ */
+export interface EntityEvent {
+  /** Event ID (ordered, the same on all instances), used to resume the stream after reconnect */
+  id: number;
+  /** Action that was performed: Create | Update | Delete (Reset when the missed events are not available) */
+  action: string;
+  /** Item type (entity table name, e.g. contact) */
+  itemType: string;
+  /** Item Id */
+  itemId: string;
+  /** Item Name */
+  itemName: string;
+  /** Account of the item (the account itself, the contact account), set for delete too */
+  accountId: string;
+  /** Item value after change (empty for delete) */
+  item: { [key: string]: any };
+  /** Subject that performed the action */
+  userId: string;
+  /** Correlation ID of the request that performed the action */
+  requestId: string;
+  /** When the action was performed [Epoch milliseconds Timestamp] */
+  timestamp: number;
+}
+
+/** HealthStatus model represents the readiness of the service and the status of its dependencies */
+export interface HealthStatus {
+  /** Service status: STARTING | READY | DRAINING */
+  status: string;
+  /** Service is ready to accept traffic (status is READY and all dependencies are UP) */
+  ready: boolean;
+  /** Service version (build tag) */
+  version: string;
+  /** Status of the service dependencies */
+  checks: DependencyStatus[];
+}
+
+/** ImportReport model represents the validation report of data import (dry-run) or the import results */
+export interface ImportReport {
+  /** Import ID, the audit log entries of the import include it in props.importId */
+  importId: string;
+  /** Dry-run flag: the data was validated but not saved */
+  dryRun: boolean;
+  /** Total number of rows */
+  total: number;
+  /** Number of valid rows */
+  valid: number;
+  /** Number of invalid rows */
+  invalid: number;
+  /** Number of duplicate rows */
+  duplicates: number;
+  /** Number of imported rows */
+  imported: number;
+  /** Number of valid rows that failed to be saved */
+  failed: number;
+  /** Per row results */
+  rows: ImportRowResult[];
+}
+
+/** ImportRowResult model represents the validation / import result of a single row */
+export interface ImportRowResult {
+  /** Row number in the file (1-based, not including the header) */
+  row: number;
+  /** Item name */
+  name: string;
+  /** Row status: VALID | INVALID | DUPLICATE | IMPORTED | FAILED */
+  status: ImportStatusCode;
+  /** Imported item ID or the ID of the existing item (for duplicates) */
+  itemId: string;
+  /** Validation errors and warnings */
+  messages: string[];
+}
+
+/** LoginParams model used for authorize user by email or by SMS */
+export interface LoginParams {
+  /** User email for login authentication or mail verification */
+  email: string;
+  /** User mobile phone for SMS verification */
+  mobile: string;
+  /** User token if he wsa already authenticated */
+  token: string;
+}
+
+/**
+ * OutboxStats model represents the state of the transactional outbox: entries waiting to be relayed (pending and
+ * retries), stuck entries (waiting longer than the stuck threshold) and failed entries
+ */
+export interface OutboxStats {
+  /** Number of entries waiting to be relayed (including retries) */
+  pending: number;
+  /** Number of entries waiting longer than the stuck threshold */
+  stuck: number;
+  /** Number of entries that failed all the relay attempts */
+  failed: number;
+  /** Creation time of the oldest waiting entry (0 when there is none) [Epoch milliseconds Timestamp] */
+  oldestPending: number;
+  /** Stuck threshold in milliseconds */
+  stuckAfter: number;
+}
+
+/** RouteInfo model represents a single REST route */
+export interface RouteInfo {
+  /** HTTP method */
+  method: string;
+  /** Full route path (including the version prefix) */
+  path: string;
+}
+
+/** TokenData model represents user in account which is encrypted with the JWT token */
+export interface TokenData {
+  /** Authenticated subject ID (can be user, or service account) */
+  subjectId: string;
+  /** Subject type: UNDEFINED | SYSADMIN | USER | SERVICE_ACCOUNT */
+  subjectType: UserTypeCode;
+  /** User status: UNDEFINED | PENDING | ACTIVE | BLOCKED | SUSPENDED */
+  status: UserStatusCode;
+  /** Token expiration [Epoch milliseconds Timestamp] */
+  expiresIn: number;
+}
+
+/**
+ * WebhookPayload model represents the json payload posted to the webhook receiver, the payload is signed by the webhook
+ * secret (X-Webhook-Signature header)
+ */
+export interface WebhookPayload {
+  /** Delivery ID, the same in all the attempts (used by the receiver to ignore duplicates) */
+  id: string;
+  /** Webhook ID */
+  webhookId: string;
+  /** Event name: item type and action (e.g. contact.Create) */
+  event: string;
+  /** When the change was performed [Epoch milliseconds Timestamp] */
+  timestamp: number;
+  /** The entity change event */
+  data: EntityEvent;
+}
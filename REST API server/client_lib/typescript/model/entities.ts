@@ -0,0 +1,240 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Address } from './common';
+import { AccountStatusCode, AccountTypeCode, DeliveryStatusCode, OutboxStatusCode, UserRoleFlag, UserStatusCode, UserTypeCode } from './enums';
+
+/** Account entity is a billing account in the system */
+export interface Account {
+  /** Unique object Id */
+  id: string;
+  /** When the object was created [Epoch milliseconds Timestamp] */
+  createdOn: number;
+  /** When the object was last updated [Epoch milliseconds Timestamp] */
+  updatedOn: number;
+  /** Entity status flag (e.g. -1 = deleted) */
+  flag: number;
+  /** List of custom properties */
+  props: { [key: string]: any };
+  /** Account name */
+  name: string;
+  /** Account description */
+  description: string;
+  /** Account type:  STUDENT | PRIVATE | BUSINESS ... */
+  type: AccountTypeCode;
+  /** Account status: UNDEFINED | ACTIVE | INACTIVE | BLOCKED | SUSPENDED */
+  status: AccountStatusCode;
+  /** Office / Landline phone */
+  phone: string;
+  /** Mobile phone */
+  mobile: string;
+  /** Email address */
+  email: string;
+}
+
+/** AuditLog entity is a log entry in the audit log to track users / service account actions */
+export interface AuditLog {
+  /** Unique object Id */
+  id: string;
+  /** When the object was created [Epoch milliseconds Timestamp] */
+  createdOn: number;
+  /** When the object was last updated [Epoch milliseconds Timestamp] */
+  updatedOn: number;
+  /** Entity status flag (e.g. -1 = deleted) */
+  flag: number;
+  /** List of custom properties */
+  props: { [key: string]: any };
+  /** User Id */
+  userId: string;
+  /** User type: UNDEFINED | SYSADMIN | USER | SERVICE_ACCOUNT */
+  userType: UserTypeCode;
+  /** Action that was performed */
+  action: string;
+  /** Item type */
+  itemType: string;
+  /** Item Id */
+  itemId: string;
+  /** Item Name */
+  itemName: string;
+  /** Item value before change [Json] */
+  beforeChange: string;
+  /** Item delta after change [Json] */
+  afterChange: string;
+  /** Correlation ID of the request that performed the action */
+  requestId: string;
+}
+
+/** Contact entity is a billing account in the system */
+export interface Contact {
+  /** Unique object Id */
+  id: string;
+  /** When the object was created [Epoch milliseconds Timestamp] */
+  createdOn: number;
+  /** When the object was last updated [Epoch milliseconds Timestamp] */
+  updatedOn: number;
+  /** Entity status flag (e.g. -1 = deleted) */
+  flag: number;
+  /** List of custom properties */
+  props: { [key: string]: any };
+  /** Related billing account ID */
+  accountId: string;
+  /** Contact name */
+  name: string;
+  /** Contact description */
+  description: string;
+  /** Mobile phone */
+  mobile: string;
+  /** Email address */
+  email: string;
+  /** Contact address */
+  address: Address;
+  /** Contact groups */
+  groups: string[];
+}
+
+/**
+ * OutboxEntry entity is an event saved in the same transaction as the entity change (transactional outbox), the entry
+ * is relayed to the message bus or webhooks after the change was committed
+ */
+export interface OutboxEntry {
+  /** Unique object Id */
+  id: string;
+  /** When the object was created [Epoch milliseconds Timestamp] */
+  createdOn: number;
+  /** When the object was last updated [Epoch milliseconds Timestamp] */
+  updatedOn: number;
+  /** Entity status flag (e.g. -1 = deleted) */
+  flag: number;
+  /** List of custom properties */
+  props: { [key: string]: any };
+  /** Event topic: entity.<item type> (entity change event) or domain.<event name> (domain event) */
+  topic: string;
+  /** Event payload [Json] */
+  payload: string;
+  /** Relay status: UNDEFINED | PENDING | RETRY | SENT | FAILED */
+  status: OutboxStatusCode;
+  /** Number of relay attempts */
+  attempts: number;
+  /** When the next attempt is due [Epoch milliseconds Timestamp] */
+  nextAttempt: number;
+  /** Error of the last attempt */
+  error: string;
+  /** When the entry was relayed [Epoch milliseconds Timestamp] */
+  sentOn: number;
+  /** Correlation ID of the request that performed the change */
+  requestId: string;
+}
+
+/**
+ * User represents a human / system operator that has access to the system, and can perform operations
+ * User authentication is done by an external identity provider
+ */
+export interface User {
+  /** Unique object Id */
+  id: string;
+  /** When the object was created [Epoch milliseconds Timestamp] */
+  createdOn: number;
+  /** When the object was last updated [Epoch milliseconds Timestamp] */
+  updatedOn: number;
+  /** Entity status flag (e.g. -1 = deleted) */
+  flag: number;
+  /** List of custom properties */
+  props: { [key: string]: any };
+  /** User name */
+  name: string;
+  /** User email */
+  email: string;
+  /** User mobile phone number (for notification and validation) */
+  mobile: string;
+  /** User type: UNDEFINED | SYSADMIN | SUPPORT | USER */
+  type: UserTypeCode;
+  /** User roles flags */
+  roles: UserRoleFlag;
+  /** User permissions groups */
+  groups: string[];
+  /** User status: UNDEFINED | PENDING | ACTIVE |  BLOCKED | SUSPENDED */
+  status: UserStatusCode;
+  /** User last successful sign in timestamp [epoch time milliseconds] */
+  lastSignIn: number;
+}
+
+/** UsersGroup represents a group of users to share permissions */
+export interface UsersGroup {
+  /** Unique object Id */
+  id: string;
+  /** When the object was created [Epoch milliseconds Timestamp] */
+  createdOn: number;
+  /** When the object was last updated [Epoch milliseconds Timestamp] */
+  updatedOn: number;
+  /** Entity status flag (e.g. -1 = deleted) */
+  flag: number;
+  /** List of custom properties */
+  props: { [key: string]: any };
+  /** Group name */
+  name: string;
+  /** Group email */
+  email: string;
+  /** List of group members (user Ids) */
+  members: string[];
+}
+
+/** Webhook entity is a subscription of external receiver (URL) to the entity change events */
+export interface Webhook {
+  /** Unique object Id */
+  id: string;
+  /** When the object was created [Epoch milliseconds Timestamp] */
+  createdOn: number;
+  /** When the object was last updated [Epoch milliseconds Timestamp] */
+  updatedOn: number;
+  /** Entity status flag (e.g. -1 = deleted) */
+  flag: number;
+  /** List of custom properties */
+  props: { [key: string]: any };
+  /** Related account ID: only the changes of the account and its contacts are sent (empty for all) */
+  accountId: string;
+  /** Webhook name */
+  name: string;
+  /** Receiver URL, the events are posted as json payload (WebhookPayload) */
+  url: string;
+  /** Subscribed events: item type or item type and action (e.g. contact, account.Update), empty for all */
+  events: string[];
+  /** Secret key of the payload HMAC signature (generated if empty, returned only on create) */
+  secret: string;
+  /** Active flag, inactive webhook is not notified */
+  active: boolean;
+}
+
+/** WebhookDelivery entity is the delivery of entity change event to webhook (delivery history) */
+export interface WebhookDelivery {
+  /** Unique object Id */
+  id: string;
+  /** When the object was created [Epoch milliseconds Timestamp] */
+  createdOn: number;
+  /** When the object was last updated [Epoch milliseconds Timestamp] */
+  updatedOn: number;
+  /** Entity status flag (e.g. -1 = deleted) */
+  flag: number;
+  /** List of custom properties */
+  props: { [key: string]: any };
+  /** Related webhook ID */
+  webhookId: string;
+  /** Event name: item type and action (e.g. contact.Create) */
+  event: string;
+  /** Changed item ID */
+  itemId: string;
+  /** Receiver URL (of the webhook when the event was published) */
+  url: string;
+  /** Posted payload [Json] */
+  payload: string;
+  /** Delivery status: UNDEFINED | PENDING | RETRY | DELIVERED | FAILED */
+  status: DeliveryStatusCode;
+  /** Number of delivery attempts */
+  attempts: number;
+  /** When the next attempt is due [Epoch milliseconds Timestamp] */
+  nextAttempt: number;
+  /** HTTP status of the last attempt (0 when there was no response) */
+  statusCode: number;
+  /** Error of the last attempt */
+  error: string;
+  /** When the payload was delivered [Epoch milliseconds Timestamp] */
+  deliveredOn: number;
+}
@@ -0,0 +1,328 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+/** AccountStatusCode represents the account status: ACTIVE | INACTIVE | BLOCKED ... */
+export type AccountStatusCode = number;
+
+/** AccountStatusCode values by name */
+export const AccountStatusCodes = {
+  /** Undefined [0] */
+  UNDEFINED: 0,
+  /** Active account in the system [1] */
+  ACTIVE: 1,
+  /** Inactive account in the system [2] */
+  INACTIVE: 2,
+  /** Blocked account [3] */
+  BLOCKED: 3,
+  /** Suspended account (about to be deleted) [4] */
+  SUSPENDED: 4,
+} as const;
+
+/** AccountStatusCode names by value */
+export const AccountStatusCodeNames: { [value: number]: string } = {
+  0: 'UNDEFINED',
+  1: 'ACTIVE',
+  2: 'INACTIVE',
+  3: 'BLOCKED',
+  4: 'SUSPENDED',
+};
+
+/** AccountTypeCode represents the account type: DEMO | TRIAL | PARTNER | BUSINESS ... */
+export type AccountTypeCode = number;
+
+/** AccountTypeCode values by name */
+export const AccountTypeCodes = {
+  /** Undefined [0] */
+  UNDEFINED: 0,
+  /** Demo account [1] */
+  DEMO: 1,
+  /** Trial account [2] */
+  TRIAL: 2,
+  /** Partner account [3] */
+  PARTNER: 3,
+  /** Business account [4] */
+  BUSINESS: 4,
+} as const;
+
+/** AccountTypeCode names by value */
+export const AccountTypeCodeNames: { [value: number]: string } = {
+  0: 'UNDEFINED',
+  1: 'DEMO',
+  2: 'TRIAL',
+  3: 'PARTNER',
+  4: 'BUSINESS',
+};
+
+/** DeliveryStatusCode represents the status of webhook delivery: PENDING | RETRY | DELIVERED | FAILED ... */
+export type DeliveryStatusCode = number;
+
+/** DeliveryStatusCode values by name */
+export const DeliveryStatusCodes = {
+  /** Undefined [0] */
+  UNDEFINED: 0,
+  /** Delivery is waiting for the first attempt [1] */
+  PENDING: 1,
+  /** Delivery attempt failed, waiting for the next attempt [2] */
+  RETRY: 2,
+  /** Payload was accepted by the receiver (2xx response) [3] */
+  DELIVERED: 3,
+  /** All the delivery attempts failed (dead letter), can be redelivered manually [4] */
+  FAILED: 4,
+} as const;
+
+/** DeliveryStatusCode names by value */
+export const DeliveryStatusCodeNames: { [value: number]: string } = {
+  0: 'UNDEFINED',
+  1: 'PENDING',
+  2: 'RETRY',
+  3: 'DELIVERED',
+  4: 'FAILED',
+};
+
+/** ErrorCode represents a general error */
+export type ErrorCode = number;
+
+/** ErrorCode values by name */
+export const ErrorCodes = {
+  /** Not found [-2] */
+  NOT_FOUND: -10,
+  /** Unauthorized [-3] */
+  UNAUTHORIZED: -3,
+  /** Unauthenticated [-2] */
+  UNAUTHENTICATED: -2,
+  /** General server error [-1] */
+  GENERAL_ERROR: -1,
+  /** Undefined [0] */
+  UNDEFINED: 0,
+} as const;
+
+/** ErrorCode names by value */
+export const ErrorCodeNames: { [value: number]: string } = {
+  -10: 'NOT_FOUND',
+  -3: 'UNAUTHORIZED',
+  -2: 'UNAUTHENTICATED',
+  -1: 'GENERAL_ERROR',
+  0: 'UNDEFINED',
+};
+
+/** ImportStatusCode represents the status of a single row in data import: VALID | INVALID | DUPLICATE | IMPORTED ... */
+export type ImportStatusCode = number;
+
+/** ImportStatusCode values by name */
+export const ImportStatusCodes = {
+  /** Undefined [0] */
+  UNDEFINED: 0,
+  /** Row is valid and can be imported [1] */
+  VALID: 1,
+  /** Row includes invalid data [2] */
+  INVALID: 2,
+  /** Row is a duplicate of an existing item or of previous row in the file [3] */
+  DUPLICATE: 3,
+  /** Row was imported [4] */
+  IMPORTED: 4,
+  /** Row is valid but failed to be saved [5] */
+  FAILED: 5,
+} as const;
+
+/** ImportStatusCode names by value */
+export const ImportStatusCodeNames: { [value: number]: string } = {
+  0: 'UNDEFINED',
+  1: 'VALID',
+  2: 'INVALID',
+  3: 'DUPLICATE',
+  4: 'IMPORTED',
+  5: 'FAILED',
+};
+
+/** OutboxStatusCode represents the status of outbox entry: PENDING | RETRY | SENT | FAILED ... */
+export type OutboxStatusCode = number;
+
+/** OutboxStatusCode values by name */
+export const OutboxStatusCodes = {
+  /** Undefined [0] */
+  UNDEFINED: 0,
+  /** Entry is waiting to be relayed [1] */
+  PENDING: 1,
+  /** Relay attempt failed, waiting for the next attempt [2] */
+  RETRY: 2,
+  /** Entry was relayed to the message bus or webhooks [3] */
+  SENT: 3,
+  /** All the relay attempts failed, can be retried manually [4] */
+  FAILED: 4,
+} as const;
+
+/** OutboxStatusCode names by value */
+export const OutboxStatusCodeNames: { [value: number]: string } = {
+  0: 'UNDEFINED',
+  1: 'PENDING',
+  2: 'RETRY',
+  3: 'SENT',
+  4: 'FAILED',
+};
+
+/** PermissionFlag represents combination of permissions: READ | CREATE | UPDATE | DELETE | MANAGE */
+export type PermissionFlag = number;
+
+/** PermissionFlag values by name */
+export const PermissionFlags = {
+  /** Undefined [0] */
+  UNDEFINED: 0,
+  /** Read [1] */
+  READ: 1,
+  /** Create [2] */
+  CREATE: 2,
+  /** Update [4] */
+  UPDATE: 4,
+  /** Delete [8] */
+  DELETE: 8,
+  /** Manage [16] */
+  MANAGE: 16,
+  /** All permissions combined */
+  ALL: 31,
+} as const;
+
+/** PermissionFlag names by value */
+export const PermissionFlagNames: { [value: number]: string } = {
+  0: 'UNDEFINED',
+  1: 'READ',
+  2: 'CREATE',
+  4: 'UPDATE',
+  8: 'DELETE',
+  16: 'MANAGE',
+  31: 'ALL',
+};
+
+/** PriorityCode represents a priority: LOW | MEDIUM | HIGH */
+export type PriorityCode = number;
+
+/** PriorityCode values by name */
+export const PriorityCodes = {
+  /** Undefined [0] */
+  UNDEFINED: 0,
+  /** No priority [1] */
+  NONE: 1,
+  /** Low priority [2] */
+  LOW: 2,
+  /** Medium priority [3] */
+  MEDIUM: 3,
+  /** High priority [4] */
+  HIGH: 4,
+} as const;
+
+/** PriorityCode names by value */
+export const PriorityCodeNames: { [value: number]: string } = {
+  0: 'UNDEFINED',
+  1: 'NONE',
+  2: 'LOW',
+  3: 'MEDIUM',
+  4: 'HIGH',
+};
+
+/** StatusCode represents a general workflow status: PENDING | IN_PROGRESS | DONE ... */
+export type StatusCode = number;
+
+/** StatusCode values by name */
+export const StatusCodes = {
+  /** Undefined [0] */
+  UNDEFINED: 0,
+  /** Flow not started yet [1] */
+  PENDING: 1,
+  /** Flow in process [2] */
+  IN_PROCESS: 2,
+  /** Flow completed [3] */
+  COMPLETED: 3,
+  /** Flow cancelled by user [4] */
+  CANCELLED: 4,
+  /** Flow automatically cancelled by the system [5] */
+  AUTO_CANCELLED: 5,
+} as const;
+
+/** StatusCode names by value */
+export const StatusCodeNames: { [value: number]: string } = {
+  0: 'UNDEFINED',
+  1: 'PENDING',
+  2: 'IN_PROCESS',
+  3: 'COMPLETED',
+  4: 'CANCELLED',
+  5: 'AUTO_CANCELLED',
+};
+
+/** UserRoleFlag represents combination of roles: STUDENT | PILOT | INSTRUCTOR | SALES | OPERATIONS | MANAGER */
+export type UserRoleFlag = number;
+
+/** UserRoleFlag values by name */
+export const UserRoleFlags = {
+  /** Undefined [0] */
+  UNDEFINED: 0,
+  /** Sales [8] */
+  SALES: 8,
+  /** Operations [16] */
+  OPERATIONS: 16,
+  /** Maintenance [32] */
+  MAINTENANCE: 32,
+  /** Manager [1024] */
+  MANAGER: 1024,
+  /** All roles combined */
+  ALL: 2047,
+} as const;
+
+/** UserRoleFlag names by value */
+export const UserRoleFlagNames: { [value: number]: string } = {
+  0: 'UNDEFINED',
+  8: 'SALES',
+  16: 'OPERATIONS',
+  32: 'MAINTENANCE',
+  1024: 'MANAGER',
+  2047: 'ALL',
+};
+
+/** UserStatusCode represents the user status: PENDING | ACTIVE | BLOCKED ... */
+export type UserStatusCode = number;
+
+/** UserStatusCode values by name */
+export const UserStatusCodes = {
+  /** Undefined [0] */
+  UNDEFINED: 0,
+  /** User is registered and pending verification [1] */
+  PENDING: 1,
+  /** Active user in the system [2] */
+  ACTIVE: 2,
+  /** Blocked user (only account system can unblock the user) [3] */
+  BLOCKED: 3,
+  /** Suspended user (about to be deleted) [4] */
+  SUSPENDED: 4,
+} as const;
+
+/** UserStatusCode names by value */
+export const UserStatusCodeNames: { [value: number]: string } = {
+  0: 'UNDEFINED',
+  1: 'PENDING',
+  2: 'ACTIVE',
+  3: 'BLOCKED',
+  4: 'SUSPENDED',
+};
+
+/** UserTypeCode represents the user type: SYSADMIN | SUPPORT | USER ... */
+export type UserTypeCode = number;
+
+/** UserTypeCode values by name */
+export const UserTypeCodes = {
+  /** Undefined [0] */
+  UNDEFINED: 0,
+  /** System administrator has access to all accounts and permissions to perform all actions [1] */
+  SYSADMIN: 1,
+  /** Support user has view permissions only for all accounts that enabled option Enable Support [2] */
+  SUPPORT: 2,
+  /** Account user - has access to specific accounts with role based access control [3] */
+  USER: 3,
+  /** Service Account - to be used by other systems to perform actions using the API (can't login as a user to the portal) [4] */
+  SERVICE: 4,
+} as const;
+
+/** UserTypeCode names by value */
+export const UserTypeCodeNames: { [value: number]: string } = {
+  0: 'UNDEFINED',
+  1: 'SYSADMIN',
+  2: 'SUPPORT',
+  3: 'USER',
+  4: 'SERVICE',
+};
@@ -0,0 +1,80 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Injectable } from '@angular/core';
+import { Observable } from 'rxjs';
+import { Account } from '../model/entities';
+import { AccountStatusCode } from '../model/enums';
+import { ActionResponse, EntitiesResponse, EntityResponse } from '../model/responses';
+import { RestApiClient } from '../rest-api.client';
+
+/** AccountsEndPoint Services for user registration and login */
+@Injectable({ providedIn: 'root' })
+export class AccountsService {
+
+  constructor(private api: RestApiClient) {}
+
+  /**
+   * Find accounts by query
+   * @param params.search filter accounts by free text search on account id, account name
+   * @param params.filter filter expression (e.g. status in (ACTIVE,BLOCKED) and name like 'acme*')
+   * @param params.status filter accounts by status(s)
+   * @param params.sort sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+   * @param params.page page number (for pagination)
+   * @param params.size number of items per page (for pagination)
+   * @param params.fields list of fields (json paths) to include in the results (e.g. id,name,status)
+   */
+  find(params?: { search?: string; filter?: string; status?: AccountStatusCode[]; sort?: string; page?: number; size?: number; fields?: string[] }): Observable<EntitiesResponse<Account>> {
+    return this.api.request<EntitiesResponse<Account>>('GET', '/v2/accounts', params, undefined, 'json');
+  }
+
+  /**
+   * Create new account
+   * @param body account data to create
+   */
+  create(body: Account): Observable<EntityResponse<Account>> {
+    return this.api.request<EntityResponse<Account>>('POST', '/v2/accounts', undefined, body, 'json');
+  }
+
+  /**
+   * Update existing account
+   * @param body account data to update
+   */
+  update(body: Account): Observable<EntityResponse<Account>> {
+    return this.api.request<EntityResponse<Account>>('PUT', '/v2/accounts', undefined, body, 'json');
+  }
+
+  /**
+   * Export accounts by query to file (csv, ndjson or xlsx), all the matching accounts are exported (no pagination)
+   * @param params.format export file format: csv | ndjson | xlsx (default: csv)
+   * @param params.search filter accounts by free text search on account id, account name
+   * @param params.filter filter expression (e.g. status in (ACTIVE,BLOCKED) and name like 'acme*')
+   * @param params.status filter accounts by status(s)
+   * @param params.sort sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+   * @param params.fields list of fields (json paths) to export (default: all fields) (e.g. id,name,status)
+   */
+  export(params?: { format?: string; search?: string; filter?: string; status?: AccountStatusCode[]; sort?: string; fields?: string[] }): Observable<Blob> {
+    return this.api.request<Blob>('GET', '/v2/accounts/export', params, undefined, 'blob');
+  }
+
+  /** Get new and empty account template */
+  new(): Observable<EntityResponse<Account>> {
+    return this.api.request<EntityResponse<Account>>('POST', '/v2/accounts/new', undefined, undefined, 'json');
+  }
+
+  /**
+   * Delete account and all its content
+   * @param id account ID to delete
+   */
+  delete(id: string): Observable<ActionResponse> {
+    return this.api.request<ActionResponse>('DELETE', `/v2/accounts/${encodeURIComponent(id)}`, undefined, undefined, 'json');
+  }
+
+  /**
+   * Get a single account by id
+   * @param id account ID to fetch
+   * @param params.fields list of fields (json paths) to include in the result (e.g. id,name,status)
+   */
+  get(id: string, params?: { fields?: string[] }): Observable<EntityResponse<Account>> {
+    return this.api.request<EntityResponse<Account>>('GET', `/v2/accounts/${encodeURIComponent(id)}`, params, undefined, 'json');
+  }
+}
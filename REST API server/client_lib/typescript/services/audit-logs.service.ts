@@ -0,0 +1,89 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Injectable } from '@angular/core';
+import { Observable } from 'rxjs';
+import { AuditLog } from '../model/entities';
+import { EntitiesResponse, EntityResponse, TimeSeries } from '../model/responses';
+import { RestApiClient } from '../rest-api.client';
+
+/** AuditLogsEndPoint Services for auditLogs actions */
+@Injectable({ providedIn: 'root' })
+export class AuditLogsService {
+
+  constructor(private api: RestApiClient) {}
+
+  /**
+   * Find auditLogs by query
+   * @param params.from start of time range filter
+   * @param params.to end of time range filter
+   * @param params.userId filter auditLogs by user id
+   * @param params.action filter auditLogs by action
+   * @param params.itemType filter auditLogs by item type
+   * @param params.itemId filter auditLogs by item id
+   * @param params.itemName filter auditLogs by item name
+   * @param params.search filter auditLogs by free text search
+   * @param params.filter filter expression (e.g. action = 'Delete' and not itemType in ('user','account'))
+   * @param params.sort sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+   * @param params.page page number (for pagination)
+   * @param params.size number of items per page (for pagination)
+   * @param params.fields list of fields (json paths) to include in the results (e.g. id,action,itemName)
+   */
+  find(params?: { from?: number; to?: number; userId?: string; action?: string; itemType?: string; itemId?: string; itemName?: string; search?: string; filter?: string; sort?: string; page?: number; size?: number; fields?: string[] }): Observable<EntitiesResponse<AuditLog>> {
+    return this.api.request<EntitiesResponse<AuditLog>>('GET', '/v2/audit_logs', params, undefined, 'json');
+  }
+
+  /**
+   * Create new auditLog
+   * @param body auditLog data to create
+   */
+  create(body: AuditLog): Observable<EntityResponse<AuditLog>> {
+    return this.api.request<EntityResponse<AuditLog>>('POST', '/v2/audit_logs', undefined, body, 'json');
+  }
+
+  /**
+   * Export auditLogs by query to file (csv, ndjson or xlsx), all the matching auditLogs are exported (no pagination)
+   * @param params.format export file format: csv | ndjson | xlsx (default: csv)
+   * @param params.from start of time range filter
+   * @param params.to end of time range filter
+   * @param params.userId filter auditLogs by user id
+   * @param params.action filter auditLogs by action
+   * @param params.itemType filter auditLogs by item type
+   * @param params.itemId filter auditLogs by item id
+   * @param params.itemName filter auditLogs by item name
+   * @param params.search filter auditLogs by free text search
+   * @param params.filter filter expression (e.g. action = 'Delete' and not itemType in ('user','account'))
+   * @param params.sort sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+   * @param params.fields list of fields (json paths) to export (default: all fields) (e.g. id,action,itemName)
+   */
+  export(params?: { format?: string; from?: number; to?: number; userId?: string; action?: string; itemType?: string; itemId?: string; itemName?: string; search?: string; filter?: string; sort?: string; fields?: string[] }): Observable<Blob> {
+    return this.api.request<Blob>('GET', '/v2/audit_logs/export', params, undefined, 'blob');
+  }
+
+  /**
+   * Find auditLogs count histogram over time
+   * @param params.from start of time range filter
+   * @param params.to end of time range filter
+   * @param params.userId filter auditLogs by user id
+   * @param params.action filter auditLogs by action
+   * @param params.itemType filter auditLogs by item type
+   * @param params.itemId filter auditLogs by item id
+   * @param params.itemName filter auditLogs by item name
+   * @param params.search filter auditLogs by free text search
+   * @param params.filter filter expression (e.g. action = 'Delete' and not itemType in ('user','account'))
+   * @param params.sort sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+   * @param params.page page number (for pagination)
+   * @param params.size number of items per page (for pagination)
+   */
+  histogram(params?: { from?: number; to?: number; userId?: string; action?: string; itemType?: string; itemId?: string; itemName?: string; search?: string; filter?: string; sort?: string; page?: number; size?: number }): Observable<EntityResponse<TimeSeries<number>>> {
+    return this.api.request<EntityResponse<TimeSeries<number>>>('GET', '/v2/audit_logs/histogram', params, undefined, 'json');
+  }
+
+  /**
+   * Get a single auditLog by id
+   * @param id auditLog ID to fetch
+   * @param params.fields list of fields (json paths) to include in the result (e.g. id,action,itemName)
+   */
+  get(id: string, params?: { fields?: string[] }): Observable<EntityResponse<AuditLog>> {
+    return this.api.request<EntityResponse<AuditLog>>('GET', `/v2/audit_logs/${encodeURIComponent(id)}`, params, undefined, 'json');
+  }
+}
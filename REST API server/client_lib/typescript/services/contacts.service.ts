@@ -0,0 +1,93 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Injectable } from '@angular/core';
+import { Observable } from 'rxjs';
+import { ImportReport } from '../model/common';
+import { Contact } from '../model/entities';
+import { StatusCode } from '../model/enums';
+import { ActionResponse, EntitiesResponse, EntityResponse } from '../model/responses';
+import { RestApiClient } from '../rest-api.client';
+
+/** ContactsEndPoint Services for contacts actions */
+@Injectable({ providedIn: 'root' })
+export class ContactsService {
+
+  constructor(private api: RestApiClient) {}
+
+  /**
+   * Find contacts by query
+   * @param params.search filter contacts by free text search
+   * @param params.filter filter expression (e.g. address.city = 'London' or name like 'john*')
+   * @param params.status filter contacts by status(es)
+   * @param params.sort sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+   * @param params.page page number (for pagination)
+   * @param params.size number of items per page (for pagination)
+   * @param params.fields list of fields (json paths) to include in the results (e.g. id,name,address.city)
+   */
+  find(params?: { search?: string; filter?: string; status?: StatusCode[]; sort?: string; page?: number; size?: number; fields?: string[] }): Observable<EntitiesResponse<Contact>> {
+    return this.api.request<EntitiesResponse<Contact>>('GET', '/v2/contacts', params, undefined, 'json');
+  }
+
+  /**
+   * Create new contact
+   * @param body contact data to create
+   */
+  create(body: Contact): Observable<EntityResponse<Contact>> {
+    return this.api.request<EntityResponse<Contact>>('POST', '/v2/contacts', undefined, body, 'json');
+  }
+
+  /**
+   * Update existing contact
+   * @param body contact data to update
+   */
+  update(body: Contact): Observable<EntityResponse<Contact>> {
+    return this.api.request<EntityResponse<Contact>>('PUT', '/v2/contacts', undefined, body, 'json');
+  }
+
+  /**
+   * Export contacts by query to file (csv, ndjson or xlsx), all the matching contacts are exported (no pagination)
+   * @param params.format export file format: csv | ndjson | xlsx (default: csv)
+   * @param params.search filter contacts by free text search
+   * @param params.filter filter expression (e.g. address.city = 'London' or name like 'john*')
+   * @param params.status filter contacts by status(es)
+   * @param params.sort sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+   * @param params.fields list of fields (json paths) to export (default: all fields) (e.g. id,name,address.city)
+   */
+  export(params?: { format?: string; search?: string; filter?: string; status?: StatusCode[]; sort?: string; fields?: string[] }): Observable<Blob> {
+    return this.api.request<Blob>('GET', '/v2/contacts/export', params, undefined, 'blob');
+  }
+
+  /**
+   * Import contacts from CSV or vCard file, run in dry-run mode first to get the validation report and then commit
+   * @param file CSV / vCard file (multipart form field or the raw request body)
+   * @param params.format file format: csv | vcard (default: by the file extension or csv)
+   * @param params.mapping CSV column mapping json: contact field -> column header (e.g. {"name":"Full Name","address.city":"City"})
+   * @param params.accountId related account of the imported contacts (rows of other accounts are invalid)
+   * @param params.dryRun validate the file without saving the contacts (default: true)
+   */
+  importFile(file: Blob, params?: { format?: string; mapping?: string; accountId?: string; dryRun?: boolean }): Observable<EntityResponse<ImportReport>> {
+    return this.api.request<EntityResponse<ImportReport>>('POST', '/v2/contacts/import', params, this.api.formData('file', file), 'json');
+  }
+
+  /** Get new and empty contact template */
+  new(): Observable<EntityResponse<Contact>> {
+    return this.api.request<EntityResponse<Contact>>('POST', '/v2/contacts/new', undefined, undefined, 'json');
+  }
+
+  /**
+   * Delete contact and all its content
+   * @param id contact ID to delete
+   */
+  delete(id: string): Observable<ActionResponse> {
+    return this.api.request<ActionResponse>('DELETE', `/v2/contacts/${encodeURIComponent(id)}`, undefined, undefined, 'json');
+  }
+
+  /**
+   * Get a single contact by id
+   * @param id contact ID to fetch
+   * @param params.fields list of fields (json paths) to include in the result (e.g. id,name,address.city)
+   */
+  get(id: string, params?: { fields?: string[] }): Observable<EntityResponse<Contact>> {
+    return this.api.request<EntityResponse<Contact>>('GET', `/v2/contacts/${encodeURIComponent(id)}`, params, undefined, 'json');
+  }
+}
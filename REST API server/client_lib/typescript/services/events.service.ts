@@ -0,0 +1,26 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Injectable } from '@angular/core';
+import { Observable } from 'rxjs';
+import { EntityEvent } from '../model/common';
+import { RestApiClient } from '../rest-api.client';
+
+/** EventsEndPoint Stream of entity change events (create, update and delete) over Server-Sent Events or WebSocket */
+@Injectable({ providedIn: 'root' })
+export class EventsService {
+
+  constructor(private api: RestApiClient) {}
+
+  /**
+   * Stream the entity change events as Server-Sent Events, or as WebSocket json messages when the request is WebSocket
+   * upgrade. The stream includes the events of the entities visible to the token subject, to resume after reconnect
+   * provide the last received event ID (when the missed events are no longer available the stream starts with Reset
+   * event and the client should reload its data)
+   * @param params.itemType filter by item type: account | contact | user | users_group | audit_log (default: all)
+   * @param params.itemId filter by item ID (default: all)
+   * @param params.lastEventId resume after the last received event ID (or Last-Event-ID header)
+   */
+  stream(params?: { itemType?: string[]; itemId?: string[]; lastEventId?: number }): Observable<EntityEvent> {
+    return this.api.events<EntityEvent>('/v2/events', params);
+  }
+}
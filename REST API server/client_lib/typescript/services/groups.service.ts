@@ -0,0 +1,77 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Injectable } from '@angular/core';
+import { Observable } from 'rxjs';
+import { UsersGroup } from '../model/entities';
+import { ActionResponse, EntitiesResponse, EntityResponse } from '../model/responses';
+import { RestApiClient } from '../rest-api.client';
+
+/** GroupsEndPoint Services for groups actions */
+@Injectable({ providedIn: 'root' })
+export class GroupsService {
+
+  constructor(private api: RestApiClient) {}
+
+  /**
+   * Find groups by query
+   * @param params.search filter groups by free text search
+   * @param params.filter filter expression (e.g. members = 'user@org.io')
+   * @param params.sort sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+   * @param params.page page number (for pagination)
+   * @param params.size number of items per page (for pagination)
+   * @param params.fields list of fields (json paths) to include in the results (e.g. id,name,members)
+   */
+  find(params?: { search?: string; filter?: string; sort?: string; page?: number; size?: number; fields?: string[] }): Observable<EntitiesResponse<UsersGroup>> {
+    return this.api.request<EntitiesResponse<UsersGroup>>('GET', '/v2/groups', params, undefined, 'json');
+  }
+
+  /**
+   * Create new group
+   * @param body group data to create
+   */
+  create(body: UsersGroup): Observable<EntityResponse<UsersGroup>> {
+    return this.api.request<EntityResponse<UsersGroup>>('POST', '/v2/groups', undefined, body, 'json');
+  }
+
+  /**
+   * Update existing group
+   * @param body group data to update
+   */
+  update(body: UsersGroup): Observable<EntityResponse<UsersGroup>> {
+    return this.api.request<EntityResponse<UsersGroup>>('PUT', '/v2/groups', undefined, body, 'json');
+  }
+
+  /**
+   * Export groups by query to file (csv, ndjson or xlsx), all the matching groups are exported (no pagination)
+   * @param params.format export file format: csv | ndjson | xlsx (default: csv)
+   * @param params.search filter groups by free text search
+   * @param params.filter filter expression (e.g. members = 'user@org.io')
+   * @param params.sort sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+   * @param params.fields list of fields (json paths) to export (default: all fields) (e.g. id,name,members)
+   */
+  export(params?: { format?: string; search?: string; filter?: string; sort?: string; fields?: string[] }): Observable<Blob> {
+    return this.api.request<Blob>('GET', '/v2/groups/export', params, undefined, 'blob');
+  }
+
+  /** Get new and empty flight template */
+  new(): Observable<EntityResponse<UsersGroup>> {
+    return this.api.request<EntityResponse<UsersGroup>>('POST', '/v2/groups/new', undefined, undefined, 'json');
+  }
+
+  /**
+   * Delete group and all its content
+   * @param id group ID to delete
+   */
+  delete(id: string): Observable<ActionResponse> {
+    return this.api.request<ActionResponse>('DELETE', `/v2/groups/${encodeURIComponent(id)}`, undefined, undefined, 'json');
+  }
+
+  /**
+   * Get a single group by id
+   * @param id group ID to fetch
+   * @param params.fields list of fields (json paths) to include in the result (e.g. id,name,members)
+   */
+  get(id: string, params?: { fields?: string[] }): Observable<EntityResponse<UsersGroup>> {
+    return this.api.request<EntityResponse<UsersGroup>>('GET', `/v2/groups/${encodeURIComponent(id)}`, params, undefined, 'json');
+  }
+}
@@ -0,0 +1,33 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Injectable } from '@angular/core';
+import { Observable } from 'rxjs';
+import { HealthStatus } from '../model/common';
+import { ActionResponse, EntityResponse } from '../model/responses';
+import { RestApiClient } from '../rest-api.client';
+
+/** HealthEndPoint for health check */
+@Injectable({ providedIn: 'root' })
+export class HealthService {
+
+  constructor(private api: RestApiClient) {}
+
+  /** Root handler returns the current version number of the API */
+  root(): Observable<ActionResponse> {
+    return this.api.request<ActionResponse>('GET', '/', undefined, undefined, 'json');
+  }
+
+  /** Liveness probe: the service process is running and serving requests (no dependency checks) */
+  live(): Observable<ActionResponse> {
+    return this.api.request<ActionResponse>('GET', '/health/live', undefined, undefined, 'json');
+  }
+
+  /**
+   * Readiness probe: the service is ready to accept traffic, the database and data cache are checked with timeout and the
+   * response includes the status and latency of each dependency. Returns 503 (Service Unavailable) while the service is
+   * starting (e.g. verifying the database schema), draining (shutting down) or when any dependency is down
+   */
+  ready(): Observable<EntityResponse<HealthStatus>> {
+    return this.api.request<EntityResponse<HealthStatus>>('GET', '/health/ready', undefined, undefined, 'json');
+  }
+}
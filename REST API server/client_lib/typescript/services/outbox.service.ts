@@ -0,0 +1,54 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Injectable } from '@angular/core';
+import { Observable } from 'rxjs';
+import { OutboxStats } from '../model/common';
+import { OutboxEntry } from '../model/entities';
+import { OutboxStatusCode } from '../model/enums';
+import { EntitiesResponse, EntityResponse } from '../model/responses';
+import { RestApiClient } from '../rest-api.client';
+
+/**
+ * OutboxEndPoint Services to monitor the transactional outbox (events saved with the entity changes and relayed to the
+ * message bus and webhooks) and retry failed entries, available to the system administrator only
+ */
+@Injectable({ providedIn: 'root' })
+export class OutboxService {
+
+  constructor(private api: RestApiClient) {}
+
+  /**
+   * Find outbox entries by query
+   * @param params.topic filter entries by topic (e.g. domain.UserCreated, entity.*)
+   * @param params.status filter entries by status(s)
+   * @param params.stuck filter stuck entries: waiting to be relayed longer than the stuck threshold
+   * @param params.sort sort results by field and direction (default: createdOn = oldest first)
+   * @param params.page page number (for pagination)
+   * @param params.size number of items per page (for pagination)
+   */
+  find(params?: { topic?: string; status?: OutboxStatusCode[]; stuck?: boolean; sort?: string; page?: number; size?: number }): Observable<EntitiesResponse<OutboxEntry>> {
+    return this.api.request<EntitiesResponse<OutboxEntry>>('GET', '/v2/outbox', params, undefined, 'json');
+  }
+
+  /** Get the state of the outbox: number of pending, stuck and failed entries and the oldest pending entry */
+  stats(): Observable<EntityResponse<OutboxStats>> {
+    return this.api.request<EntityResponse<OutboxStats>>('GET', '/v2/outbox/stats', undefined, undefined, 'json');
+  }
+
+  /**
+   * Get a single outbox entry by id (including the event payload)
+   * @param id outbox entry ID to fetch
+   */
+  get(id: string): Observable<EntityResponse<OutboxEntry>> {
+    return this.api.request<EntityResponse<OutboxEntry>>('GET', `/v2/outbox/${encodeURIComponent(id)}`, undefined, undefined, 'json');
+  }
+
+  /**
+   * Retry the relay of outbox entry (e.g. failed entry after the message bus was fixed), the entry is relayed again and
+   * the number of attempts is reset (sent entries can't be retried)
+   * @param id outbox entry ID to retry
+   */
+  retry(id: string): Observable<EntityResponse<OutboxEntry>> {
+    return this.api.request<EntityResponse<OutboxEntry>>('POST', `/v2/outbox/${encodeURIComponent(id)}/retry`, undefined, undefined, 'json');
+  }
+}
@@ -0,0 +1,24 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Injectable } from '@angular/core';
+import { Observable } from 'rxjs';
+import { LoginParams } from '../model/common';
+import { User } from '../model/entities';
+import { EntityResponse } from '../model/responses';
+import { RestApiClient } from '../rest-api.client';
+
+/** UserEndPoint Services for user registration and login */
+@Injectable({ providedIn: 'root' })
+export class UserService {
+
+  constructor(private api: RestApiClient) {}
+
+  /**
+   * Authorize user, verify user exists in the system
+   * The response includes access token valid for 20 minutes. The client side should renew the token before expiration using refresh-token method
+   * @param body User verified email
+   */
+  authorize(body: LoginParams): Observable<EntityResponse<User>> {
+    return this.api.request<EntityResponse<User>>('POST', '/v2/user/authorize', undefined, body, 'json');
+  }
+}
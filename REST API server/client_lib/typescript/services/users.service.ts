@@ -0,0 +1,82 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Injectable } from '@angular/core';
+import { Observable } from 'rxjs';
+import { User } from '../model/entities';
+import { UserStatusCode, UserTypeCode } from '../model/enums';
+import { ActionResponse, EntitiesResponse, EntityResponse } from '../model/responses';
+import { RestApiClient } from '../rest-api.client';
+
+/** UsersEndPoint Services for users actions */
+@Injectable({ providedIn: 'root' })
+export class UsersService {
+
+  constructor(private api: RestApiClient) {}
+
+  /**
+   * Find users by query
+   * @param params.search filter users by free text search
+   * @param params.filter filter expression (e.g. type = USER and (status = PENDING or lastSignIn < '2024-01-01'))
+   * @param params.type filter users by type(s)
+   * @param params.status filter users by status(es)
+   * @param params.sort sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+   * @param params.page page number (for pagination)
+   * @param params.size number of items per page (for pagination)
+   * @param params.fields list of fields (json paths) to include in the results (e.g. id,name,status)
+   */
+  find(params?: { search?: string; filter?: string; type?: UserTypeCode[]; status?: UserStatusCode[]; sort?: string; page?: number; size?: number; fields?: string[] }): Observable<EntitiesResponse<User>> {
+    return this.api.request<EntitiesResponse<User>>('GET', '/v2/users', params, undefined, 'json');
+  }
+
+  /**
+   * Create new user
+   * @param body user data to create
+   */
+  create(body: User): Observable<EntityResponse<User>> {
+    return this.api.request<EntityResponse<User>>('POST', '/v2/users', undefined, body, 'json');
+  }
+
+  /**
+   * Update existing user
+   * @param body user data to update
+   */
+  update(body: User): Observable<EntityResponse<User>> {
+    return this.api.request<EntityResponse<User>>('PUT', '/v2/users', undefined, body, 'json');
+  }
+
+  /**
+   * Export users by query to file (csv, ndjson or xlsx), all the matching users are exported (no pagination)
+   * @param params.format export file format: csv | ndjson | xlsx (default: csv)
+   * @param params.search filter users by free text search
+   * @param params.filter filter expression (e.g. type = USER and (status = PENDING or lastSignIn < '2024-01-01'))
+   * @param params.type filter users by type(s)
+   * @param params.status filter users by status(es)
+   * @param params.sort sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+   * @param params.fields list of fields (json paths) to export (default: all fields) (e.g. id,name,status)
+   */
+  export(params?: { format?: string; search?: string; filter?: string; type?: UserTypeCode[]; status?: UserStatusCode[]; sort?: string; fields?: string[] }): Observable<Blob> {
+    return this.api.request<Blob>('GET', '/v2/users/export', params, undefined, 'blob');
+  }
+
+  /** Get new and empty user template */
+  new(): Observable<EntityResponse<User>> {
+    return this.api.request<EntityResponse<User>>('POST', '/v2/users/new', undefined, undefined, 'json');
+  }
+
+  /**
+   * Delete user and all its content
+   * @param id user ID to delete
+   */
+  delete(id: string): Observable<ActionResponse> {
+    return this.api.request<ActionResponse>('DELETE', `/v2/users/${encodeURIComponent(id)}`, undefined, undefined, 'json');
+  }
+
+  /**
+   * Get a single user by id
+   * @param id user ID to fetch
+   * @param params.fields list of fields (json paths) to include in the result (e.g. id,name,status)
+   */
+  get(id: string, params?: { fields?: string[] }): Observable<EntityResponse<User>> {
+    return this.api.request<EntityResponse<User>>('GET', `/v2/users/${encodeURIComponent(id)}`, params, undefined, 'json');
+  }
+}
@@ -0,0 +1,19 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Injectable } from '@angular/core';
+import { Observable } from 'rxjs';
+import { ApiVersionInfo } from '../model/common';
+import { EntitiesResponse } from '../model/responses';
+import { RestApiClient } from '../rest-api.client';
+
+/** VersionsEndPoint for the API versions and route table (documentation) */
+@Injectable({ providedIn: 'root' })
+export class VersionsService {
+
+  constructor(private api: RestApiClient) {}
+
+  /** Get the API versions (including deprecation and sunset dates) and the routes of each version */
+  list(): Observable<EntitiesResponse<ApiVersionInfo>> {
+    return this.api.request<EntitiesResponse<ApiVersionInfo>>('GET', '/versions', undefined, undefined, 'json');
+  }
+}
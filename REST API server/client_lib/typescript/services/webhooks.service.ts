@@ -0,0 +1,98 @@
+// Code generated by cmd/tsclient from the model and endpoints annotations. DO NOT EDIT.
+
+import { Injectable } from '@angular/core';
+import { Observable } from 'rxjs';
+import { Webhook, WebhookDelivery } from '../model/entities';
+import { DeliveryStatusCode } from '../model/enums';
+import { ActionResponse, EntitiesResponse, EntityResponse } from '../model/responses';
+import { RestApiClient } from '../rest-api.client';
+
+/**
+ * WebhooksEndPoint Services to manage the webhooks (subscriptions of external receivers to the entity change events)
+ * and their delivery history, available to the system administrator only
+ */
+@Injectable({ providedIn: 'root' })
+export class WebhooksService {
+
+  constructor(private api: RestApiClient) {}
+
+  /**
+   * Find webhooks by query (without the secret)
+   * @param params.search filter webhooks by free text search on webhook id, name and url
+   * @param params.accountId filter webhooks by account
+   * @param params.sort sort results by field and direction: (e.g. name = sort by name asc, name- = sort by name desc)
+   * @param params.page page number (for pagination)
+   * @param params.size number of items per page (for pagination)
+   * @param params.fields list of fields (json paths) to include in the results (e.g. id,name,url)
+   */
+  find(params?: { search?: string; accountId?: string; sort?: string; page?: number; size?: number; fields?: string[] }): Observable<EntitiesResponse<Webhook>> {
+    return this.api.request<EntitiesResponse<Webhook>>('GET', '/v2/webhooks', params, undefined, 'json');
+  }
+
+  /**
+   * Create new webhook, the secret is generated if not provided (the secret is returned only in the create response)
+   * @param body webhook data to create
+   */
+  create(body: Webhook): Observable<EntityResponse<Webhook>> {
+    return this.api.request<EntityResponse<Webhook>>('POST', '/v2/webhooks', undefined, body, 'json');
+  }
+
+  /**
+   * Update existing webhook, the secret is kept if not provided
+   * @param body webhook data to update
+   */
+  update(body: Webhook): Observable<EntityResponse<Webhook>> {
+    return this.api.request<EntityResponse<Webhook>>('PUT', '/v2/webhooks', undefined, body, 'json');
+  }
+
+  /**
+   * Get a single webhook delivery by id (including the posted payload)
+   * @param id delivery ID to fetch
+   */
+  delivery(id: string): Observable<EntityResponse<WebhookDelivery>> {
+    return this.api.request<EntityResponse<WebhookDelivery>>('GET', `/v2/webhooks/deliveries/${encodeURIComponent(id)}`, undefined, undefined, 'json');
+  }
+
+  /**
+   * Redeliver the webhook payload (e.g. failed delivery after the receiver was fixed), the delivery is attempted again
+   * with the same delivery ID (X-Webhook-Id header) and the number of attempts is reset
+   * @param id delivery ID to redeliver
+   */
+  redeliver(id: string): Observable<EntityResponse<WebhookDelivery>> {
+    return this.api.request<EntityResponse<WebhookDelivery>>('POST', `/v2/webhooks/deliveries/${encodeURIComponent(id)}/redeliver`, undefined, undefined, 'json');
+  }
+
+  /** Get new and empty webhook template */
+  new(): Observable<EntityResponse<Webhook>> {
+    return this.api.request<EntityResponse<Webhook>>('POST', '/v2/webhooks/new', undefined, undefined, 'json');
+  }
+
+  /**
+   * Delete webhook
+   * @param id webhook ID to delete
+   */
+  delete(id: string): Observable<ActionResponse> {
+    return this.api.request<ActionResponse>('DELETE', `/v2/webhooks/${encodeURIComponent(id)}`, undefined, undefined, 'json');
+  }
+
+  /**
+   * Get a single webhook by id (without the secret)
+   * @param id webhook ID to fetch
+   * @param params.fields list of fields (json paths) to include in the result (e.g. id,name,url)
+   */
+  get(id: string, params?: { fields?: string[] }): Observable<EntityResponse<Webhook>> {
+    return this.api.request<EntityResponse<Webhook>>('GET', `/v2/webhooks/${encodeURIComponent(id)}`, params, undefined, 'json');
+  }
+
+  /**
+   * Get the delivery history of the webhook
+   * @param id webhook ID
+   * @param params.status filter deliveries by status(s)
+   * @param params.sort sort results by field and direction (default: createdOn- = latest first)
+   * @param params.page page number (for pagination)
+   * @param params.size number of items per page (for pagination)
+   */
+  deliveries(id: string, params?: { status?: DeliveryStatusCode[]; sort?: string; page?: number; size?: number }): Observable<EntitiesResponse<WebhookDelivery>> {
+    return this.api.request<EntitiesResponse<WebhookDelivery>>('GET', `/v2/webhooks/${encodeURIComponent(id)}/deliveries`, params, undefined, 'json');
+  }
+}
@@ -0,0 +1,83 @@
+// The openapi command generates the OpenAPI 3.1 document of the REST API from the endpoints and domain model annotations
+//
+// Usage (from the module root folder):
+//
+//	go run ./cmd/openapi                 generate ./doc/openapi.json
+//	go run ./cmd/openapi -check          verify that all the routes are annotated and the document is up-to-date
+//
+// The check mode exits with non-zero status when a route has no annotations, an annotation does not match its route,
+// a referenced type is unknown or the generated document is different from the existing one (CI gate)
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/apidoc"
+)
+
+// Default values of the command flags
+const (
+	defaultOut     = "doc/openapi.json"
+	defaultTitle   = "REST API Server"
+	defaultVersion = "1.0.0"
+)
+
+func main() {
+	root := flag.String("root", ".", "module root folder")
+	out := flag.String("out", defaultOut, "output file (relative to the module root folder)")
+	title := flag.String("title", defaultTitle, "API title")
+	version := flag.String("version", defaultVersion, "API version")
+	check := flag.Bool("check", false, "verify the annotations and that the output file is up-to-date, without writing it")
+	flag.Parse()
+
+	api, content, err := generate(*root, *title, *version)
+	if err != nil {
+		if api != nil {
+			for _, problem := range api.Problems {
+				_, _ = fmt.Fprintln(os.Stderr, problem)
+			}
+		}
+		fail("%v", err)
+	}
+
+	path := filepath.Join(*root, *out)
+	if *check {
+		if existing, er := os.ReadFile(path); er != nil || !bytes.Equal(existing, content) {
+			fail("%s is not up-to-date, run: go run ./cmd/openapi", *out)
+		}
+		fmt.Printf("%s is up-to-date (%d services)\n", *out, len(api.Services))
+		return
+	}
+
+	if err = os.WriteFile(path, content, 0644); err != nil {
+		fail("failed to write %s: %v", path, err)
+	}
+	fmt.Printf("%s generated (%d services)\n", *out, len(api.Services))
+}
+
+// Parse the API of the module in the root folder and create the OpenAPI document, fails if there are annotation problems
+// (the parsed API is returned with the problems)
+func generate(root, title, version string) (*apidoc.Api, []byte, error) {
+	api, err := apidoc.Parse(root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the API: %v", err)
+	}
+	if len(api.Problems) > 0 {
+		return api, nil, fmt.Errorf("found %d annotation problems", len(api.Problems))
+	}
+
+	content, err := api.OpenAPIJson(title, version)
+	if err != nil {
+		return api, nil, fmt.Errorf("failed to create the OpenAPI document: %v", err)
+	}
+	return api, append(content, '\n'), nil
+}
+
+func fail(format string, args ...any) {
+	_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
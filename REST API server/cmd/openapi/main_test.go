@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// The module root folder relative to the package folder
+const moduleRoot = "../.."
+
+// TestOpenAPIUpToDate fails when a route is not annotated or the OpenAPI document is different from the routes and the
+// domain model (run: go run ./cmd/openapi)
+func TestOpenAPIUpToDate(t *testing.T) {
+	api, content, err := generate(moduleRoot, defaultTitle, defaultVersion)
+	if err != nil {
+		if api != nil {
+			for _, problem := range api.Problems {
+				t.Error(problem)
+			}
+		}
+		t.Fatal(err)
+	}
+
+	existing, err := os.ReadFile(filepath.Join(moduleRoot, defaultOut))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(existing, content) {
+		t.Fatalf("%s is not up-to-date, run: go run ./cmd/openapi", defaultOut)
+	}
+}
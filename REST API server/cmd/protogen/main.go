@@ -0,0 +1,169 @@
+// The protogen command generates the protobuf definitions and the Go gRPC code of the gRPC API from the endpoints and
+// domain model annotations
+//
+// Usage (from the module root folder):
+//
+//	go run ./cmd/protogen                generate ./rpc/pb (api.proto, api.pb.go, api_grpc.pb.go)
+//	go run ./cmd/protogen -check         verify that the generated files are up-to-date
+//
+// The definitions are built from the API model (no protoc required), the Go code is generated by the protoc-gen-go and
+// protoc-gen-go-grpc plugins, which must be installed in the PATH (the versions are part of the generated code):
+//
+//	go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.36.8
+//	go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.5.1
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/apidoc"
+)
+
+// Protobuf definitions file, package and Go package of the generated code
+const (
+	protoFile    = "api.proto"
+	protoPackage = "restapi.v1"
+	goPackage    = "github.com/go-yaaf/yaaf-examples/rest-api/rpc/pb;pb"
+)
+
+// Code generator plugins (executed in this order)
+var plugins = []string{"protoc-gen-go", "protoc-gen-go-grpc"}
+
+func main() {
+	root := flag.String("root", ".", "module root folder")
+	out := flag.String("out", "rpc/pb", "output folder (relative to the module root folder)")
+	check := flag.Bool("check", false, "verify that the output folder is up-to-date, without writing it")
+	flag.Parse()
+
+	api, err := apidoc.Parse(*root)
+	if err != nil {
+		fail("failed to parse the API: %v", err)
+	}
+
+	for _, problem := range api.Problems {
+		_, _ = fmt.Fprintln(os.Stderr, problem)
+	}
+	if len(api.Problems) > 0 {
+		fail("found %d annotation problems", len(api.Problems))
+	}
+
+	fd := api.Proto(protoFile, protoPackage, goPackage)
+	files, err := generate(fd)
+	if err != nil {
+		fail("%v", err)
+	}
+	files[protoFile] = apidoc.ProtoText(fd)
+
+	folder := filepath.Join(*root, *out)
+	stale := staleFiles(folder, files)
+
+	if *check {
+		outdated := append([]string{}, stale...)
+		for name, content := range files {
+			if existing, er := os.ReadFile(filepath.Join(folder, name)); er != nil || !bytes.Equal(existing, content) {
+				outdated = append(outdated, name)
+			}
+		}
+		if len(outdated) > 0 {
+			sort.Strings(outdated)
+			fail("%s is not up-to-date (%s), run: go run ./cmd/protogen", *out, strings.Join(outdated, ", "))
+		}
+		fmt.Printf("%s is up-to-date (%d files)\n", *out, len(files))
+		return
+	}
+
+	for _, name := range stale {
+		if err = os.Remove(filepath.Join(folder, name)); err != nil {
+			fail("failed to remove %s: %v", name, err)
+		}
+	}
+	if err = os.MkdirAll(folder, 0755); err != nil {
+		fail("failed to create folder %s: %v", folder, err)
+	}
+	for name, content := range files {
+		if err = os.WriteFile(filepath.Join(folder, name), content, 0644); err != nil {
+			fail("failed to write %s: %v", name, err)
+		}
+	}
+	fmt.Printf("%s generated (%d files)\n", *out, len(files))
+}
+
+// Generate the Go code of the file descriptor by the plugins (the same request protoc sends to the plugins)
+func generate(fd *descriptorpb.FileDescriptorProto) (map[string][]byte, error) {
+	deps := []protoreflect.FileDescriptor{structpb.File_google_protobuf_struct_proto, emptypb.File_google_protobuf_empty_proto}
+
+	// Validate the definitions before running the plugins
+	if _, err := protodesc.NewFile(fd, protoregistry.GlobalFiles); err != nil {
+		return nil, fmt.Errorf("invalid protobuf definitions: %w", err)
+	}
+
+	request := &pluginpb.CodeGeneratorRequest{FileToGenerate: []string{fd.GetName()}, Parameter: proto.String("paths=source_relative")}
+	for _, dep := range deps {
+		request.ProtoFile = append(request.ProtoFile, protodesc.ToFileDescriptorProto(dep))
+	}
+	request.ProtoFile = append(request.ProtoFile, fd)
+	input, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for _, plugin := range plugins {
+		cmd := exec.Command(plugin)
+		cmd.Stdin, cmd.Stderr = bytes.NewReader(input), os.Stderr
+		output, er := cmd.Output()
+		if er != nil {
+			return nil, fmt.Errorf("failed to run %s (install the plugins, see: go doc ./cmd/protogen): %w", plugin, er)
+		}
+
+		response := &pluginpb.CodeGeneratorResponse{}
+		if er = proto.Unmarshal(output, response); er != nil {
+			return nil, fmt.Errorf("invalid %s response: %w", plugin, er)
+		}
+		if len(response.GetError()) > 0 {
+			return nil, fmt.Errorf("%s failed: %s", plugin, response.GetError())
+		}
+		for _, file := range response.GetFile() {
+			files[file.GetName()] = []byte(file.GetContent())
+		}
+	}
+	return files, nil
+}
+
+// Get the generated files in the output folder which are no longer generated
+func staleFiles(folder string, files map[string][]byte) []string {
+	result := make([]string, 0)
+	_ = filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !(strings.HasSuffix(path, ".pb.go") || strings.HasSuffix(path, ".proto")) {
+			return nil
+		}
+		name, _ := filepath.Rel(folder, path)
+		if _, ok := files[filepath.ToSlash(name)]; !ok {
+			result = append(result, name)
+		}
+		return nil
+	})
+	return result
+}
+
+func fail(format string, args ...any) {
+	_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -2,13 +2,26 @@
 package server
 
 import (
+	"context"
 	"fmt"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/go-yaaf/yaaf-common/logger"
 	"github.com/go-yaaf/yaaf-examples/rest-api/common"
 	"github.com/go-yaaf/yaaf-examples/rest-api/config"
 	"github.com/go-yaaf/yaaf-examples/rest-api/rest"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rpc"
+	"github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// Process exit codes
+const (
+	ExitOK              = 0 // Graceful shutdown completed
+	ExitStartupError    = 1 // Failed to initialize the application (bootstrap or database schema)
+	ExitServerError     = 2 // The REST or gRPC server failed (e.g. the port is in use)
+	ExitShutdownTimeout = 3 // Shutdown deadline exceeded or failed to release resources
 )
 
 // region Application structure and factory method ---------------------------------------------------------------------
@@ -16,17 +29,23 @@ import (
 // Application is the main server struct
 // It contains and manages the state of the application and apply business login
 type Application struct {
-	config *config.ServiceConfig // Application configuration
-	server *rest.Server          // REST server
-	facade *common.ServiceHub    // Group all facility services (database, elastic, streaming etc)
+	config   *config.ServiceConfig       // Application configuration
+	server   *rest.Server                // REST server
+	grpc     *rpc.Server                 // gRPC server
+	webhooks *services.WebhookDispatcher // Webhooks dispatcher
+	outbox   *services.OutboxRelay       // Transactional outbox relay
+	facade   *common.ServiceHub          // Group all facility services (database, elastic, streaming etc)
 }
 
 // NewApplication Factory method
-func NewApplication(cfg *config.ServiceConfig, hub *common.ServiceHub, server *rest.Server) (*Application, error) {
+func NewApplication(cfg *config.ServiceConfig, hub *common.ServiceHub, server *rest.Server, grpc *rpc.Server, webhooks *services.WebhookDispatcher, outbox *services.OutboxRelay) (*Application, error) {
 	return &Application{
-		config: cfg,
-		server: server,
-		facade: hub,
+		config:   cfg,
+		server:   server,
+		grpc:     grpc,
+		webhooks: webhooks,
+		outbox:   outbox,
+		facade:   hub,
 	}, nil
 }
 
@@ -34,46 +53,173 @@ func NewApplication(cfg *config.ServiceConfig, hub *common.ServiceHub, server *r
 
 // region Application methods ------------------------------------------------------------------------------------------
 
-// Start the application
-func (app *Application) Start() {
-
-	//application = app
+// Start the application, blocks until the service is stopped (SIGTERM / SIGINT or server failure) and returns the
+// process exit code
+func (app *Application) Start() int {
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Start REST server for prometheus metrics endpoint, the readiness probe returns 503 until the service is ready
+	// The channel of a disabled server is nil (never selected), so the application keeps running with no listener
+	app.facade.SetState(common.StateStarting)
+	var serverErrors, grpcErrors chan error
+	if app.restEnabled() {
+		serverErrors = make(chan error, 1)
+		go func() {
+			serverErrors <- app.startRestServer()
+		}()
+	}
+	if app.grpcEnabled() {
+		grpcErrors = make(chan error, 1)
+		go func() {
+			grpcErrors <- app.startGrpcServer()
+		}()
+	}
 
 	// Verify database schema
 	if err := verifyDatabaseSchema(app.facade.Database); err != nil {
-		logger.Fatal("error initializing database: %s", err.Error())
-		return
+		logger.Error("error initializing database: %s", err.Error())
+		return app.shutdown(ExitStartupError)
 	}
 
 	// Verify root account schema
 	if err := initializeDatabase(app.facade.Database); err != nil {
-		logger.Fatal("error initializing database: %s", err.Error())
-		return
+		logger.Error("error initializing database: %s", err.Error())
+		return app.shutdown(ExitStartupError)
 	}
 
-	// Start REST server for prometheus metrics endpoint
-	go func() {
-		app.startRestServer()
-		logger.Info("Closing the REST server...")
-	}()
+	// Start delivering the pending webhooks (including the deliveries interrupted by the previous shutdown)
+	if app.webhooks != nil {
+		app.webhooks.Start()
+	}
+
+	// Start relaying the outbox entries (including the entries committed before the previous shutdown)
+	if app.outbox != nil {
+		app.outbox.Start()
+	}
 
-	<-make(chan struct{})
+	app.facade.SetState(common.StateReady)
+	logger.Info("Service is ready")
+
+	select {
+	case <-ctx.Done():
+		logger.Info("Shutdown signal received")
+		return app.shutdown(ExitOK)
+	case err := <-serverErrors:
+		logger.Error("REST server failed: %v", err)
+		return app.shutdown(ExitServerError)
+	case err := <-grpcErrors:
+		logger.Error("gRPC server failed: %v", err)
+		return app.shutdown(ExitServerError)
+	}
 }
 
-// Start the REST server
-func (app *Application) startRestServer() {
+// REST server is enabled when the server port is configured
+func (app *Application) restEnabled() bool {
+	return app.config.ServerPort() > 0
+}
 
-	port := config.GetConfig().ServerPort()
-	if port == 0 {
-		return
-	}
+// gRPC server is enabled when the gRPC port is configured
+func (app *Application) grpcEnabled() bool {
+	return app.config.GrpcPort() > 0 && app.grpc != nil
+}
+
+// Start the REST server, blocks until the server is stopped (returns nil when the server is shut down before start)
+func (app *Application) startRestServer() error {
 
+	port := app.config.ServerPort()
 	logger.Info(logTimezoneOffset())
 	logger.Info("Starting REST server, listening on port: %d", port)
 
 	if err := app.server.Start(port); err != nil {
-		logger.Warn("error starting REST server: %s", err.Error())
+		return err
+	}
+	logger.Info("Closing the REST server...")
+	return nil
+}
+
+// Start the gRPC server, blocks until the server is stopped (returns nil when the server is shut down before start)
+func (app *Application) startGrpcServer() error {
+
+	port := app.config.GrpcPort()
+	logger.Info("Starting gRPC server, listening on port: %d", port)
+
+	if err := app.grpc.Start(port); err != nil {
+		return err
+	}
+	logger.Info("Closing the gRPC server...")
+	return nil
+}
+
+// Gracefully stop the application: report draining state, complete the in-flight requests up to the shutdown deadline,
+// release the service hub resources and flush the pending spans. Returns the exit code (the provided code, unless the
+// shutdown itself fails)
+func (app *Application) shutdown(code int) int {
+
+	app.facade.SetState(common.StateDraining)
+	logger.Info("Service is draining")
+
+	// Let the load balancer observe the readiness probe before closing the listener (no wait without listener)
+	if delay := app.config.ShutdownDelay(); delay > 0 && code == ExitOK && (app.restEnabled() || app.grpcEnabled()) {
+		time.Sleep(delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.config.ShutdownTimeout())
+	defer cancel()
+
+	// End the events streams, otherwise the open streams hold the shutdown until the deadline
+	app.facade.Events.Close()
+
+	if err := app.server.Shutdown(ctx); err != nil {
+		logger.Error("error stopping REST server: %s", err.Error())
+		if code == ExitOK {
+			code = ExitShutdownTimeout
+		}
+	}
+
+	if app.grpc != nil {
+		if err := app.grpc.Shutdown(ctx); err != nil {
+			logger.Error("error stopping gRPC server: %s", err.Error())
+			if code == ExitOK {
+				code = ExitShutdownTimeout
+			}
+		}
 	}
+
+	// Stop the outbox relay after the servers, the entries committed by the in-flight requests are relayed after restart
+	if app.outbox != nil {
+		if err := app.outbox.Stop(ctx); err != nil {
+			logger.Error("error stopping outbox relay: %s", err.Error())
+			if code == ExitOK {
+				code = ExitShutdownTimeout
+			}
+		}
+	}
+
+	// Stop the webhooks dispatcher after the relay, the deliveries enqueued by the relay are attempted after restart
+	if app.webhooks != nil {
+		if err := app.webhooks.Stop(ctx); err != nil {
+			logger.Error("error stopping webhooks dispatcher: %s", err.Error())
+			if code == ExitOK {
+				code = ExitShutdownTimeout
+			}
+		}
+	}
+
+	if err := app.facade.Close(); err != nil {
+		logger.Error("error closing resources: %s", err.Error())
+		if code == ExitOK {
+			code = ExitShutdownTimeout
+		}
+	}
+
+	if err := common.ShutdownTracing(ctx); err != nil {
+		logger.Warn("error flushing spans: %s", err.Error())
+	}
+
+	logger.Info("Service stopped, exit code: %d", code)
+	return code
 }
 
 func logTimezoneOffset() string {
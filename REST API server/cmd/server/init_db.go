@@ -21,8 +21,11 @@ func verifyDatabaseSchema(database database.IDatabase) error {
 	ddl["account"] = []string{"name", "status", "flag"}
 	ddl["audit_log"] = []string{"createdOn", "accountId", "userId", "action", "itemType", "itemId", "itemName"}
 	ddl["contact"] = []string{"firstName", "lastName", "status", "updatedOn", "flag"}
+	ddl["outbox"] = []string{"topic", "status", "nextAttempt", "createdOn"}
 	ddl["user"] = []string{"name", "email"}
 	ddl["users_group"] = []string{"name", "updatedOn"}
+	ddl["webhook"] = []string{"name", "accountId", "active", "flag"}
+	ddl["webhook_delivery"] = []string{"webhookId", "status", "nextAttempt", "createdOn"}
 
 	if err := database.ExecuteDDL(ddl); err != nil {
 		return err
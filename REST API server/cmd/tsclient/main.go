@@ -0,0 +1,98 @@
+// The tsclient command generates the TypeScript client library for Angular from the endpoints and domain model annotations
+//
+// Usage (from the module root folder):
+//
+//	go run ./cmd/tsclient                generate ./client_lib/typescript
+//	go run ./cmd/tsclient -check         verify that the generated library is up-to-date
+//
+// The output is deterministic (sorted types and services, no timestamps), stale generated files are removed from the
+// output folder. The check mode exits with non-zero status when any of the files is missing, different or stale (CI gate)
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/apidoc"
+)
+
+func main() {
+	root := flag.String("root", ".", "module root folder")
+	out := flag.String("out", "client_lib/typescript", "output folder (relative to the module root folder)")
+	check := flag.Bool("check", false, "verify that the output folder is up-to-date, without writing it")
+	flag.Parse()
+
+	api, err := apidoc.Parse(*root)
+	if err != nil {
+		fail("failed to parse the API: %v", err)
+	}
+
+	for _, problem := range api.Problems {
+		_, _ = fmt.Fprintln(os.Stderr, problem)
+	}
+	if len(api.Problems) > 0 {
+		fail("found %d annotation problems", len(api.Problems))
+	}
+
+	files := api.TypeScript()
+	folder := filepath.Join(*root, *out)
+	stale := staleFiles(folder, files)
+
+	if *check {
+		outdated := append([]string{}, stale...)
+		for name, content := range files {
+			if existing, er := os.ReadFile(filepath.Join(folder, name)); er != nil || !bytes.Equal(existing, content) {
+				outdated = append(outdated, name)
+			}
+		}
+		if len(outdated) > 0 {
+			sort.Strings(outdated)
+			fail("%s is not up-to-date (%s), run: go run ./cmd/tsclient", *out, strings.Join(outdated, ", "))
+		}
+		fmt.Printf("%s is up-to-date (%d files)\n", *out, len(files))
+		return
+	}
+
+	for _, name := range stale {
+		if err = os.Remove(filepath.Join(folder, name)); err != nil {
+			fail("failed to remove %s: %v", name, err)
+		}
+	}
+	for name, content := range files {
+		path := filepath.Join(folder, name)
+		if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			fail("failed to create folder of %s: %v", path, err)
+		}
+		if err = os.WriteFile(path, content, 0644); err != nil {
+			fail("failed to write %s: %v", path, err)
+		}
+	}
+	fmt.Printf("%s generated (%d files)\n", *out, len(files))
+}
+
+// Get the TypeScript files in the output folder which are no longer generated (e.g. of removed endpoints)
+func staleFiles(folder string, files map[string][]byte) []string {
+	result := make([]string, 0)
+	_ = filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".ts") {
+			return nil
+		}
+		name, _ := filepath.Rel(folder, path)
+		if _, ok := files[filepath.ToSlash(name)]; !ok {
+			result = append(result, name)
+		}
+		return nil
+	})
+	return result
+}
+
+func fail(format string, args ...any) {
+	_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
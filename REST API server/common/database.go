@@ -1,6 +1,8 @@
 package common
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 
 	pgsql "github.com/go-yaaf/yaaf-common-postgresql/postgresql"
@@ -30,6 +32,29 @@ func NewDatabase() database.IDatabase {
 	if err != nil {
 		panic(err)
 	} else {
-		return db
+		return newMemoryDatabase(db)
 	}
 }
+
+var fieldPathRegex = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// SelectExpressions returns the native database expressions to select the provided fields (json paths e.g. address.city)
+// Each expression is aliased by the field json path and returns the field value as json text.
+// Returns false if the configured database does not support projection push-down
+func SelectExpressions(fields []string) ([]string, bool) {
+
+	uri := config.GetConfig().DatabaseUri()
+	if !strings.HasPrefix(uri, "postgres://") {
+		return nil, false
+	}
+
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !fieldPathRegex.MatchString(field) {
+			return nil, false
+		}
+		path := strings.ReplaceAll(field, ".", ",")
+		result = append(result, fmt.Sprintf(`coalesce((data #> '{%s}')::text, 'null') as "%s"`, path, field))
+	}
+	return result, true
+}
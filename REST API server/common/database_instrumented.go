@@ -0,0 +1,346 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// region Instrumented database ----------------------------------------------------------------------------------------
+
+// instrumentedDatabase decorates IDatabase with latency and error metrics and trace spans of the data access operations
+// and queries, the other operations (e.g. DDL, advanced query) are delegated to the database as is
+// The spans are children of the span in the bound context (see WithContext), operations of cancelled or expired context
+// (e.g. request deadline) fail with the context error. The deadline is checked before each call only, the database
+// calls do not accept context, so a query already running is not stopped
+type instrumentedDatabase struct {
+	IDatabase
+	ctx context.Context
+}
+
+// NewInstrumentedDatabase wraps the database with metrics and tracing instrumentation
+func NewInstrumentedDatabase(db IDatabase) IDatabase {
+	return &instrumentedDatabase{IDatabase: db, ctx: context.Background()}
+}
+
+// WithContext returns the instrumented database bound to the request context (parent span of the database spans)
+func (d *instrumentedDatabase) WithContext(ctx context.Context) IDatabase {
+	return &instrumentedDatabase{IDatabase: d.IDatabase, ctx: ctx}
+}
+
+// Start the database operation span and timer, the returned function records the result
+func (d *instrumentedDatabase) observe(operation string) func(*error) {
+	return observeCall(d.ctx, MiddlewareDatabase, operation)
+}
+
+func (d *instrumentedDatabase) Get(factory EntityFactory, entityID string, keys ...string) (result Entity, err error) {
+	defer d.observe("Get")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.Get(factory, entityID, keys...)
+}
+
+func (d *instrumentedDatabase) List(factory EntityFactory, entityIDs []string, keys ...string) (list []Entity, err error) {
+	defer d.observe("List")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.List(factory, entityIDs, keys...)
+}
+
+func (d *instrumentedDatabase) Exists(factory EntityFactory, entityID string, keys ...string) (result bool, err error) {
+	defer d.observe("Exists")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.Exists(factory, entityID, keys...)
+}
+
+func (d *instrumentedDatabase) Insert(entity Entity) (added Entity, err error) {
+	defer d.observe("Insert")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.Insert(entity)
+}
+
+func (d *instrumentedDatabase) Update(entity Entity) (updated Entity, err error) {
+	defer d.observe("Update")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.Update(entity)
+}
+
+func (d *instrumentedDatabase) Upsert(entity Entity) (updated Entity, err error) {
+	defer d.observe("Upsert")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.Upsert(entity)
+}
+
+func (d *instrumentedDatabase) Delete(factory EntityFactory, entityID string, keys ...string) (err error) {
+	defer d.observe("Delete")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.Delete(factory, entityID, keys...)
+}
+
+func (d *instrumentedDatabase) BulkInsert(entities []Entity) (affected int64, err error) {
+	defer d.observe("BulkInsert")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.BulkInsert(entities)
+}
+
+func (d *instrumentedDatabase) BulkUpdate(entities []Entity) (affected int64, err error) {
+	defer d.observe("BulkUpdate")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.BulkUpdate(entities)
+}
+
+func (d *instrumentedDatabase) BulkUpsert(entities []Entity) (affected int64, err error) {
+	defer d.observe("BulkUpsert")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.BulkUpsert(entities)
+}
+
+func (d *instrumentedDatabase) BulkDelete(factory EntityFactory, entityIDs []string, keys ...string) (affected int64, err error) {
+	defer d.observe("BulkDelete")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.BulkDelete(factory, entityIDs, keys...)
+}
+
+func (d *instrumentedDatabase) SetField(factory EntityFactory, entityID string, field string, value any, keys ...string) (err error) {
+	defer d.observe("SetField")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.SetField(factory, entityID, field, value, keys...)
+}
+
+func (d *instrumentedDatabase) SetFields(factory EntityFactory, entityID string, fields map[string]any, keys ...string) (err error) {
+	defer d.observe("SetFields")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.SetFields(factory, entityID, fields, keys...)
+}
+
+func (d *instrumentedDatabase) BulkSetFields(factory EntityFactory, field string, values map[string]any, keys ...string) (affected int64, err error) {
+	defer d.observe("BulkSetFields")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.BulkSetFields(factory, field, values, keys...)
+}
+
+func (d *instrumentedDatabase) ExecuteSQL(sql string, args ...any) (affected int64, err error) {
+	defer d.observe("ExecuteSQL")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.ExecuteSQL(sql, args...)
+}
+
+func (d *instrumentedDatabase) ExecuteQuery(source, sql string, args ...any) (result []Json, err error) {
+	defer d.observe("ExecuteQuery")(&err)
+	if err = d.ctx.Err(); err != nil {
+		return
+	}
+	return d.IDatabase.ExecuteQuery(source, sql, args...)
+}
+
+func (d *instrumentedDatabase) Query(factory EntityFactory) IQuery {
+	return &instrumentedQuery{IQuery: d.IDatabase.Query(factory), ctx: d.ctx}
+}
+
+// endregion
+
+// region Instrumented query -------------------------------------------------------------------------------------------
+
+// instrumentedQuery decorates IQuery with latency and error metrics and trace spans of the query execution methods
+// (operation name is prefixed with Query, e.g. QueryFind), the builder methods return the instrumented query
+type instrumentedQuery struct {
+	IQuery
+	ctx context.Context
+}
+
+// Start the query operation span and timer, the returned function records the result
+func (q *instrumentedQuery) observe(operation string) func(*error) {
+	return observeCall(q.ctx, MiddlewareDatabase, operation)
+}
+
+func (q *instrumentedQuery) Apply(cb func(in Entity) Entity) IQuery {
+	q.IQuery = q.IQuery.Apply(cb)
+	return q
+}
+
+func (q *instrumentedQuery) Filter(filter QueryFilter) IQuery {
+	q.IQuery = q.IQuery.Filter(filter)
+	return q
+}
+
+func (q *instrumentedQuery) Range(field string, from Timestamp, to Timestamp) IQuery {
+	q.IQuery = q.IQuery.Range(field, from, to)
+	return q
+}
+
+func (q *instrumentedQuery) MatchAll(filters ...QueryFilter) IQuery {
+	q.IQuery = q.IQuery.MatchAll(filters...)
+	return q
+}
+
+func (q *instrumentedQuery) MatchAny(filters ...QueryFilter) IQuery {
+	q.IQuery = q.IQuery.MatchAny(filters...)
+	return q
+}
+
+func (q *instrumentedQuery) Sort(sort string) IQuery {
+	q.IQuery = q.IQuery.Sort(sort)
+	return q
+}
+
+func (q *instrumentedQuery) Page(page int) IQuery {
+	q.IQuery = q.IQuery.Page(page)
+	return q
+}
+
+func (q *instrumentedQuery) Limit(page int) IQuery {
+	q.IQuery = q.IQuery.Limit(page)
+	return q
+}
+
+func (q *instrumentedQuery) List(entityIDs []string, keys ...string) (out []Entity, err error) {
+	defer q.observe("QueryList")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.List(entityIDs, keys...)
+}
+
+func (q *instrumentedQuery) Find(keys ...string) (out []Entity, total int64, err error) {
+	defer q.observe("QueryFind")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.Find(keys...)
+}
+
+func (q *instrumentedQuery) Select(fields ...string) (out []Json, err error) {
+	defer q.observe("QuerySelect")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.Select(fields...)
+}
+
+func (q *instrumentedQuery) Count(keys ...string) (total int64, err error) {
+	defer q.observe("QueryCount")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.Count(keys...)
+}
+
+func (q *instrumentedQuery) Aggregation(field string, function AggFunc, keys ...string) (value float64, err error) {
+	defer q.observe("QueryAggregation")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.Aggregation(field, function, keys...)
+}
+
+func (q *instrumentedQuery) GroupCount(field string, keys ...string) (out map[any]int64, total int64, err error) {
+	defer q.observe("QueryGroupCount")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.GroupCount(field, keys...)
+}
+
+func (q *instrumentedQuery) GroupAggregation(field string, function AggFunc, keys ...string) (out map[any]Tuple[int64, float64], total float64, err error) {
+	defer q.observe("QueryGroupAggregation")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.GroupAggregation(field, function, keys...)
+}
+
+func (q *instrumentedQuery) Histogram(field string, function AggFunc, timeField string, interval time.Duration, keys ...string) (out map[Timestamp]Tuple[int64, float64], total float64, err error) {
+	defer q.observe("QueryHistogram")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.Histogram(field, function, timeField, interval, keys...)
+}
+
+func (q *instrumentedQuery) Histogram2D(field string, function AggFunc, dim, timeField string, interval time.Duration, keys ...string) (out map[Timestamp]map[any]Tuple[int64, float64], total float64, err error) {
+	defer q.observe("QueryHistogram2D")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.Histogram2D(field, function, dim, timeField, interval, keys...)
+}
+
+func (q *instrumentedQuery) FindSingle(keys ...string) (entity Entity, err error) {
+	defer q.observe("QueryFindSingle")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.FindSingle(keys...)
+}
+
+func (q *instrumentedQuery) GetMap(keys ...string) (out map[string]Entity, err error) {
+	defer q.observe("QueryGetMap")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.GetMap(keys...)
+}
+
+func (q *instrumentedQuery) GetIDs(keys ...string) (out []string, err error) {
+	defer q.observe("QueryGetIDs")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.GetIDs(keys...)
+}
+
+func (q *instrumentedQuery) Delete(keys ...string) (total int64, err error) {
+	defer q.observe("QueryDelete")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.Delete(keys...)
+}
+
+func (q *instrumentedQuery) SetField(field string, value any, keys ...string) (total int64, err error) {
+	defer q.observe("QuerySetField")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.SetField(field, value, keys...)
+}
+
+func (q *instrumentedQuery) SetFields(fields map[string]any, keys ...string) (total int64, err error) {
+	defer q.observe("QuerySetFields")(&err)
+	if err = q.ctx.Err(); err != nil {
+		return
+	}
+	return q.IQuery.SetFields(fields, keys...)
+}
+
+// endregion
@@ -0,0 +1,232 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// region In-memory database -------------------------------------------------------------------------------------------
+
+// memoryDatabase decorates the in-memory database to support multiple MatchAny groups in a query, the in-memory query
+// keeps only the last group (and mixes it with the MatchAll filters), so OR clauses of the filter expression and the
+// search term would be dropped
+type memoryDatabase struct {
+	IDatabase
+}
+
+// newMemoryDatabase wraps the in-memory database with the query decorator
+func newMemoryDatabase(db IDatabase) IDatabase {
+	return &memoryDatabase{IDatabase: db}
+}
+
+func (d *memoryDatabase) Query(factory EntityFactory) IQuery {
+	return &memoryQuery{IQuery: d.IDatabase.Query(factory), db: d.IDatabase, factory: factory}
+}
+
+// endregion
+
+// region In-memory query ----------------------------------------------------------------------------------------------
+
+// memoryQuery collects the MatchAny groups and resolves each group to the IDs of the matching entities (by a query of
+// the single group) before the query execution, the builder methods return the decorated query
+type memoryQuery struct {
+	IQuery
+	db      IDatabase
+	factory EntityFactory
+	anyOf   [][]QueryFilter
+}
+
+func (q *memoryQuery) Apply(cb func(in Entity) Entity) IQuery {
+	q.IQuery = q.IQuery.Apply(cb)
+	return q
+}
+
+func (q *memoryQuery) Filter(filter QueryFilter) IQuery {
+	q.IQuery = q.IQuery.Filter(filter)
+	return q
+}
+
+func (q *memoryQuery) Range(field string, from Timestamp, to Timestamp) IQuery {
+	q.IQuery = q.IQuery.Range(field, from, to)
+	return q
+}
+
+func (q *memoryQuery) MatchAll(filters ...QueryFilter) IQuery {
+	q.IQuery = q.IQuery.MatchAll(filters...)
+	return q
+}
+
+// MatchAny adds the group of the active filters, a group without active filters is ignored (as in the other databases)
+func (q *memoryQuery) MatchAny(filters ...QueryFilter) IQuery {
+	group := make([]QueryFilter, 0, len(filters))
+	for _, filter := range filters {
+		if filter.IsActive() {
+			group = append(group, filter)
+		}
+	}
+	if len(group) > 0 {
+		q.anyOf = append(q.anyOf, group)
+	}
+	return q
+}
+
+func (q *memoryQuery) Sort(sort string) IQuery {
+	q.IQuery = q.IQuery.Sort(sort)
+	return q
+}
+
+func (q *memoryQuery) Page(page int) IQuery {
+	q.IQuery = q.IQuery.Page(page)
+	return q
+}
+
+func (q *memoryQuery) Limit(page int) IQuery {
+	q.IQuery = q.IQuery.Limit(page)
+	return q
+}
+
+func (q *memoryQuery) Find(keys ...string) (out []Entity, total int64, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return nil, 0, er
+	}
+	return q.IQuery.Find(keys...)
+}
+
+func (q *memoryQuery) Count(keys ...string) (total int64, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return 0, er
+	}
+	return q.IQuery.Count(keys...)
+}
+
+func (q *memoryQuery) FindSingle(keys ...string) (entity Entity, err error) {
+	if matched, er := q.resolve(keys...); er != nil {
+		return nil, er
+	} else if !matched {
+		return nil, fmt.Errorf("not found")
+	}
+	return q.IQuery.FindSingle(keys...)
+}
+
+func (q *memoryQuery) GetMap(keys ...string) (out map[string]Entity, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return map[string]Entity{}, er
+	}
+	return q.IQuery.GetMap(keys...)
+}
+
+func (q *memoryQuery) GetIDs(keys ...string) (out []string, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return []string{}, er
+	}
+	return q.IQuery.GetIDs(keys...)
+}
+
+func (q *memoryQuery) Delete(keys ...string) (total int64, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return 0, er
+	}
+	return q.IQuery.Delete(keys...)
+}
+
+func (q *memoryQuery) SetField(field string, value any, keys ...string) (total int64, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return 0, er
+	}
+	return q.IQuery.SetField(field, value, keys...)
+}
+
+func (q *memoryQuery) SetFields(fields map[string]any, keys ...string) (total int64, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return 0, er
+	}
+	return q.IQuery.SetFields(fields, keys...)
+}
+
+func (q *memoryQuery) List(entityIDs []string, keys ...string) (out []Entity, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return []Entity{}, er
+	}
+	return q.IQuery.List(entityIDs, keys...)
+}
+
+func (q *memoryQuery) Select(fields ...string) ([]Json, error) {
+	if matched, er := q.resolve(); er != nil || !matched {
+		return []Json{}, er
+	}
+	return q.IQuery.Select(fields...)
+}
+
+func (q *memoryQuery) Aggregation(field string, function AggFunc, keys ...string) (value float64, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return 0, er
+	}
+	return q.IQuery.Aggregation(field, function, keys...)
+}
+
+func (q *memoryQuery) GroupCount(field string, keys ...string) (out map[any]int64, total int64, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return map[any]int64{}, 0, er
+	}
+	return q.IQuery.GroupCount(field, keys...)
+}
+
+func (q *memoryQuery) GroupAggregation(field string, function AggFunc, keys ...string) (out map[any]Tuple[int64, float64], total float64, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return map[any]Tuple[int64, float64]{}, 0, er
+	}
+	return q.IQuery.GroupAggregation(field, function, keys...)
+}
+
+func (q *memoryQuery) Histogram(field string, function AggFunc, timeField string, interval time.Duration, keys ...string) (out map[Timestamp]Tuple[int64, float64], total float64, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return map[Timestamp]Tuple[int64, float64]{}, 0, er
+	}
+	return q.IQuery.Histogram(field, function, timeField, interval, keys...)
+}
+
+func (q *memoryQuery) Histogram2D(field string, function AggFunc, dim, timeField string, interval time.Duration, keys ...string) (out map[Timestamp]map[any]Tuple[int64, float64], total float64, err error) {
+	if matched, er := q.resolve(keys...); er != nil || !matched {
+		return map[Timestamp]map[any]Tuple[int64, float64]{}, 0, er
+	}
+	return q.IQuery.Histogram2D(field, function, dim, timeField, interval, keys...)
+}
+
+// Resolve the MatchAny groups to a single MatchAll filter of the IDs matching all the groups, returns false if no entity
+// matches all the groups (the query result is empty)
+func (q *memoryQuery) resolve(keys ...string) (bool, error) {
+	if len(q.anyOf) == 0 {
+		return true, nil
+	}
+
+	var ids map[string]bool
+	for _, group := range q.anyOf {
+		list, err := q.db.Query(q.factory).MatchAny(group...).GetIDs(keys...)
+		if err != nil {
+			return false, err
+		}
+		matched := make(map[string]bool, len(list))
+		for _, id := range list {
+			if ids == nil || ids[id] {
+				matched[id] = true
+			}
+		}
+		ids = matched
+	}
+	q.anyOf = nil
+
+	if len(ids) == 0 {
+		return false, nil
+	}
+	values := make([]any, 0, len(ids))
+	for id := range ids {
+		values = append(values, id)
+	}
+	q.IQuery = q.IQuery.MatchAll(F("id").In(values...))
+	return true, nil
+}
+
+// endregion
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+)
+
+func TestMemoryQueryResolvesGroups(t *testing.T) {
+	inner, err := NewInMemoryDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := newMemoryDatabase(inner)
+	contacts := []struct{ id, account, name string }{
+		{"1", "a1", "alice"},
+		{"2", "a1", "bob"},
+		{"3", "a2", "carol"},
+	}
+	for _, c := range contacts {
+		ent := NewContact().(*Contact)
+		ent.Id, ent.AccountId, ent.Name = c.id, c.account, c.name
+		if _, err := db.Insert(ent); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// (name = alice or name = carol) and (accountId = a1 or name = bob) matches alice only
+	newQuery := func() *memoryQuery {
+		return db.Query(NewContact).
+			MatchAny(F("name").Eq("alice"), F("name").Eq("carol")).
+			MatchAny(F("accountId").Eq("a1"), F("name").Eq("bob")).(*memoryQuery)
+	}
+
+	// The groups are resolved to the inner query before the call (the in-memory database does not implement the
+	// aggregations, so the inner query is checked after the call)
+	calls := map[string]func(q *memoryQuery){
+		"Select":           func(q *memoryQuery) { _, _ = q.Select("name") },
+		"Aggregation":      func(q *memoryQuery) { _, _ = q.Aggregation("name", COUNT) },
+		"GroupCount":       func(q *memoryQuery) { _, _, _ = q.GroupCount("accountId") },
+		"GroupAggregation": func(q *memoryQuery) { _, _, _ = q.GroupAggregation("accountId", COUNT) },
+		"Histogram":        func(q *memoryQuery) { _, _, _ = q.Histogram("", COUNT, "createdOn", time.Hour) },
+		"Histogram2D":      func(q *memoryQuery) { _, _, _ = q.Histogram2D("", COUNT, "accountId", "createdOn", time.Hour) },
+	}
+	for name, call := range calls {
+		q := newQuery()
+		call(q)
+		if len(q.anyOf) > 0 {
+			t.Errorf("%s: groups were not resolved", name)
+			continue
+		}
+		if list, _, err := q.IQuery.Find(); err != nil || len(list) != 1 || list[0].ID() != "1" {
+			t.Errorf("%s: expected contact 1 but got %d contacts: %v", name, len(list), err)
+		}
+	}
+
+	// No match returns empty result without calling the in-memory database
+	none := db.Query(NewContact).MatchAny(F("name").Eq("dave"), F("name").Eq("erin"))
+	if counts, total, err := none.GroupCount("accountId"); err != nil || total != 0 || len(counts) != 0 {
+		t.Errorf("group count: expected no match but got %v (total %d): %v", counts, total, err)
+	}
+}
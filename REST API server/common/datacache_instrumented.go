@@ -0,0 +1,151 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// region Instrumented data cache --------------------------------------------------------------------------------------
+
+// instrumentedDataCache decorates IDataCache with latency and error metrics and trace spans of the key-value, hash and
+// list operations, the other operations (e.g. scan, locker) are delegated to the cache as is
+// The spans are children of the span in the bound context (see WithContext)
+type instrumentedDataCache struct {
+	IDataCache
+	ctx context.Context
+}
+
+// NewInstrumentedDataCache wraps the data cache with metrics and tracing instrumentation
+func NewInstrumentedDataCache(dc IDataCache) IDataCache {
+	return &instrumentedDataCache{IDataCache: dc, ctx: context.Background()}
+}
+
+// WithContext returns the instrumented data cache bound to the request context (parent span of the cache spans)
+func (d *instrumentedDataCache) WithContext(ctx context.Context) IDataCache {
+	return &instrumentedDataCache{IDataCache: d.IDataCache, ctx: ctx}
+}
+
+// Start the data cache operation span and timer, the returned function records the result
+func (d *instrumentedDataCache) observe(operation string) func(*error) {
+	return observeCall(d.ctx, MiddlewareDataCache, operation)
+}
+
+func (d *instrumentedDataCache) Get(factory EntityFactory, key string) (result Entity, err error) {
+	defer d.observe("Get")(&err)
+	return d.IDataCache.Get(factory, key)
+}
+
+func (d *instrumentedDataCache) GetRaw(key string) (result []byte, err error) {
+	defer d.observe("GetRaw")(&err)
+	return d.IDataCache.GetRaw(key)
+}
+
+func (d *instrumentedDataCache) GetKeys(factory EntityFactory, keys ...string) (result []Entity, err error) {
+	defer d.observe("GetKeys")(&err)
+	return d.IDataCache.GetKeys(factory, keys...)
+}
+
+func (d *instrumentedDataCache) GetRawKeys(keys ...string) (result []Tuple[string, []byte], err error) {
+	defer d.observe("GetRawKeys")(&err)
+	return d.IDataCache.GetRawKeys(keys...)
+}
+
+func (d *instrumentedDataCache) Set(key string, entity Entity, expiration ...time.Duration) (err error) {
+	defer d.observe("Set")(&err)
+	return d.IDataCache.Set(key, entity, expiration...)
+}
+
+func (d *instrumentedDataCache) SetRaw(key string, bytes []byte, expiration ...time.Duration) (err error) {
+	defer d.observe("SetRaw")(&err)
+	return d.IDataCache.SetRaw(key, bytes, expiration...)
+}
+
+func (d *instrumentedDataCache) SetNX(key string, entity Entity, expiration ...time.Duration) (result bool, err error) {
+	defer d.observe("SetNX")(&err)
+	return d.IDataCache.SetNX(key, entity, expiration...)
+}
+
+func (d *instrumentedDataCache) SetRawNX(key string, bytes []byte, expiration ...time.Duration) (result bool, err error) {
+	defer d.observe("SetRawNX")(&err)
+	return d.IDataCache.SetRawNX(key, bytes, expiration...)
+}
+
+func (d *instrumentedDataCache) Add(key string, entity Entity, expiration time.Duration) (result bool, err error) {
+	defer d.observe("Add")(&err)
+	return d.IDataCache.Add(key, entity, expiration)
+}
+
+func (d *instrumentedDataCache) AddRaw(key string, bytes []byte, expiration time.Duration) (result bool, err error) {
+	defer d.observe("AddRaw")(&err)
+	return d.IDataCache.AddRaw(key, bytes, expiration)
+}
+
+func (d *instrumentedDataCache) Del(keys ...string) (err error) {
+	defer d.observe("Del")(&err)
+	return d.IDataCache.Del(keys...)
+}
+
+func (d *instrumentedDataCache) Exists(key string) (result bool, err error) {
+	defer d.observe("Exists")(&err)
+	return d.IDataCache.Exists(key)
+}
+
+func (d *instrumentedDataCache) HGet(factory EntityFactory, key, field string) (result Entity, err error) {
+	defer d.observe("HGet")(&err)
+	return d.IDataCache.HGet(factory, key, field)
+}
+
+func (d *instrumentedDataCache) HGetRaw(key, field string) (result []byte, err error) {
+	defer d.observe("HGetRaw")(&err)
+	return d.IDataCache.HGetRaw(key, field)
+}
+
+func (d *instrumentedDataCache) HGetAll(factory EntityFactory, key string) (result map[string]Entity, err error) {
+	defer d.observe("HGetAll")(&err)
+	return d.IDataCache.HGetAll(factory, key)
+}
+
+func (d *instrumentedDataCache) HGetRawAll(key string) (result map[string][]byte, err error) {
+	defer d.observe("HGetRawAll")(&err)
+	return d.IDataCache.HGetRawAll(key)
+}
+
+func (d *instrumentedDataCache) HSet(key, field string, entity Entity) (err error) {
+	defer d.observe("HSet")(&err)
+	return d.IDataCache.HSet(key, field, entity)
+}
+
+func (d *instrumentedDataCache) HSetRaw(key, field string, bytes []byte) (err error) {
+	defer d.observe("HSetRaw")(&err)
+	return d.IDataCache.HSetRaw(key, field, bytes)
+}
+
+func (d *instrumentedDataCache) HDel(key string, fields ...string) (err error) {
+	defer d.observe("HDel")(&err)
+	return d.IDataCache.HDel(key, fields...)
+}
+
+func (d *instrumentedDataCache) RPush(key string, value ...Entity) (err error) {
+	defer d.observe("RPush")(&err)
+	return d.IDataCache.RPush(key, value...)
+}
+
+func (d *instrumentedDataCache) LPush(key string, value ...Entity) (err error) {
+	defer d.observe("LPush")(&err)
+	return d.IDataCache.LPush(key, value...)
+}
+
+func (d *instrumentedDataCache) RPop(factory EntityFactory, key string) (entity Entity, err error) {
+	defer d.observe("RPop")(&err)
+	return d.IDataCache.RPop(factory, key)
+}
+
+func (d *instrumentedDataCache) LPop(factory EntityFactory, key string) (entity Entity, err error) {
+	defer d.observe("LPop")(&err)
+	return d.IDataCache.LPop(factory, key)
+}
+
+// endregion
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-yaaf/yaaf-common/logger"
+	"github.com/go-yaaf/yaaf-common/messaging"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/events"
+)
+
+// Topics prefix of the domain events (the topic is the prefix and the event name, e.g. domain.UserCreated)
+const DomainEventsTopic = "domain."
+
+// DomainEventTopic returns the message bus topic of the domain event
+func DomainEventTopic(event DomainEvent) string {
+	return DomainEventsTopic + event.EventName()
+}
+
+// PublishDomainEvent publishes the domain event to the message bus, the event metadata is set by the caller
+func (sh *ServiceHub) PublishDomainEvent(ctx context.Context, event DomainEvent) (err error) {
+	defer observeCall(ctx, MiddlewareMessageBus, "Publish")(&err)
+	return sh.MessageBus.Publish(messaging.GetMessage(DomainEventTopic(event), event))
+}
+
+// PublishDomainEventPayload publishes serialized domain event (e.g. relayed from the outbox) to the topic of the event,
+// the subscribers receive it as the typed event
+func (sh *ServiceHub) PublishDomainEventPayload(ctx context.Context, topic string, payload []byte) (err error) {
+	defer observeCall(ctx, MiddlewareMessageBus, "Publish")(&err)
+	return sh.MessageBus.Publish(messaging.GetMessage(topic, json.RawMessage(payload)))
+}
+
+// SubscribeDomainEvent registers in-process handler of the domain event type (e.g. *UserCreated), returns the
+// subscription ID. With Redis message bus the handler is called on every service instance (and may be called
+// concurrently), the handlers should be idempotent. Handler panic is recovered and logged
+func SubscribeDomainEvent[E DomainEvent](sh *ServiceHub, subscription string, handler func(event E)) (string, error) {
+	var zero E
+	topic := DomainEventTopic(zero)
+
+	return sh.MessageBus.Subscribe(subscription, messaging.NewMessage[E], func(msg messaging.IMessage) bool {
+		message, ok := msg.(*messaging.Message[E])
+		if !ok || any(message.MsgPayload) == any(zero) {
+			logger.Warn("[%s]: unexpected message on topic %s", subscription, topic)
+			return true
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("[%s]: %s handler failed: %v", subscription, topic, fmt.Sprint(r))
+			}
+		}()
+		handler(message.MsgPayload)
+		return true
+	}, topic)
+}
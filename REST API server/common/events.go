@@ -0,0 +1,207 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-yaaf/yaaf-common/entity"
+	"github.com/go-yaaf/yaaf-common/logger"
+	"github.com/go-yaaf/yaaf-common/messaging"
+
+	mc "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+)
+
+// Entity change event actions (in addition to the audit log actions: Create | Update | Delete)
+const (
+	EventActionReset = "Reset" // The events since the last event ID are not available, the subscriber should reload
+)
+
+// Topics prefix of the entity change events (the topic is the prefix and the item type, e.g. entity.contact)
+const EntityEventsTopic = "entity."
+
+// Size of the subscription channel, the subscription is closed if the subscriber does not keep up (it may resume from
+// the last event ID it received)
+const subscriptionBuffer = 100
+
+// region Event broker -------------------------------------------------------------------------------------------------
+
+// EventBroker distributes the entity change events to the subscribers of this service instance, the recent events are
+// kept to resume the subscription after reconnect (from the last event ID). The event IDs are set by the outbox relay,
+// so they are the same on all the service instances
+type EventBroker struct {
+	mu          sync.Mutex
+	last        int64                           // Last event ID
+	since       int64                           // The events after this ID are kept (0 before the first event)
+	size        int                             // Number of recent events to keep
+	recent      []*mc.EntityEvent               // Recent events (ordered by ID)
+	subscribers map[*EventSubscription]struct{} // Active subscriptions
+	closed      bool
+}
+
+// EventSubscription is a subscription to the entity change events that match the filter, the channel is closed when the
+// subscription is closed, when the subscriber does not keep up or when the broker is closed
+type EventSubscription struct {
+	C <-chan *mc.EntityEvent
+
+	ch     chan *mc.EntityEvent
+	filter func(event *mc.EntityEvent) bool
+	broker *EventBroker
+}
+
+// NewEventBroker factory method, keeps the last size events to resume the subscriptions
+func NewEventBroker(size int) *EventBroker {
+	return &EventBroker{size: size, subscribers: make(map[*EventSubscription]struct{})}
+}
+
+// Publish the event to the matching subscribers (the timestamp is set if not set). An event that does not follow the
+// last event ID (e.g. received out of order) is delivered but not kept for resume
+func (b *EventBroker) Publish(event *mc.EntityEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	if event.Timestamp == 0 {
+		event.Timestamp = entity.Now()
+	}
+
+	if event.Id > b.last {
+		if b.since == 0 {
+			b.since = event.Id
+		}
+		b.last = event.Id
+		b.recent = append(b.recent, event)
+		if len(b.recent) > b.size {
+			evicted := len(b.recent) - b.size
+			b.since = b.recent[evicted-1].Id
+			b.recent = b.recent[evicted:]
+		}
+	}
+
+	for sub := range b.subscribers {
+		if !sub.filter(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			// Slow subscriber, drop it (it resumes from its last event ID)
+			b.remove(sub)
+		}
+	}
+}
+
+// Subscribe to the events that match the filter, the events published after the last event ID (0 for new events only)
+// are returned with the subscription. If these events are not available (expired or published before the service was
+// restarted) the returned events start with Reset event. A last event ID after the last event of this instance (received
+// from other instance) is resumed by the new events after it
+func (b *EventBroker) Subscribe(filter func(event *mc.EntityEvent) bool, lastEventId int64) (*EventSubscription, []*mc.EntityEvent) {
+	ch := make(chan *mc.EntityEvent, subscriptionBuffer)
+	sub := &EventSubscription{C: ch, ch: ch, filter: filter, broker: b}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return sub, nil
+	}
+	b.subscribers[sub] = struct{}{}
+
+	if lastEventId <= 0 || lastEventId == b.last {
+		return sub, nil
+	}
+
+	var replay []*mc.EntityEvent
+	if b.since == 0 || lastEventId < b.since {
+		replay = append(replay, &mc.EntityEvent{Id: b.last, Action: EventActionReset, Timestamp: entity.Now()})
+		return sub, replay
+	}
+	if lastEventId > b.last {
+		sub.filter = func(event *mc.EntityEvent) bool { return event.Id > lastEventId && filter(event) }
+		return sub, nil
+	}
+	for _, event := range b.recent {
+		if event.Id > lastEventId && filter(event) {
+			replay = append(replay, event)
+		}
+	}
+	return sub, replay
+}
+
+// Subscribers returns the number of active subscriptions
+func (b *EventBroker) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+// Close all the subscriptions (on shutdown), the events published after close are ignored
+func (b *EventBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subscribers {
+		b.remove(sub)
+	}
+}
+
+// Remove the subscription and close its channel (the caller holds the lock)
+func (b *EventBroker) remove(sub *EventSubscription) {
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Close the subscription
+func (s *EventSubscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
+
+// endregion
+
+// region Entity events message bus ------------------------------------------------------------------------------------
+
+// EntityEventTopic returns the message bus topic of the entity change events of the item type
+func EntityEventTopic(itemType string) string {
+	return EntityEventsTopic + itemType
+}
+
+// PublishEntityEvent publishes the entity change event (relayed from the outbox) to the message bus, the subscribed
+// service instances publish it to their event broker
+func (sh *ServiceHub) PublishEntityEvent(ctx context.Context, event *mc.EntityEvent) (err error) {
+	defer observeCall(ctx, MiddlewareMessageBus, "Publish")(&err)
+	return sh.MessageBus.Publish(messaging.GetMessage(EntityEventTopic(event.ItemType), event))
+}
+
+// SubscribeEntityEvents publishes the entity change events of the item types received from the message bus to the
+// event broker of this service instance, returns the subscription ID
+func (sh *ServiceHub) SubscribeEntityEvents(subscription string, itemTypes ...string) (string, error) {
+	topics := make([]string, 0, len(itemTypes))
+	for _, itemType := range itemTypes {
+		topics = append(topics, EntityEventTopic(itemType))
+	}
+
+	return sh.MessageBus.Subscribe(subscription, messaging.NewMessage[*mc.EntityEvent], func(msg messaging.IMessage) bool {
+		message, ok := msg.(*messaging.Message[*mc.EntityEvent])
+		if !ok || message.MsgPayload == nil {
+			logger.Warn("[%s]: unexpected message on topic %s", subscription, msg.Topic())
+			return true
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("[%s]: %s event failed: %v", subscription, msg.Topic(), fmt.Sprint(r))
+			}
+		}()
+		if sh.Events != nil {
+			sh.Events.Publish(message.MsgPayload)
+		}
+		return true
+	}, topics...)
+}
+
+// endregion
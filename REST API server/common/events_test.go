@@ -0,0 +1,162 @@
+package common
+
+import (
+	"testing"
+
+	mc "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+)
+
+// Subscription filter of all the events
+func allEvents(*mc.EntityEvent) bool { return true }
+
+// Publish events of the item types with the IDs following the last event ID (as set by the outbox relay)
+func publishEvents(b *EventBroker, itemTypes ...string) {
+	for _, itemType := range itemTypes {
+		b.Publish(&mc.EntityEvent{Id: b.last + 1, Action: "Create", ItemType: itemType})
+	}
+}
+
+// Get the IDs of the events
+func eventIds(events []*mc.EntityEvent) []int64 {
+	result := make([]int64, 0, len(events))
+	for _, event := range events {
+		result = append(result, event.Id)
+	}
+	return result
+}
+
+// Check the subscription channel is closed (after reading the buffered events)
+func isClosed(sub *EventSubscription) bool {
+	for {
+		select {
+		case _, ok := <-sub.C:
+			if !ok {
+				return true
+			}
+		default:
+			return false
+		}
+	}
+}
+
+func TestEventBrokerResume(t *testing.T) {
+	b := NewEventBroker(3)
+	publishEvents(b, "contact", "account", "contact", "contact")
+
+	// Resume from the last event ID replays the recent events that match the filter
+	contacts := func(event *mc.EntityEvent) bool { return event.ItemType == "contact" }
+	sub, replay := b.Subscribe(contacts, 2)
+	if ids := eventIds(replay); len(ids) != 2 || ids[0] != 3 || ids[1] != 4 {
+		t.Errorf("expected replay of events [3 4] but got %v", ids)
+	}
+
+	// New events are delivered by the channel
+	publishEvents(b, "account", "contact")
+	if event := <-sub.C; event.Id != 6 || event.ItemType != "contact" {
+		t.Errorf("expected contact event 6 but got %d %s", event.Id, event.ItemType)
+	}
+
+	// Subscriber that is up to date or subscribes to new events only has no replay
+	for _, lastEventId := range []int64{0, 6} {
+		if _, replay = b.Subscribe(allEvents, lastEventId); len(replay) != 0 {
+			t.Errorf("last event %d: expected no replay but got %v", lastEventId, eventIds(replay))
+		}
+	}
+}
+
+func TestEventBrokerResumeFromOtherInstance(t *testing.T) {
+	b := NewEventBroker(3)
+	publishEvents(b, "contact", "contact")
+
+	// The last event ID was received from other instance, this instance did not receive the events up to it yet
+	sub, replay := b.Subscribe(allEvents, 10)
+	if len(replay) != 0 {
+		t.Errorf("expected no replay but got %v", eventIds(replay))
+	}
+	b.Publish(&mc.EntityEvent{Id: 10, Action: "Create", ItemType: "contact"})
+	b.Publish(&mc.EntityEvent{Id: 12, Action: "Create", ItemType: "contact"})
+	if event := <-sub.C; event.Id != 12 {
+		t.Errorf("expected event 12 but got %d", event.Id)
+	}
+}
+
+func TestEventBrokerOutOfOrder(t *testing.T) {
+	b := NewEventBroker(3)
+	sub, _ := b.Subscribe(allEvents, 0)
+	for _, id := range []int64{2, 1, 3} {
+		b.Publish(&mc.EntityEvent{Id: id, Action: "Create", ItemType: "contact"})
+	}
+
+	// The out of order event is delivered but not kept for resume
+	if ids := []int64{(<-sub.C).Id, (<-sub.C).Id, (<-sub.C).Id}; ids[0] != 2 || ids[1] != 1 || ids[2] != 3 {
+		t.Errorf("expected events [2 1 3] but got %v", ids)
+	}
+	if _, replay := b.Subscribe(allEvents, 2); len(replay) != 1 || replay[0].Id != 3 {
+		t.Errorf("expected replay of events [3] but got %v", eventIds(replay))
+	}
+}
+
+func TestEventBrokerReset(t *testing.T) {
+	b := NewEventBroker(2)
+	publishEvents(b, "contact", "contact", "contact", "contact")
+
+	// The events after the last event ID expired
+	if _, replay := b.Subscribe(allEvents, 1); len(replay) != 1 || replay[0].Action != EventActionReset || replay[0].Id != 4 {
+		t.Errorf("expected Reset event 4 but got %+v", replay)
+	}
+
+	// The events after the last event ID were published before the service was restarted
+	if _, replay := NewEventBroker(2).Subscribe(allEvents, 10); len(replay) != 1 || replay[0].Action != EventActionReset || replay[0].Id != 0 {
+		t.Errorf("expected Reset event 0 but got %+v", replay)
+	}
+
+	// The last expired event is the last event ID, no events are missed
+	if _, replay := b.Subscribe(allEvents, 2); len(replay) != 2 || replay[0].Action == EventActionReset {
+		t.Errorf("expected replay of events [3 4] but got %v", eventIds(replay))
+	}
+}
+
+func TestEventBrokerDropsSlowSubscriber(t *testing.T) {
+	b := NewEventBroker(0)
+	slow, _ := b.Subscribe(allEvents, 0)
+	other, _ := b.Subscribe(func(event *mc.EntityEvent) bool { return event.ItemType == "account" }, 0)
+
+	// The slow subscriber does not read, the channel buffer is full
+	for i := 0; i < subscriptionBuffer+1; i++ {
+		publishEvents(b, "contact")
+	}
+	if !isClosed(slow) {
+		t.Error("expected closed subscription of the slow subscriber")
+	}
+	if b.Subscribers() != 1 || isClosed(other) {
+		t.Errorf("expected the other subscription to remain but got %d subscribers", b.Subscribers())
+	}
+
+	// The closed subscription can be closed again by the subscriber
+	slow.Close()
+	other.Close()
+	if b.Subscribers() != 0 || !isClosed(other) {
+		t.Errorf("expected no subscribers but got %d", b.Subscribers())
+	}
+}
+
+func TestEventBrokerClose(t *testing.T) {
+	b := NewEventBroker(10)
+	sub, _ := b.Subscribe(allEvents, 0)
+	b.Close()
+
+	if !isClosed(sub) || b.Subscribers() != 0 {
+		t.Errorf("expected closed subscription but got %d subscribers", b.Subscribers())
+	}
+
+	// Events published after close are ignored, new subscription is closed
+	publishEvents(b, "contact")
+	late, replay := b.Subscribe(allEvents, 0)
+	if !isClosed(late) || len(replay) != 0 {
+		t.Error("expected closed subscription after close")
+	}
+	if _, replay = b.Subscribe(allEvents, -1); len(replay) != 0 {
+		t.Errorf("expected no replay after close but got %v", eventIds(replay))
+	}
+	sub.Close()
+}
@@ -0,0 +1,107 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	mc "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+)
+
+// Service states (readiness)
+const (
+	StateStarting int32 = iota // Service is starting (e.g. verifying database schema), not ready
+	StateReady                 // Service is ready to accept traffic
+	StateDraining              // Service is shutting down, not ready
+)
+
+// Service state names
+var stateNames = map[int32]string{
+	StateStarting: "STARTING",
+	StateReady:    "READY",
+	StateDraining: "DRAINING",
+}
+
+// Dependency status values
+const (
+	DependencyUp   = "UP"
+	DependencyDown = "DOWN"
+)
+
+// region Service state and dependencies health ------------------------------------------------------------------------
+
+// SetState sets the service state: StateStarting | StateReady | StateDraining
+func (sh *ServiceHub) SetState(state int32) {
+	sh.state.Store(state)
+}
+
+// State returns the service state
+func (sh *ServiceHub) State() int32 {
+	return sh.state.Load()
+}
+
+// StateName returns the service state name: STARTING | READY | DRAINING
+func (sh *ServiceHub) StateName() string {
+	return stateNames[sh.State()]
+}
+
+// CheckHealth pings the service dependencies (database, data cache and message bus) concurrently, each check fails if the dependency
+// does not respond within the timeout, the service is ready if its state is ready and all the dependencies are up
+func (sh *ServiceHub) CheckHealth(timeout time.Duration) *mc.HealthStatus {
+	checks := []struct {
+		name string
+		ping func() error
+	}{
+		{name: MiddlewareDatabase, ping: func() error { return sh.Database.Ping(1, 0) }},
+		{name: MiddlewareDataCache, ping: func() error { return sh.DataCache.Ping(1, 0) }},
+		{name: MiddlewareMessageBus, ping: func() error { return sh.MessageBus.Ping(1, 0) }},
+	}
+
+	result := &mc.HealthStatus{
+		Status:  sh.StateName(),
+		Ready:   sh.State() == StateReady,
+		Version: sh.Version,
+		Checks:  make([]mc.DependencyStatus, len(checks)),
+	}
+
+	done := make(chan struct{}, len(checks))
+	for i, check := range checks {
+		go func() {
+			result.Checks[i] = pingDependency(check.name, check.ping, timeout)
+			done <- struct{}{}
+		}()
+	}
+	for range checks {
+		<-done
+	}
+
+	for _, check := range result.Checks {
+		if check.Status != DependencyUp {
+			result.Ready = false
+		}
+	}
+	return result
+}
+
+// Ping the dependency with timeout and return its status and latency (the ping is abandoned after the timeout)
+func pingDependency(name string, ping func() error, timeout time.Duration) mc.DependencyStatus {
+	start := time.Now()
+	errc := make(chan error, 1)
+	go func() {
+		errc <- ping()
+	}()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-time.After(timeout):
+		err = fmt.Errorf("no response within %s", timeout)
+	}
+
+	result := mc.DependencyStatus{Name: name, Status: DependencyUp, Latency: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		result.Status, result.Error = DependencyDown, err.Error()
+	}
+	return result
+}
+
+// endregion
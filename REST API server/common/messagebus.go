@@ -0,0 +1,35 @@
+package common
+
+import (
+	"strings"
+
+	rds "github.com/go-yaaf/yaaf-common-redis/redis"
+	"github.com/go-yaaf/yaaf-common/messaging"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+)
+
+// NewMessageBus is the factory method for a concrete implementation of the IMessageBus interface
+// In this project we support two implementations: in-memory message bus (for testing) and Redis pub/sub (for production)
+// The concrete implementation is defined by the message bus URI schema
+func NewMessageBus() messaging.IMessageBus {
+
+	uri := config.GetConfig().MessageBusUri()
+
+	// For redis schema, create redis implementation
+	if strings.HasPrefix(uri, "redis://") {
+		if mb, err := rds.NewRedisMessageBus(uri); err != nil {
+			panic(err)
+		} else {
+			return mb
+		}
+	}
+
+	// For unknown or empty schema, create local in-memory message bus
+	mb, err := messaging.NewInMemoryMessageBus()
+	if err != nil {
+		panic(err)
+	} else {
+		return mb
+	}
+}
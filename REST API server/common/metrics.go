@@ -0,0 +1,189 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Metrics namespace (prefix of all the metric names)
+const metricsNamespace = "rest_api"
+
+// Middleware names (label of the middleware metrics)
+const (
+	MiddlewareDatabase   = "database"
+	MiddlewareDataCache  = "datacache"
+	MiddlewareMessageBus = "messagebus"
+)
+
+// region Metrics structure and singleton ------------------------------------------------------------------------------
+
+// MetricsStruct holds the Prometheus collectors of the service: HTTP requests, service methods, middleware calls
+// (database, cache and message bus) and the transactional outbox, the collectors are registered in a dedicated registry
+type MetricsStruct struct {
+	registry *prometheus.Registry
+
+	httpRequests     *prometheus.CounterVec   // HTTP requests by method, route template and status
+	httpDuration     *prometheus.HistogramVec // HTTP request latency by method, route template and status
+	serviceDuration  *prometheus.HistogramVec // Service method latency by service and method
+	serviceErrors    *prometheus.CounterVec   // Service method errors by service and method
+	middlewareCalls  *prometheus.HistogramVec // Middleware call latency by middleware and operation
+	middlewareErrors *prometheus.CounterVec   // Middleware call errors by middleware and operation
+	outboxEntries    *prometheus.GaugeVec     // Outbox entries by state: pending | stuck | failed
+	outboxOldestAge  prometheus.Gauge         // Age of the oldest pending outbox entry
+	outboxRelayed    *prometheus.CounterVec   // Outbox relay attempts by result: sent | retry | failed
+}
+
+var doOnceForMetrics sync.Once
+
+var metricsSingleton *MetricsStruct = nil
+
+// Metrics is a factory method that acts as a static member
+func Metrics() *MetricsStruct {
+	doOnceForMetrics.Do(func() {
+		metricsSingleton = newMetrics()
+	})
+	return metricsSingleton
+}
+
+// Create the collectors and register them (including the Go runtime and process collectors)
+func newMetrics() *MetricsStruct {
+	m := &MetricsStruct{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace, Name: "http_requests_total",
+			Help: "Total number of HTTP requests by method, route template and status",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace, Name: "http_request_duration_seconds",
+			Help:    "HTTP request latency by method, route template and status",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		serviceDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace, Name: "service_method_duration_seconds",
+			Help:    "Service method latency by service and method",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"service", "method"}),
+		serviceErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace, Name: "service_method_errors_total",
+			Help: "Total number of service method errors by service and method",
+		}, []string{"service", "method"}),
+		middlewareCalls: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace, Name: "middleware_call_duration_seconds",
+			Help:    "Middleware (database, datacache, messagebus) call latency by middleware and operation",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"middleware", "operation"}),
+		middlewareErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace, Name: "middleware_call_errors_total",
+			Help: "Total number of middleware (database, datacache, messagebus) call errors by middleware and operation",
+		}, []string{"middleware", "operation"}),
+		outboxEntries: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace, Name: "outbox_entries",
+			Help: "Number of outbox entries by state: pending (waiting to be relayed), stuck (pending longer than the threshold), failed",
+		}, []string{"state"}),
+		outboxOldestAge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace, Name: "outbox_oldest_pending_age_seconds",
+			Help: "Age of the oldest outbox entry waiting to be relayed (0 when there is none)",
+		}),
+		outboxRelayed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace, Name: "outbox_relayed_total",
+			Help: "Total number of outbox relay attempts by result: sent | retry | failed",
+		}, []string{"result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.serviceDuration,
+		m.serviceErrors,
+		m.middlewareCalls,
+		m.middlewareErrors,
+		m.outboxEntries,
+		m.outboxOldestAge,
+		m.outboxRelayed,
+	)
+	return m
+}
+
+// endregion
+
+// region Metrics methods ----------------------------------------------------------------------------------------------
+
+// Handler returns the HTTP handler of the metrics endpoint (Prometheus text exposition format)
+func (m *MetricsStruct) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveHttpRequest records HTTP request by method, route template and status
+func (m *MetricsStruct) ObserveHttpRequest(method, route string, status int, latency time.Duration) {
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(latency.Seconds())
+}
+
+// ObserveServiceMethod records service method latency
+func (m *MetricsStruct) ObserveServiceMethod(service, method string, latency time.Duration) {
+	m.serviceDuration.WithLabelValues(service, method).Observe(latency.Seconds())
+}
+
+// CountServiceError records service method error
+func (m *MetricsStruct) CountServiceError(service, method string) {
+	m.serviceErrors.WithLabelValues(service, method).Inc()
+}
+
+// ObserveMiddlewareCall records middleware call latency and error (if any)
+func (m *MetricsStruct) ObserveMiddlewareCall(middleware, operation string, start time.Time, err error) {
+	m.middlewareCalls.WithLabelValues(middleware, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.middlewareErrors.WithLabelValues(middleware, operation).Inc()
+	}
+}
+
+// Start the middleware call span (only as child of a recording span) and timer, the returned function records the call
+// result (metrics and span status) and ends the span
+func observeCall(ctx context.Context, middleware, operation string) func(*error) {
+	start := time.Now()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		_, span = Tracer().Start(ctx, middleware+"."+operation, trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String("middleware", middleware), attribute.String("operation", operation)))
+	}
+
+	return func(err *error) {
+		Metrics().ObserveMiddlewareCall(middleware, operation, start, *err)
+		if span.SpanContext().IsValid() {
+			if *err != nil {
+				span.RecordError(*err)
+				span.SetStatus(codes.Error, (*err).Error())
+			}
+			span.End()
+		}
+	}
+}
+
+// SetOutboxStats records the state of the outbox: number of pending, stuck and failed entries and the age of the oldest
+// pending entry
+func (m *MetricsStruct) SetOutboxStats(pending, stuck, failed int64, oldest time.Duration) {
+	m.outboxEntries.WithLabelValues("pending").Set(float64(pending))
+	m.outboxEntries.WithLabelValues("stuck").Set(float64(stuck))
+	m.outboxEntries.WithLabelValues("failed").Set(float64(failed))
+	m.outboxOldestAge.Set(oldest.Seconds())
+}
+
+// CountOutboxRelay records outbox relay attempt by result: sent | retry | failed
+func (m *MetricsStruct) CountOutboxRelay(result string) {
+	m.outboxRelayed.WithLabelValues(result).Inc()
+}
+
+// endregion
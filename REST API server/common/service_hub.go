@@ -1,17 +1,28 @@
 package common
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 
 	"github.com/go-yaaf/yaaf-common/database"
+	"github.com/go-yaaf/yaaf-common/messaging"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
 )
 
 // ServiceHub is the main application hub for all middleware facilities (e.g. database, cache, messaging, etc`)
 type ServiceHub struct {
-	Database  database.IDatabase  // Configuration database middleware facade
-	DataCache database.IDataCache // Distributed cache middleware facade
-	Version   string              // Current service version
+	Database   database.IDatabase    // Configuration database middleware facade
+	DataCache  database.IDataCache   // Distributed cache middleware facade
+	MessageBus messaging.IMessageBus // Real-time messaging middleware facade (domain events)
+	Events     *EventBroker          // Entity change events broker (streamed by the events endpoint)
+	Version    string                // Current service version
+
+	state atomic.Int32 // Service state: StateStarting | StateReady | StateDraining (see health.go)
 }
 
 var facade *ServiceHub = nil
@@ -26,12 +37,55 @@ func GetServiceHub() *ServiceHub {
 	return facade
 }
 
+// DatabaseContext returns the database bound to the request context (parent span of the database calls spans)
+func (sh *ServiceHub) DatabaseContext(ctx context.Context) database.IDatabase {
+	if db, ok := sh.Database.(*instrumentedDatabase); ok {
+		return db.WithContext(ctx)
+	}
+	return sh.Database
+}
+
+// DataCacheContext returns the data cache bound to the request context (parent span of the cache calls spans)
+func (sh *ServiceHub) DataCacheContext(ctx context.Context) database.IDataCache {
+	if dc, ok := sh.DataCache.(*instrumentedDataCache); ok {
+		return dc.WithContext(ctx)
+	}
+	return sh.DataCache
+}
+
+// Close releases the middleware resources in reverse order of their creation (events subscriptions, message bus, data
+// cache, then database), all the resources are closed even if some of them fail
+func (sh *ServiceHub) Close() error {
+	var errs []error
+	if sh.Events != nil {
+		sh.Events.Close()
+	}
+	if sh.MessageBus != nil {
+		if err := sh.MessageBus.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close message bus: %w", err))
+		}
+	}
+	if sh.DataCache != nil {
+		if err := sh.DataCache.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close data cache: %w", err))
+		}
+	}
+	if sh.Database != nil {
+		if err := sh.Database.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close database: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // NewServiceHub is a service hub factory method
 func NewServiceHub() *ServiceHub {
 	facade = &ServiceHub{
-		Database:  NewDatabase(),
-		DataCache: NewDataCache(),
-		Version:   getVersion(),
+		Database:   NewInstrumentedDatabase(NewDatabase()),
+		DataCache:  NewInstrumentedDataCache(NewDataCache()),
+		MessageBus: NewMessageBus(),
+		Events:     NewEventBroker(config.GetConfig().EventsBufferSize()),
+		Version:    getVersion(),
 	}
 
 	return facade
@@ -0,0 +1,119 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing service name and instrumentation scope
+const (
+	tracingServiceName = "rest-api"
+	tracingScope       = "github.com/go-yaaf/yaaf-examples/rest-api"
+)
+
+// Tracing exporters
+const (
+	TracingExporterNone   = "none"   // Tracing disabled (trace context is still propagated)
+	TracingExporterStdout = "stdout" // Write spans to stdout (for development)
+	TracingExporterOtlp   = "otlp"   // Export spans to OTLP collector over HTTP (configured by OTEL_EXPORTER_OTLP_* variables)
+)
+
+var tracerProvider *sdktrace.TracerProvider = nil
+
+// region Tracing configuration ----------------------------------------------------------------------------------------
+
+// InitTracing configures the global tracer provider with the exporter: otlp | stdout | none (default)
+// The W3C trace context and baggage propagators are set regardless of the exporter
+func InitTracing(exporter string) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	switch strings.ToLower(exporter) {
+	case TracingExporterOtlp:
+		exp, err := otlptracehttp.New(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		setTracerProvider(sdktrace.WithBatcher(exp))
+	case TracingExporterStdout:
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return fmt.Errorf("failed to create stdout trace exporter: %w", err)
+		}
+		setTracerProvider(sdktrace.WithBatcher(exp))
+	case TracingExporterNone, "":
+		return nil
+	default:
+		return fmt.Errorf("unknown tracing exporter: %s", exporter)
+	}
+	return nil
+}
+
+// InitTracingWithExporter configures the global tracer provider with the provided exporter, the spans are exported
+// synchronously when ended (e.g. in-memory exporter of tracetest package to assert on the recorded spans)
+func InitTracingWithExporter(exporter sdktrace.SpanExporter) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	setTracerProvider(sdktrace.WithSyncer(exporter))
+}
+
+// InitTracingWithProcessor configures the global tracer provider with the provided span processor (e.g. span recorder
+// of tracetest package to assert on the recorded spans and their hierarchy)
+func InitTracingWithProcessor(processor sdktrace.SpanProcessor) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	setTracerProvider(sdktrace.WithSpanProcessor(processor))
+}
+
+// ShutdownTracing flushes the pending spans and stops the tracer provider
+func ShutdownTracing(ctx context.Context) error {
+	if tracerProvider == nil {
+		return nil
+	}
+	return tracerProvider.Shutdown(ctx)
+}
+
+// Create the tracer provider with the service resource and set it as the global tracer provider
+func setTracerProvider(opts ...sdktrace.TracerProviderOption) {
+	res := resource.NewSchemaless(
+		attribute.String("service.name", tracingServiceName),
+		attribute.String("service.version", getVersion()),
+	)
+	tracerProvider = sdktrace.NewTracerProvider(append(opts, sdktrace.WithResource(res))...)
+	otel.SetTracerProvider(tracerProvider)
+}
+
+// endregion
+
+// region Tracing helpers ----------------------------------------------------------------------------------------------
+
+// Tracer returns the service tracer (no-op tracer when tracing is disabled)
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracingScope)
+}
+
+// StartSpan starts internal span as child of the span in the context
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// SpanError records the error in the span of the context and sets the span status to error
+func SpanError(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// endregion
@@ -0,0 +1,276 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-yaaf/yaaf-common/database"
+	"github.com/go-yaaf/yaaf-common/entity"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+)
+
+// Transaction operations
+const (
+	txInsert = iota
+	txUpdate
+	txDelete
+)
+
+// Error state of the transaction statement when an updated or deleted entity is not found (division by zero)
+const txMissingRowState = "SQLSTATE 22012"
+
+// Transaction is a unit of work: the inserts, updates and deletes (of entities of any table) are applied on Commit,
+// either all of them or none (e.g. entity change with its audit log and outbox entries). Each entity should be changed
+// once in a transaction
+type Transaction interface {
+	// Insert adds new entities to the transaction
+	Insert(entities ...entity.Entity) Transaction
+
+	// Update adds existing entities to the transaction (Commit fails if any of them is not found)
+	Update(entities ...entity.Entity) Transaction
+
+	// Delete adds entities to delete (by ID) to the transaction (Commit fails if any of them is not found)
+	Delete(entities ...entity.Entity) Transaction
+
+	// Commit applies the operations in their order, if any of them fails none is applied
+	Commit() error
+}
+
+// Transaction returns new transaction of the database bound to the request context
+func (sh *ServiceHub) Transaction(ctx context.Context) Transaction {
+	return NewTransaction(ctx, sh.DatabaseContext(ctx))
+}
+
+// NewTransaction is the factory method for a concrete implementation of the Transaction interface, defined by the
+// database URI schema (as the database): a single statement for postgresql, and operations that are reverted on failure
+// for the in-memory database
+func NewTransaction(ctx context.Context, db database.IDatabase) Transaction {
+	base := baseTransaction{ctx: ctx, db: db}
+	if strings.HasPrefix(config.GetConfig().DatabaseUri(), "postgres://") {
+		return &sqlTransaction{baseTransaction: base}
+	}
+	return &memoryTransaction{baseTransaction: base}
+}
+
+// region Base transaction ---------------------------------------------------------------------------------------------
+
+type txOperation struct {
+	op     int
+	entity entity.Entity
+}
+
+type baseTransaction struct {
+	ctx context.Context
+	db  database.IDatabase
+	ops []txOperation
+}
+
+func (t *baseTransaction) add(op int, entities []entity.Entity) {
+	for _, ent := range entities {
+		if ent != nil {
+			t.ops = append(t.ops, txOperation{op: op, entity: ent})
+		}
+	}
+}
+
+// endregion
+
+// region Postgresql transaction ---------------------------------------------------------------------------------------
+
+// sqlTransaction executes all the operations as a single statement of data-modifying CTEs, the statement is atomic
+// (IDatabase does not expose transactions over multiple statements). The table names and the data are resolved as the
+// postgresql database does (the {key} placeholder replaced by the entity key, entity.Marshal)
+type sqlTransaction struct {
+	baseTransaction
+}
+
+func (t *sqlTransaction) Insert(entities ...entity.Entity) Transaction {
+	t.add(txInsert, entities)
+	return t
+}
+
+func (t *sqlTransaction) Update(entities ...entity.Entity) Transaction {
+	t.add(txUpdate, entities)
+	return t
+}
+
+func (t *sqlTransaction) Delete(entities ...entity.Entity) Transaction {
+	t.add(txDelete, entities)
+	return t
+}
+
+func (t *sqlTransaction) Commit() (err error) {
+	if len(t.ops) == 0 {
+		return nil
+	}
+	defer observeCall(t.ctx, MiddlewareDatabase, "Transaction")(&err)
+	if err = t.ctx.Err(); err != nil {
+		return
+	}
+
+	sql, args, err := txStatement(t.ops)
+	if err != nil {
+		return
+	}
+	if _, err = t.db.ExecuteSQL(sql, args...); err != nil && strings.Contains(err.Error(), txMissingRowState) {
+		err = fmt.Errorf("updated or deleted entity not found, transaction is not applied: %v", err)
+	}
+	return
+}
+
+// Build the statement of the operations: data-modifying CTE of each operation (returning the ID of the changed row),
+// the final select divides by the number of rows of each update and delete, so the statement fails (division by zero)
+// when any of them does not find its entity
+func txStatement(ops []txOperation) (string, []any, error) {
+	ctes := make([]string, 0, len(ops))
+	counts := make([]string, 0, len(ops))
+	args := make([]any, 0, 2*len(ops))
+	for i, op := range ops {
+		id := len(args) + 1
+		table, err := txTableName(op.entity)
+		if err != nil {
+			return "", nil, err
+		}
+		switch op.op {
+		case txInsert, txUpdate:
+			data, err := entity.Marshal(op.entity)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to serialize %s %s: %v", table, op.entity.ID(), err)
+			}
+			if op.op == txInsert {
+				ctes = append(ctes, fmt.Sprintf(`w%d AS (INSERT INTO "%s" (id, data) VALUES ($%d, $%d) RETURNING id)`, i, table, id, id+1))
+			} else {
+				ctes = append(ctes, fmt.Sprintf(`w%d AS (UPDATE "%s" SET data = $%d WHERE id = $%d RETURNING id)`, i, table, id+1, id))
+				counts = append(counts, fmt.Sprintf(" / (SELECT count(*) FROM w%d)", i))
+			}
+			args = append(args, op.entity.ID(), data)
+		case txDelete:
+			ctes = append(ctes, fmt.Sprintf(`w%d AS (DELETE FROM "%s" WHERE id = $%d RETURNING id)`, i, table, id))
+			counts = append(counts, fmt.Sprintf(" / (SELECT count(*) FROM w%d)", i))
+			args = append(args, op.entity.ID())
+		}
+	}
+	return "WITH " + strings.Join(ctes, ", ") + " SELECT 1" + strings.Join(counts, ""), args, nil
+}
+
+// Resolve the table name of the entity as the postgresql database does: the {key} placeholder is replaced by the entity
+// key. Fails on table without the key or with other placeholders (not resolved by the transaction)
+func txTableName(ent entity.Entity) (string, error) {
+	table := ent.TABLE()
+	if strings.Contains(table, "{key}") {
+		if len(ent.KEY()) == 0 {
+			return "", fmt.Errorf("table %s of %s requires entity key", table, ent.ID())
+		}
+		table = strings.ReplaceAll(table, "{key}", ent.KEY())
+	}
+	if len(table) == 0 || strings.ContainsAny(table, `{}"`) {
+		return "", fmt.Errorf("unsupported table name %q of %s in transaction", table, ent.ID())
+	}
+	return table, nil
+}
+
+// endregion
+
+// region In-memory transaction ----------------------------------------------------------------------------------------
+
+// Serializes the in-memory transactions (the changes of a transaction are not isolated from the other database calls)
+var memoryTransactionLock sync.Mutex
+
+// memoryTransaction applies the operations one by one and reverts the applied operations if any of them fails (the
+// in-memory database keeps the entity instances, so changes made on the stored instance itself are not reverted)
+type memoryTransaction struct {
+	baseTransaction
+}
+
+func (t *memoryTransaction) Insert(entities ...entity.Entity) Transaction {
+	t.add(txInsert, entities)
+	return t
+}
+
+func (t *memoryTransaction) Update(entities ...entity.Entity) Transaction {
+	t.add(txUpdate, entities)
+	return t
+}
+
+func (t *memoryTransaction) Delete(entities ...entity.Entity) Transaction {
+	t.add(txDelete, entities)
+	return t
+}
+
+func (t *memoryTransaction) Commit() (err error) {
+	if len(t.ops) == 0 {
+		return nil
+	}
+	defer observeCall(t.ctx, MiddlewareDatabase, "Transaction")(&err)
+
+	memoryTransactionLock.Lock()
+	defer memoryTransactionLock.Unlock()
+
+	// The revert function of each applied operation
+	reverts := make([]func() error, 0, len(t.ops))
+	for _, op := range t.ops {
+		var revert func() error
+		if revert, err = t.apply(op); err != nil {
+			break
+		}
+		reverts = append(reverts, revert)
+	}
+	if err == nil {
+		return nil
+	}
+
+	for i := len(reverts) - 1; i >= 0; i-- {
+		if er := reverts[i](); er != nil {
+			err = errors.Join(err, fmt.Errorf("failed to revert transaction: %v", er))
+		}
+	}
+	return err
+}
+
+// Apply the operation, returns the function to revert it
+func (t *memoryTransaction) apply(op txOperation) (func() error, error) {
+	if err := t.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ent, factory := op.entity, entityFactory(op.entity)
+	switch op.op {
+	case txInsert:
+		if _, err := t.db.Insert(ent); err != nil {
+			return nil, err
+		}
+		return func() error { return t.db.Delete(factory, ent.ID()) }, nil
+	case txUpdate:
+		existing, err := t.db.Get(factory, ent.ID())
+		if err != nil {
+			return nil, err
+		}
+		if _, err = t.db.Update(ent); err != nil {
+			return nil, err
+		}
+		return func() error { _, er := t.db.Update(existing); return er }, nil
+	default:
+		existing, err := t.db.Get(factory, ent.ID())
+		if err != nil {
+			return nil, err
+		}
+		if err = t.db.Delete(factory, ent.ID()); err != nil {
+			return nil, err
+		}
+		return func() error { _, er := t.db.Insert(existing); return er }, nil
+	}
+}
+
+// Get factory of new instances of the entity type
+func entityFactory(ent entity.Entity) entity.EntityFactory {
+	return func() entity.Entity {
+		return reflect.New(reflect.TypeOf(ent).Elem()).Interface().(entity.Entity)
+	}
+}
+
+// endregion
@@ -0,0 +1,133 @@
+package common
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	"github.com/go-yaaf/yaaf-common/entity"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+)
+
+// Entity of configurable table and key
+type txTestEntity struct {
+	entity.BaseEntity
+	table string
+	key   string
+}
+
+func (e *txTestEntity) TABLE() string { return e.table }
+func (e *txTestEntity) KEY() string   { return e.key }
+
+func TestTxTableName(t *testing.T) {
+	tests := []struct {
+		table    string
+		key      string
+		expected string
+		valid    bool
+	}{
+		{"contact", "", "contact", true},
+		{"audit_{key}", "2026", "audit_2026", true},
+		{"audit_{key}", "", "", false},
+		{"audit_{month}", "2026", "", false},
+		{`bad"name`, "", "", false},
+		{"", "", "", false},
+	}
+	for _, tt := range tests {
+		table, err := txTableName(&txTestEntity{BaseEntity: entity.BaseEntity{Id: "e1"}, table: tt.table, key: tt.key})
+		if (err == nil) != tt.valid || table != tt.expected {
+			t.Errorf("%s (key %q): expected %q valid %v but got %q: %v", tt.table, tt.key, tt.expected, tt.valid, table, err)
+		}
+	}
+}
+
+// Create contact with the ID and name
+func txContact(id, name string) *Contact {
+	contact := NewContact().(*Contact)
+	contact.Id, contact.Name = id, name
+	return contact
+}
+
+func TestTxStatement(t *testing.T) {
+	ops := []txOperation{
+		{op: txInsert, entity: txContact("c1", "a")},
+		{op: txUpdate, entity: txContact("c2", "b")},
+		{op: txDelete, entity: txContact("c3", "c")},
+	}
+	sql, args, err := txStatement(ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each update and delete divides the select by its number of rows, the statement fails if the entity is not found
+	expected := `WITH w0 AS (INSERT INTO "contact" (id, data) VALUES ($1, $2) RETURNING id), ` +
+		`w1 AS (UPDATE "contact" SET data = $4 WHERE id = $3 RETURNING id), ` +
+		`w2 AS (DELETE FROM "contact" WHERE id = $5 RETURNING id) ` +
+		`SELECT 1 / (SELECT count(*) FROM w1) / (SELECT count(*) FROM w2)`
+	if sql != expected {
+		t.Errorf("unexpected statement:\n%s\nexpected:\n%s", sql, expected)
+	}
+	if len(args) != 5 || args[0] != "c1" || args[2] != "c2" || args[4] != "c3" {
+		t.Errorf("unexpected args: %v", args)
+	}
+	if data, ok := args[3].([]byte); !ok || !strings.Contains(string(data), `"name":"b"`) {
+		t.Errorf("expected the data of the updated entity but got %v", args[3])
+	}
+
+	// Inserts only can't fail on missing rows
+	if sql, _, _ = txStatement(ops[:1]); !strings.HasSuffix(sql, "RETURNING id) SELECT 1") {
+		t.Errorf("unexpected statement: %s", sql)
+	}
+
+	// Unsupported table fails the statement
+	if _, _, err = txStatement([]txOperation{{op: txDelete, entity: &txTestEntity{table: "audit_{month}"}}}); err == nil {
+		t.Error("expected error for unsupported table")
+	}
+}
+
+func TestMemoryTransactionRollback(t *testing.T) {
+	inner, err := NewInMemoryDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := newMemoryDatabase(inner)
+	if err = db.ExecuteDDL(map[string][]string{"contact": {"name"}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, contact := range []*Contact{txContact("c1", "before"), txContact("c2", "deleted")} {
+		if _, err = db.Insert(contact); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The update of missing entity fails the transaction, the applied operations are reverted
+	tx := &memoryTransaction{baseTransaction: baseTransaction{ctx: context.Background(), db: db}}
+	tx.Insert(txContact("c3", "inserted")).Update(txContact("c1", "after")).Delete(txContact("c2", "")).Update(txContact("c9", "missing"))
+	if err = tx.Commit(); err == nil {
+		t.Fatal("expected error for update of missing entity")
+	}
+	if exists, _ := db.Exists(NewContact, "c3"); exists {
+		t.Error("expected reverted insert")
+	}
+	if ent, er := db.Get(NewContact, "c1"); er != nil || ent.(*Contact).Name != "before" {
+		t.Errorf("expected reverted update but got %v: %v", ent, er)
+	}
+	if exists, _ := db.Exists(NewContact, "c2"); !exists {
+		t.Error("expected reverted delete")
+	}
+
+	// The transaction of existing entities is applied
+	tx = &memoryTransaction{baseTransaction: baseTransaction{ctx: context.Background(), db: db}}
+	tx.Insert(txContact("c3", "inserted")).Update(txContact("c1", "after")).Delete(txContact("c2", ""))
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if ent, er := db.Get(NewContact, "c1"); er != nil || ent.(*Contact).Name != "after" {
+		t.Errorf("expected updated entity but got %v: %v", ent, er)
+	}
+	if exists, _ := db.Exists(NewContact, "c2"); exists {
+		t.Error("expected deleted entity")
+	}
+}
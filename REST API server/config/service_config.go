@@ -2,7 +2,11 @@ package config
 
 import (
 	bc "github.com/go-yaaf/yaaf-common/config"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
 const (
@@ -10,16 +14,85 @@ const (
 	CfgLogJsonFormat  = "LOG_JSON_FORMAT"  // Enable Json log format
 	CfgDatabaseUri    = "DATABASE_URI"     // Configuration database URI
 	CfgDataCacheUri   = "DATACACHE_URI"    // Distributed cache middleware URI
+	CfgMessageBusUri  = "MESSAGEBUS_URI"   // Message bus middleware URI
 	CfgFileStorageUri = "FILE_STORAGE_URI" // File storage location URI
 	CfgExposeHttpPort = "EXPOSE_HTTP_PORT" // Port number to expose HTTP REST API endpoint
+	CfgExposeGrpcPort = "EXPOSE_GRPC_PORT" // Port number to expose the gRPC API endpoint, 0 to disable the gRPC server
 	CfgInitialAdmin   = "INIT_ADMIN_EMAIL" // On system startup, set the initial administrator email if not exists
 	CfgMailRelayUri   = "MAIL_RELAY_URI"   // Mail Relay URI
 	CfgMailRelayUsr   = "MAIL_RELAY_USR"   // Mail Relay User
 	CfgMailRelayPwd   = "MAIL_RELAY_PWD"   // Mail Relay Password
 	CfgMailRelayTls   = "MAIL_RELAY_TLS"   // Mail Relay TLS flag
+	CfgApiV1Deprecate = "API_V1_DEPRECATE" // Date when API v1 is deprecated (yyyy-mm-dd), empty for active version
+	CfgApiV1Sunset    = "API_V1_SUNSET"    // Date when API v1 is going to be removed (yyyy-mm-dd)
+	CfgAccessLogSkip  = "ACCESS_LOG_SKIP"  // Comma separated list of routes excluded from the access log (e.g. health check)
+	CfgAccessLogSlow  = "ACCESS_LOG_SLOW"  // Slow request threshold in milliseconds (logged as warning even if skipped), 0 to disable
+	CfgMetricsToken   = "METRICS_TOKEN"    // Bearer token required by the metrics endpoint (the endpoint is disabled when empty)
+	CfgTracing        = "TRACING_EXPORTER" // Tracing exporter: otlp | stdout | none
+	CfgHealthTimeout  = "HEALTH_TIMEOUT"   // Timeout in milliseconds of each dependency check of the readiness probe
+	CfgShutdownWait   = "SHUTDOWN_TIMEOUT" // Deadline in milliseconds to complete in-flight requests on shutdown
+	CfgShutdownDelay  = "SHUTDOWN_DELAY"   // Delay in milliseconds between reporting not ready and closing the listener
 
 )
 
+// HTTP server transport configuration
+const (
+	CfgTlsCertFile       = "TLS_CERT_FILE"            // TLS certificate file (PEM), TLS is enabled when both certificate and key are set
+	CfgTlsKeyFile        = "TLS_KEY_FILE"             // TLS private key file (PEM)
+	CfgTlsClientCaFile   = "TLS_CLIENT_CA_FILE"       // Client CA certificates file (PEM), enables mutual TLS
+	CfgHttp2Enabled      = "HTTP2_ENABLED"            // Enable HTTP/2 over TLS
+	CfgH2cEnabled        = "H2C_ENABLED"              // Enable HTTP/2 over cleartext (h2c) when TLS is disabled (e.g. in service mesh)
+	CfgHttpReadTimeout   = "HTTP_READ_TIMEOUT"        // Timeout in milliseconds to read the entire request (including body), 0 for no timeout
+	CfgHttpHeaderTimeout = "HTTP_READ_HEADER_TIMEOUT" // Timeout in milliseconds to read the request headers, 0 for no timeout
+	CfgHttpWriteTimeout  = "HTTP_WRITE_TIMEOUT"       // Timeout in milliseconds to write the response, 0 for no timeout
+	CfgHttpIdleTimeout   = "HTTP_IDLE_TIMEOUT"        // Timeout in milliseconds of idle keep-alive connection, 0 for no timeout
+	CfgHttpMaxHeaderSize = "HTTP_MAX_HEADER_BYTES"    // Maximum size in bytes of the request headers
+	CfgCompression       = "COMPRESSION_ENABLED"      // Enable response compression (zstd, br, gzip) negotiated by Accept-Encoding
+	CfgCompressionMin    = "COMPRESSION_MIN_SIZE"     // Minimal response size in bytes to compress
+	CfgRequestMaxBody    = "REQUEST_MAX_BODY_BYTES"   // Default maximum request body size in bytes, 0 for no limit
+	CfgRequestTimeout    = "REQUEST_TIMEOUT"          // Default handler deadline in milliseconds, 0 for no deadline
+	CfgIdempotencyTTL    = "IDEMPOTENCY_TTL"          // Retention in milliseconds of the responses stored by Idempotency-Key
+	CfgGraphQLMaxDepth   = "GRAPHQL_MAX_DEPTH"        // Maximal nesting depth of the GraphQL query selections, 0 for no limit
+)
+
+// Rate limits configuration (requests per period, 0 for no limit)
+const (
+	CfgRateLimit             = "RATE_LIMIT_ENABLED"       // Enable rate limiting of the API routes
+	CfgRateLimitPeriod       = "RATE_LIMIT_PERIOD"        // Rate limit period in milliseconds
+	CfgRateLimitIp           = "RATE_LIMIT_IP"            // Requests per period by remote IP
+	CfgRateLimitApiKey       = "RATE_LIMIT_API_KEY"       // Requests per period by API key (application)
+	CfgRateLimitSubject      = "RATE_LIMIT_SUBJECT"       // Requests per period by token subject (user or service account)
+	CfgRateLimitGroups       = "RATE_LIMIT_GROUPS"        // Requests per period by route group and subject, e.g: /contacts=300,/audit_logs=100
+	CfgRateLimitAccountTypes = "RATE_LIMIT_ACCOUNT_TYPES" // Requests per period by subject of account type, e.g: DEMO=60,BUSINESS=3000
+)
+
+// Entity change events configuration
+const (
+	CfgEventsBuffer    = "EVENTS_BUFFER_SIZE" // Number of recent entity change events kept to resume the streams after reconnect
+	CfgEventsHeartbeat = "EVENTS_HEARTBEAT"   // Interval in milliseconds of the events stream heartbeat (SSE comment or WebSocket ping)
+)
+
+// Webhooks dispatcher configuration
+const (
+	CfgWebhookAttempts   = "WEBHOOK_MAX_ATTEMPTS"  // Number of delivery attempts before the delivery is dead-lettered (FAILED)
+	CfgWebhookBackoff    = "WEBHOOK_BACKOFF"       // Delay in milliseconds before the first retry, doubled on each retry
+	CfgWebhookBackoffMax = "WEBHOOK_BACKOFF_MAX"   // Maximal delay in milliseconds between retries
+	CfgWebhookTimeout    = "WEBHOOK_TIMEOUT"       // Timeout in milliseconds of the delivery request
+	CfgWebhookPoll       = "WEBHOOK_POLL_INTERVAL" // Interval in milliseconds of checking the due deliveries (retries)
+	CfgWebhookPrivate    = "WEBHOOK_ALLOW_PRIVATE" // Allow webhook receivers on loopback, link-local and private addresses
+)
+
+// Transactional outbox relay configuration
+const (
+	CfgOutboxAttempts   = "OUTBOX_MAX_ATTEMPTS"  // Number of relay attempts before the outbox entry fails (FAILED)
+	CfgOutboxBackoff    = "OUTBOX_BACKOFF"       // Delay in milliseconds before the first retry, doubled on each retry
+	CfgOutboxBackoffMax = "OUTBOX_BACKOFF_MAX"   // Maximal delay in milliseconds between retries
+	CfgOutboxPoll       = "OUTBOX_POLL_INTERVAL" // Interval in milliseconds of checking the due entries (and updating the metrics)
+	CfgOutboxStuckAfter = "OUTBOX_STUCK_AFTER"   // Time in milliseconds after which a waiting entry is reported as stuck
+	CfgOutboxRetention  = "OUTBOX_RETENTION"     // Time in milliseconds to keep the sent entries (0 to keep them)
+	CfgOutboxRelay      = "OUTBOX_RELAY"         // Run the outbox relay and the webhooks dispatcher (on a single instance only)
+)
+
 type ServiceConfig struct {
 	bc.BaseConfig
 }
@@ -35,13 +108,62 @@ func NewConfig() *ServiceConfig {
 	c.AddConfigVar(CfgLogJsonFormat, "false")
 	c.AddConfigVar(CfgDatabaseUri, "")
 	c.AddConfigVar(CfgDataCacheUri, "")
+	c.AddConfigVar(CfgMessageBusUri, "")
 	c.AddConfigVar(CfgFileStorageUri, "")
 	c.AddConfigVar(CfgExposeHttpPort, "8080")
+	c.AddConfigVar(CfgExposeGrpcPort, "9090")
 	c.AddConfigVar(CfgInitialAdmin, "")
 	c.AddConfigVar(CfgMailRelayUri, "")
 	c.AddConfigVar(CfgMailRelayUsr, "")
 	c.AddConfigVar(CfgMailRelayPwd, "")
 	c.AddConfigVar(CfgMailRelayTls, "false")
+	c.AddConfigVar(CfgApiV1Deprecate, "")
+	c.AddConfigVar(CfgApiV1Sunset, "")
+	c.AddConfigVar(CfgAccessLogSkip, "/,/metrics,/health/live,/health/ready")
+	c.AddConfigVar(CfgAccessLogSlow, "1000")
+	c.AddConfigVar(CfgMetricsToken, "")
+	c.AddConfigVar(CfgTracing, "none")
+	c.AddConfigVar(CfgHealthTimeout, "2000")
+	c.AddConfigVar(CfgShutdownWait, "30000")
+	c.AddConfigVar(CfgShutdownDelay, "0")
+	c.AddConfigVar(CfgTlsCertFile, "")
+	c.AddConfigVar(CfgTlsKeyFile, "")
+	c.AddConfigVar(CfgTlsClientCaFile, "")
+	c.AddConfigVar(CfgHttp2Enabled, "true")
+	c.AddConfigVar(CfgH2cEnabled, "false")
+	c.AddConfigVar(CfgHttpReadTimeout, "30000")
+	c.AddConfigVar(CfgHttpHeaderTimeout, "10000")
+	c.AddConfigVar(CfgHttpWriteTimeout, "60000")
+	c.AddConfigVar(CfgHttpIdleTimeout, "120000")
+	c.AddConfigVar(CfgHttpMaxHeaderSize, "1048576")
+	c.AddConfigVar(CfgCompression, "true")
+	c.AddConfigVar(CfgCompressionMin, "1024")
+	c.AddConfigVar(CfgRequestMaxBody, "1048576")
+	c.AddConfigVar(CfgRequestTimeout, "30000")
+	c.AddConfigVar(CfgIdempotencyTTL, "86400000")
+	c.AddConfigVar(CfgGraphQLMaxDepth, "8")
+	c.AddConfigVar(CfgRateLimit, "true")
+	c.AddConfigVar(CfgRateLimitPeriod, "60000")
+	c.AddConfigVar(CfgRateLimitIp, "1200")
+	c.AddConfigVar(CfgRateLimitApiKey, "6000")
+	c.AddConfigVar(CfgRateLimitSubject, "600")
+	c.AddConfigVar(CfgRateLimitGroups, "")
+	c.AddConfigVar(CfgRateLimitAccountTypes, "")
+	c.AddConfigVar(CfgEventsBuffer, "1000")
+	c.AddConfigVar(CfgEventsHeartbeat, "30000")
+	c.AddConfigVar(CfgWebhookAttempts, "8")
+	c.AddConfigVar(CfgWebhookBackoff, "10000")
+	c.AddConfigVar(CfgWebhookBackoffMax, "3600000")
+	c.AddConfigVar(CfgWebhookTimeout, "10000")
+	c.AddConfigVar(CfgWebhookPoll, "1000")
+	c.AddConfigVar(CfgWebhookPrivate, "false")
+	c.AddConfigVar(CfgOutboxAttempts, "10")
+	c.AddConfigVar(CfgOutboxBackoff, "1000")
+	c.AddConfigVar(CfgOutboxBackoffMax, "300000")
+	c.AddConfigVar(CfgOutboxPoll, "1000")
+	c.AddConfigVar(CfgOutboxStuckAfter, "60000")
+	c.AddConfigVar(CfgOutboxRetention, "604800000")
+	c.AddConfigVar(CfgOutboxRelay, "true")
 	return c
 }
 
@@ -59,6 +181,11 @@ func (c *ServiceConfig) ServerPort() (result int) {
 	return c.GetIntParamValueOrDefault(CfgExposeHttpPort, 0)
 }
 
+// GrpcPort returns the port of the gRPC API (0 when the gRPC server is disabled)
+func (c *ServiceConfig) GrpcPort() (result int) {
+	return c.GetIntParamValueOrDefault(CfgExposeGrpcPort, 0)
+}
+
 // RunAsJob returns json job scheduler flag
 func (c *ServiceConfig) RunAsJob() bool {
 	return c.GetBoolParamValueOrDefault(CfgRunAsJob, false)
@@ -79,6 +206,11 @@ func (c *ServiceConfig) DataCacheUri() string {
 	return c.GetStringParamValueOrDefault(CfgDataCacheUri, "")
 }
 
+// MessageBusUri returns the message bus middleware URI
+func (c *ServiceConfig) MessageBusUri() string {
+	return c.GetStringParamValueOrDefault(CfgMessageBusUri, "")
+}
+
 // FileStorageUri returns the file storage location URI
 func (c *ServiceConfig) FileStorageUri() string {
 	return c.GetStringParamValueOrDefault(CfgFileStorageUri, "")
@@ -108,3 +240,282 @@ func (c *ServiceConfig) MailRelayPwd() string {
 func (c *ServiceConfig) MailRelayTls() bool {
 	return c.GetBoolParamValueOrDefault(CfgMailRelayTls, true)
 }
+
+// ApiV1DeprecationDate returns the date when API v1 is deprecated (zero time for active version)
+func (c *ServiceConfig) ApiV1DeprecationDate() time.Time {
+	return c.getDate(CfgApiV1Deprecate)
+}
+
+// ApiV1SunsetDate returns the date when API v1 is going to be removed (zero time if not scheduled)
+func (c *ServiceConfig) ApiV1SunsetDate() time.Time {
+	return c.getDate(CfgApiV1Sunset)
+}
+
+// AccessLogSkipPaths returns the list of routes excluded from the access log
+func (c *ServiceConfig) AccessLogSkipPaths() (result []string) {
+	for _, path := range strings.Split(c.GetStringParamValueOrDefault(CfgAccessLogSkip, "/,/metrics,/health/live,/health/ready"), ",") {
+		if path = strings.TrimSpace(path); len(path) > 0 {
+			result = append(result, path)
+		}
+	}
+	return
+}
+
+// AccessLogSlowThreshold returns the slow request threshold of the access log (zero to disable)
+func (c *ServiceConfig) AccessLogSlowThreshold() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgAccessLogSlow, 1000)) * time.Millisecond
+}
+
+// MetricsToken returns the bearer token required by the metrics endpoint (the endpoint is disabled when empty)
+func (c *ServiceConfig) MetricsToken() string {
+	return c.GetStringParamValueOrDefault(CfgMetricsToken, "")
+}
+
+// TracingExporter returns the tracing exporter: otlp | stdout | none
+func (c *ServiceConfig) TracingExporter() string {
+	return c.GetStringParamValueOrDefault(CfgTracing, "none")
+}
+
+// HealthCheckTimeout returns the timeout of each dependency check of the readiness probe
+func (c *ServiceConfig) HealthCheckTimeout() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgHealthTimeout, 2000)) * time.Millisecond
+}
+
+// ShutdownTimeout returns the deadline to complete the in-flight requests on shutdown
+func (c *ServiceConfig) ShutdownTimeout() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgShutdownWait, 30000)) * time.Millisecond
+}
+
+// ShutdownDelay returns the delay between reporting not ready (readiness probe) and closing the listener on shutdown
+func (c *ServiceConfig) ShutdownDelay() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgShutdownDelay, 0)) * time.Millisecond
+}
+
+// TlsCertFile returns the TLS certificate file (empty when TLS is disabled)
+func (c *ServiceConfig) TlsCertFile() string {
+	return c.GetStringParamValueOrDefault(CfgTlsCertFile, "")
+}
+
+// TlsKeyFile returns the TLS private key file (empty when TLS is disabled)
+func (c *ServiceConfig) TlsKeyFile() string {
+	return c.GetStringParamValueOrDefault(CfgTlsKeyFile, "")
+}
+
+// TlsClientCaFile returns the client CA certificates file (empty when mutual TLS is disabled)
+func (c *ServiceConfig) TlsClientCaFile() string {
+	return c.GetStringParamValueOrDefault(CfgTlsClientCaFile, "")
+}
+
+// Http2Enabled returns true if HTTP/2 over TLS is enabled
+func (c *ServiceConfig) Http2Enabled() bool {
+	return c.GetBoolParamValueOrDefault(CfgHttp2Enabled, true)
+}
+
+// H2cEnabled returns true if HTTP/2 over cleartext is enabled (when TLS is disabled)
+func (c *ServiceConfig) H2cEnabled() bool {
+	return c.GetBoolParamValueOrDefault(CfgH2cEnabled, false)
+}
+
+// HttpReadTimeout returns the timeout to read the entire request (zero for no timeout)
+func (c *ServiceConfig) HttpReadTimeout() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgHttpReadTimeout, 30000)) * time.Millisecond
+}
+
+// HttpReadHeaderTimeout returns the timeout to read the request headers (zero for no timeout)
+func (c *ServiceConfig) HttpReadHeaderTimeout() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgHttpHeaderTimeout, 10000)) * time.Millisecond
+}
+
+// HttpWriteTimeout returns the timeout to write the response (zero for no timeout)
+func (c *ServiceConfig) HttpWriteTimeout() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgHttpWriteTimeout, 60000)) * time.Millisecond
+}
+
+// HttpIdleTimeout returns the timeout of idle keep-alive connection (zero for no timeout)
+func (c *ServiceConfig) HttpIdleTimeout() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgHttpIdleTimeout, 120000)) * time.Millisecond
+}
+
+// HttpMaxHeaderBytes returns the maximum size of the request headers
+func (c *ServiceConfig) HttpMaxHeaderBytes() int {
+	return c.GetIntParamValueOrDefault(CfgHttpMaxHeaderSize, 1<<20)
+}
+
+// CompressionEnabled returns true if response compression is enabled
+func (c *ServiceConfig) CompressionEnabled() bool {
+	return c.GetBoolParamValueOrDefault(CfgCompression, true)
+}
+
+// CompressionMinSize returns the minimal response size in bytes to compress
+func (c *ServiceConfig) CompressionMinSize() int {
+	return c.GetIntParamValueOrDefault(CfgCompressionMin, 1024)
+}
+
+// RequestMaxBodySize returns the default maximum request body size in bytes (negative for no limit)
+func (c *ServiceConfig) RequestMaxBodySize() int64 {
+	if size := c.GetInt64ParamValueOrDefault(CfgRequestMaxBody, 1<<20); size > 0 {
+		return size
+	}
+	return -1
+}
+
+// RequestTimeout returns the default handler deadline (negative for no deadline)
+func (c *ServiceConfig) RequestTimeout() time.Duration {
+	if timeout := c.GetIntParamValueOrDefault(CfgRequestTimeout, 30000); timeout > 0 {
+		return time.Duration(timeout) * time.Millisecond
+	}
+	return -1
+}
+
+// IdempotencyTTL returns the retention of the responses stored by Idempotency-Key
+func (c *ServiceConfig) IdempotencyTTL() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgIdempotencyTTL, 86400000)) * time.Millisecond
+}
+
+// GraphQLMaxDepth returns the maximal nesting depth of the GraphQL query selections (0 for no limit)
+func (c *ServiceConfig) GraphQLMaxDepth() int {
+	return c.GetIntParamValueOrDefault(CfgGraphQLMaxDepth, 8)
+}
+
+// RateLimitEnabled returns true if rate limiting of the API routes is enabled
+func (c *ServiceConfig) RateLimitEnabled() bool {
+	return c.GetBoolParamValueOrDefault(CfgRateLimit, true)
+}
+
+// RateLimitPeriod returns the rate limit period
+func (c *ServiceConfig) RateLimitPeriod() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgRateLimitPeriod, 60000)) * time.Millisecond
+}
+
+// RateLimitIp returns the requests per period by remote IP (zero for no limit)
+func (c *ServiceConfig) RateLimitIp() int {
+	return c.GetIntParamValueOrDefault(CfgRateLimitIp, 1200)
+}
+
+// RateLimitApiKey returns the requests per period by API key (zero for no limit)
+func (c *ServiceConfig) RateLimitApiKey() int {
+	return c.GetIntParamValueOrDefault(CfgRateLimitApiKey, 6000)
+}
+
+// RateLimitSubject returns the requests per period by token subject (zero for no limit)
+func (c *ServiceConfig) RateLimitSubject() int {
+	return c.GetIntParamValueOrDefault(CfgRateLimitSubject, 600)
+}
+
+// RateLimitGroups returns the requests per period by route group path (e.g. /contacts) and subject
+func (c *ServiceConfig) RateLimitGroups() map[string]int {
+	return c.getIntMap(CfgRateLimitGroups)
+}
+
+// RateLimitAccountTypes returns the requests per period by subject of account type (overrides the subject limit)
+func (c *ServiceConfig) RateLimitAccountTypes() map[AccountTypeCode]int {
+	result := make(map[AccountTypeCode]int)
+	for name, limit := range c.getIntMap(CfgRateLimitAccountTypes) {
+		for code := AccountTypeCodes.UNDEFINED; AccountTypeCodes.IsValid(code); code++ {
+			if strings.EqualFold(AccountTypeCodes.String(code), name) {
+				result[code] = limit
+			}
+		}
+	}
+	return result
+}
+
+// EventsBufferSize returns the number of recent entity change events kept to resume the streams after reconnect
+func (c *ServiceConfig) EventsBufferSize() int {
+	return c.GetIntParamValueOrDefault(CfgEventsBuffer, 1000)
+}
+
+// EventsHeartbeat returns the interval of the events stream heartbeat
+func (c *ServiceConfig) EventsHeartbeat() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgEventsHeartbeat, 30000)) * time.Millisecond
+}
+
+// WebhookMaxAttempts returns the number of delivery attempts before the delivery is dead-lettered
+func (c *ServiceConfig) WebhookMaxAttempts() int {
+	return c.GetIntParamValueOrDefault(CfgWebhookAttempts, 8)
+}
+
+// WebhookBackoff returns the delay before the first retry (doubled on each retry)
+func (c *ServiceConfig) WebhookBackoff() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgWebhookBackoff, 10000)) * time.Millisecond
+}
+
+// WebhookBackoffMax returns the maximal delay between retries
+func (c *ServiceConfig) WebhookBackoffMax() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgWebhookBackoffMax, 3600000)) * time.Millisecond
+}
+
+// WebhookTimeout returns the timeout of the delivery request
+func (c *ServiceConfig) WebhookTimeout() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgWebhookTimeout, 10000)) * time.Millisecond
+}
+
+// WebhookPollInterval returns the interval of checking the due deliveries
+func (c *ServiceConfig) WebhookPollInterval() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgWebhookPoll, 1000)) * time.Millisecond
+}
+
+// WebhookAllowPrivate returns true if webhook receivers on loopback, link-local and private addresses are allowed (e.g.
+// local receiver in development)
+func (c *ServiceConfig) WebhookAllowPrivate() bool {
+	return c.GetBoolParamValueOrDefault(CfgWebhookPrivate, false)
+}
+
+// OutboxMaxAttempts returns the number of relay attempts before the outbox entry fails
+func (c *ServiceConfig) OutboxMaxAttempts() int {
+	return c.GetIntParamValueOrDefault(CfgOutboxAttempts, 10)
+}
+
+// OutboxBackoff returns the delay before the first relay retry (doubled on each retry)
+func (c *ServiceConfig) OutboxBackoff() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgOutboxBackoff, 1000)) * time.Millisecond
+}
+
+// OutboxBackoffMax returns the maximal delay between relay retries
+func (c *ServiceConfig) OutboxBackoffMax() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgOutboxBackoffMax, 300000)) * time.Millisecond
+}
+
+// OutboxPollInterval returns the interval of checking the due outbox entries
+func (c *ServiceConfig) OutboxPollInterval() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgOutboxPoll, 1000)) * time.Millisecond
+}
+
+// OutboxStuckAfter returns the time after which a waiting outbox entry is reported as stuck
+func (c *ServiceConfig) OutboxStuckAfter() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgOutboxStuckAfter, 60000)) * time.Millisecond
+}
+
+// OutboxRetention returns the time to keep the sent outbox entries (0 to keep them)
+func (c *ServiceConfig) OutboxRetention() time.Duration {
+	return time.Duration(c.GetIntParamValueOrDefault(CfgOutboxRetention, 604800000)) * time.Millisecond
+}
+
+// OutboxRelayEnabled returns true if the instance runs the outbox relay and the webhooks dispatcher (the entries are not
+// claimed, so it should be enabled on a single instance)
+func (c *ServiceConfig) OutboxRelayEnabled() bool {
+	return c.GetBoolParamValueOrDefault(CfgOutboxRelay, true)
+}
+
+// Get comma separated name=value configuration value (e.g: DEMO=60,TRIAL=300), invalid entries are ignored
+func (c *ServiceConfig) getIntMap(key string) map[string]int {
+	result := make(map[string]int)
+	for _, entry := range strings.Split(c.GetStringParamValueOrDefault(key, ""), ",") {
+		name, value, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			result[strings.TrimSpace(name)] = n
+		}
+	}
+	return result
+}
+
+// Get date (yyyy-mm-dd) configuration value, returns zero time for empty or invalid value
+func (c *ServiceConfig) getDate(key string) time.Time {
+	if date, err := time.Parse(time.DateOnly, c.GetStringParamValueOrDefault(key, "")); err == nil {
+		return date
+	}
+	return time.Time{}
+}
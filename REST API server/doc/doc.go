@@ -0,0 +1,10 @@
+// Package doc includes the API documentation: the OpenAPI document generated from the endpoints annotations (openapi.json)
+// and its viewer (index.html). The files are embedded in the server binary and served by the /doc endpoint
+package doc
+
+import "embed"
+
+//go:generate go run ../cmd/openapi -root ..
+
+//go:embed index.html openapi.json
+var Files embed.FS
@@ -0,0 +1,224 @@
+// Package filter implements the filter query language of the Find endpoints
+//
+// The filter expression supports comparisons (= != > >= < <=), in, like, between, logical operators (and / or / not),
+// parentheses and nested fields (e.g. address.city = 'London' and (status in (ACTIVE, BLOCKED) or not name like 'test*')).
+// The expression is validated against the entity fields and translated to the database query builder filters
+package filter
+
+import (
+	"strings"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// Maximum number of clauses after normalization, protects the database from exponential expressions
+const maxClauses = 32
+
+// Criteria is a parsed and validated filter expression in conjunctive normal form (AND of ORs)
+type Criteria struct {
+	clauses [][]condition
+}
+
+// Parse the filter expression and validate it against the entity fields
+// Returns nil criteria for empty expression
+func Parse(ef EntityFactory, expression string) (*Criteria, error) {
+
+	if len(strings.TrimSpace(expression)) == 0 {
+		return nil, nil
+	}
+
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{ef: ef, tokens: tokens}
+	tree, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, newError(t.pos, "unexpected %s", t)
+	}
+
+	clauses, err := normalize(tree, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Criteria{clauses: clauses}, nil
+}
+
+// Apply adds the criteria filters to the query: single conditions are added using MatchAll and each disjunction using its
+// own MatchAny group (the query should support multiple MatchAny groups). Applying nil criteria returns the query as is
+func (c *Criteria) Apply(query IQuery) IQuery {
+	if c == nil {
+		return query
+	}
+
+	all := make([]QueryFilter, 0)
+	for _, clause := range c.clauses {
+		if len(clause) == 1 {
+			all = append(all, clause[0].filter())
+			continue
+		}
+		anyOf := make([]QueryFilter, 0, len(clause))
+		for _, cond := range clause {
+			anyOf = append(anyOf, cond.filter())
+		}
+		query = query.MatchAny(anyOf...)
+	}
+
+	if len(all) > 0 {
+		query = query.MatchAll(all...)
+	}
+	return query
+}
+
+// Convert the syntax tree to conjunctive normal form (list of clauses, each clause is a list of conditions)
+// Negations are pushed down to the conditions using De Morgan's laws
+func normalize(n node, negate bool) ([][]condition, error) {
+
+	switch v := n.(type) {
+	case condition:
+		if negate {
+			return normalize(v.negate(), false)
+		}
+		return [][]condition{{v}}, nil
+
+	case notNode:
+		return normalize(v.child, !negate)
+
+	case andNode:
+		if negate {
+			return disjunction([]node(v), true)
+		}
+		return conjunction([]node(v), false)
+
+	case orNode:
+		if negate {
+			return conjunction([]node(v), true)
+		}
+		return disjunction([]node(v), false)
+	}
+	return nil, newError(0, "unsupported expression")
+}
+
+// All the children should be satisfied: concatenate the clauses of the children
+func conjunction(children []node, negate bool) ([][]condition, error) {
+	result := make([][]condition, 0)
+	for _, child := range children {
+		clauses, err := normalize(child, negate)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, clauses...)
+		if len(result) > maxClauses {
+			return nil, newError(0, "filter expression is too complex")
+		}
+	}
+	return result, nil
+}
+
+// Any of the children should be satisfied: distribute the clauses of the children (cartesian product)
+func disjunction(children []node, negate bool) ([][]condition, error) {
+	result := [][]condition{{}}
+	for _, child := range children {
+		clauses, err := normalize(child, negate)
+		if err != nil {
+			return nil, err
+		}
+
+		product := make([][]condition, 0, len(result)*len(clauses))
+		for _, left := range result {
+			for _, right := range clauses {
+				clause := make([]condition, 0, len(left)+len(right))
+				clause = append(clause, left...)
+				clause = append(clause, right...)
+				product = append(product, clause)
+			}
+		}
+		if len(product) > maxClauses {
+			return nil, newError(0, "filter expression is too complex")
+		}
+		result = product
+	}
+	return result, nil
+}
+
+// Negate the condition, between is negated to a disjunction of two conditions
+func (c condition) negate() node {
+	switch c.operator {
+	case Eq:
+		return condition{field: c.field, operator: Neq, values: c.values}
+	case Neq:
+		return condition{field: c.field, operator: Eq, values: c.values}
+	case Gt:
+		return condition{field: c.field, operator: Lte, values: c.values}
+	case Gte:
+		return condition{field: c.field, operator: Lt, values: c.values}
+	case Lt:
+		return condition{field: c.field, operator: Gte, values: c.values}
+	case Lte:
+		return condition{field: c.field, operator: Gt, values: c.values}
+	case In:
+		return condition{field: c.field, operator: NotIn, values: c.values}
+	case NotIn:
+		return condition{field: c.field, operator: In, values: c.values}
+	case Like:
+		return condition{field: c.field, operator: NotLike, values: c.values}
+	case NotLike:
+		return condition{field: c.field, operator: Like, values: c.values}
+	case Contains:
+		return condition{field: c.field, operator: NotContains, values: c.values}
+	case NotContains:
+		return condition{field: c.field, operator: Contains, values: c.values}
+	case WithFlag:
+		return condition{field: c.field, operator: WithNoFlag, values: c.values}
+	case WithNoFlag:
+		return condition{field: c.field, operator: WithFlag, values: c.values}
+	case Between:
+		return orNode{
+			condition{field: c.field, operator: Lt, values: c.values[:1]},
+			condition{field: c.field, operator: Gt, values: c.values[1:]},
+		}
+	}
+	return c
+}
+
+// Create the query builder filter of the condition
+func (c condition) filter() QueryFilter {
+	f := F(c.field)
+	switch c.operator {
+	case Neq:
+		return f.Neq(c.values[0])
+	case Gt:
+		return f.Gt(c.values[0])
+	case Gte:
+		return f.Gte(c.values[0])
+	case Lt:
+		return f.Lt(c.values[0])
+	case Lte:
+		return f.Lte(c.values[0])
+	case In:
+		return f.In(c.values...)
+	case NotIn:
+		return f.NotIn(c.values...)
+	case Like:
+		return f.Like(c.values[0].(string))
+	case NotLike:
+		return f.NotLike(c.values[0].(string))
+	case Between:
+		return f.Between(c.values[0], c.values[1])
+	case Contains:
+		return f.Contains(c.values[0])
+	case NotContains:
+		return f.NotContains(c.values[0])
+	case WithFlag:
+		return f.WithFlag(c.values[0].(int))
+	case WithNoFlag:
+		return f.WithNoFlag(c.values[0].(int))
+	default:
+		return f.Eq(c.values[0])
+	}
+}
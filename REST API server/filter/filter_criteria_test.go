@@ -0,0 +1,158 @@
+package filter
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// Create in-memory database with the test contacts
+func newContactsDatabase(t *testing.T) IDatabase {
+	db := common.NewDatabase()
+	contacts := []struct{ id, account, name, email, mobile string }{
+		{"1", "a1", "alice", "alice@example.com", "111"},
+		{"2", "a1", "bob", "bob@example.com", "222"},
+		{"3", "a2", "carol", "carol@test.com", "111"},
+		{"4", "a2", "dave", "dave@example.com", "444"},
+		{"5", "a2", "carl", "carl@example.com", "555"},
+	}
+	for _, c := range contacts {
+		ent := NewContact().(*Contact)
+		ent.Id, ent.AccountId, ent.Name, ent.Email, ent.Mobile = c.id, c.account, c.name, c.email, c.mobile
+		if _, err := db.Insert(ent); err != nil {
+			t.Fatalf("insert contact %s: %v", c.id, err)
+		}
+	}
+	return db
+}
+
+// Get the sorted IDs of the entities
+func entityIds(list []Entity) string {
+	ids := make([]string, 0, len(list))
+	for _, ent := range list {
+		ids = append(ids, ent.ID())
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+func TestApplyMultipleDisjunctions(t *testing.T) {
+	db := newContactsDatabase(t)
+
+	tests := []struct {
+		name       string
+		expression string
+		search     string
+		expected   string
+	}{
+		{"single condition", "accountId = 'a2'", "", "3,4,5"},
+		{"single disjunction", "accountId = 'a1' or mobile = '111'", "", "1,2,3"},
+		{"two disjunctions", "(accountId = 'a1' or mobile = '111') and (name like 'a*' or name like 'c*')", "", "1,3"},
+		{"two disjunctions and search", "(accountId = 'a1' or mobile = '111') and (name like 'a*' or name like 'c*')", "*example.com", "1"},
+		{"disjunction and condition", "(name like 'c*' or name = 'bob') and accountId = 'a2'", "", "3,5"},
+		{"no match", "(accountId = 'a1' or mobile = '111') and (name = 'dave' or name = 'carl')", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criteria, err := Parse(NewContact, tt.expression)
+			if err != nil {
+				t.Fatalf("parse %q: %v", tt.expression, err)
+			}
+
+			// The search term is added as MatchAny group (as in the services Find)
+			query := criteria.Apply(db.Query(NewContact)).
+				MatchAny(
+					F("id").Eq(tt.search),
+					F("email").Like(tt.search),
+				).
+				MatchAll(F("flag").Gte(0))
+
+			list, total, err := query.Find()
+			if err != nil {
+				t.Fatalf("find: %v", err)
+			}
+			if got := entityIds(list); got != tt.expected {
+				t.Errorf("expected contacts [%s] but got [%s]", tt.expected, got)
+			}
+			if int(total) != len(list) {
+				t.Errorf("expected total %d but got %d", len(list), total)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"unknown = 1",
+		"name = ",
+		"(name = a",
+		"name like 1",
+		"createdOn > 'not a date'",
+	}
+	for _, expression := range tests {
+		if _, err := Parse(NewContact, expression); err == nil {
+			t.Errorf("expected error for %q", expression)
+		}
+	}
+}
+
+func TestFlagsField(t *testing.T) {
+	db := common.NewDatabase()
+	users := []struct {
+		id    string
+		roles int
+	}{
+		{"u1", UserRoleFlags.SALES},
+		{"u2", UserRoleFlags.SALES | UserRoleFlags.MANAGER},
+		{"u3", UserRoleFlags.OPERATIONS},
+	}
+	for _, u := range users {
+		ent := NewUser().(*User)
+		ent.Id, ent.Email, ent.Roles = u.id, u.id+"@example.com", u.roles
+		if _, err := db.Insert(ent); err != nil {
+			t.Fatalf("insert user %s: %v", u.id, err)
+		}
+	}
+
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"roles = SALES", "u1,u2"},
+		{"roles = MANAGER", "u2"},
+		{"roles = 1024", "u2"},
+		{"roles != SALES", "u3"},
+		{"not roles = MANAGER", "u1,u3"},
+		{"roles in (MANAGER, OPERATIONS)", "u2,u3"},
+		{"roles not in (MANAGER, OPERATIONS)", "u1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			criteria, err := Parse(NewUser, tt.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			list, _, err := criteria.Apply(db.Query(NewUser)).Find()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := entityIds(list); got != tt.expected {
+				t.Errorf("expected [%s] but got [%s]", tt.expected, got)
+			}
+		})
+	}
+
+	for _, expression := range []string{"roles = ALL", "roles = UNDEFINED", "roles > SALES", "roles between 1 and 8", "roles = 3"} {
+		if _, err := Parse(NewUser, expression); err == nil {
+			t.Errorf("expected error for %q", expression)
+		}
+	}
+}
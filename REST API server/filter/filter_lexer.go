@@ -0,0 +1,134 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF      tokenKind = iota // End of input
+	tokenIdent                     // Field name, keyword or enum name
+	tokenString                    // Quoted string literal
+	tokenNumber                    // Numeric literal
+	tokenOperator                  // Comparison operator: = != <> > >= < <=
+	tokenLParen                    // (
+	tokenRParen                    // )
+	tokenComma                     // ,
+)
+
+// token is a single lexical unit of the filter expression
+type token struct {
+	kind tokenKind // Token kind
+	text string    // Token text (unquoted for string literals)
+	pos  int       // Token position in the expression (1-based)
+}
+
+// Test if the token is the provided keyword (case-insensitive)
+func (t token) is(keyword string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+// Describe the token for error messages
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of filter"
+	}
+	return fmt.Sprintf("'%s'", t.text)
+}
+
+// tokenize splits the filter expression to tokens
+func tokenize(input string) ([]token, error) {
+
+	runes := []rune(input)
+	tokens := make([]token, 0)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			i++
+
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			i++
+
+		case r == '\'' || r == '"':
+			str, next, err := scanString(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: str, pos: pos})
+			i = next
+
+		case strings.ContainsRune("=!<>", r):
+			op := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')) {
+				op += string(runes[i+1])
+			}
+			if op == "!" {
+				return nil, newError(pos, "unexpected character '!'")
+			}
+			i += len(op)
+			switch op {
+			case "<>":
+				op = "!="
+			case "==":
+				op = "="
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), pos: pos})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: pos})
+
+		default:
+			return nil, newError(pos, "unexpected character '%c'", r)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(runes) + 1})
+	return tokens, nil
+}
+
+// Scan quoted string literal, the quote character is escaped by doubling it (e.g. 'O”Brien')
+func scanString(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	sb := strings.Builder{}
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] != quote {
+			sb.WriteRune(runes[i])
+			continue
+		}
+		if i+1 < len(runes) && runes[i+1] == quote {
+			sb.WriteRune(quote)
+			i++
+			continue
+		}
+		return sb.String(), i + 1, nil
+	}
+	return "", 0, newError(start+1, "unterminated string")
+}
@@ -0,0 +1,474 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	meta "github.com/go-yaaf/yaaf-examples/rest-api/model"
+)
+
+// region Syntax tree --------------------------------------------------------------------------------------------------
+
+// node is an expression in the filter syntax tree
+type node interface{}
+
+// andNode is satisfied when all the child expressions are satisfied
+type andNode []node
+
+// orNode is satisfied when any of the child expressions is satisfied
+type orNode []node
+
+// notNode negates the child expression
+type notNode struct {
+	child node
+}
+
+// condition is a single field criteria (leaf of the syntax tree)
+type condition struct {
+	field    string        // Field json path
+	operator QueryOperator // Criteria operator
+	values   []any         // Criteria values
+}
+
+// SyntaxError is a filter syntax or validation error
+type SyntaxError struct {
+	Pos     int    // Position of the error in the filter expression (1-based)
+	Message string // Error description
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Pos > 0 {
+		return fmt.Sprintf("invalid filter at position %d: %s", e.Pos, e.Message)
+	}
+	return fmt.Sprintf("invalid filter: %s", e.Message)
+}
+
+func newError(pos int, format string, args ...any) error {
+	return &SyntaxError{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// endregion
+
+// region Parser -------------------------------------------------------------------------------------------------------
+
+var keywords = []string{"and", "or", "not", "in", "like", "between"}
+
+var comparisonOperators = map[string]QueryOperator{
+	"=":  Eq,
+	"!=": Neq,
+	">":  Gt,
+	">=": Gte,
+	"<":  Lt,
+	"<=": Lte,
+}
+
+var timestampType = reflect.TypeOf(Timestamp(0))
+
+// parser is a recursive descent parser of the filter grammar:
+//
+//	expression := term ( OR term )*
+//	term       := factor ( AND factor )*
+//	factor     := NOT factor | '(' expression ')' | condition
+//	condition  := field operator value
+//	            | field [NOT] IN '(' value ( ',' value )* ')'
+//	            | field [NOT] LIKE string
+//	            | field [NOT] BETWEEN value AND value
+type parser struct {
+	ef     EntityFactory // Entity factory to validate the fields against
+	tokens []token       // Expression tokens
+	pos    int           // Current token index
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	if t := p.next(); t.kind != kind {
+		return newError(t.pos, "expected '%s' but found %s", text, t)
+	}
+	return nil
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if t := p.next(); !t.is(keyword) {
+		return newError(t.pos, "expected '%s' but found %s", keyword, t)
+	}
+	return nil
+}
+
+// Parse expression: term ( OR term )*
+func (p *parser) parseExpression() (node, error) {
+	first, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	children := orNode{first}
+	for p.peek().is("or") {
+		p.next()
+		child, er := p.parseTerm()
+		if er != nil {
+			return nil, er
+		}
+		children = append(children, child)
+	}
+
+	if len(children) == 1 {
+		return first, nil
+	}
+	return children, nil
+}
+
+// Parse term: factor ( AND factor )*
+func (p *parser) parseTerm() (node, error) {
+	first, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+
+	children := andNode{first}
+	for p.peek().is("and") {
+		p.next()
+		child, er := p.parseFactor()
+		if er != nil {
+			return nil, er
+		}
+		children = append(children, child)
+	}
+
+	if len(children) == 1 {
+		return first, nil
+	}
+	return children, nil
+}
+
+// Parse factor: NOT factor | '(' expression ')' | condition
+func (p *parser) parseFactor() (node, error) {
+	t := p.peek()
+
+	if t.is("not") {
+		p.next()
+		child, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{child: child}, nil
+	}
+
+	if t.kind == tokenLParen {
+		p.next()
+		expr, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+
+	return p.parseCondition()
+}
+
+// Parse condition on a single field
+func (p *parser) parseCondition() (node, error) {
+
+	ft := p.next()
+	if ft.kind != tokenIdent || isKeyword(ft.text) {
+		return nil, newError(ft.pos, "expected field name but found %s", ft)
+	}
+
+	field := ft.text
+	typ, err := meta.GetFieldType(p.ef, field)
+	if err != nil {
+		return nil, newError(ft.pos, "unknown field '%s'", field)
+	}
+	if kind := typ.Kind(); kind == reflect.Struct || kind == reflect.Map {
+		return nil, newError(ft.pos, "field '%s' can't be compared, use one of its nested fields", field)
+	}
+
+	negate := false
+	if p.peek().is("not") {
+		p.next()
+		negate = true
+	}
+
+	var result node
+	ot := p.next()
+	switch {
+	case meta.IsFlagField(p.ef, field):
+		result, err = p.parseFlags(field, typ, ot, negate)
+	case ot.kind == tokenOperator && !negate:
+		result, err = p.parseComparison(field, typ, ot)
+	case ot.is("in"):
+		result, err = p.parseIn(field, typ)
+	case ot.is("like"):
+		result, err = p.parseLike(field, typ)
+	case ot.is("between"):
+		result, err = p.parseBetween(field, typ)
+	case negate:
+		return nil, newError(ot.pos, "expected 'in', 'like' or 'between' after 'not' but found %s", ot)
+	default:
+		return nil, newError(ot.pos, "expected operator after field '%s' but found %s", field, ot)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if negate {
+		return notNode{child: result}, nil
+	}
+	return result, nil
+}
+
+// Parse comparison: field operator value
+func (p *parser) parseComparison(field string, typ reflect.Type, ot token) (node, error) {
+	value, err := p.parseValue(field, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	operator := comparisonOperators[ot.text]
+	if typ.Kind() == reflect.Slice {
+		switch operator {
+		case Eq:
+			operator = Contains
+		case Neq:
+			operator = NotContains
+		default:
+			return nil, newError(ot.pos, "operator '%s' is not supported for array field '%s'", ot.text, field)
+		}
+	}
+	return condition{field: field, operator: operator, values: []any{value}}, nil
+}
+
+// Parse condition on bit flags field: = (includes the flag), != (does not include the flag) and in (includes any of the
+// flags), the values are single flags (the drivers differ on combined flags)
+func (p *parser) parseFlags(field string, typ reflect.Type, ot token, negate bool) (node, error) {
+	switch {
+	case ot.kind == tokenOperator && !negate && (ot.text == "=" || ot.text == "!="):
+		value, err := p.parseFlag(field, typ)
+		if err != nil {
+			return nil, err
+		}
+		if ot.text == "!=" {
+			return condition{field: field, operator: WithNoFlag, values: []any{value}}, nil
+		}
+		return condition{field: field, operator: WithFlag, values: []any{value}}, nil
+	case ot.is("in"):
+		if err := p.expect(tokenLParen, "("); err != nil {
+			return nil, err
+		}
+		result := orNode{}
+		for {
+			value, err := p.parseFlag(field, typ)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, condition{field: field, operator: WithFlag, values: []any{value}})
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	return nil, newError(ot.pos, "operator %s is not supported for flags field '%s', use =, != or in", ot, field)
+}
+
+// Parse single flag value
+func (p *parser) parseFlag(field string, typ reflect.Type) (int, error) {
+	pos := p.peek().pos
+	value, err := p.parseValue(field, typ)
+	if err != nil {
+		return 0, err
+	}
+	flag := 0
+	switch v := value.(type) {
+	case int:
+		flag = v
+	case int64:
+		flag = int(v)
+	}
+	if flag <= 0 || flag&(flag-1) != 0 {
+		return 0, newError(pos, "value of flags field '%s' should be a single flag", field)
+	}
+	return flag, nil
+}
+
+// Parse in: field IN '(' value ( ',' value )* ')'
+func (p *parser) parseIn(field string, typ reflect.Type) (node, error) {
+	if err := p.expect(tokenLParen, "("); err != nil {
+		return nil, err
+	}
+
+	values := make([]any, 0)
+	for {
+		value, err := p.parseValue(field, typ)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		if p.peek().kind != tokenComma {
+			break
+		}
+		p.next()
+	}
+
+	if err := p.expect(tokenRParen, ")"); err != nil {
+		return nil, err
+	}
+
+	// Array field contains any of the values
+	if typ.Kind() == reflect.Slice {
+		result := orNode{}
+		for _, value := range values {
+			result = append(result, condition{field: field, operator: Contains, values: []any{value}})
+		}
+		return result, nil
+	}
+	return condition{field: field, operator: In, values: values}, nil
+}
+
+// Parse like: field LIKE string
+func (p *parser) parseLike(field string, typ reflect.Type) (node, error) {
+	vt := p.next()
+	if vt.kind != tokenString {
+		return nil, newError(vt.pos, "expected string pattern for field '%s' but found %s", field, vt)
+	}
+	if typ.Kind() != reflect.String && typ.Kind() != reflect.Interface {
+		return nil, newError(vt.pos, "operator 'like' is not supported for field '%s'", field)
+	}
+	if len(vt.text) == 0 {
+		return nil, newError(vt.pos, "empty pattern for field '%s'", field)
+	}
+	return condition{field: field, operator: Like, values: []any{vt.text}}, nil
+}
+
+// Parse between: field BETWEEN value AND value
+func (p *parser) parseBetween(field string, typ reflect.Type) (node, error) {
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Bool {
+		return nil, newError(p.peek().pos, "operator 'between' is not supported for field '%s'", field)
+	}
+	from, err := p.parseValue(field, typ)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectKeyword("and"); err != nil {
+		return nil, err
+	}
+	to, err := p.parseValue(field, typ)
+	if err != nil {
+		return nil, err
+	}
+	return condition{field: field, operator: Between, values: []any{from, to}}, nil
+}
+
+// Parse literal value and convert it to the field type
+func (p *parser) parseValue(field string, typ reflect.Type) (any, error) {
+	vt := p.next()
+	if vt.kind != tokenString && vt.kind != tokenNumber && vt.kind != tokenIdent {
+		return nil, newError(vt.pos, "expected value for field '%s' but found %s", field, vt)
+	}
+	if vt.kind == tokenString && len(vt.text) == 0 {
+		return nil, newError(vt.pos, "empty value for field '%s'", field)
+	}
+	if value, ok := p.convert(field, typ, vt); ok {
+		return value, nil
+	}
+	return nil, newError(vt.pos, "invalid value %s for field '%s'", vt, field)
+}
+
+// Convert literal value to the field type, enum fields accept the enum names and timestamp fields accept ISO dates
+func (p *parser) convert(field string, typ reflect.Type, vt token) (any, bool) {
+
+	if enum := meta.GetFieldEnum(p.ef, field); enum != nil && vt.kind != tokenNumber {
+		return meta.EnumValueOf(enum, vt.text)
+	}
+
+	if typ == timestampType && vt.kind == tokenString {
+		return parseTimestamp(vt.text)
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return vt.text, vt.kind == tokenString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if vt.kind == tokenNumber {
+			n, err := strconv.ParseInt(vt.text, 10, 64)
+			return n, err == nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if vt.kind == tokenNumber {
+			n, err := strconv.ParseFloat(vt.text, 64)
+			return n, err == nil
+		}
+	case reflect.Bool:
+		if vt.is("true") || vt.is("false") {
+			return vt.is("true"), true
+		}
+	case reflect.Slice:
+		return p.convert(field, typ.Elem(), vt)
+	case reflect.Interface:
+		return naturalValue(vt)
+	}
+	return nil, false
+}
+
+// Get the natural value of a literal for dynamic fields (custom properties)
+func naturalValue(vt token) (any, bool) {
+	switch {
+	case vt.kind == tokenString:
+		return vt.text, true
+	case vt.kind == tokenNumber:
+		if n, err := strconv.ParseInt(vt.text, 10, 64); err == nil {
+			return n, true
+		}
+		n, err := strconv.ParseFloat(vt.text, 64)
+		return n, err == nil
+	case vt.is("true") || vt.is("false"):
+		return vt.is("true"), true
+	}
+	return nil, false
+}
+
+// Parse ISO date / datetime to timestamp
+func parseTimestamp(value string) (any, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return NewTimestamp(t), true
+		}
+	}
+	return nil, false
+}
+
+// Check if the identifier is a reserved keyword
+func isKeyword(text string) bool {
+	for _, kw := range keywords {
+		if strings.EqualFold(kw, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// endregion
@@ -0,0 +1,177 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/go-yaaf/yaaf-common/rest"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rest"
+)
+
+// region Endpoint structure and factory method ------------------------------------------------------------------------
+
+// GraphQLEndPoint is the GraphQL endpoint of the entity model (single POST /graphql route)
+type GraphQLEndPoint struct {
+	rest.BaseEndPoint
+	schema   graphql.Schema
+	lists    map[string]listFunc // Related entities loaders by entity type (new batch loaders per request)
+	maxDepth int                 // Maximal nesting depth of the query selections (0 for no limit)
+}
+
+// GraphQL request body
+type graphQLRequest struct {
+	Query         string         `json:"query"`         // Query or mutation document
+	OperationName string         `json:"operationName"` // Operation to execute (when the document includes multiple operations)
+	Variables     map[string]any `json:"variables"`     // Operation variables
+}
+
+// NewGraphQLEndPoint factory method, the schema is derived from the model entities and the resolvers delegate to the
+// services of the service hub (panics if the model can't be mapped to valid schema). Queries nested deeper than maxDepth
+// are rejected (0 for no limit)
+func NewGraphQLEndPoint(facade *common.ServiceHub, maxDepth int) rest.RestEndpoint {
+	apis := entityApis(facade)
+	schema, err := buildSchema(apis)
+	if err != nil {
+		panic(fmt.Sprintf("invalid GraphQL schema: %v", err))
+	}
+
+	lists := make(map[string]listFunc)
+	for _, api := range apis {
+		if api.list != nil {
+			lists[typeName(reflect.TypeOf(api.factory()))] = api.list
+		}
+	}
+	return &GraphQLEndPoint{schema: schema, lists: lists, maxDepth: maxDepth}
+}
+
+// endregion
+
+// region Endpoint methods implementation ------------------------------------------------------------------------------
+
+func (h *GraphQLEndPoint) Path() string {
+	return "/graphql"
+}
+
+func (h *GraphQLEndPoint) RestEntries() (restEntries []rest.RestEntry) {
+	restEntries = []rest.RestEntry{
+		{Method: http.MethodPost, Handler: h.execute, Path: "", RateLimit: true},
+	}
+	return
+}
+
+// Execute GraphQL query or mutation, the result (data and errors) is returned with status 200 unless the request is not
+// a valid GraphQL request
+func (h *GraphQLEndPoint) execute(c *gin.Context) {
+	// Get token data
+	td := h.GetTokenData(c)
+	if td == nil {
+		return
+	}
+
+	var request graphQLRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse(fmt.Errorf("invalid GraphQL request: %v", err)))
+		return
+	}
+	if len(request.Query) == 0 {
+		c.JSON(http.StatusBadRequest, NewErrorResponse(fmt.Errorf("missing GraphQL query")))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.do(c.Request.Context(), td, &request))
+}
+
+// Execute the request with new request state (batch loaders), queries nested deeper than the limit are not executed
+func (h *GraphQLEndPoint) do(ctx context.Context, td *TokenData, request *graphQLRequest) *graphql.Result {
+	if h.maxDepth > 0 {
+		if depth := queryDepth(request.Query, h.maxDepth); depth > h.maxDepth {
+			err := gqlerrors.NewFormattedError(fmt.Sprintf("query depth exceeds the limit of %d", h.maxDepth))
+			return &graphql.Result{Errors: []gqlerrors.FormattedError{err}}
+		}
+	}
+	return graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  request.Query,
+		VariableValues: request.Variables,
+		OperationName:  request.OperationName,
+		Context:        withRequestState(ctx, td, h.lists),
+	})
+}
+
+// endregion
+
+// region Query depth limit --------------------------------------------------------------------------------------------
+
+// Get the nesting depth of the query fields (up to the limit + 1), the introspection fields (e.g. __schema) are not
+// counted. Invalid document returns 0 (the parse error is reported by the execution)
+func queryDepth(query string, limit int) int {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(query)})})
+	if err != nil {
+		return 0
+	}
+
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	result := 0
+	for _, def := range doc.Definitions {
+		if operation, ok := def.(*ast.OperationDefinition); ok {
+			result = max(result, selectionDepth(operation.SelectionSet, fragments, map[string]bool{}, limit))
+		}
+	}
+	return result
+}
+
+// Get the nesting depth of the selections, fragments are expanded (cyclic spread is skipped) and the walk stops when the
+// limit is exceeded
+func selectionDepth(set *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, visited map[string]bool, limit int) int {
+	if set == nil {
+		return 0
+	}
+	result := 0
+	for _, selection := range set.Selections {
+		depth := 0
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if sel.Name == nil || strings.HasPrefix(sel.Name.Value, "__") {
+				continue
+			}
+			depth = 1
+			if limit > 0 {
+				depth += selectionDepth(sel.SelectionSet, fragments, visited, limit-1)
+			}
+		case *ast.InlineFragment:
+			depth = selectionDepth(sel.SelectionSet, fragments, visited, limit)
+		case *ast.FragmentSpread:
+			fragment := fragments[sel.Name.Value]
+			if fragment == nil || visited[sel.Name.Value] {
+				continue
+			}
+			visited[sel.Name.Value] = true
+			depth = selectionDepth(fragment.SelectionSet, fragments, visited, limit)
+			delete(visited, sel.Name.Value)
+		}
+		if result = max(result, depth); result > limit {
+			return result
+		}
+	}
+	return result
+}
+
+// endregion
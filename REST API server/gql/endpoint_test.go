@@ -0,0 +1,124 @@
+package gql
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+var testEndPointOnce sync.Once
+var testEndPoint *GraphQLEndPoint
+var testHub *common.ServiceHub
+
+// Get the endpoint of the test hub (the services are bound to the first hub) with accounts and their contacts
+func newTestEndPoint(t *testing.T) *GraphQLEndPoint {
+	t.Helper()
+	testEndPointOnce.Do(func() {
+		testHub = common.NewServiceHub()
+		if err := testHub.Database.ExecuteDDL(map[string][]string{"account": {"name"}, "contact": {"name"}}); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range []string{"a1", "a2"} {
+			account := NewAccount().(*Account)
+			account.Id, account.Name = id, "Account "+id
+			if _, err := testHub.Database.Insert(account); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for i, accountId := range []string{"a1", "a2", "a1", "a2", "a1"} {
+			contact := NewContact().(*Contact)
+			contact.Id, contact.Name, contact.AccountId = string(rune('1'+i)), "Contact", accountId
+			if _, err := testHub.Database.Insert(contact); err != nil {
+				t.Fatal(err)
+			}
+		}
+		testEndPoint = NewGraphQLEndPoint(testHub, 8).(*GraphQLEndPoint)
+	})
+	return testEndPoint
+}
+
+// Token data of the system administrator
+func adminTokenData() *TokenData {
+	return &TokenData{SubjectId: "admin@example.com", SubjectType: UserTypeCodes.SYSADMIN}
+}
+
+func TestQueryDepth(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{"{ contacts { total } }", 2},
+		{"{ contacts { list { name account { name } } } }", 4},
+		{"query { a: contacts { total } b: contacts { list { account { name } } } }", 4},
+		{"{ contacts { ...page } } fragment page on ContactsPage { list { account { name } } }", 4},
+		{"{ contacts { ... on ContactsPage { list { name } } } }", 3},
+		{"{ ...a } fragment a on Query { ...a contacts { total } }", 2},
+		{"{ __schema { types { fields { type { ofType { ofType { name } } } } } } }", 0},
+		{"{ users { list { groups { members { groups { members { groups { members { name } } } } } } } } }", 6},
+		{"{ contacts { ", 0},
+	}
+	for _, tt := range tests {
+		if got := queryDepth(tt.query, 5); got != tt.expected {
+			t.Errorf("%s: expected depth %d but got %d", tt.query, tt.expected, got)
+		}
+	}
+}
+
+func TestRelatedEntitiesBatchLoading(t *testing.T) {
+	ep := newTestEndPoint(t)
+
+	// Count the accounts service calls
+	var calls [][]string
+	list := ep.lists["Account"]
+	ep.lists["Account"] = func(td *TokenData, ids []string) ([]Entity, error) {
+		calls = append(calls, slices.Sorted(slices.Values(ids)))
+		return list(td, ids)
+	}
+	t.Cleanup(func() { ep.lists["Account"] = list })
+
+	result := ep.do(context.Background(), adminTokenData(), &graphQLRequest{Query: "{ contacts { total list { id account { id name } } } }"})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	// The accounts of all the contacts are loaded by a single call
+	if len(calls) != 1 || !slices.Equal(calls[0], []string{"a1", "a2"}) {
+		t.Errorf("expected single accounts call of [a1 a2] but got %v", calls)
+	}
+	page := result.Data.(map[string]any)["contacts"].(map[string]any)
+	contacts := page["list"].([]any)
+	if len(contacts) != 5 {
+		t.Fatalf("expected 5 contacts but got %d", len(contacts))
+	}
+	for _, item := range contacts {
+		contact := item.(map[string]any)
+		account, _ := contact["account"].(map[string]any)
+		if account == nil || account["name"] != "Account "+account["id"].(string) {
+			t.Errorf("contact %v: unexpected account %v", contact["id"], contact["account"])
+		}
+	}
+}
+
+func TestQueryDepthLimit(t *testing.T) {
+	ep := newTestEndPoint(t)
+
+	query := "{ contacts { list { account { id } } } }"
+	limited := &GraphQLEndPoint{schema: ep.schema, lists: ep.lists, maxDepth: 3}
+	if result := limited.do(context.Background(), adminTokenData(), &graphQLRequest{Query: query}); !result.HasErrors() || result.Data != nil {
+		t.Errorf("expected depth limit error but got %v", result)
+	}
+
+	// Query within the limit is executed
+	limited.maxDepth = 4
+	if result := limited.do(context.Background(), adminTokenData(), &graphQLRequest{Query: query}); result.HasErrors() {
+		t.Errorf("unexpected errors: %v", result.Errors)
+	}
+}
@@ -0,0 +1,114 @@
+package gql
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+)
+
+// listFunc gets the entities by IDs (missing entities are ignored)
+type listFunc func(td *TokenData, ids []string) ([]Entity, error)
+
+// region Batch loader -------------------------------------------------------------------------------------------------
+
+// batchLoader collects the IDs requested by the related entities resolvers and loads them by a single list call of the
+// entity service: the resolvers of the same level are executed before their results are resolved, so the first result
+// loads the batch of all of them (avoids N+1 service calls). The loaded entities are cached for the request
+type batchLoader struct {
+	mu      sync.Mutex
+	list    listFunc
+	pending map[string]bool   // IDs of the next batch
+	cache   map[string]Entity // Loaded entities (nil for missing entity)
+}
+
+func newBatchLoader(list listFunc) *batchLoader {
+	return &batchLoader{list: list, pending: make(map[string]bool), cache: make(map[string]Entity)}
+}
+
+// Load the entities by IDs, the IDs are added to the next batch and the returned function resolves the entities (in the
+// IDs order, missing entities are skipped)
+func (l *batchLoader) Load(td *TokenData, ids []string) func() ([]Entity, error) {
+	l.mu.Lock()
+	for _, id := range ids {
+		if _, loaded := l.cache[id]; !loaded && len(id) > 0 {
+			l.pending[id] = true
+		}
+	}
+	l.mu.Unlock()
+
+	return func() ([]Entity, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if err := l.flush(td); err != nil {
+			return nil, err
+		}
+
+		result := make([]Entity, 0, len(ids))
+		for _, id := range ids {
+			if ent := l.cache[id]; ent != nil {
+				result = append(result, ent)
+			}
+		}
+		return result, nil
+	}
+}
+
+// Load the pending batch (the caller holds the lock)
+func (l *batchLoader) flush(td *TokenData) error {
+	if len(l.pending) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(l.pending))
+	for id := range l.pending {
+		ids = append(ids, id)
+	}
+	l.pending = make(map[string]bool)
+
+	list, err := l.list(td, ids)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		l.cache[id] = nil
+	}
+	for _, ent := range list {
+		l.cache[ent.ID()] = ent
+	}
+	return nil
+}
+
+// endregion
+
+// region Request state ------------------------------------------------------------------------------------------------
+
+// Request state in the resolvers context: the security token data and the batch loaders by entity type
+type requestState struct {
+	td      *TokenData
+	loaders map[string]*batchLoader
+}
+
+type contextKey int
+
+const requestStateKey contextKey = iota
+
+// Create request context with new batch loaders for the entity types
+func withRequestState(ctx context.Context, td *TokenData, lists map[string]listFunc) context.Context {
+	state := &requestState{td: td, loaders: make(map[string]*batchLoader, len(lists))}
+	for name, list := range lists {
+		state.loaders[name] = newBatchLoader(list)
+	}
+	return context.WithValue(ctx, requestStateKey, state)
+}
+
+// Get the request state of the resolver context
+func getRequestState(ctx context.Context) *requestState {
+	if state, ok := ctx.Value(requestStateKey).(*requestState); ok {
+		return state
+	}
+	return &requestState{td: &TokenData{}, loaders: map[string]*batchLoader{}}
+}
+
+// endregion
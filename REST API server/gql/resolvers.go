@@ -0,0 +1,415 @@
+package gql
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"github.com/graphql-go/graphql"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/filter"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// region Entities API definitions -------------------------------------------------------------------------------------
+
+// entityApi declares the GraphQL API of an entity type: the queries (single entity and page of entities), mutations and
+// the related entities loading, all delegate to the entity service
+type entityApi struct {
+	factory EntityFactory
+	single  string         // Single entity query name (e.g. contact)
+	plural  string         // Entities page query name (e.g. contacts)
+	enums   map[string]any // Enum fields of the entity: json name -> enum values (e.g. AccountTypeCodes)
+	args    map[string]any // Find arguments (besides search, filter, sort, page and size): type or enum values (list)
+	sort    string         // Default sort (same as the REST API)
+
+	get    func(td *TokenData, id string) (Entity, error)
+	find   func(td *TokenData, q findArgs) ([]Entity, int64, int, error)
+	list   listFunc // Load entities by IDs (nil if not related entity)
+	create func(td *TokenData, ent Entity) (Entity, error)
+	update func(td *TokenData, ent Entity) (Entity, error) // nil if not supported
+	delete func(td *TokenData, id string) error            // nil if not supported
+}
+
+// relation declares the related entity field resolved by the IDs field of the entity (e.g. contact account)
+type relation struct {
+	source string // Source entity type name
+	field  string // Related entity field name
+	ids    string // ID / IDs field json name
+	raw    string // New name of the IDs field (when the related entity field replaces it)
+	target string // Related entity type name
+	many   bool   // List of related entities
+}
+
+// Related entities of the model
+var relations = []relation{
+	{source: "Contact", field: "account", ids: "accountId", target: "Account"},
+	{source: "User", field: "groups", ids: "groups", raw: "groupIds", target: "UsersGroup", many: true},
+	{source: "UsersGroup", field: "members", ids: "members", raw: "memberIds", target: "User", many: true},
+}
+
+// Get the API definitions of the model entities
+func entityApis(facade *common.ServiceHub) []*entityApi {
+	accounts := s.GetAccountsService(facade)
+	auditLogs := s.GetAuditLogsService(facade)
+	contacts := s.GetContactsService(facade)
+	groups := s.GetGroupsService(facade)
+	users := s.GetUsersService(facade)
+
+	return []*entityApi{
+		{
+			factory: NewAccount,
+			single:  "account",
+			plural:  "accounts",
+			enums:   map[string]any{"type": AccountTypeCodes, "status": AccountStatusCodes},
+			args:    map[string]any{"status": AccountStatusCodes},
+			sort:    "name",
+			get:     accounts.Get,
+			find: func(td *TokenData, q findArgs) ([]Entity, int64, int, error) {
+				p := s.AccountsFindParams{Search: q.search, Status: q.enumsArg("status"), Sort: q.sort, Page: q.page, Size: q.size, Filter: q.filter}
+				return accounts.Find(td, p)
+			},
+			list:   accounts.List,
+			create: accounts.Create,
+			update: accounts.Update,
+			delete: accounts.Delete,
+		},
+		{
+			factory: NewAuditLog,
+			single:  "auditLog",
+			plural:  "auditLogs",
+			enums:   map[string]any{"userType": UserTypeCodes},
+			args: map[string]any{
+				"from": Long, "to": Long, "userId": graphql.String, "action": graphql.String,
+				"itemType": graphql.String, "itemId": graphql.String, "itemName": graphql.String,
+			},
+			sort: "createdOn-",
+			get:  auditLogs.Get,
+			find: func(td *TokenData, q findArgs) ([]Entity, int64, int, error) {
+				p := s.AuditLogsFindParams{
+					From:     absoluteTime(q.longArg("from")),
+					To:       absoluteTime(q.longArg("to")),
+					UserId:   q.stringArg("userId"),
+					Action:   q.stringArg("action"),
+					ItemType: q.stringArg("itemType"),
+					ItemId:   q.stringArg("itemId"),
+					ItemName: q.stringArg("itemName"),
+					Search:   q.search,
+					Sort:     q.sort,
+					Page:     q.page,
+					Size:     q.size,
+					Filter:   q.filter,
+				}
+				return auditLogs.Find(td, p)
+			},
+			create: auditLogs.Create,
+		},
+		{
+			factory: NewContact,
+			single:  "contact",
+			plural:  "contacts",
+			args:    map[string]any{"status": StatusCodes},
+			sort:    "lastName",
+			get:     contacts.Get,
+			find: func(td *TokenData, q findArgs) ([]Entity, int64, int, error) {
+				p := s.ContactsFindParams{Search: q.search, Status: q.enumsArg("status"), Sort: q.sort, Page: q.page, Size: q.size, Filter: q.filter}
+				return contacts.Find(td, p)
+			},
+			create: contacts.Create,
+			update: contacts.Update,
+			delete: contacts.Delete,
+		},
+		{
+			factory: NewUsersGroup,
+			single:  "group",
+			plural:  "groups",
+			get:     groups.Get,
+			find: func(td *TokenData, q findArgs) ([]Entity, int64, int, error) {
+				p := s.GroupsFindParams{Search: q.search, Sort: q.sort, Page: q.page, Size: q.size, Filter: q.filter}
+				return groups.Find(td, p)
+			},
+			list:   groups.List,
+			create: groups.Create,
+			update: groups.Update,
+			delete: groups.Delete,
+		},
+		{
+			factory: NewUser,
+			single:  "user",
+			plural:  "users",
+			enums:   map[string]any{"type": UserTypeCodes, "status": UserStatusCodes},
+			args:    map[string]any{"type": UserTypeCodes, "status": UserStatusCodes},
+			sort:    "name",
+			get:     users.Get,
+			find: func(td *TokenData, q findArgs) ([]Entity, int64, int, error) {
+				p := s.UsersFindParams{Search: q.search, Type: q.enumsArg("type"), Status: q.enumsArg("status"), Sort: q.sort, Page: q.page, Size: q.size, Filter: q.filter}
+				return users.Find(td, p)
+			},
+			list:   users.List,
+			create: users.Create,
+			update: users.Update,
+			delete: users.Delete,
+		},
+	}
+}
+
+// Convert relative time (negative number of milliseconds) to absolute time
+func absoluteTime(input int64) Timestamp {
+	if input < 0 {
+		return Now() + Timestamp(input)
+	}
+	return Timestamp(input)
+}
+
+// endregion
+
+// region Schema -------------------------------------------------------------------------------------------------------
+
+// entitiesPage is the result of the entities page queries
+type entitiesPage struct {
+	Page  int      `json:"page"`  // Page number
+	Size  int      `json:"size"`  // Page size
+	Pages int      `json:"pages"` // Total number of pages
+	Total int64    `json:"total"` // Total number of matching entities
+	List  []Entity `json:"list"`  // Entities of the page
+}
+
+// Build the GraphQL schema of the entities API: the entity types (with the related entities fields), the queries and the
+// mutations
+func buildSchema(apis []*entityApi) (graphql.Schema, error) {
+	b := newTypeBuilder()
+	types := make(map[string]*graphql.Object, len(apis))
+	for _, api := range apis {
+		t := reflect.TypeOf(api.factory())
+		types[typeName(t)] = b.object(t, api.enums)
+	}
+	for _, r := range relations {
+		if err := addRelation(types, r); err != nil {
+			return graphql.Schema{}, err
+		}
+	}
+
+	idArgs := graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}}
+	query, mutation := graphql.Fields{}, graphql.Fields{}
+	for _, api := range apis {
+		t := reflect.TypeOf(api.factory())
+		object := types[typeName(t)]
+		inputArgs := graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(b.input(t, api.enums))}}
+		name := strings.ToUpper(api.single[:1]) + api.single[1:]
+
+		query[api.single] = &graphql.Field{Type: object, Args: idArgs, Resolve: api.resolveGet}
+		query[api.plural] = &graphql.Field{Type: pageType(api, object), Args: api.findArgs(b), Resolve: api.resolveFind}
+		mutation["create"+name] = &graphql.Field{Type: object, Args: inputArgs, Resolve: api.resolveSave(api.create)}
+		if api.update != nil {
+			mutation["update"+name] = &graphql.Field{Type: object, Args: inputArgs, Resolve: api.resolveSave(api.update)}
+		}
+		if api.delete != nil {
+			mutation["delete"+name] = &graphql.Field{Type: graphql.String, Args: idArgs, Resolve: api.resolveDelete}
+		}
+	}
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: query}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{Name: "Mutation", Fields: mutation}),
+	})
+}
+
+// Get the entities page type of the entity (e.g. ContactsPage)
+func pageType(api *entityApi, object *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: strings.ToUpper(api.plural[:1]) + api.plural[1:] + "Page",
+		Fields: graphql.Fields{
+			"page":  &graphql.Field{Type: graphql.Int},
+			"size":  &graphql.Field{Type: graphql.Int},
+			"pages": &graphql.Field{Type: graphql.Int},
+			"total": &graphql.Field{Type: Long},
+			"list":  &graphql.Field{Type: graphql.NewList(object)},
+		},
+	})
+}
+
+// Add the related entity field to the entity type, the IDs field is renamed if the related entity field replaces it
+func addRelation(types map[string]*graphql.Object, r relation) error {
+	source, target := types[r.source], types[r.target]
+	if source == nil || target == nil {
+		return fmt.Errorf("invalid relation %s.%s: unknown entity type", r.source, r.field)
+	}
+	ids, ok := source.Fields()[r.ids]
+	if !ok {
+		return fmt.Errorf("invalid relation %s.%s: unknown field %s", r.source, r.field, r.ids)
+	}
+
+	var fieldType graphql.Output = target
+	if r.many {
+		fieldType = graphql.NewList(target)
+	}
+	if len(r.raw) > 0 {
+		source.AddFieldConfig(r.raw, &graphql.Field{Type: ids.Type, Resolve: ids.Resolve})
+	}
+	source.AddFieldConfig(r.field, &graphql.Field{Type: fieldType, Resolve: relationResolver(r, ids.Resolve)})
+	return nil
+}
+
+// endregion
+
+// region Resolvers ----------------------------------------------------------------------------------------------------
+
+// Resolve the related entity (or entities) by the IDs field, the IDs are added to the batch of the target entity loader
+// and the entities are resolved after all the resolvers of the same level were executed
+func relationResolver(r relation, ids graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		value, err := ids(p)
+		if err != nil {
+			return nil, err
+		}
+		var keys []string
+		switch v := value.(type) {
+		case string:
+			keys = []string{v}
+		case []string:
+			keys = v
+		}
+
+		state := getRequestState(p.Context)
+		loader, ok := state.loaders[r.target]
+		if !ok {
+			return nil, fmt.Errorf("%s loader is not available", r.target)
+		}
+		load := loader.Load(state.td, keys)
+		return func() (any, error) {
+			list, er := load()
+			if er != nil || r.many {
+				return list, er
+			}
+			if len(list) == 0 {
+				return nil, nil
+			}
+			return list[0], nil
+		}, nil
+	}
+}
+
+// Get a single entity by id
+func (api *entityApi) resolveGet(p graphql.ResolveParams) (any, error) {
+	id, _ := p.Args["id"].(string)
+	return api.get(getRequestState(p.Context).td, id)
+}
+
+// Find page of entities by query
+func (api *entityApi) resolveFind(p graphql.ResolveParams) (any, error) {
+	q := findArgs{args: p.Args, sort: api.sort, page: 1, size: 100}
+	q.search = q.stringArg("search")
+	if sort := q.stringArg("sort"); len(sort) > 0 {
+		q.sort = sort
+	}
+	if page, ok := p.Args["page"].(int); ok && page > 0 {
+		q.page = page
+	}
+	if size, ok := p.Args["size"].(int); ok && size > 0 {
+		q.size = size
+	}
+	criteria, err := filter.Parse(api.factory, q.stringArg("filter"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %v", err)
+	}
+	q.filter = criteria
+
+	list, total, pages, err := api.find(getRequestState(p.Context).td, q)
+	if err != nil {
+		return nil, err
+	}
+	return &entitiesPage{Page: q.page, Size: q.size, Pages: pages, Total: total, List: list}, nil
+}
+
+// Create or update entity by the input (the input is converted to entity by the json field names)
+func (api *entityApi) resolveSave(save func(td *TokenData, ent Entity) (Entity, error)) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		ent := api.factory()
+		content, err := json.Marshal(p.Args["input"])
+		if err == nil {
+			err = json.Unmarshal(content, ent)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", ent.TABLE(), err)
+		}
+		return save(getRequestState(p.Context).td, ent)
+	}
+}
+
+// Delete entity by id, returns the deleted entity id (only system administrator can delete entities)
+func (api *entityApi) resolveDelete(p graphql.ResolveParams) (any, error) {
+	td := getRequestState(p.Context).td
+	if td.SubjectType != UserTypeCodes.SYSADMIN {
+		return nil, fmt.Errorf("delete is forbidden")
+	}
+	id, _ := p.Args["id"].(string)
+	if err := api.delete(td, id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// endregion
+
+// region Find arguments -----------------------------------------------------------------------------------------------
+
+// findArgs are the arguments of the entities page query
+type findArgs struct {
+	args   map[string]any   // Query arguments
+	search string           // Free text search
+	sort   string           // Sort descriptor (or the default sort)
+	page   int              // Page number (default: 1)
+	size   int              // Page size (default: 100)
+	filter *filter.Criteria // Parsed filter expression
+}
+
+// Get the arguments of the entities page query
+func (api *entityApi) findArgs(b *typeBuilder) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"search": &graphql.ArgumentConfig{Type: graphql.String, Description: "Free text search (using * wildcard)"},
+		"filter": &graphql.ArgumentConfig{Type: graphql.String, Description: "Filter expression (e.g. name like 'john*')"},
+		"sort":   &graphql.ArgumentConfig{Type: graphql.String, Description: "Sort by field and direction (e.g. name-)"},
+		"page":   &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1, Description: "Page number"},
+		"size":   &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 100, Description: "Page size"},
+	}
+	for name, arg := range api.args {
+		if input, ok := arg.(graphql.Input); ok {
+			args[name] = &graphql.ArgumentConfig{Type: input}
+		} else {
+			args[name] = &graphql.ArgumentConfig{Type: graphql.NewList(b.enum(arg))}
+		}
+	}
+	return args
+}
+
+// Get string argument
+func (q findArgs) stringArg(name string) string {
+	value, _ := q.args[name].(string)
+	return value
+}
+
+// Get Long argument
+func (q findArgs) longArg(name string) int64 {
+	value, _ := q.args[name].(int64)
+	return value
+}
+
+// Get enum list argument
+func (q findArgs) enumsArg(name string) []int {
+	items, _ := q.args[name].([]any)
+	result := make([]int, 0, len(items))
+	for _, item := range items {
+		if value, ok := item.(int); ok {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// endregion
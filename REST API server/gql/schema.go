@@ -0,0 +1,287 @@
+// Package gql The GraphQL endpoint exposes the entity model (queries with filters, pagination and nested resolution of
+// related entities, and mutations), the schema types are derived from the entity structs in model/entities and the
+// resolvers delegate to the services (same as the REST endpoints)
+package gql
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// region Scalars ------------------------------------------------------------------------------------------------------
+
+// Long is a 64-bit integer scalar (e.g. epoch milliseconds timestamp), the GraphQL Int is limited to 32-bit
+var Long = graphql.NewScalar(graphql.ScalarConfig{
+	Name:         "Long",
+	Description:  "64-bit integer (e.g. epoch milliseconds timestamp)",
+	Serialize:    serializeLong,
+	ParseValue:   parseLong,
+	ParseLiteral: parseLongLiteral,
+})
+
+// JSON is an arbitrary json value scalar (e.g. entity custom properties)
+var JSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:         "JSON",
+	Description:  "Arbitrary json value (e.g. custom properties)",
+	Serialize:    func(value any) any { return value },
+	ParseValue:   func(value any) any { return value },
+	ParseLiteral: parseJsonLiteral,
+})
+
+func serializeLong(value any) any {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return int64(v.Float())
+	default:
+		return nil
+	}
+}
+
+func parseLong(value any) any {
+	switch v := value.(type) {
+	case string:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+		return nil
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		return nil
+	default:
+		return serializeLong(value)
+	}
+}
+
+func parseLongLiteral(valueAST ast.Value) any {
+	switch v := valueAST.(type) {
+	case *ast.IntValue:
+		return parseLong(v.Value)
+	case *ast.StringValue:
+		return parseLong(v.Value)
+	default:
+		return nil
+	}
+}
+
+func parseJsonLiteral(valueAST ast.Value) any {
+	switch v := valueAST.(type) {
+	case *ast.ObjectValue:
+		result := make(map[string]any, len(v.Fields))
+		for _, field := range v.Fields {
+			result[field.Name.Value] = parseJsonLiteral(field.Value)
+		}
+		return result
+	case *ast.ListValue:
+		result := make([]any, 0, len(v.Values))
+		for _, item := range v.Values {
+			result = append(result, parseJsonLiteral(item))
+		}
+		return result
+	case *ast.IntValue:
+		return parseLong(v.Value)
+	case *ast.FloatValue:
+		if f, err := strconv.ParseFloat(v.Value, 64); err == nil {
+			return f
+		}
+		return nil
+	case *ast.StringValue:
+		return v.Value
+	case *ast.BooleanValue:
+		return v.Value
+	case *ast.EnumValue:
+		return v.Value
+	default:
+		return nil
+	}
+}
+
+// endregion
+
+// region Types builder ------------------------------------------------------------------------------------------------
+
+var timestampType = reflect.TypeOf(Timestamp(0))
+
+// typeBuilder derives the GraphQL object, input and enum types from the model structs by the json field names. The enum
+// fields must be declared (the enum types are aliases of int), the rest of the types are mapped by kind: Timestamp and
+// int64 to Long, Json and maps to JSON, slices to lists and structs to objects
+type typeBuilder struct {
+	objects map[reflect.Type]*graphql.Object
+	inputs  map[reflect.Type]*graphql.InputObject
+	enums   map[string]*graphql.Enum
+}
+
+// Struct field mapped to GraphQL field
+type structField struct {
+	name  string       // json name
+	index []int        // index path (including embedded structs)
+	typ   reflect.Type // field type
+}
+
+func newTypeBuilder() *typeBuilder {
+	return &typeBuilder{
+		objects: make(map[reflect.Type]*graphql.Object),
+		inputs:  make(map[reflect.Type]*graphql.InputObject),
+		enums:   make(map[string]*graphql.Enum),
+	}
+}
+
+// Get the exported fields of the struct by the json names (including the fields of the embedded structs)
+func jsonFields(t reflect.Type) (result []structField) {
+	for _, f := range reflect.VisibleFields(t) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if len(name) == 0 {
+			name = f.Name
+		}
+		result = append(result, structField{name: name, index: f.Index, typ: f.Type})
+	}
+	return
+}
+
+// Get the GraphQL type name of the model struct (e.g. *Contact -> Contact)
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// Get the enum type of enum values struct (e.g. AccountTypeCodes -> AccountTypeCode), the enum values are the fields with
+// value tag
+func (b *typeBuilder) enum(values any) *graphql.Enum {
+	v := reflect.Indirect(reflect.ValueOf(values))
+	name := strings.ToUpper(v.Type().Name()[:1]) + v.Type().Name()[1:]
+	if result, ok := b.enums[name]; ok {
+		return result
+	}
+
+	config := graphql.EnumValueConfigMap{}
+	for i := 0; i < v.NumField(); i++ {
+		if _, ok := v.Type().Field(i).Tag.Lookup("value"); ok {
+			config[v.Type().Field(i).Name] = &graphql.EnumValueConfig{Value: int(v.Field(i).Int())}
+		}
+	}
+	result := graphql.NewEnum(graphql.EnumConfig{Name: name, Values: config})
+	b.enums[name] = result
+	return result
+}
+
+// Get the object type of the model struct, the enum fields are declared by json name
+func (b *typeBuilder) object(t reflect.Type, enums map[string]any) *graphql.Object {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if result, ok := b.objects[t]; ok {
+		return result
+	}
+
+	fields := graphql.Fields{}
+	for _, f := range jsonFields(t) {
+		fields[f.name] = &graphql.Field{Type: b.outputType(f.typ, enums[f.name]), Resolve: fieldResolver(f.index)}
+	}
+	result := graphql.NewObject(graphql.ObjectConfig{Name: typeName(t), Fields: fields})
+	b.objects[t] = result
+	return result
+}
+
+// Get the input type of the model struct (used by the mutations), the enum fields are declared by json name
+func (b *typeBuilder) input(t reflect.Type, enums map[string]any) *graphql.InputObject {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if result, ok := b.inputs[t]; ok {
+		return result
+	}
+
+	fields := graphql.InputObjectConfigFieldMap{}
+	for _, f := range jsonFields(t) {
+		fields[f.name] = &graphql.InputObjectFieldConfig{Type: b.inputType(f.typ, enums[f.name])}
+	}
+	result := graphql.NewInputObject(graphql.InputObjectConfig{Name: typeName(t) + "Input", Fields: fields})
+	b.inputs[t] = result
+	return result
+}
+
+// Get the output type of the field type
+func (b *typeBuilder) outputType(t reflect.Type, enum any) graphql.Output {
+	switch {
+	case t.Kind() == reflect.Ptr:
+		return b.outputType(t.Elem(), enum)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		return graphql.NewList(b.outputType(t.Elem(), enum))
+	case t.Kind() == reflect.Struct:
+		return b.object(t, nil)
+	default:
+		return b.leafType(t, enum)
+	}
+}
+
+// Get the input type of the field type
+func (b *typeBuilder) inputType(t reflect.Type, enum any) graphql.Input {
+	switch {
+	case t.Kind() == reflect.Ptr:
+		return b.inputType(t.Elem(), enum)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		return graphql.NewList(b.inputType(t.Elem(), enum))
+	case t.Kind() == reflect.Struct:
+		return b.input(t, nil)
+	default:
+		return b.leafType(t, enum)
+	}
+}
+
+// Get the scalar or enum type of the field type
+func (b *typeBuilder) leafType(t reflect.Type, enum any) graphql.Type {
+	if enum != nil {
+		return b.enum(enum)
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return graphql.String
+	case reflect.Bool:
+		return graphql.Boolean
+	case reflect.Int64, reflect.Uint64:
+		return Long
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		if t == timestampType {
+			return Long
+		}
+		return graphql.Int
+	case reflect.Float32, reflect.Float64:
+		return graphql.Float
+	default:
+		// Json, maps, interfaces and bytes
+		return JSON
+	}
+}
+
+// Resolve the struct field by index path (the default resolver does not support embedded structs)
+func fieldResolver(index []int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		v := reflect.Indirect(reflect.ValueOf(p.Source))
+		if v.Kind() != reflect.Struct {
+			return nil, nil
+		}
+		return v.FieldByIndex(index).Interface(), nil
+	}
+}
+
+// endregion
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 
@@ -9,8 +10,14 @@ import (
 	"github.com/go-yaaf/yaaf-examples/rest-api/cmd/server"
 	"github.com/go-yaaf/yaaf-examples/rest-api/common"
 	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+	"github.com/go-yaaf/yaaf-examples/rest-api/doc"
+	"github.com/go-yaaf/yaaf-examples/rest-api/gql"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
 	"github.com/go-yaaf/yaaf-examples/rest-api/rest"
 	usr "github.com/go-yaaf/yaaf-examples/rest-api/rest/user"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rpc"
+	"github.com/go-yaaf/yaaf-examples/rest-api/services"
 )
 
 func init() {
@@ -23,12 +30,14 @@ func init() {
 // Main entry point
 func main() {
 
-	if app, err := setup(); err != nil {
+	app, err := setup()
+	if err != nil {
 		logger.Error("bootstrap error: %s", err.Error())
-	} else {
-		logger.Info("Starting the service...")
-		app.Start()
+		os.Exit(server.ExitStartupError)
 	}
+
+	logger.Info("Starting the service...")
+	os.Exit(app.Start())
 }
 
 // Bootstrap the application components and inject all dependencies
@@ -37,14 +46,39 @@ func setup() (*server.Application, error) {
 	// Init config
 	serviceConfig := config.GetConfig()
 
+	// Init tracing
+	if err := common.InitTracing(serviceConfig.TracingExporter()); err != nil {
+		return nil, err
+	}
+
 	// Init service hub
 	facade := common.NewServiceHub()
 
 	// Init REST server
 	restServer := newRestServer(serviceConfig, facade)
 
+	// Init gRPC server (same services as the REST server)
+	grpcServer := rpc.NewGRPCServer(serviceConfig, facade)
+
+	// Subscribe the in-process domain events handlers
+	if err := services.RegisterDomainEventHandlers(facade); err != nil {
+		return nil, err
+	}
+	if err := services.SubscribeEntityEvents(facade); err != nil {
+		return nil, err
+	}
+
+	// Init webhooks dispatcher (delivers the entity change events to the webhooks) and outbox relay (publishes the events
+	// saved with the entity changes), both run on a single instance only
+	var dispatcher *services.WebhookDispatcher
+	var relay *services.OutboxRelay
+	if serviceConfig.OutboxRelayEnabled() {
+		dispatcher = services.GetWebhookDispatcher(facade)
+		relay = services.GetOutboxRelay(facade)
+	}
+
 	// Init application
-	application, err := server.NewApplication(serviceConfig, facade, restServer)
+	application, err := server.NewApplication(serviceConfig, facade, restServer, grpcServer, dispatcher, relay)
 	if err != nil {
 		return nil, err
 	}
@@ -53,16 +87,38 @@ func setup() (*server.Application, error) {
 
 // Create the REST server for the prometheus metrics endpoint
 func newRestServer(cfg *config.ServiceConfig, facade *common.ServiceHub) *rest.Server {
-	restServer := rest.NewRESTServer(cfg)
-	restServer.AddEndpoints(usr.NewListOfUserRestEndPoints(facade)...)
-	restServer.AddEndpoints(rest.NewHealthEndPoint())
+	rateLimits := rest.NewRateLimitConfig(cfg, facade.DataCache)
+	rateLimits.AccountType = subjectAccountType(facade)
+
+	restServer := rest.NewRESTServer(cfg).
+		WithIdempotency(facade.DataCache, cfg.IdempotencyTTL()).
+		WithRateLimits(rateLimits)
+	for _, version := range usr.NewUserApiVersions(cfg) {
+		restServer.AddApiVersion(version, usr.NewListOfUserRestEndPoints(facade)...)
+	}
+	restServer.AddEndpoints(rest.NewHealthEndPoint(facade, cfg.HealthCheckTimeout()), rest.NewVersionsEndPoint(restServer))
+
+	// Add GraphQL endpoint (same services as the REST endpoints)
+	restServer.AddEndpoints(gql.NewGraphQLEndPoint(facade, cfg.GraphQLMaxDepth()))
 
-	// Add documentation endpoint
-	restServer.AddStaticEndpoint("/doc", "./doc")
+	// Add Prometheus metrics endpoint
+	restServer.AddMetricsEndpoint("/metrics", cfg.MetricsToken())
+
+	// Add documentation endpoint (OpenAPI document and viewer)
+	restServer.AddStaticFileSystem("/doc", http.FS(doc.Files))
 
 	return restServer
 }
 
+// Resolve the account type of the subject for the rate limits by account type
+func subjectAccountType(facade *common.ServiceHub) func(subjectId string) (AccountTypeCode, bool) {
+	service := services.GetAccountsService(facade)
+	return func(subjectId string) (AccountTypeCode, bool) {
+		accountType, err := service.GetSubjectAccountType(&TokenData{SubjectId: subjectId})
+		return accountType, err == nil
+	}
+}
+
 // Log directory structure, for DEBUG only
 // Motivation: to understand volume mapping of Google bucket to Google Cloud Run container
 func logFileSystemStructure(root string) {
@@ -0,0 +1,23 @@
+package model
+
+// ApiVersionInfo model represents a version of the REST API and its routes (route table for documentation)
+// @Data
+type ApiVersionInfo struct {
+	Version    string      `json:"version"`    // Version name, used as the path prefix (e.g. v2), empty for unversioned routes
+	Deprecated string      `json:"deprecated"` // When the version was deprecated [RFC3339] (empty for active version)
+	Sunset     string      `json:"sunset"`     // When the version is going to be removed [RFC3339] (empty if not scheduled)
+	Link       string      `json:"link"`       // Link to the migration guide (for deprecated version)
+	Routes     []RouteInfo `json:"routes"`     // List of the version routes
+}
+
+func (v *ApiVersionInfo) ID() string    { return v.Version }
+func (v *ApiVersionInfo) TABLE() string { return "" }
+func (v *ApiVersionInfo) NAME() string  { return v.Version }
+func (v *ApiVersionInfo) KEY() string   { return "" }
+
+// RouteInfo model represents a single REST route
+// @Data
+type RouteInfo struct {
+	Method string `json:"method"` // HTTP method
+	Path   string `json:"path"`   // Full route path (including the version prefix)
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"strconv"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// EntityEvent model represents an entity change (create, update or delete) published by the services, the events are
+// streamed to the subscribers of the events endpoint
+// @Data
+type EntityEvent struct {
+	Id        int64     `json:"id"`        // Event ID (ordered, the same on all instances), used to resume the stream after reconnect
+	Action    string    `json:"action"`    // Action that was performed: Create | Update | Delete (Reset when the missed events are not available)
+	ItemType  string    `json:"itemType"`  // Item type (entity table name, e.g. contact)
+	ItemId    string    `json:"itemId"`    // Item Id
+	ItemName  string    `json:"itemName"`  // Item Name
+	AccountId string    `json:"accountId"` // Account of the item (the account itself, the contact account), set for delete too
+	Item      Json      `json:"item"`      // Item value after change (empty for delete)
+	UserId    string    `json:"userId"`    // Subject that performed the action
+	RequestId string    `json:"requestId"` // Correlation ID of the request that performed the action
+	Timestamp Timestamp `json:"timestamp"` // When the action was performed [Epoch milliseconds Timestamp]
+}
+
+func (e *EntityEvent) ID() string    { return strconv.FormatInt(e.Id, 10) }
+func (e *EntityEvent) TABLE() string { return "" }
+func (e *EntityEvent) NAME() string  { return e.ItemName }
+func (e *EntityEvent) KEY() string   { return e.ItemType }
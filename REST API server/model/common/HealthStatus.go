@@ -0,0 +1,24 @@
+package model
+
+// HealthStatus model represents the readiness of the service and the status of its dependencies
+// @Data
+type HealthStatus struct {
+	Status  string             `json:"status"`  // Service status: STARTING | READY | DRAINING
+	Ready   bool               `json:"ready"`   // Service is ready to accept traffic (status is READY and all dependencies are UP)
+	Version string             `json:"version"` // Service version (build tag)
+	Checks  []DependencyStatus `json:"checks"`  // Status of the service dependencies
+}
+
+func (h *HealthStatus) ID() string    { return h.Status }
+func (h *HealthStatus) TABLE() string { return "" }
+func (h *HealthStatus) NAME() string  { return h.Status }
+func (h *HealthStatus) KEY() string   { return "" }
+
+// DependencyStatus model represents the status of a single service dependency (e.g. database)
+// @Data
+type DependencyStatus struct {
+	Name    string  `json:"name"`    // Dependency name: database | datacache
+	Status  string  `json:"status"`  // Dependency status: UP | DOWN
+	Latency float64 `json:"latency"` // Check latency in milliseconds
+	Error   string  `json:"error"`   // Error message (if the dependency is down)
+}
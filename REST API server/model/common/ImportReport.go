@@ -0,0 +1,34 @@
+package model
+
+import (
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// ImportReport model represents the validation report of data import (dry-run) or the import results
+// @Data
+type ImportReport struct {
+	ImportId   string            `json:"importId"`   // Import ID, the audit log entries of the import include it in props.importId
+	DryRun     bool              `json:"dryRun"`     // Dry-run flag: the data was validated but not saved
+	Total      int               `json:"total"`      // Total number of rows
+	Valid      int               `json:"valid"`      // Number of valid rows
+	Invalid    int               `json:"invalid"`    // Number of invalid rows
+	Duplicates int               `json:"duplicates"` // Number of duplicate rows
+	Imported   int               `json:"imported"`   // Number of imported rows
+	Failed     int               `json:"failed"`     // Number of valid rows that failed to be saved
+	Rows       []ImportRowResult `json:"rows"`       // Per row results
+}
+
+func (r *ImportReport) ID() string    { return r.ImportId }
+func (r *ImportReport) TABLE() string { return "" }
+func (r *ImportReport) NAME() string  { return r.ImportId }
+func (r *ImportReport) KEY() string   { return "" }
+
+// ImportRowResult model represents the validation / import result of a single row
+// @Data
+type ImportRowResult struct {
+	Row      int              `json:"row"`      // Row number in the file (1-based, not including the header)
+	Name     string           `json:"name"`     // Item name
+	Status   ImportStatusCode `json:"status"`   // Row status: VALID | INVALID | DUPLICATE | IMPORTED | FAILED
+	ItemId   string           `json:"itemId"`   // Imported item ID or the ID of the existing item (for duplicates)
+	Messages []string         `json:"messages"` // Validation errors and warnings
+}
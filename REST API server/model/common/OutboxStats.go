@@ -0,0 +1,21 @@
+package model
+
+import (
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// OutboxStats model represents the state of the transactional outbox: entries waiting to be relayed (pending and
+// retries), stuck entries (waiting longer than the stuck threshold) and failed entries
+// @Data
+type OutboxStats struct {
+	Pending       int64     `json:"pending"`       // Number of entries waiting to be relayed (including retries)
+	Stuck         int64     `json:"stuck"`         // Number of entries waiting longer than the stuck threshold
+	Failed        int64     `json:"failed"`        // Number of entries that failed all the relay attempts
+	OldestPending Timestamp `json:"oldestPending"` // Creation time of the oldest waiting entry (0 when there is none) [Epoch milliseconds Timestamp]
+	StuckAfter    int64     `json:"stuckAfter"`    // Stuck threshold in milliseconds
+}
+
+func (s *OutboxStats) ID() string    { return "outbox" }
+func (s *OutboxStats) TABLE() string { return "" }
+func (s *OutboxStats) NAME() string  { return "outbox" }
+func (s *OutboxStats) KEY() string   { return "" }
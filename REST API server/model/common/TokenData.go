@@ -1,6 +1,8 @@
 package model
 
 import (
+	"context"
+
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
 )
 
@@ -11,4 +13,25 @@ type TokenData struct {
 	SubjectType UserTypeCode   `json:"subjectType"` // Subject type: UNDEFINED | SYSADMIN | USER | SERVICE_ACCOUNT
 	Status      UserStatusCode `json:"status"`      // User status: UNDEFINED | PENDING | ACTIVE | BLOCKED | SUSPENDED
 	ExpiresIn   int64          `json:"expiresIn"`   // Token expiration [Epoch milliseconds Timestamp]
+	RequestId   string         `json:"-"`           // Correlation ID of the current request (not part of the token)
+
+	ctx context.Context // Context of the current request (trace span, cancellation)
+}
+
+// Context returns the context of the current request (background context if not set)
+func (t *TokenData) Context() context.Context {
+	if t == nil || t.ctx == nil {
+		return context.Background()
+	}
+	return t.ctx
+}
+
+// WithContext returns a shallow copy of the token data with the provided request context
+func (t *TokenData) WithContext(ctx context.Context) *TokenData {
+	if t == nil {
+		return nil
+	}
+	result := *t
+	result.ctx = ctx
+	return &result
 }
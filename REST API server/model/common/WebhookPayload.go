@@ -0,0 +1,21 @@
+package model
+
+import (
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// WebhookPayload model represents the json payload posted to the webhook receiver, the payload is signed by the webhook
+// secret (X-Webhook-Signature header)
+// @Data
+type WebhookPayload struct {
+	Id        string      `json:"id"`        // Delivery ID, the same in all the attempts (used by the receiver to ignore duplicates)
+	WebhookId string      `json:"webhookId"` // Webhook ID
+	Event     string      `json:"event"`     // Event name: item type and action (e.g. contact.Create)
+	Timestamp Timestamp   `json:"timestamp"` // When the change was performed [Epoch milliseconds Timestamp]
+	Data      EntityEvent `json:"data"`      // The entity change event
+}
+
+func (p *WebhookPayload) ID() string    { return p.Id }
+func (p *WebhookPayload) TABLE() string { return "" }
+func (p *WebhookPayload) NAME() string  { return p.Event }
+func (p *WebhookPayload) KEY() string   { return p.WebhookId }
@@ -17,6 +17,7 @@ type AuditLog struct {
 	ItemName     string       `json:"itemName"`     // Item Name
 	BeforeChange string       `json:"beforeChange"` // Item value before change [Json]
 	AfterChange  string       `json:"afterChange"`  // Item delta after change [Json]
+	RequestId    string       `json:"requestId"`    // Correlation ID of the request that performed the action
 }
 
 func (a *AuditLog) TABLE() string { return "audit_log" }
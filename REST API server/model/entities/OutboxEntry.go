@@ -0,0 +1,30 @@
+package model
+
+import (
+	. "github.com/go-yaaf/yaaf-common/entity"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// OutboxEntry entity is an event saved in the same transaction as the entity change (transactional outbox), the entry
+// is relayed to the message bus or webhooks after the change was committed
+// @Entity: outbox
+type OutboxEntry struct {
+	BaseEntityEx
+	Topic       string           `json:"topic"`       // Event topic: entity.<item type> (entity change event) or domain.<event name> (domain event)
+	Payload     string           `json:"payload"`     // Event payload [Json]
+	Status      OutboxStatusCode `json:"status"`      // Relay status: UNDEFINED | PENDING | RETRY | SENT | FAILED
+	Attempts    int              `json:"attempts"`    // Number of relay attempts
+	NextAttempt Timestamp        `json:"nextAttempt"` // When the next attempt is due [Epoch milliseconds Timestamp]
+	Error       string           `json:"error"`       // Error of the last attempt
+	SentOn      Timestamp        `json:"sentOn"`      // When the entry was relayed [Epoch milliseconds Timestamp]
+	RequestId   string           `json:"requestId"`   // Correlation ID of the request that performed the change
+}
+
+func (a *OutboxEntry) TABLE() string { return "outbox" }
+func (a *OutboxEntry) NAME() string  { return a.Topic }
+func (a *OutboxEntry) KEY() string   { return "" }
+
+// NewOutboxEntry is a factory method to create new instance
+func NewOutboxEntry() Entity {
+	return &OutboxEntry{BaseEntityEx: BaseEntityEx{CreatedOn: Now(), UpdatedOn: Now(), Id: GUID(), Props: make(Json)}}
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// Webhook entity is a subscription of external receiver (URL) to the entity change events
+// @Entity: webhook
+type Webhook struct {
+	BaseEntityEx
+	AccountId string   `json:"accountId"` // Related account ID: only the changes of the account and its contacts are sent (empty for all)
+	Name      string   `json:"name"`      // Webhook name
+	Url       string   `json:"url"`       // Receiver URL, the events are posted as json payload (WebhookPayload)
+	Events    []string `json:"events"`    // Subscribed events: item type or item type and action (e.g. contact, account.Update), empty for all
+	Secret    string   `json:"secret"`    // Secret key of the payload HMAC signature (generated if empty, returned only on create)
+	Active    bool     `json:"active"`    // Active flag, inactive webhook is not notified
+}
+
+func (a *Webhook) TABLE() string { return "webhook" }
+func (a *Webhook) NAME() string  { return a.Name }
+func (a *Webhook) KEY() string   { return a.AccountId }
+
+// NewWebhook is a factory method to create new instance
+func NewWebhook() Entity {
+	return &Webhook{BaseEntityEx: BaseEntityEx{CreatedOn: Now(), UpdatedOn: Now(), Id: GUID(), Props: make(Json)}, Events: make([]string, 0)}
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	. "github.com/go-yaaf/yaaf-common/entity"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// WebhookDelivery entity is the delivery of entity change event to webhook (delivery history)
+// @Entity: webhook_delivery
+type WebhookDelivery struct {
+	BaseEntityEx
+	WebhookId   string             `json:"webhookId"`   // Related webhook ID
+	Event       string             `json:"event"`       // Event name: item type and action (e.g. contact.Create)
+	ItemId      string             `json:"itemId"`      // Changed item ID
+	Url         string             `json:"url"`         // Receiver URL (of the webhook when the event was published)
+	Payload     string             `json:"payload"`     // Posted payload [Json]
+	Status      DeliveryStatusCode `json:"status"`      // Delivery status: UNDEFINED | PENDING | RETRY | DELIVERED | FAILED
+	Attempts    int                `json:"attempts"`    // Number of delivery attempts
+	NextAttempt Timestamp          `json:"nextAttempt"` // When the next attempt is due [Epoch milliseconds Timestamp]
+	StatusCode  int                `json:"statusCode"`  // HTTP status of the last attempt (0 when there was no response)
+	Error       string             `json:"error"`       // Error of the last attempt
+	DeliveredOn Timestamp          `json:"deliveredOn"` // When the payload was delivered [Epoch milliseconds Timestamp]
+}
+
+func (a *WebhookDelivery) TABLE() string { return "webhook_delivery" }
+func (a *WebhookDelivery) NAME() string  { return a.Event }
+func (a *WebhookDelivery) KEY() string   { return a.WebhookId }
+
+// NewWebhookDelivery is a factory method to create new instance
+func NewWebhookDelivery() Entity {
+	return &WebhookDelivery{BaseEntityEx: BaseEntityEx{CreatedOn: Now(), UpdatedOn: Now(), Id: GUID(), Props: make(Json)}}
+}
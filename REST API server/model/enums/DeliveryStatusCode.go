@@ -0,0 +1,57 @@
+package model
+
+// DeliveryStatusCode represents the status of webhook delivery: PENDING | RETRY | DELIVERED | FAILED ...
+// @Enum
+type DeliveryStatusCode = int
+
+// List of webhook delivery status values
+// @EnumValuesFor: DeliveryStatusCode
+type deliveryStatusCode struct {
+	// Undefined [0]
+	UNDEFINED DeliveryStatusCode `value:"0"`
+
+	// Delivery is waiting for the first attempt [1]
+	PENDING DeliveryStatusCode `value:"1"`
+
+	// Delivery attempt failed, waiting for the next attempt [2]
+	RETRY DeliveryStatusCode `value:"2"`
+
+	// Payload was accepted by the receiver (2xx response) [3]
+	DELIVERED DeliveryStatusCode `value:"3"`
+
+	// All the delivery attempts failed (dead letter), can be redelivered manually [4]
+	FAILED DeliveryStatusCode `value:"4"`
+
+	IsValid func(int) bool
+	String  func(int) string
+}
+
+var DeliveryStatusCodes = &deliveryStatusCode{
+	UNDEFINED: 0, // Undefined [0]
+	PENDING:   1, // Delivery is waiting for the first attempt [1]
+	RETRY:     2, // Delivery attempt failed, waiting for the next attempt [2]
+	DELIVERED: 3, // Payload was accepted by the receiver (2xx response) [3]
+	FAILED:    4, // All the delivery attempts failed (dead letter), can be redelivered manually [4]
+	IsValid:   isValidDeliveryStatusCode,
+	String:    stringDeliveryStatusCode,
+}
+
+func isValidDeliveryStatusCode(code int) bool {
+	return code >= 0 && code <= 4
+}
+
+var deliveryStatusCodes = []string{
+	"UNDEFINED",
+	"PENDING",
+	"RETRY",
+	"DELIVERED",
+	"FAILED",
+}
+
+func stringDeliveryStatusCode(code int) string {
+	if isValidDeliveryStatusCode(code) {
+		return deliveryStatusCodes[code]
+	} else {
+		return "UNKNOWN"
+	}
+}
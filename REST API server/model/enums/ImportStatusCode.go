@@ -0,0 +1,62 @@
+package model
+
+// ImportStatusCode represents the status of a single row in data import: VALID | INVALID | DUPLICATE | IMPORTED ...
+// @Enum
+type ImportStatusCode = int
+
+// List of import status values
+// @EnumValuesFor: ImportStatusCode
+type importStatusCode struct {
+	// Undefined [0]
+	UNDEFINED ImportStatusCode `value:"0"`
+
+	// Row is valid and can be imported [1]
+	VALID ImportStatusCode `value:"1"`
+
+	// Row includes invalid data [2]
+	INVALID ImportStatusCode `value:"2"`
+
+	// Row is a duplicate of an existing item or of previous row in the file [3]
+	DUPLICATE ImportStatusCode `value:"3"`
+
+	// Row was imported [4]
+	IMPORTED ImportStatusCode `value:"4"`
+
+	// Row is valid but failed to be saved [5]
+	FAILED ImportStatusCode `value:"5"`
+
+	IsValid func(int) bool
+	String  func(int) string
+}
+
+var ImportStatusCodes = &importStatusCode{
+	UNDEFINED: 0, // Undefined [0]
+	VALID:     1, // Row is valid and can be imported [1]
+	INVALID:   2, // Row includes invalid data [2]
+	DUPLICATE: 3, // Row is a duplicate of an existing item or of previous row in the file [3]
+	IMPORTED:  4, // Row was imported [4]
+	FAILED:    5, // Row is valid but failed to be saved [5]
+	IsValid:   isValidImportStatusCode,
+	String:    stringImportStatusCode,
+}
+
+func isValidImportStatusCode(code int) bool {
+	return code >= 0 && code <= 5
+}
+
+var importStatusCodes = []string{
+	"UNDEFINED",
+	"VALID",
+	"INVALID",
+	"DUPLICATE",
+	"IMPORTED",
+	"FAILED",
+}
+
+func stringImportStatusCode(code int) string {
+	if isValidImportStatusCode(code) {
+		return importStatusCodes[code]
+	} else {
+		return "UNKNOWN"
+	}
+}
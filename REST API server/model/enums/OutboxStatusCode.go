@@ -0,0 +1,57 @@
+package model
+
+// OutboxStatusCode represents the status of outbox entry: PENDING | RETRY | SENT | FAILED ...
+// @Enum
+type OutboxStatusCode = int
+
+// List of outbox entry status values
+// @EnumValuesFor: OutboxStatusCode
+type outboxStatusCode struct {
+	// Undefined [0]
+	UNDEFINED OutboxStatusCode `value:"0"`
+
+	// Entry is waiting to be relayed [1]
+	PENDING OutboxStatusCode `value:"1"`
+
+	// Relay attempt failed, waiting for the next attempt [2]
+	RETRY OutboxStatusCode `value:"2"`
+
+	// Entry was relayed to the message bus or webhooks [3]
+	SENT OutboxStatusCode `value:"3"`
+
+	// All the relay attempts failed, can be retried manually [4]
+	FAILED OutboxStatusCode `value:"4"`
+
+	IsValid func(int) bool
+	String  func(int) string
+}
+
+var OutboxStatusCodes = &outboxStatusCode{
+	UNDEFINED: 0, // Undefined [0]
+	PENDING:   1, // Entry is waiting to be relayed [1]
+	RETRY:     2, // Relay attempt failed, waiting for the next attempt [2]
+	SENT:      3, // Entry was relayed to the message bus or webhooks [3]
+	FAILED:    4, // All the relay attempts failed, can be retried manually [4]
+	IsValid:   isValidOutboxStatusCode,
+	String:    stringOutboxStatusCode,
+}
+
+func isValidOutboxStatusCode(code int) bool {
+	return code >= 0 && code <= 4
+}
+
+var outboxStatusCodes = []string{
+	"UNDEFINED",
+	"PENDING",
+	"RETRY",
+	"SENT",
+	"FAILED",
+}
+
+func stringOutboxStatusCode(code int) string {
+	if isValidOutboxStatusCode(code) {
+		return outboxStatusCodes[code]
+	} else {
+		return "UNKNOWN"
+	}
+}
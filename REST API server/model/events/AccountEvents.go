@@ -0,0 +1,33 @@
+package model
+
+import (
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// AccountCreated is published when a new account is created
+type AccountCreated struct {
+	EventMeta
+	AccountId string          `json:"accountId"` // Account ID
+	Name      string          `json:"name"`      // Account name
+	Type      AccountTypeCode `json:"type"`      // Account type
+}
+
+func (e *AccountCreated) EventName() string { return "AccountCreated" }
+
+// AccountSuspended is published when the account status is changed to suspended (or the account is deleted)
+type AccountSuspended struct {
+	EventMeta
+	AccountId string `json:"accountId"` // Account ID
+	Name      string `json:"name"`      // Account name
+}
+
+func (e *AccountSuspended) EventName() string { return "AccountSuspended" }
+
+// AccountDeleted is published when the account is permanently deleted
+type AccountDeleted struct {
+	EventMeta
+	AccountId string `json:"accountId"` // Account ID
+	Name      string `json:"name"`      // Account name
+}
+
+func (e *AccountDeleted) EventName() string { return "AccountDeleted" }
@@ -0,0 +1,25 @@
+package model
+
+import (
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// DomainEvent is a business event published by the services to the message bus after the change was saved (e.g.
+// UserCreated, AccountSuspended), the event name is the message topic suffix
+type DomainEvent interface {
+	// EventName returns the event type name (callable on nil pointer to get the topic of the event type)
+	EventName() string
+
+	// Meta returns the event metadata
+	Meta() *EventMeta
+}
+
+// EventMeta is the metadata of all the domain events
+type EventMeta struct {
+	EventId   string    `json:"eventId"`   // Unique event ID (to dedupe the events)
+	SubjectId string    `json:"subjectId"` // Subject that performed the action
+	RequestId string    `json:"requestId"` // Correlation ID of the request that performed the action
+	Timestamp Timestamp `json:"timestamp"` // When the event was published [Epoch milliseconds Timestamp]
+}
+
+func (m *EventMeta) Meta() *EventMeta { return m }
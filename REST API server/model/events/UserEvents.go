@@ -0,0 +1,34 @@
+package model
+
+import (
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// UserCreated is published when a new user is created
+type UserCreated struct {
+	EventMeta
+	UserId string       `json:"userId"` // User ID
+	Email  string       `json:"email"`  // User email
+	Name   string       `json:"name"`   // User name
+	Type   UserTypeCode `json:"type"`   // User type
+}
+
+func (e *UserCreated) EventName() string { return "UserCreated" }
+
+// UserStatusChanged is published when the user status is changed (e.g. blocked)
+type UserStatusChanged struct {
+	EventMeta
+	UserId string         `json:"userId"` // User ID
+	From   UserStatusCode `json:"from"`   // Previous status
+	To     UserStatusCode `json:"to"`     // New status
+}
+
+func (e *UserStatusChanged) EventName() string { return "UserStatusChanged" }
+
+// UserDeleted is published when the user is deleted (flagged as deleted, the permanent delete is not published)
+type UserDeleted struct {
+	EventMeta
+	UserId string `json:"userId"` // User ID
+}
+
+func (e *UserDeleted) EventName() string { return "UserDeleted" }
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// Map of entity field (table.field) -> enum values structure
+var enumFields = make(map[string]any)
+
+// registerEnumField register the enum values structure of an entity field
+func registerEnumField(ef EntityFactory, field string, enum any) {
+	enumFields[ef().TABLE()+"."+field] = enum
+}
+
+// Set of the bit flags fields (table.field)
+var flagFields = make(map[string]bool)
+
+// registerFlagField register the flag values structure of an entity bit flags field (e.g. user roles)
+func registerFlagField(ef EntityFactory, field string, enum any) {
+	registerEnumField(ef, field, enum)
+	flagFields[ef().TABLE()+"."+field] = true
+}
+
+// IsFlagField returns true if the entity field (json path) is a bit flags field
+func IsFlagField(ef EntityFactory, field string) bool {
+	return flagFields[ef().TABLE()+"."+field]
+}
+
+// GetFieldEnum returns the enum values structure of the entity field (json path), or nil if the field is not an enum
+func GetFieldEnum(ef EntityFactory, field string) any {
+	if enum, exists := enumFields[ef().TABLE()+"."+field]; exists {
+		return enum
+	} else {
+		return nil
+	}
+}
+
+// EnumValueOf returns the int value of the enum by its name (case-insensitive)
+func EnumValueOf(enum any, name string) (int, bool) {
+	ref := reflect.Indirect(reflect.ValueOf(enum))
+	typ := ref.Type()
+	for i := 0; i < ref.NumField(); i++ {
+		if ref.Field(i).Kind() != reflect.Int {
+			continue
+		}
+		if strings.EqualFold(typ.Field(i).Name, name) {
+			return int(ref.Field(i).Int()), true
+		}
+	}
+	return 0, false
+}
+
+// EnumNameOf returns the name of the enum value, flags enums (e.g. UserRoleFlag) return the names of all the included flags
+// separated by | and unknown values are returned as numbers
+func EnumNameOf(enum any, value int) string {
+	ref := reflect.Indirect(reflect.ValueOf(enum))
+	typ := ref.Type()
+
+	flags := make([]string, 0)
+	for i := 0; i < ref.NumField(); i++ {
+		if ref.Field(i).Kind() != reflect.Int {
+			continue
+		}
+		v := int(ref.Field(i).Int())
+		if v == value {
+			return typ.Field(i).Name
+		}
+		if v != 0 && value&v == v {
+			flags = append(flags, typ.Field(i).Name)
+		}
+	}
+
+	if strings.HasSuffix(typ.Name(), "Flag") && len(flags) > 0 {
+		return strings.Join(flags, "|")
+	}
+	return strconv.Itoa(value)
+}
@@ -0,0 +1,117 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// GetEntityFields returns the json paths of all the entity fields, including fields of nested structures (e.g. address.city)
+func GetEntityFields(ef EntityFactory) []string {
+	return structFields(indirectType(reflect.TypeOf(ef())), "")
+}
+
+// GetFieldType returns the type of the entity field by its json path (e.g. address.city)
+func GetFieldType(ef EntityFactory, path string) (reflect.Type, error) {
+
+	typ := reflect.TypeOf(ef())
+	for _, name := range strings.Split(path, ".") {
+		typ = indirectType(typ)
+		switch typ.Kind() {
+		case reflect.Struct:
+			if field, ok := findJsonField(typ, name); ok {
+				typ = field.Type
+			} else {
+				return nil, fmt.Errorf("unknown field: %s", path)
+			}
+		case reflect.Map:
+			// Custom properties (Json) accept any key
+			typ = typ.Elem()
+		case reflect.Interface:
+			// Dynamic value, the rest of the path can't be verified
+			continue
+		default:
+			return nil, fmt.Errorf("unknown field: %s", path)
+		}
+	}
+	return typ, nil
+}
+
+// ValidateFields verifies that all the provided fields (json paths) are valid fields of the entity
+func ValidateFields(ef EntityFactory, fields ...string) error {
+	for _, field := range fields {
+		if _, err := GetFieldType(ef, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the list of json paths of the struct fields, nested structures are flattened using dot notation
+func structFields(typ reflect.Type, prefix string) (result []string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct {
+			result = append(result, structFields(indirectType(field.Type), prefix)...)
+			continue
+		}
+
+		name := jsonName(field)
+		if len(name) == 0 {
+			continue
+		}
+
+		if ft := indirectType(field.Type); ft.Kind() == reflect.Struct {
+			result = append(result, structFields(ft, prefix+name+".")...)
+		} else {
+			result = append(result, prefix+name)
+		}
+	}
+	return
+}
+
+// Find struct field by its json name (including fields of embedded structures)
+func findJsonField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct {
+			if f, ok := findJsonField(indirectType(field.Type), name); ok {
+				return f, true
+			}
+			continue
+		}
+		if jsonName(field) == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// Get the field json name, returns empty string for ignored fields
+func jsonName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); len(name) > 0 {
+		return name
+	}
+	return field.Name
+}
+
+// Get the underlying type of pointer
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ
+}
@@ -5,6 +5,7 @@ import (
 
 	. "github.com/go-yaaf/yaaf-common/entity"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
 )
 
 var EntityRepo map[string]EntityFactory = make(map[string]EntityFactory)
@@ -28,6 +29,18 @@ func init() {
 	registerEntity(NewAccount)
 	registerEntity(NewAuditLog)
 	registerEntity(NewContact)
+	registerEntity(NewOutboxEntry)
 	registerEntity(NewUser)
 	registerEntity(NewUsersGroup)
+	registerEntity(NewWebhook)
+	registerEntity(NewWebhookDelivery)
+
+	registerEnumField(NewAccount, "type", *AccountTypeCodes)
+	registerEnumField(NewAccount, "status", *AccountStatusCodes)
+	registerEnumField(NewAuditLog, "userType", *UserTypeCodes)
+	registerEnumField(NewUser, "type", *UserTypeCodes)
+	registerFlagField(NewUser, "roles", *UserRoleFlags)
+	registerEnumField(NewUser, "status", *UserStatusCodes)
+	registerEnumField(NewOutboxEntry, "status", *OutboxStatusCodes)
+	registerEnumField(NewWebhookDelivery, "status", *DeliveryStatusCodes)
 }
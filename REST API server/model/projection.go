@@ -0,0 +1,94 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+)
+
+// Projection is a partial representation of an entity which includes only a subset of its fields (sparse fieldset)
+// The fields are organized in the same structure of the original entity json
+type Projection Json
+
+func (p Projection) ID() string    { return p.getString("id") }
+func (p Projection) TABLE() string { return "" }
+func (p Projection) NAME() string  { return p.getString("name") }
+func (p Projection) KEY() string   { return "" }
+
+// NewProjection is a factory method to create an empty projection of an entity with the provided id
+func NewProjection(id string) Projection {
+	return Projection{"id": id}
+}
+
+// ProjectEntity creates a projection of the entity which includes only the provided fields (json paths e.g. address.city)
+// The entity id is always included, if no fields are provided, the entity is returned as is
+func ProjectEntity(ent Entity, fields []string) Entity {
+	if ent == nil || len(fields) == 0 {
+		return ent
+	}
+
+	doc := Json{}
+	if bytes, err := json.Marshal(ent); err == nil {
+		_ = json.Unmarshal(bytes, &doc)
+	}
+
+	result := NewProjection(ent.ID())
+	for _, field := range fields {
+		if value, ok := PathValue(doc, field); ok {
+			result.Set(field, value)
+		}
+	}
+	return result
+}
+
+// ProjectEntities creates a projection of each entity in the list
+func ProjectEntities(entities []Entity, fields []string) []Entity {
+	if len(fields) == 0 {
+		return entities
+	}
+	for i, ent := range entities {
+		entities[i] = ProjectEntity(ent, fields)
+	}
+	return entities
+}
+
+// Set the value of a field by its json path, nested objects are created as needed
+func (p Projection) Set(path string, value any) {
+	names := strings.Split(path, ".")
+	doc := map[string]any(p)
+	for _, name := range names[:len(names)-1] {
+		next, ok := doc[name].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			doc[name] = next
+		}
+		doc = next
+	}
+	doc[names[len(names)-1]] = value
+}
+
+// Get string value of a top level field
+func (p Projection) getString(field string) string {
+	if value, ok := p[field]; !ok || value == nil {
+		return ""
+	} else if str, isStr := value.(string); isStr {
+		return str
+	} else {
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// PathValue returns the value of a field in the json document by its json path (e.g. address.city)
+func PathValue(doc map[string]any, path string) (any, bool) {
+	var value any = doc
+	for _, name := range strings.Split(path, ".") {
+		if m, ok := value.(map[string]any); !ok {
+			return nil, false
+		} else if value, ok = m[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,105 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/logger"
+)
+
+// Key of the authenticated subject ID in the gin context (set by the token validator)
+const SubjectIdKey = "subjectId"
+
+// region Access log configuration -------------------------------------------------------------------------------------
+
+// AccessLogConfig configures the access log middleware
+type AccessLogConfig struct {
+	SkipPaths     []string      // Route templates (or paths of unmatched routes) excluded from the access log (e.g. health check)
+	SlowThreshold time.Duration // Requests slower than the threshold are logged as warning (including skipped paths), zero to disable
+}
+
+// endregion
+
+// region Access log middleware ----------------------------------------------------------------------------------------
+
+// Write access log entry of each request through the service logger (text or json format), the entry is a list of
+// key=value fields: method, route template, path, status, latency, bytes, subject, remote IP and request ID
+// Server errors are logged as error, client errors and slow requests as warning and the rest as info. Requests to skipped
+// paths are logged only when they fail or exceed the slow threshold
+func accessLog(cfg AccessLogConfig) gin.HandlerFunc {
+	skip := make(map[string]bool, len(cfg.SkipPaths))
+	for _, path := range cfg.SkipPaths {
+		skip[path] = true
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		c.Next()
+
+		latency := time.Since(start)
+		status := c.Writer.Status()
+		route := c.FullPath()
+		slow := cfg.SlowThreshold > 0 && latency >= cfg.SlowThreshold
+
+		if (skip[route] || (len(route) == 0 && skip[path])) && !slow && status < http.StatusBadRequest {
+			return
+		}
+
+		entry := accessLogEntry{}
+		entry.add("method", c.Request.Method)
+		entry.add("route", route)
+		entry.add("path", path)
+		entry.add("status", strconv.Itoa(status))
+		entry.add("latency", strconv.FormatFloat(float64(latency.Microseconds())/1000, 'f', 3, 64)+"ms")
+		entry.add("bytes", strconv.Itoa(max(c.Writer.Size(), 0)))
+		entry.add("subject", c.GetString(SubjectIdKey))
+		entry.add("ip", c.ClientIP())
+		entry.add("requestId", GetRequestId(c))
+		if slow {
+			entry.add("slow", "true")
+		}
+		if len(c.Errors) > 0 {
+			entry.add("error", c.Errors.Last().Error())
+		}
+
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.Error("%s", entry.String())
+		case status >= http.StatusBadRequest || slow:
+			logger.Warn("%s", entry.String())
+		default:
+			logger.Info("%s", entry.String())
+		}
+	}
+}
+
+// Access log entry fields (key=value, values with spaces or quotes are quoted, empty values are omitted)
+type accessLogEntry struct {
+	sb strings.Builder
+}
+
+// Add field to the entry
+func (e *accessLogEntry) add(key, value string) {
+	if len(value) == 0 {
+		return
+	}
+	if e.sb.Len() == 0 {
+		e.sb.WriteString("access")
+	}
+	if strings.ContainsAny(value, " \"=") {
+		value = strconv.Quote(value)
+	}
+	e.sb.WriteString(fmt.Sprintf(" %s=%s", key, value))
+}
+
+// String returns the access log line
+func (e *accessLogEntry) String() string {
+	return e.sb.String()
+}
+
+// endregion
@@ -0,0 +1,147 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/rest"
+)
+
+// Version prefix of versioned REST path (e.g. /v2/users)
+var versionPrefixRegex = regexp.MustCompile(`^/v[0-9]+/`)
+
+// RequestTransformer is a version specific transformation of the request (e.g. rename legacy query parameters)
+// returning error aborts the request with bad request status
+type RequestTransformer func(c *gin.Context) error
+
+// ResponseTransformer is a version specific transformation of the json response body (e.g. restore legacy fields)
+// the body is decoded with json numbers, the returned value is encoded as the response body
+type ResponseTransformer func(c *gin.Context, body any) any
+
+// region ApiVersion structure and factory method ----------------------------------------------------------------------
+
+// ApiVersion is a version of the REST API: the version endpoints are registered under the version prefix (e.g. /v2/users)
+// the same endpoints (and services) can be registered in multiple versions
+type ApiVersion struct {
+	Name       string    // Version name, used as the path prefix (e.g. v2)
+	Deprecated time.Time // When the version was deprecated (zero for active version)
+	Sunset     time.Time // When the version is going to be removed (zero if not scheduled)
+	Link       string    // Link to the migration guide (for deprecated version)
+
+	requestTransformers  []RequestTransformer
+	responseTransformers []ResponseTransformer
+}
+
+// NewApiVersion factory method
+func NewApiVersion(name string) *ApiVersion {
+	return &ApiVersion{Name: name}
+}
+
+// Deprecate marks the version as deprecated since the provided time, sunset and link are optional
+func (v *ApiVersion) Deprecate(since, sunset time.Time, link string) *ApiVersion {
+	v.Deprecated = since
+	v.Sunset = sunset
+	v.Link = link
+	return v
+}
+
+// AddRequestTransformer adds version specific request transformer
+func (v *ApiVersion) AddRequestTransformer(t RequestTransformer) *ApiVersion {
+	v.requestTransformers = append(v.requestTransformers, t)
+	return v
+}
+
+// AddResponseTransformer adds version specific json response transformer
+func (v *ApiVersion) AddResponseTransformer(t ResponseTransformer) *ApiVersion {
+	v.responseTransformers = append(v.responseTransformers, t)
+	return v
+}
+
+// IsDeprecated returns true if the version is deprecated
+func (v *ApiVersion) IsDeprecated() bool {
+	return !v.Deprecated.IsZero() && !time.Now().Before(v.Deprecated)
+}
+
+// Prefix returns the version path prefix (e.g. /v2)
+func (v *ApiVersion) Prefix() string {
+	return "/" + v.Name
+}
+
+// endregion
+
+// region ApiVersion middleware ----------------------------------------------------------------------------------------
+
+// Middleware of the version routes: adds the version headers (X-API-VERSION, Deprecation, Sunset, Link) and applies the
+// version request and response transformers
+func (v *ApiVersion) middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		c.Header("X-API-VERSION", v.Name)
+		if v.IsDeprecated() {
+			// Deprecation header (RFC 9745) and Sunset header (RFC 8594)
+			c.Header("Deprecation", fmt.Sprintf("@%d", v.Deprecated.Unix()))
+			if !v.Sunset.IsZero() {
+				c.Header("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if len(v.Link) > 0 {
+				c.Header("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, v.Link))
+			}
+		}
+
+		for _, t := range v.requestTransformers {
+			if err := t(c); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+				return
+			}
+		}
+
+		if len(v.responseTransformers) == 0 {
+			c.Next()
+			return
+		}
+
+		bw := newBufferedWriter(c.Writer, isJsonResponse)
+		c.Writer = bw
+		c.Next()
+		c.Writer = bw.ResponseWriter
+		if bw.Buffered() {
+			bw.Commit(transformJson(c, bw.Body(), v.responseTransformers))
+		}
+	}
+}
+
+// Apply the response transformers on json content, invalid json is returned as is
+func transformJson(c *gin.Context, content []byte, transformers []ResponseTransformer) []byte {
+	if len(content) == 0 {
+		return content
+	}
+
+	var body any
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return content
+	}
+	for _, t := range transformers {
+		body = t(c, body)
+	}
+	if transformed, err := json.Marshal(body); err == nil {
+		return transformed
+	}
+	return content
+}
+
+// Strip the version prefix of the path (e.g. /v2/users -> /users)
+func stripVersion(path string) string {
+	if loc := versionPrefixRegex.FindStringIndex(path); loc != nil {
+		return path[loc[1]-1:]
+	}
+	return path
+}
+
+// endregion
@@ -15,7 +15,11 @@ import (
 type RestEntry struct {
 	Path, // Rest method path
 	Method string // HTTP method verb
-	Handler gin.HandlerFunc // Handler function
+	Handler        gin.HandlerFunc // Handler function
+	MaxBodySize    int64           // Maximum request body size in bytes (zero for the server default, negative for no limit)
+	Timeout        time.Duration   // Handler deadline (zero for the server default, negative for no deadline)
+	IdempotencyKey bool            // Support Idempotency-Key header (replay the first response of retried create and bulk requests)
+	RateLimit      bool            // Apply the rate limits to unversioned route (the API version routes are always limited)
 }
 
 // RestEndpoint is a group of RestEntry
@@ -26,7 +30,8 @@ type RestEndpoint interface {
 
 type BaseEndPoint struct{}
 
-// GetTokenData extract security token data from Authorization header
+// GetTokenData extract security token data from Authorization header, the token data includes the request correlation ID
+// and the request context (trace span)
 func (b *BaseEndPoint) GetTokenData(c *gin.Context) *mc.TokenData {
 
 	token := c.GetHeader("X-ACCESS-TOKEN")
@@ -34,10 +39,18 @@ func (b *BaseEndPoint) GetTokenData(c *gin.Context) *mc.TokenData {
 		_ = c.AbortWithError(http.StatusForbidden, fmt.Errorf("invalid access token"))
 		return nil
 	} else {
-		return td
+		td.RequestId = GetRequestId(c)
+		return td.WithContext(c.Request.Context())
 	}
 }
 
+// GetAnonymousTokenData returns token data of unauthenticated request (e.g. login) with the request correlation ID and
+// the request context only
+func (b *BaseEndPoint) GetAnonymousTokenData(c *gin.Context) *mc.TokenData {
+	td := &mc.TokenData{RequestId: GetRequestId(c)}
+	return td.WithContext(c.Request.Context())
+}
+
 // GetTimezoneOffset returns the value of timezone offset header in minutes
 func (b *BaseEndPoint) GetTimezoneOffset(c *gin.Context) int {
 
@@ -0,0 +1,334 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/logger"
+	"github.com/go-yaaf/yaaf-common/rest"
+	"github.com/xuri/excelize/v2"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	meta "github.com/go-yaaf/yaaf-examples/rest-api/model"
+)
+
+// EntityCallback is called for each entity of the exported query
+type EntityCallback = func(Entity) error
+
+// EntityIterator passes all the entities of a query to the callback (see the Export method of the services)
+type EntityIterator func(cb EntityCallback) error
+
+// Export file formats and their content types
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"ndjson": "application/x-ndjson",
+	"xlsx":   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+var timestampType = reflect.TypeOf(Timestamp(0))
+
+// ExportTimeout is the deadline of the export routes (streaming of large result set, longer than the default timeout)
+const ExportTimeout = 10 * time.Minute
+
+// region Export -------------------------------------------------------------------------------------------------------
+
+// Export streams all the entities provided by the iterator to the response as a file in the format provided by the
+// format query parameter (csv | ndjson | xlsx). The columns are the provided fields (or all the entity fields),
+// enum fields are exported by name and timestamps as ISO dates in the client timezone (X-TIMEZONE-OFFSET header, UTC
+// by default). Spreadsheet cells starting with formula characters are escaped (the data may come from imported files)
+func (b *BaseEndPoint) Export(c *gin.Context, name string, ef EntityFactory, fields []string, iterate EntityIterator) {
+
+	format := strings.ToLower(b.GetParamAsString(c, "format", "csv"))
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(fmt.Errorf("unsupported export format: %s (use csv, ndjson or xlsx)", format)))
+		return
+	}
+
+	if len(fields) == 0 {
+		fields = meta.GetEntityFields(ef)
+	}
+	columns := make([]exportColumn, 0, len(fields))
+	for _, field := range fields {
+		typ, _ := meta.GetFieldType(ef, field)
+		columns = append(columns, exportColumn{path: field, enum: meta.GetFieldEnum(ef, field), timestamp: typ == timestampType})
+	}
+
+	fileName := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+	c.Header("Content-Filename", fileName)
+	c.Status(http.StatusOK)
+
+	writer, err := newExportWriter(format, c.Writer, fields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+		return
+	}
+
+	loc := b.GetTimezone(c)
+	err = iterate(func(ent Entity) error {
+		doc := entityDocument(ent)
+		values := make([]any, len(columns))
+		for i, col := range columns {
+			value, _ := meta.PathValue(doc, col.path)
+			values[i] = col.format(value, loc)
+		}
+		return writer.write(values)
+	})
+
+	// Once the stream has started the status can't be changed, the error is logged and the file is truncated
+	if err != nil {
+		writer.abort()
+		logger.Error("[%s] export %s failed: %s", GetRequestId(c), name, err.Error())
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Filename")
+			c.Writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+			c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+		}
+		return
+	}
+	if err = writer.close(); err != nil {
+		logger.Error("[%s] export %s failed: %s", GetRequestId(c), name, err.Error())
+	}
+}
+
+// GetTimezone returns the client timezone based on the X-TIMEZONE-OFFSET header, the header is the client offset in
+// minutes as returned by javascript Date.getTimezoneOffset(): UTC minus local time (e.g. -120 for UTC+2), so the zone
+// offset (east of UTC) is its negation. Returns UTC if the header is missing or invalid
+func (b *BaseEndPoint) GetTimezone(c *gin.Context) *time.Location {
+	clientOffset, err := strconv.Atoi(c.GetHeader("X-TIMEZONE-OFFSET"))
+	if err != nil {
+		return time.UTC
+	}
+	return time.FixedZone("", -clientOffset*60)
+}
+
+// Convert the entity to json document, numbers are kept as json.Number to avoid precision loss
+func entityDocument(ent Entity) map[string]any {
+	doc := make(map[string]any)
+	if data, err := json.Marshal(ent); err == nil {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		_ = decoder.Decode(&doc)
+	}
+	return doc
+}
+
+// endregion
+
+// region Export columns -----------------------------------------------------------------------------------------------
+
+// exportColumn is a single exported field (json path)
+type exportColumn struct {
+	path      string // Field json path
+	enum      any    // Enum values structure (for enum fields)
+	timestamp bool   // Timestamp field flag
+}
+
+// Format the field value: enums by name and timestamps as ISO dates
+func (col exportColumn) format(value any, loc *time.Location) any {
+	if value == nil {
+		return ""
+	}
+
+	if n, ok := value.(json.Number); ok {
+		v, err := n.Int64()
+		switch {
+		case err != nil:
+			return n
+		case col.enum != nil:
+			return meta.EnumNameOf(col.enum, int(v))
+		case col.timestamp && v == 0:
+			return ""
+		case col.timestamp:
+			return Timestamp(v).Time().In(loc).Format(time.RFC3339)
+		}
+		return n
+	}
+	return value
+}
+
+// Get the value of a spreadsheet cell (csv, xlsx), nested objects and arrays are written as json and text starting with
+// formula characters is prefixed by ' (formula injection)
+func cellValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any, []any:
+		data, _ := json.Marshal(value)
+		return string(data)
+	case string:
+		if len(v) > 0 && strings.ContainsRune(formulaChars, rune(v[0])) {
+			return "'" + v
+		}
+	}
+	return value
+}
+
+// Leading characters of spreadsheet formulas
+const formulaChars = "=+-@\t\r"
+
+// endregion
+
+// region Export writers -----------------------------------------------------------------------------------------------
+
+// exportWriter writes the exported rows in a specific file format
+type exportWriter interface {
+	write(values []any) error // Write a single row
+	close() error             // Complete the file
+	abort()                   // Release the resources of incomplete file
+}
+
+// Create export writer for the format, the writer starts with the column headers (if relevant)
+func newExportWriter(format string, w gin.ResponseWriter, fields []string) (exportWriter, error) {
+	switch format {
+	case "ndjson":
+		return &ndjsonWriter{w: w, encoder: json.NewEncoder(w), fields: fields}, nil
+	case "xlsx":
+		return newXlsxWriter(w, fields)
+	default:
+		return newCsvWriter(w, fields)
+	}
+}
+
+// Number of rows to write before flushing the response stream
+const exportFlushRows = 1000
+
+// csvWriter writes comma separated values with header row
+type csvWriter struct {
+	w     gin.ResponseWriter
+	csv   *csv.Writer
+	count int
+}
+
+func newCsvWriter(w gin.ResponseWriter, fields []string) (exportWriter, error) {
+	cw := &csvWriter{w: w, csv: csv.NewWriter(w)}
+	return cw, cw.csv.Write(fields)
+}
+
+func (cw *csvWriter) write(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = fmt.Sprintf("%v", cellValue(value))
+	}
+	if err := cw.csv.Write(record); err != nil {
+		return err
+	}
+	if cw.count++; cw.count%exportFlushRows == 0 {
+		cw.csv.Flush()
+		cw.w.Flush()
+	}
+	return cw.csv.Error()
+}
+
+func (cw *csvWriter) close() error {
+	cw.csv.Flush()
+	cw.w.Flush()
+	return cw.csv.Error()
+}
+
+// Nothing to release, the buffered rows are dropped (truncated file)
+func (cw *csvWriter) abort() {}
+
+// ndjsonWriter writes json document per line, nested fields are written as nested objects
+type ndjsonWriter struct {
+	w       gin.ResponseWriter
+	encoder *json.Encoder
+	fields  []string
+	count   int
+}
+
+func (nw *ndjsonWriter) write(values []any) error {
+	doc := meta.Projection{}
+	for i, field := range nw.fields {
+		doc.Set(field, values[i])
+	}
+	if err := nw.encoder.Encode(doc); err != nil {
+		return err
+	}
+	if nw.count++; nw.count%exportFlushRows == 0 {
+		nw.w.Flush()
+	}
+	return nil
+}
+
+func (nw *ndjsonWriter) close() error {
+	nw.w.Flush()
+	return nil
+}
+
+// Nothing to release, the buffered rows are dropped (truncated file)
+func (nw *ndjsonWriter) abort() {}
+
+// xlsxWriter writes spreadsheet using the excelize stream writer, rows are buffered by excelize in a temporary file
+// (and not in memory) and the zipped workbook is written to the response when the export is completed
+type xlsxWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXlsxWriter(w io.Writer, fields []string) (exportWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	xw := &xlsxWriter{w: w, file: file, stream: stream}
+	header := make([]any, len(fields))
+	for i, field := range fields {
+		header[i] = field
+	}
+	if err = xw.write(header); err != nil {
+		xw.abort()
+		return nil, err
+	}
+	return xw, nil
+}
+
+func (xw *xlsxWriter) write(values []any) error {
+	xw.row++
+	for i, value := range values {
+		if n, ok := value.(json.Number); ok {
+			if f, err := n.Float64(); err == nil {
+				values[i] = f
+			}
+		} else {
+			values[i] = cellValue(value)
+		}
+	}
+	cell, err := excelize.CoordinatesToCellName(1, xw.row)
+	if err != nil {
+		return err
+	}
+	return xw.stream.SetRow(cell, values)
+}
+
+func (xw *xlsxWriter) close() error {
+	defer func() { _ = xw.file.Close() }()
+	if err := xw.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := xw.file.WriteTo(xw.w)
+	return err
+}
+
+// The workbook is not written, the temporary files are removed
+func (xw *xlsxWriter) abort() {
+	_ = xw.file.Close()
+}
+
+// endregion
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+)
+
+// Export the contacts by the format query parameter and the headers
+func exportContacts(contacts []*Contact, query string, header http.Header, failAfter int) *httptest.ResponseRecorder {
+	engine := gin.New()
+	engine.GET("/export", func(c *gin.Context) {
+		b := &BaseEndPoint{}
+		b.Export(c, "contacts", NewContact, []string{"id", "name", "createdOn"}, func(cb EntityCallback) error {
+			for i, contact := range contacts {
+				if i == failAfter {
+					return errors.New("database failed")
+				}
+				if err := cb(contact); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	req := httptest.NewRequest(http.MethodGet, "/export?"+query, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestExportEscapesFormulas(t *testing.T) {
+	contacts := []*Contact{
+		{BaseEntityEx: BaseEntityEx{Id: "c1", CreatedOn: 1700000000000}, Name: "=HYPERLINK(\"http://evil\")"},
+		{BaseEntityEx: BaseEntityEx{Id: "c2"}, Name: "@SUM(A1)"},
+		{BaseEntityEx: BaseEntityEx{Id: "c3"}, Name: "-2+3"},
+		{BaseEntityEx: BaseEntityEx{Id: "c4"}, Name: "John"},
+	}
+	w := exportContacts(contacts, "format=csv", nil, -1)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 but got %d", w.Code)
+	}
+
+	expected := strings.Join([]string{
+		"id,name,createdOn",
+		`c1,"'=HYPERLINK(""http://evil"")",2023-11-14T22:13:20Z`,
+		"c2,'@SUM(A1),",
+		"c3,'-2+3,",
+		"c4,John,",
+		"",
+	}, "\n")
+	if body := w.Body.String(); body != expected {
+		t.Errorf("unexpected csv:\n%s", body)
+	}
+
+	// Timestamps in the client timezone (UTC+2)
+	w = exportContacts(contacts[:1], "format=csv", http.Header{"X-Timezone-Offset": {"-120"}}, -1)
+	if !strings.Contains(w.Body.String(), "2023-11-15T00:13:20+02:00") {
+		t.Errorf("expected timestamp in the client timezone:\n%s", w.Body.String())
+	}
+}
+
+func TestExportFailure(t *testing.T) {
+	contacts := []*Contact{{BaseEntityEx: BaseEntityEx{Id: "c1"}, Name: "John"}}
+
+	// Failure before any row is flushed returns an error (the xlsx workbook is discarded)
+	for _, format := range []string{"csv", "ndjson", "xlsx"} {
+		w := exportContacts(contacts, "format="+format, nil, 0)
+		if w.Code != http.StatusInternalServerError || strings.HasPrefix(w.Body.String(), "PK") {
+			t.Errorf("%s: expected error response but got %d: %q", format, w.Code, w.Body.String())
+		}
+	}
+}
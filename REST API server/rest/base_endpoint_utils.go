@@ -9,6 +9,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-yaaf/yaaf-common/entity"
 	"github.com/go-yaaf/yaaf-common/utils/collections"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/filter"
+	meta "github.com/go-yaaf/yaaf-examples/rest-api/model"
 )
 
 // ResolveRemoteIp extract remote ip from HTTP header X-Forwarded-For
@@ -84,6 +87,25 @@ func (b *BaseEndPoint) GetParamAsStringArray(c *gin.Context, paramName string) (
 	return
 }
 
+// GetParamAsFields extract sparse fieldset (list of field json paths) from query string and validate it against the entity fields
+// This supports multiple values query string e.g. https//some/domain?fields=id,name,address.city
+func (b *BaseEndPoint) GetParamAsFields(c *gin.Context, paramName string, ef entity.EntityFactory) ([]string, error) {
+	fields := b.GetParamAsStringArray(c, paramName)
+	for i, field := range fields {
+		fields[i] = strings.Trim(field, " ")
+	}
+	if err := meta.ValidateFields(ef, fields...); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// GetParamAsFilter extract filter expression from query string, parse and validate it against the entity fields
+// e.g. https//some/domain?filter=status in (ACTIVE,PENDING) and (address.city = 'London' or name like 'john*')
+func (b *BaseEndPoint) GetParamAsFilter(c *gin.Context, paramName string, ef entity.EntityFactory) (*filter.Criteria, error) {
+	return filter.Parse(ef, b.GetParamAsString(c, paramName, ""))
+}
+
 // GetParamAsIntArray extract parameter array values from query string
 // This supports multiple values query string e.g. https//some/domain?id=1&id=2&id=3
 func (b *BaseEndPoint) GetParamAsIntArray(c *gin.Context, paramName string) (res []int) {
@@ -0,0 +1,112 @@
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bufferedWriter buffers the response body when the capture condition is met on the first write (e.g. json response)
+// so the middleware can modify it after the handler is done, other content (e.g. export files) is written through as is
+type bufferedWriter struct {
+	gin.ResponseWriter
+	capture     func(status int, header http.Header) bool
+	buffer      bytes.Buffer
+	status      int
+	decided     bool
+	passThrough bool
+}
+
+// Create buffered writer wrapping the response writer with the capture condition
+func newBufferedWriter(w gin.ResponseWriter, capture func(status int, header http.Header) bool) *bufferedWriter {
+	return &bufferedWriter{ResponseWriter: w, capture: capture, status: w.Status()}
+}
+
+// Check if the response is json
+func isJsonResponse(_ int, header http.Header) bool {
+	return strings.Contains(header.Get("Content-Type"), "json")
+}
+
+// Unwrap returns the underlying writer (http.ResponseController support, e.g. write deadline)
+func (w *bufferedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if w.passThrough {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	if w.passThrough {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *bufferedWriter) Status() int {
+	if w.passThrough {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.decided
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.decided = true
+		w.passThrough = !w.capture(w.status, w.Header())
+		if w.passThrough {
+			w.ResponseWriter.WriteHeader(w.status)
+		}
+	}
+	if w.passThrough {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.buffer.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Buffered returns true if the response was not written through (buffered or no content), and should be committed
+func (w *bufferedWriter) Buffered() bool {
+	return !w.passThrough
+}
+
+// Body returns the buffered response body
+func (w *bufferedWriter) Body() []byte {
+	return w.buffer.Bytes()
+}
+
+func (w *bufferedWriter) Flush() {
+	if w.passThrough {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// Hijack takes over the connection (e.g. WebSocket upgrade), the response is written through
+func (w *bufferedWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided, w.passThrough = true, true
+	return w.ResponseWriter.Hijack()
+}
+
+// Commit writes the status and the provided content (modified buffered body) to the underlying writer
+func (w *bufferedWriter) Commit(content []byte) {
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+	if len(content) > 0 {
+		_, _ = w.ResponseWriter.Write(content)
+	}
+}
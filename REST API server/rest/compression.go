@@ -0,0 +1,258 @@
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Supported content encodings (by server preference)
+const (
+	EncodingZstd   = "zstd"
+	EncodingBrotli = "br"
+	EncodingGzip   = "gzip"
+)
+
+// Brotli compression level of dynamic content (the default level is too slow for on-the-fly compression)
+const brotliLevel = 5
+
+// CompressionConfig is the configuration of the response compression middleware
+type CompressionConfig struct {
+	Enabled bool // Enable response compression
+	MinSize int  // Minimal response size in bytes to compress (smaller responses are sent as is)
+}
+
+// Stream encoder of content encoding, the encoders are pooled and reset for each response
+type streamEncoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// Encoders pool by content encoding
+var encoders = map[string]*sync.Pool{
+	EncodingZstd: {New: func() any {
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithLowerEncoderMem(true))
+		return enc
+	}},
+	EncodingBrotli: {New: func() any {
+		return brotli.NewWriterLevel(nil, brotliLevel)
+	}},
+	EncodingGzip: {New: func() any {
+		return gzip.NewWriter(nil)
+	}},
+}
+
+// region Compression middleware ---------------------------------------------------------------------------------------
+
+// Compress the response by the encoding negotiated with the Accept-Encoding header (zstd, br, gzip), only compressible
+// content types larger than the minimal size are compressed, streamed responses (e.g. export) are compressed on flush
+func compression(cfg CompressionConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cfg.Enabled || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		encoding := negotiate(c.GetHeader("Accept-Encoding"), EncodingZstd, EncodingBrotli, EncodingGzip)
+		if len(encoding) == 0 {
+			c.Next()
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: c.Writer, encoding: encoding, minSize: cfg.MinSize, status: c.Writer.Status()}
+		c.Writer = cw
+		c.Next()
+		c.Writer = cw.ResponseWriter
+		cw.close()
+	}
+}
+
+// endregion
+
+// region Compression writer -------------------------------------------------------------------------------------------
+
+// compressWriter buffers the response up to the minimal size, then decides whether to compress it (by content type and
+// size), the compressed content is streamed to the underlying writer
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minSize  int
+	buffer   bytes.Buffer
+	status   int
+	checked  bool // The response was checked for compression (on first write or flush)
+	eligible bool // The response is compressible (status, content type and content encoding)
+	decided  bool // The header was written (compressed or as is)
+	encoder  streamEncoder
+}
+
+// Unwrap returns the underlying writer (http.ResponseController support, e.g. write deadline)
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+// The header is written when the compression is decided (first flush, minimal size reached or end of response)
+func (w *compressWriter) WriteHeaderNow() {
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *compressWriter) Status() int {
+	if w.decided {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *compressWriter) Written() bool {
+	return w.decided || w.buffer.Len() > 0
+}
+
+func (w *compressWriter) Size() int {
+	if w.decided {
+		return w.ResponseWriter.Size()
+	}
+	return -1
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		if !w.compressible() {
+			w.decide(false)
+		} else if w.buffer.Write(data); w.buffer.Len() >= w.minSize {
+			w.decide(true)
+			return len(data), nil
+		} else {
+			return len(data), nil
+		}
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(w.compressible())
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Hijack takes over the connection (e.g. WebSocket upgrade), the response is not compressed
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	return w.ResponseWriter.Hijack()
+}
+
+// Check if the response can be compressed by status, content type and existing content encoding (checked once, when
+// the handler starts writing the body)
+func (w *compressWriter) compressible() bool {
+	if w.checked {
+		return w.eligible
+	}
+	w.checked = true
+
+	switch {
+	case w.status < http.StatusOK, w.status == http.StatusNoContent, w.status == http.StatusPartialContent,
+		w.status == http.StatusNotModified:
+		return false
+	}
+
+	header := w.Header()
+	if len(header.Get("Content-Encoding")) > 0 || len(header.Get("Content-Range")) > 0 {
+		return false
+	}
+	if w.eligible = isCompressibleType(header.Get("Content-Type")); w.eligible {
+		header.Add("Vary", "Accept-Encoding")
+	}
+	return w.eligible
+}
+
+// Write the header and the buffered content, compressed or as is
+func (w *compressWriter) decide(compress bool) {
+	w.decided = true
+	if compress {
+		w.Header().Del("Content-Length")
+		w.Header().Set("Content-Encoding", w.encoding)
+		w.encoder = encoders[w.encoding].Get().(streamEncoder)
+		w.encoder.Reset(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buffer.Len() == 0 {
+		return
+	}
+	if w.encoder != nil {
+		_, _ = w.encoder.Write(w.buffer.Bytes())
+	} else {
+		_, _ = w.ResponseWriter.Write(w.buffer.Bytes())
+	}
+	w.buffer.Reset()
+}
+
+// Complete the response: write the buffered content (smaller than the minimal size) or close the encoder
+func (w *compressWriter) close() {
+	if !w.decided {
+		// Small response or no content
+		w.decide(false)
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Close()
+		w.encoder.Reset(nil)
+		encoders[w.encoding].Put(w.encoder)
+		w.encoder = nil
+	}
+}
+
+// Check if the content type is compressible (text, json, xml, javascript, msgpack, cbor, svg)
+func isCompressibleType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case strings.HasSuffix(mediaType, "json"), strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+
+	switch mediaType {
+	case "application/javascript", "application/xml", "image/svg+xml", MimeMsgpack, MimeCbor:
+		return true
+	default:
+		return false
+	}
+}
+
+// endregion
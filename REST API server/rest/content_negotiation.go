@@ -0,0 +1,183 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/rest"
+	"github.com/ugorji/go/codec"
+)
+
+// Supported media types of request and response bodies (json is the default)
+const (
+	MimeJson    = "application/json"
+	MimeMsgpack = "application/msgpack"
+	MimeCbor    = "application/cbor"
+)
+
+// Binary encodings handles by media type (including the common msgpack aliases), the handles are configured once
+// and safe for concurrent use
+var binaryHandles = map[string]codec.Handle{
+	MimeMsgpack:               newMsgpackHandle(),
+	"application/x-msgpack":   newMsgpackHandle(),
+	"application/vnd.msgpack": newMsgpackHandle(),
+	MimeCbor:                  newCborHandle(),
+}
+
+// Response media types offered to the Accept header (by server preference)
+var responseOffers = []string{MimeJson, MimeMsgpack, "application/x-msgpack", "application/vnd.msgpack", MimeCbor}
+
+// Create MessagePack handle (new spec: str8 and bin types), maps are decoded with string keys (json compatible)
+func newMsgpackHandle() codec.Handle {
+	h := &codec.MsgpackHandle{WriteExt: true}
+	h.RawToString = true
+	h.MapType = reflect.TypeOf(map[string]any(nil))
+	return h
+}
+
+// Create CBOR handle, maps are decoded with string keys (json compatible)
+func newCborHandle() codec.Handle {
+	h := &codec.CborHandle{}
+	h.MapType = reflect.TypeOf(map[string]any(nil))
+	return h
+}
+
+// region Content negotiation middlewares ------------------------------------------------------------------------------
+
+// Convert json response to the binary encoding negotiated with the Accept header (MessagePack, CBOR), including error
+// responses, so the endpoints produce json only. The middleware precedes the request ID middleware to encode the final
+// error response (with the request ID)
+func encodeResponse() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept")
+
+		accept := c.GetHeader("Accept")
+		if len(accept) == 0 {
+			c.Next()
+			return
+		}
+		mediaType := negotiate(accept, responseOffers...)
+		handle, binary := binaryHandles[mediaType]
+		if !binary {
+			c.Next()
+			return
+		}
+
+		bw := newBufferedWriter(c.Writer, isJsonContent)
+		c.Writer = bw
+		c.Next()
+		c.Writer = bw.ResponseWriter
+		if !bw.Buffered() {
+			return
+		}
+		if len(bw.Body()) == 0 {
+			bw.Commit(nil)
+			return
+		}
+
+		content, err := jsonToBinary(bw.Body(), handle)
+		if err != nil {
+			// Send the json response as is
+			bw.Commit(bw.Body())
+			return
+		}
+		bw.Header().Set("Content-Type", mediaType)
+		bw.Commit(content)
+	}
+}
+
+// Convert request body in binary encoding (MessagePack, CBOR, by the Content-Type header) to json, so the endpoints
+// bind json only, invalid body aborts the request with bad request status
+func decodeRequest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			return
+		}
+		mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		if err != nil {
+			return
+		}
+		handle, binary := binaryHandles[mediaType]
+		if !binary {
+			return
+		}
+
+		content, err := binaryToJson(c.Request.Body, handle)
+		_ = c.Request.Body.Close()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, rest.NewErrorResponse(fmt.Errorf("invalid %s request body: %w", mediaType, err)))
+			return
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(content))
+		c.Request.ContentLength = int64(len(content))
+		c.Request.Header.Set("Content-Type", MimeJson)
+		c.Request.Header.Set("Content-Length", strconv.Itoa(len(content)))
+	}
+}
+
+// Convert binary encoded content (MessagePack, CBOR) to json
+func binaryToJson(reader io.Reader, handle codec.Handle) ([]byte, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var body any
+	if err = codec.NewDecoderBytes(content, handle).Decode(&body); err != nil {
+		return nil, err
+	}
+	return json.Marshal(body)
+}
+
+// Check if the response is json document (not json stream, e.g. ndjson export)
+func isJsonContent(_ int, header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && mediaType == MimeJson
+}
+
+// Convert json content to binary encoding, json numbers are converted to integers when possible (otherwise to floats)
+func jsonToBinary(content []byte, handle codec.Handle) ([]byte, error) {
+	var body any
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return nil, err
+	}
+
+	var result []byte
+	if err := codec.NewEncoderBytes(&result, handle).Encode(fromJsonNumbers(body)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Replace the json numbers in the decoded json value with int64 or float64 values
+func fromJsonNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, item := range v {
+			v[key] = fromJsonNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = fromJsonNumbers(item)
+		}
+	}
+	return value
+}
+
+// endregion
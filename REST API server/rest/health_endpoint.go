@@ -4,21 +4,29 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-yaaf/yaaf-common/rest"
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
 )
 
 // region Endpoint structure and factory method ------------------------------------------------------------------------
 
 // HealthEndPoint for health check
+// @Service: HealthService
+// @Path: /
+// @Context: health
+// @ResourceGroup: Health
 type HealthEndPoint struct {
 	BaseEndPoint
+	hub     *common.ServiceHub // Service hub (state and dependencies)
+	timeout time.Duration      // Timeout of each dependency check
 }
 
-// NewHealthEndPoint factory method
-func NewHealthEndPoint() RestEndpoint {
-	return &HealthEndPoint{}
+// NewHealthEndPoint factory method, timeout is the maximum time to wait for each dependency check of the readiness probe
+func NewHealthEndPoint(hub *common.ServiceHub, timeout time.Duration) RestEndpoint {
+	return &HealthEndPoint{hub: hub, timeout: timeout}
 }
 
 // endregion
@@ -32,11 +40,15 @@ func (h *HealthEndPoint) Path() string {
 func (h *HealthEndPoint) RestEntries() (restEntries []RestEntry) {
 	restEntries = []RestEntry{
 		{Method: http.MethodGet, Handler: h.root, Path: "/"},
+		{Method: http.MethodGet, Handler: h.live, Path: "/health/live"},
+		{Method: http.MethodGet, Handler: h.ready, Path: "/health/ready"},
 	}
 	return
 }
 
 // Root handler returns the current version number of the API
+// @Http: GET /
+// @Return: ActionResponse
 func (h *HealthEndPoint) root(c *gin.Context) {
 
 	version := "1.0.0"
@@ -54,4 +66,25 @@ func (h *HealthEndPoint) root(c *gin.Context) {
 	c.JSON(http.StatusOK, rest.NewActionResponse("rest-api", version))
 }
 
+// Liveness probe: the service process is running and serving requests (no dependency checks)
+// @Http: GET /health/live
+// @Return: ActionResponse
+func (h *HealthEndPoint) live(c *gin.Context) {
+	c.JSON(http.StatusOK, rest.NewActionResponse("live", h.hub.Version))
+}
+
+// Readiness probe: the service is ready to accept traffic, the database and data cache are checked with timeout and the
+// response includes the status and latency of each dependency. Returns 503 (Service Unavailable) while the service is
+// starting (e.g. verifying the database schema), draining (shutting down) or when any dependency is down
+// @Http: GET /health/ready
+// @Return: EntityResponse<HealthStatus>
+func (h *HealthEndPoint) ready(c *gin.Context) {
+	health := h.hub.CheckHealth(h.timeout)
+	if health.Ready {
+		c.JSON(http.StatusOK, rest.NewEntityResponse(health))
+	} else {
+		c.JSON(http.StatusServiceUnavailable, rest.NewEntityResponse(health))
+	}
+}
+
 // endregion
@@ -0,0 +1,198 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/database"
+	"github.com/go-yaaf/yaaf-common/logger"
+	"github.com/go-yaaf/yaaf-common/rest"
+	"github.com/google/uuid"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"     // Client provided key of retried request (create and bulk routes)
+	IdempotencyReplayedHeader = "Idempotent-Replayed" // Response header of replayed response
+	idempotencyKeyPrefix      = "idempotency:"        // Data cache key prefix of the stored responses
+	idempotencyLockPrefix     = "idempotency-lock:"   // Data cache key prefix of the in-progress locks
+	idempotencyLockTTL        = 2 * time.Minute       // Expiration of the in-progress lock (in case the instance fails)
+)
+
+// Valid idempotency key (e.g. UUID)
+var idempotencyKeyRegex = regexp.MustCompile(`^[\x21-\x7E]{1,255}$`)
+
+// Serialize the lock acquisition within the instance (the in-memory data cache SetNX is not atomic)
+var idempotencyLockMutex sync.Mutex
+
+// region Idempotency configuration ------------------------------------------------------------------------------------
+
+// IdempotencyConfig is the configuration of the Idempotency-Key support
+type IdempotencyConfig struct {
+	Cache database.IDataCache // Storage of the responses and locks (nil to disable the support)
+	TTL   time.Duration       // Retention of the stored responses
+}
+
+// Stored response of idempotent request
+type idempotencyRecord struct {
+	Fingerprint string      `json:"fingerprint"` // Hash of the request method, URI and body
+	Status      int         `json:"status"`      // Response status
+	Header      http.Header `json:"header"`      // Response headers set by the handler (e.g. Content-Type)
+	Body        []byte      `json:"body"`        // Response body
+}
+
+// endregion
+
+// region Idempotency middleware ---------------------------------------------------------------------------------------
+
+// Replay the first response of requests with the same Idempotency-Key header (per subject and route): the response is
+// stored in the data cache with TTL, retry with different payload under the same key returns 422 and concurrent request
+// with the same key returns 409 (while the first request holds the lock). Server errors are not stored (can be retried)
+func idempotency(config IdempotencyConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if len(key) == 0 {
+			c.Next()
+			return
+		}
+		if !idempotencyKeyRegex.MatchString(key) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, rest.NewErrorResponse(fmt.Errorf("invalid %s header", IdempotencyKeyHeader)))
+			return
+		}
+
+		fingerprint, err := requestFingerprint(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, rest.NewErrorResponse(fmt.Errorf("failed to read request body: %w", err)))
+			return
+		}
+
+		cache := config.Cache
+		scope := fmt.Sprintf("%s:%s %s:%s", c.GetString(SubjectIdKey), c.Request.Method, c.FullPath(), key)
+		recordKey, lockKey := idempotencyKeyPrefix+scope, idempotencyLockPrefix+scope
+
+		if replayed := replayResponse(c, cache, recordKey, fingerprint); replayed {
+			return
+		}
+
+		// The lock holds a unique token of the request (the client may reuse the request ID)
+		token := []byte(uuid.NewString())
+		idempotencyLockMutex.Lock()
+		locked, err := cache.SetRawNX(lockKey, token, idempotencyLockTTL)
+		idempotencyLockMutex.Unlock()
+		if err != nil {
+			// The data cache is not available, process the request with no idempotency guarantee
+			logger.Warn("[idempotency] [%s]: failed to lock key: %s", GetRequestId(c), err.Error())
+			c.Next()
+			return
+		}
+		if !locked {
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusConflict, rest.NewErrorResponse(fmt.Errorf("request with the same %s is in progress", IdempotencyKeyHeader)))
+			return
+		}
+		defer releaseLock(c, cache, lockKey, token)
+
+		// The first request may have completed between the check and the lock
+		if replayed := replayResponse(c, cache, recordKey, fingerprint); replayed {
+			return
+		}
+
+		before := c.Writer.Header().Clone()
+		bw := newBufferedWriter(c.Writer, func(int, http.Header) bool { return true })
+		c.Writer = bw
+		defer func() { c.Writer = bw.ResponseWriter }() // The recovery responds by the original writer on handler panic
+		c.Next()
+		c.Writer = bw.ResponseWriter
+		bw.Commit(bw.Body())
+
+		if status := bw.Status(); status >= http.StatusInternalServerError || status == http.StatusConflict || status == http.StatusTooManyRequests {
+			return
+		}
+		record := &idempotencyRecord{Fingerprint: fingerprint, Status: bw.Status(), Header: changedHeaders(before, bw.Header()), Body: bw.Body()}
+		if content, er := json.Marshal(record); er == nil {
+			if er = cache.SetRaw(recordKey, content, config.TTL); er != nil {
+				logger.Warn("[idempotency] [%s]: failed to store response: %s", GetRequestId(c), er.Error())
+			}
+		}
+	}
+}
+
+// Release the lock only when it is still held by the request (the lock may have expired and taken by other request)
+func releaseLock(c *gin.Context, cache database.IDataCache, lockKey string, token []byte) {
+	idempotencyLockMutex.Lock()
+	defer idempotencyLockMutex.Unlock()
+
+	holder, err := cache.GetRaw(lockKey)
+	if err != nil || !bytes.Equal(holder, token) {
+		return
+	}
+	if err = cache.Del(lockKey); err != nil {
+		logger.Warn("[idempotency] [%s]: failed to release key: %s", GetRequestId(c), err.Error())
+	}
+}
+
+// Replay the stored response of the key (if exists), request with different fingerprint is rejected with 422
+func replayResponse(c *gin.Context, cache database.IDataCache, recordKey, fingerprint string) bool {
+	content, err := cache.GetRaw(recordKey)
+	if err != nil || len(content) == 0 {
+		return false
+	}
+	record := &idempotencyRecord{}
+	if err = json.Unmarshal(content, record); err != nil {
+		return false
+	}
+
+	if record.Fingerprint != fingerprint {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, rest.NewErrorResponse(fmt.Errorf("%s was used with a different request", IdempotencyKeyHeader)))
+		return true
+	}
+
+	for name, values := range record.Header {
+		c.Writer.Header()[name] = values
+	}
+	c.Header(IdempotencyReplayedHeader, "true")
+	c.Status(record.Status)
+	_, _ = c.Writer.Write(record.Body)
+	c.Abort()
+	return true
+}
+
+// Hash of the request method, URI and body, the body is restored for the handler
+func requestFingerprint(c *gin.Context) (string, error) {
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.Method + " " + c.Request.URL.RequestURI() + "\n"))
+
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		content, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return "", err
+		}
+		_ = c.Request.Body.Close()
+		c.Request.Body = io.NopCloser(bytes.NewReader(content))
+		hash.Write(content)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Get the response headers added or modified by the handler (excluding the headers of the request, e.g. request ID)
+func changedHeaders(before, after http.Header) http.Header {
+	result := make(http.Header)
+	for name, values := range after {
+		if name == "Content-Length" || slices.Equal(before[name], values) {
+			continue
+		}
+		result[name] = values
+	}
+	return result
+}
+
+// endregion
@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/database"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+)
+
+// Lock key of the items route requests with the key
+const itemsLockKey = idempotencyLockPrefix + ":POST /items:k1"
+
+// Create the items route with idempotency support, the handler counts the calls and runs the hook (if provided)
+func idempotentItems(cache database.IDataCache, calls *int, hook func()) *gin.Engine {
+	engine := gin.New()
+	engine.POST("/items", idempotency(IdempotencyConfig{Cache: cache, TTL: time.Hour}), func(c *gin.Context) {
+		*calls++
+		if hook != nil {
+			hook()
+		}
+		c.Header("Location", "/items/1")
+		c.String(http.StatusCreated, "created %d", *calls)
+	})
+	return engine
+}
+
+// Post the body to the items route with the idempotency key
+func postItem(engine *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	if len(key) > 0 {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplay(t *testing.T) {
+	cache := common.NewDataCache()
+	calls := 0
+	engine := idempotentItems(cache, &calls, nil)
+
+	first := postItem(engine, "k1", `{"name":"a"}`)
+	if first.Code != http.StatusCreated || first.Header().Get(IdempotencyReplayedHeader) != "" {
+		t.Fatalf("expected created response but got %d", first.Code)
+	}
+
+	// The retry replays the first response without calling the handler
+	retry := postItem(engine, "k1", `{"name":"a"}`)
+	if retry.Code != http.StatusCreated || retry.Body.String() != "created 1" || retry.Header().Get("Location") != "/items/1" {
+		t.Errorf("unexpected replayed response %d: %q", retry.Code, retry.Body.String())
+	}
+	if retry.Header().Get(IdempotencyReplayedHeader) != "true" || calls != 1 {
+		t.Errorf("expected replayed response with single handler call but got %d calls", calls)
+	}
+
+	// The lock is released, requests with other key or with no key are processed
+	if exists, _ := cache.Exists(itemsLockKey); exists {
+		t.Error("expected released lock")
+	}
+	if w := postItem(engine, "k2", `{"name":"a"}`); w.Body.String() != "created 2" {
+		t.Errorf("unexpected response of other key: %q", w.Body.String())
+	}
+	if w := postItem(engine, "", `{"name":"a"}`); w.Body.String() != "created 3" {
+		t.Errorf("unexpected response with no key: %q", w.Body.String())
+	}
+	if w := postItem(engine, "bad key", `{"name":"a"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid key but got %d", w.Code)
+	}
+}
+
+func TestIdempotencyDifferentPayload(t *testing.T) {
+	calls := 0
+	engine := idempotentItems(common.NewDataCache(), &calls, nil)
+
+	postItem(engine, "k1", `{"name":"a"}`)
+	if w := postItem(engine, "k1", `{"name":"b"}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422 but got %d", w.Code)
+	}
+	if calls != 1 {
+		t.Errorf("expected single handler call but got %d", calls)
+	}
+}
+
+func TestIdempotencyConcurrent(t *testing.T) {
+	cache := common.NewDataCache()
+	calls := 0
+	engine := idempotentItems(cache, &calls, nil)
+
+	// Other request holds the lock
+	if err := cache.SetRaw(itemsLockKey, []byte("other"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	w := postItem(engine, "k1", `{"name":"a"}`)
+	if w.Code != http.StatusConflict || w.Header().Get("Retry-After") != "1" || calls != 0 {
+		t.Errorf("expected status 409 with Retry-After but got %d (%d calls)", w.Code, calls)
+	}
+	if holder, _ := cache.GetRaw(itemsLockKey); string(holder) != "other" {
+		t.Errorf("expected the lock of the other request but got %q", holder)
+	}
+}
+
+func TestIdempotencyKeepsLockOfOtherRequest(t *testing.T) {
+	cache := common.NewDataCache()
+	calls := 0
+
+	// The lock expires while the handler runs and is taken by other request
+	engine := idempotentItems(cache, &calls, func() {
+		_ = cache.SetRaw(itemsLockKey, []byte("other"), time.Minute)
+	})
+	if w := postItem(engine, "k1", `{"name":"a"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201 but got %d", w.Code)
+	}
+	if holder, _ := cache.GetRaw(itemsLockKey); string(holder) != "other" {
+		t.Errorf("expected the lock of the other request but got %q", holder)
+	}
+}
+
+func TestIdempotencyHandlerPanic(t *testing.T) {
+	cache := common.NewDataCache()
+	engine := gin.New()
+	engine.Use(gin.CustomRecovery(func(c *gin.Context, _ any) { c.AbortWithStatus(http.StatusInternalServerError) }))
+	engine.POST("/items", idempotency(IdempotencyConfig{Cache: cache, TTL: time.Hour}), func(c *gin.Context) {
+		panic("handler failed")
+	})
+
+	// The recovery response is sent, the lock is released and the response is not stored
+	if w := postItem(engine, "k1", `{"name":"a"}`); w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500 but got %d", w.Code)
+	}
+	if exists, _ := cache.Exists(itemsLockKey); exists {
+		t.Error("expected released lock")
+	}
+	if exists, _ := cache.Exists(idempotencyKeyPrefix + ":POST /items:k1"); exists {
+		t.Error("expected no stored response")
+	}
+}
@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/rest"
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+)
+
+// Route label of requests that do not match any route (to keep the metrics cardinality bounded)
+const unmatchedRoute = "unmatched"
+
+// Record the HTTP request metrics (count and latency) by method, route template and status
+func httpMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if len(route) == 0 {
+			route = unmatchedRoute
+		}
+		common.Metrics().ObserveHttpRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// Serve the Prometheus metrics, the metrics endpoint has its own auth policy (instead of API key and access token): the
+// scraper must provide the token as bearer token (Authorization: Bearer <token>)
+func metricsHandler(token string) gin.HandlerFunc {
+	handler := common.Metrics().Handler()
+	return func(c *gin.Context) {
+		bearer, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if len(token) == 0 || !found || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			c.Header("WWW-Authenticate", `Bearer realm="metrics"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, rest.NewErrorResponse(fmt.Errorf("invalid metrics token")))
+			return
+		}
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,68 @@
+package rest
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Accept header (Accept, Accept-Encoding) entry: value and quality factor
+type acceptEntry struct {
+	value   string
+	quality float64
+}
+
+// Parse the accept header entries (e.g. "gzip;q=0.8, zstd" or "application/msgpack, application/json;q=0.5")
+func parseAccept(header string) []acceptEntry {
+	var result []acceptEntry
+	for _, part := range strings.Split(header, ",") {
+		value, params, _ := strings.Cut(part, ";")
+		value = strings.ToLower(strings.TrimSpace(value))
+		if len(value) == 0 {
+			continue
+		}
+
+		entry := acceptEntry{value: value, quality: 1}
+		for _, param := range strings.Split(params, ";") {
+			if q, found := strings.CutPrefix(strings.TrimSpace(param), "q="); found {
+				if f, err := strconv.ParseFloat(q, 64); err == nil {
+					entry.quality = f
+				}
+			}
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// Get the quality of the offer by the most specific matching entry (exact, type/* or wildcard), zero when not acceptable
+func acceptQuality(entries []acceptEntry, offer string) float64 {
+	quality, specificity := 0.0, -1
+	for _, e := range entries {
+		s := -1
+		switch {
+		case e.value == offer:
+			s = 2
+		case strings.HasSuffix(e.value, "/*") && strings.HasPrefix(offer, strings.TrimSuffix(e.value, "*")):
+			s = 1
+		case e.value == "*" || e.value == "*/*":
+			s = 0
+		}
+		if s > specificity {
+			quality, specificity = e.quality, s
+		}
+	}
+	return quality
+}
+
+// Select the offer with the highest quality in the accept header (the offers order breaks ties), returns empty string
+// when none of the offers is acceptable
+func negotiate(header string, offers ...string) string {
+	entries := parseAccept(header)
+	best, bestQuality := "", 0.0
+	for _, offer := range offers {
+		if q := acceptQuality(entries, offer); q > bestQuality {
+			best, bestQuality = offer, q
+		}
+	}
+	return best
+}
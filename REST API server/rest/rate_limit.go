@@ -0,0 +1,294 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/database"
+	"github.com/go-yaaf/yaaf-common/logger"
+	"github.com/go-yaaf/yaaf-common/rest"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	"github.com/go-yaaf/yaaf-examples/rest-api/utils"
+)
+
+const (
+	rateLimitKeyPrefix  = "ratelimit:"      // Data cache key prefix of the buckets
+	rateLimitLockPrefix = "ratelimit-lock:" // Data cache key prefix of the bucket locks
+	rateLimitLockTTL    = time.Second       // Expiration of the bucket lock (in case the instance fails)
+	rateLimitLockWait   = 50 * time.Millisecond
+	accountTypeCacheTTL = time.Minute // Retention of the resolved account type of the subject
+)
+
+// Serialize the bucket updates within the instance by key stripe (the in-memory data cache SetNX is not atomic)
+var rateLimitMutex [64]sync.Mutex
+
+// region Rate limits configuration ------------------------------------------------------------------------------------
+
+// RateLimitConfig is the configuration of the rate limiting of the API routes, each limit is the number of requests per
+// period (token bucket, the requests are refilled evenly over the period), zero for no limit
+type RateLimitConfig struct {
+	Cache        database.IDataCache     // Storage of the buckets, shared by the replicas (nil to disable rate limiting)
+	Period       time.Duration           // Rate limit period
+	Ip           int                     // Requests per period by remote IP
+	ApiKey       int                     // Requests per period by API key (application)
+	Subject      int                     // Requests per period by token subject (user or service account)
+	Groups       map[string]int          // Requests per period by route group path (e.g. /contacts) and subject (or IP)
+	AccountTypes map[AccountTypeCode]int // Requests per period by subject of account type (overrides the subject limit)
+
+	// Resolve the account type of the subject (required by the account type limits)
+	AccountType func(subjectId string) (AccountTypeCode, bool)
+}
+
+// NewRateLimitConfig creates the rate limits configuration from the service configuration
+func NewRateLimitConfig(cfg *config.ServiceConfig, cache database.IDataCache) RateLimitConfig {
+	result := RateLimitConfig{
+		Period:       cfg.RateLimitPeriod(),
+		Ip:           cfg.RateLimitIp(),
+		ApiKey:       cfg.RateLimitApiKey(),
+		Subject:      cfg.RateLimitSubject(),
+		Groups:       cfg.RateLimitGroups(),
+		AccountTypes: cfg.RateLimitAccountTypes(),
+	}
+	if cfg.RateLimitEnabled() {
+		result.Cache = cache
+	}
+	return result
+}
+
+// Token bucket state stored in the data cache
+type rateBucket struct {
+	Tokens  float64 `json:"tokens"`  // Available requests
+	Updated int64   `json:"updated"` // Last update [Epoch milliseconds Timestamp]
+}
+
+// Result of taking a request from the bucket
+type rateResult struct {
+	limit      int           // Requests per period
+	remaining  int           // Remaining requests
+	reset      time.Duration // Time until the bucket is full
+	retryAfter time.Duration // Time until the next request is allowed (when rejected)
+	allowed    bool
+}
+
+// endregion
+
+// region Rate limiter -------------------------------------------------------------------------------------------------
+
+// rateLimiter applies the configured limits, shared by the middlewares of all the API routes
+type rateLimiter struct {
+	config       RateLimitConfig
+	accountTypes sync.Map // Resolved account types by subject ID
+}
+
+// Resolved account type of subject
+type accountTypeEntry struct {
+	code    AccountTypeCode
+	found   bool
+	expires time.Time
+}
+
+// Create rate limiter of the configuration, returns nil when rate limiting is disabled
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	if config.Cache == nil || config.Period <= 0 {
+		return nil
+	}
+	return &rateLimiter{config: config}
+}
+
+// Limit the requests of the route group by remote IP, API key and token subject (and by the group limit when configured),
+// the RateLimit-* headers report the most restrictive bucket and rejected request returns 429 with Retry-After header.
+// The request is taken from the buckets in order, when a bucket rejects the request it is refunded to the buckets taken
+// before (a rejected request does not consume the other limits).
+// Buckets are stored in the data cache, when it is not available the requests are allowed
+func rateLimit(limiter *rateLimiter, group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var policies []string
+		var current *rateResult
+		var taken []bucketKey
+		for _, b := range limiter.buckets(c, group) {
+			result, err := limiter.take(b.key, b.limit)
+			if err != nil {
+				logger.Warn("[rate-limit] [%s]: %s", GetRequestId(c), err.Error())
+				continue
+			}
+			policies = append(policies, fmt.Sprintf("%d;w=%d", b.limit, int(limiter.config.Period.Seconds())))
+			if current == nil || !result.allowed || result.remaining < current.remaining {
+				current = result
+			}
+			if !result.allowed {
+				limiter.refund(c, taken)
+				break
+			}
+			taken = append(taken, b)
+		}
+		if current == nil {
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(current.limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(current.remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(seconds(current.reset)))
+		c.Header("RateLimit-Policy", strings.Join(policies, ", "))
+		if !current.allowed {
+			c.Header("Retry-After", strconv.Itoa(seconds(current.retryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, rest.NewErrorResponse(fmt.Errorf("rate limit exceeded, retry in %d seconds", seconds(current.retryAfter))))
+			return
+		}
+		c.Next()
+	}
+}
+
+// Bucket of the request identity
+type bucketKey struct {
+	key   string
+	limit int
+}
+
+// Get the buckets of the request: remote IP, API key, token subject and route group (by subject or remote IP)
+func (l *rateLimiter) buckets(c *gin.Context, group string) (result []bucketKey) {
+	cfg := l.config
+	ip, subject := c.ClientIP(), c.GetString(SubjectIdKey)
+
+	if cfg.Ip > 0 {
+		result = append(result, bucketKey{key: "ip:" + ip, limit: cfg.Ip})
+	}
+	if app, err := utils.TokenUtils().ParseApiKey(c.GetHeader("X-API-KEY")); err == nil && cfg.ApiKey > 0 {
+		result = append(result, bucketKey{key: "key:" + app, limit: cfg.ApiKey})
+	}
+	if len(subject) > 0 {
+		if limit := l.subjectLimit(subject); limit > 0 {
+			result = append(result, bucketKey{key: "subject:" + subject, limit: limit})
+		}
+	}
+	if limit := cfg.Groups[group]; limit > 0 {
+		identity := "ip:" + ip
+		if len(subject) > 0 {
+			identity = "subject:" + subject
+		}
+		result = append(result, bucketKey{key: "group:" + group + ":" + identity, limit: limit})
+	}
+	return
+}
+
+// Get the subject limit by the account type of the subject (or the default subject limit)
+func (l *rateLimiter) subjectLimit(subject string) int {
+	if len(l.config.AccountTypes) == 0 || l.config.AccountType == nil {
+		return l.config.Subject
+	}
+
+	var entry accountTypeEntry
+	if value, ok := l.accountTypes.Load(subject); ok && time.Now().Before(value.(accountTypeEntry).expires) {
+		entry = value.(accountTypeEntry)
+	} else {
+		entry.code, entry.found = l.config.AccountType(subject)
+		entry.expires = time.Now().Add(accountTypeCacheTTL)
+		l.accountTypes.Store(subject, entry)
+	}
+
+	if limit, exists := l.config.AccountTypes[entry.code]; entry.found && exists {
+		return limit
+	}
+	return l.config.Subject
+}
+
+// Take a request from the bucket (token bucket refilled evenly over the period)
+func (l *rateLimiter) take(key string, limit int) (*rateResult, error) {
+	result := &rateResult{limit: limit}
+	err := l.update(key, limit, func(bucket *rateBucket, rate float64) {
+		if result.allowed = bucket.Tokens >= 1; result.allowed {
+			bucket.Tokens--
+		} else {
+			result.retryAfter = time.Duration((1-bucket.Tokens)/rate) * time.Millisecond
+		}
+		result.remaining = int(bucket.Tokens)
+		result.reset = time.Duration((float64(limit)-bucket.Tokens)/rate) * time.Millisecond
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Return the request to the buckets (the request was rejected by another bucket)
+func (l *rateLimiter) refund(c *gin.Context, buckets []bucketKey) {
+	for _, b := range buckets {
+		err := l.update(b.key, b.limit, func(bucket *rateBucket, _ float64) {
+			bucket.Tokens = math.Min(float64(b.limit), bucket.Tokens+1)
+		})
+		if err != nil {
+			logger.Warn("[rate-limit] [%s]: failed to refund: %s", GetRequestId(c), err.Error())
+		}
+	}
+}
+
+// Update the bucket state (refilled up to the current time), the bucket is updated under lock shared by the replicas
+func (l *rateLimiter) update(key string, limit int, fn func(bucket *rateBucket, rate float64)) error {
+	cache, period := l.config.Cache, l.config.Period
+	bucketKey, lockKey := rateLimitKeyPrefix+key, rateLimitLockPrefix+key
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	mu := &rateLimitMutex[hash.Sum32()%uint32(len(rateLimitMutex))]
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := lockBucket(cache, lockKey); err != nil {
+		return err
+	}
+	defer func() { _ = cache.Del(lockKey) }()
+
+	now := time.Now()
+	rate := float64(limit) / float64(period.Milliseconds())
+	bucket := &rateBucket{Tokens: float64(limit), Updated: now.UnixMilli()}
+	if content, err := cache.GetRaw(bucketKey); err == nil && len(content) > 0 {
+		if er := json.Unmarshal(content, bucket); er == nil {
+			elapsed := max(now.UnixMilli()-bucket.Updated, 0)
+			bucket.Tokens = math.Min(float64(limit), bucket.Tokens+float64(elapsed)*rate)
+			bucket.Updated = now.UnixMilli()
+		}
+	}
+
+	fn(bucket, rate)
+
+	content, _ := json.Marshal(bucket)
+	if err := cache.SetRaw(bucketKey, content, period); err != nil {
+		return fmt.Errorf("failed to store bucket: %w", err)
+	}
+	return nil
+}
+
+// Obtain the bucket lock, wait while the bucket is locked by another replica
+func lockBucket(cache database.IDataCache, lockKey string) error {
+	deadline := time.Now().Add(rateLimitLockWait)
+	for {
+		locked, err := cache.SetRawNX(lockKey, []byte{1}, rateLimitLockTTL)
+		if err != nil {
+			return fmt.Errorf("failed to lock bucket: %w", err)
+		}
+		if locked {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("bucket %s is locked", lockKey)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+}
+
+// Round up the duration to seconds
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// endregion
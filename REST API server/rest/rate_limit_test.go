@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+)
+
+// Test endpoint of unversioned route (rate limited by the entry flag)
+type unversionedEndPoint struct {
+	path    string
+	limited bool
+}
+
+func (e *unversionedEndPoint) Path() string {
+	return e.path
+}
+
+func (e *unversionedEndPoint) RestEntries() []RestEntry {
+	handler := func(c *gin.Context) { c.Status(http.StatusOK) }
+	return []RestEntry{{Method: http.MethodGet, Handler: handler, Path: "", RateLimit: e.limited}}
+}
+
+func TestRateLimitRefundsRejectedRequest(t *testing.T) {
+	limiter := newRateLimiter(RateLimitConfig{
+		Cache:  common.NewDataCache(),
+		Period: time.Hour,
+		Ip:     5,
+		Groups: map[string]int{"/items": 1},
+	})
+
+	engine := gin.New()
+	engine.GET("/items", rateLimit(limiter, "/items"), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// The second request is rejected by the group bucket, the IP bucket is not consumed by the rejected requests
+	expected := []int{http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests}
+	for i, status := range expected {
+		req := httptest.NewRequest(http.MethodGet, "/items", nil)
+		req.RemoteAddr = "192.0.2.10:1234"
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		if w.Code != status {
+			t.Fatalf("request %d: expected status %d but got %d", i+1, status, w.Code)
+		}
+	}
+
+	result, err := limiter.take("ip:192.0.2.10", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.remaining != 3 {
+		t.Errorf("expected 3 remaining requests of the IP bucket but got %d", result.remaining)
+	}
+}
+
+func TestRateLimitUnversionedRoute(t *testing.T) {
+	hub := common.NewServiceHub()
+	server := NewRESTServer(config.GetConfig()).
+		WithRateLimits(RateLimitConfig{Cache: hub.DataCache, Period: time.Hour, Groups: map[string]int{"/limited": 1, "/free": 1}}).
+		AddEndpoints(&unversionedEndPoint{path: "/limited", limited: true}, &unversionedEndPoint{path: "/free"})
+
+	tests := []struct {
+		path     string
+		expected []int
+	}{
+		{"/limited", []int{http.StatusOK, http.StatusTooManyRequests}},
+		{"/free", []int{http.StatusOK, http.StatusOK}},
+	}
+	for _, tt := range tests {
+		for i, status := range tt.expected {
+			w := httptest.NewRecorder()
+			server.Handler().ServeHTTP(w, newAuthorizedRequest(t, http.MethodGet, tt.path))
+			if w.Code != status {
+				t.Errorf("%s request %d: expected status %d but got %d", tt.path, i+1, status, w.Code)
+			}
+		}
+	}
+}
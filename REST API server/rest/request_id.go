@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/rest"
+	"github.com/google/uuid"
+)
+
+const (
+	RequestIdHeader = "X-Request-ID" // Request correlation ID header (request and response)
+	RequestIdKey    = "requestId"    // Key of the request correlation ID in the gin context
+)
+
+// Valid client provided request ID (otherwise a new ID is generated)
+var requestIdRegex = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+
+// GetRequestId returns the request correlation ID of the request
+func GetRequestId(c *gin.Context) string {
+	return c.GetString(RequestIdKey)
+}
+
+// Accept the client request ID (X-Request-ID header) or generate new one, store it in the context, echo it in the
+// response header and add it to the json error responses (including errors with no body, e.g. invalid API key)
+func requestId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id := c.GetHeader(RequestIdHeader)
+		if !requestIdRegex.MatchString(id) {
+			id = uuid.NewString()
+		}
+		c.Set(RequestIdKey, id)
+		c.Header(RequestIdHeader, id)
+
+		bw := newBufferedWriter(c.Writer, isJsonError)
+		c.Writer = bw
+		c.Next()
+		c.Writer = bw.ResponseWriter
+		if !bw.Buffered() {
+			return
+		}
+
+		status := bw.Status()
+		if status < http.StatusBadRequest {
+			bw.Commit(bw.Body())
+			return
+		}
+		if len(bw.Body()) > 0 {
+			bw.Commit(withRequestId(bw.Body(), id))
+			return
+		}
+
+		// Error with no body (e.g. AbortWithError / AbortWithStatus)
+		res := &rest.BaseRestResponse{Code: -1, Error: http.StatusText(status)}
+		if err := c.Errors.Last(); err != nil {
+			res.Error = err.Error()
+		}
+		content, _ := json.Marshal(res)
+		bw.Header().Set("Content-Type", "application/json; charset=utf-8")
+		bw.Commit(withRequestId(content, id))
+	}
+}
+
+// Check if the response is json error
+func isJsonError(status int, header http.Header) bool {
+	return status >= http.StatusBadRequest && isJsonResponse(status, header)
+}
+
+// Add the request ID to the json error response
+func withRequestId(content []byte, id string) []byte {
+	body := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(content, &body); err != nil {
+		return content
+	}
+	body[RequestIdKey], _ = json.Marshal(id)
+	if result, err := json.Marshal(body); err == nil {
+		return result
+	}
+	return content
+}
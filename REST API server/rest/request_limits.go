@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/rest"
+)
+
+// Grace period added to the response write deadline of routes with explicit timeout (to write the timeout response)
+const writeDeadlineGrace = 5 * time.Second
+
+// region Request limits configuration ---------------------------------------------------------------------------------
+
+// RequestLimits are the limits of a single route: request body size and handler deadline
+type RequestLimits struct {
+	MaxBodySize int64         // Maximum request body size in bytes (zero for the server default, negative for no limit)
+	Timeout     time.Duration // Handler deadline (zero for the server default, negative for no deadline)
+}
+
+// Merge the route limits with the server default limits, the second result is true when the route overrides the timeout
+func (l RequestLimits) withDefaults(defaults RequestLimits) (RequestLimits, bool) {
+	result := l
+	if result.MaxBodySize == 0 {
+		result.MaxBodySize = defaults.MaxBodySize
+	}
+	if result.Timeout == 0 {
+		result.Timeout = defaults.Timeout
+	}
+	return result, l.Timeout != 0
+}
+
+// endregion
+
+// region Request limits middleware ------------------------------------------------------------------------------------
+
+// Enforce the route limits: request body larger than the maximal size is rejected with 413 (by the Content-Length header
+// or while the handler reads the body), the handler deadline is set on the request context (propagated to the services
+// and the database through the token data) and expired request returns 503, both in the standard error format
+func requestLimits(limits RequestLimits, overrideWriteDeadline bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var body *limitedBody
+		if limits.MaxBodySize > 0 && c.Request.Body != nil && c.Request.Body != http.NoBody {
+			if c.Request.ContentLength > limits.MaxBodySize {
+				abortWithLimitError(c, http.StatusRequestEntityTooLarge, bodyTooLarge(limits.MaxBodySize))
+				return
+			}
+			body = &limitedBody{ReadCloser: http.MaxBytesReader(c.Writer, c.Request.Body, limits.MaxBodySize)}
+			c.Request.Body = body
+		}
+
+		if limits.Timeout > 0 {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), limits.Timeout)
+			defer cancel()
+			c.Request = c.Request.WithContext(ctx)
+		}
+
+		// Route with longer (or no) deadline than the server write timeout (e.g. export)
+		if overrideWriteDeadline {
+			deadline := time.Time{}
+			if limits.Timeout > 0 {
+				deadline = time.Now().Add(limits.Timeout + writeDeadlineGrace)
+			}
+			_ = http.NewResponseController(c.Writer).SetWriteDeadline(deadline)
+		}
+
+		// Buffer error responses to replace them with the limit error
+		bw := newBufferedWriter(c.Writer, isErrorResponse)
+		c.Writer = bw
+		defer func() { c.Writer = bw.ResponseWriter }() // The recovery responds by the original writer on handler panic
+		c.Next()
+		c.Writer = bw.ResponseWriter
+		if !bw.Buffered() {
+			return
+		}
+
+		switch {
+		case body != nil && body.exceeded:
+			commitLimitError(c, bw, http.StatusRequestEntityTooLarge, bodyTooLarge(limits.MaxBodySize))
+		case errors.Is(c.Request.Context().Err(), context.DeadlineExceeded):
+			commitLimitError(c, bw, http.StatusServiceUnavailable, fmt.Errorf("request timeout, the request was not completed within %s", limits.Timeout))
+		default:
+			bw.Commit(bw.Body())
+		}
+	}
+}
+
+// Check if the response is an error (or not written yet)
+func isErrorResponse(status int, _ http.Header) bool {
+	return status >= http.StatusBadRequest
+}
+
+// Error of request body larger than the limit
+func bodyTooLarge(limit int64) error {
+	return fmt.Errorf("request body too large, the limit is %d bytes", limit)
+}
+
+// Abort the request with limit error in the standard error format
+func abortWithLimitError(c *gin.Context, status int, err error) {
+	_ = c.Error(err)
+	c.AbortWithStatusJSON(status, rest.NewErrorResponse(err))
+}
+
+// Replace the buffered response with limit error in the standard error format
+func commitLimitError(c *gin.Context, bw *bufferedWriter, status int, err error) {
+	_ = c.Error(err)
+	content, _ := json.Marshal(rest.NewErrorResponse(err))
+	bw.status = status
+	bw.Header().Set("Content-Type", "application/json; charset=utf-8")
+	bw.Commit(content)
+}
+
+// endregion
+
+// region Limited request body -----------------------------------------------------------------------------------------
+
+// Request body limited by http.MaxBytesReader, records whether the handler exceeded the limit
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		b.exceeded = true
+	}
+	return n, err
+}
+
+// endregion
@@ -1,21 +1,32 @@
 package rest
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/database"
 	"github.com/go-yaaf/yaaf-common/entity"
+	"github.com/go-yaaf/yaaf-common/logger"
+	"github.com/go-yaaf/yaaf-common/rest"
 	"github.com/go-yaaf/yaaf-examples/rest-api/common"
 	"github.com/go-yaaf/yaaf-examples/rest-api/config"
 	mc "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
 	"github.com/go-yaaf/yaaf-examples/rest-api/utils"
 	"net/http"
+	"runtime/debug"
 	"strings"
+	"sync"
 	"time"
 )
 
 var whiteList map[string]int
 
+// Path prefixes of the streaming routes that accept the API key and access token as query params (apiKey, accessToken)
+// since the browser EventSource and WebSocket APIs can't set request headers
+var queryCredentials []string
+
 const (
 	Unknown  int = 0
 	NoToken      = 1
@@ -30,18 +41,33 @@ func init() {
 	whiteList["/health/"] = NoApiKey + NoToken
 	whiteList["/doc"] = NoApiKey + NoToken
 	whiteList["/doc/"] = NoApiKey + NoToken
+	whiteList["/versions"] = NoApiKey + NoToken
 
 	// The following methods require API Key but not Token validations
 	whiteList["/user/authorize"] = NoToken
 
 	// The following methods require API Key but not Token validations
+
+	// The following methods accept API Key and Token as query params
+	queryCredentials = []string{"/events"}
 }
 
 // region REST server structure and factory method ---------------------------------------------------------------------
 
 type Server struct {
-	config *config.ServiceConfig
-	engine *gin.Engine
+	config   *config.ServiceConfig
+	engine   *gin.Engine
+	versions []*ApiVersion             // Registered API versions (by registration order)
+	routes   map[string][]mc.RouteInfo // Registered routes by API version name (empty name for unversioned routes)
+
+	transport   TransportConfig   // HTTP server transport configuration (TLS, HTTP/2, timeouts)
+	limits      RequestLimits     // Default request limits of the endpoints routes (body size, handler deadline)
+	idempotency IdempotencyConfig // Idempotency-Key support of the create and bulk routes (disabled without data cache)
+	limiter     *rateLimiter      // Rate limiter of the API version routes (nil when disabled)
+
+	mu         sync.Mutex   // Guards the HTTP server (started and stopped by different goroutines)
+	httpServer *http.Server // HTTP server (created on start)
+	closed     bool         // Shutdown was called (the server is not started after shutdown)
 }
 
 // NewRESTServer Factory method
@@ -50,13 +76,22 @@ func NewRESTServer(cfg *config.ServiceConfig) *Server {
 	// Define gin engine and set middlewares
 	gin.SetMode(gin.ReleaseMode)
 
-	engine := gin.Default()
+	engine := gin.New()
+	engine.Use(
+		accessLog(AccessLogConfig{SkipPaths: cfg.AccessLogSkipPaths(), SlowThreshold: cfg.AccessLogSlowThreshold()}),
+		httpMetrics(),
+		compression(CompressionConfig{Enabled: cfg.CompressionEnabled(), MinSize: cfg.CompressionMinSize()}),
+		encodeResponse(),
+		requestId(),
+		tracing(),
+		gin.CustomRecoveryWithWriter(nil, customRecovery),
+	)
 
 	engine.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "HEAD"},
-		AllowHeaders:     []string{"Origin", "X-API-KEY", "X-ACCESS-TOKEN", "X-TIMEZONE-OFFSET"},
-		ExposeHeaders:    []string{"Content-Length", "X-API-KEY", "X-ACCESS-TOKEN", "X-TIMEZONE-OFFSET"},
+		AllowHeaders:     []string{"Origin", "X-API-KEY", "X-ACCESS-TOKEN", "X-TIMEZONE-OFFSET", RequestIdHeader, IdempotencyKeyHeader, "traceparent", "tracestate"},
+		ExposeHeaders:    []string{"Content-Length", "X-API-KEY", "X-ACCESS-TOKEN", "X-TIMEZONE-OFFSET", RequestIdHeader, "X-API-VERSION", "X-BUILD-TAG", "Deprecation", "Sunset", "Link", IdempotencyReplayedHeader, "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", "traceparent"},
 		AllowCredentials: true,
 		AllowWebSockets:  true,
 		AllowWildcard:    true,
@@ -66,15 +101,18 @@ func NewRESTServer(cfg *config.ServiceConfig) *Server {
 	engine.Use(
 		corsMiddleware(),
 		disableCache(),
-		gin.CustomRecovery(customRecovery),
+		credentialsFromQuery(),
 		apiKeyValidator(),
 		tokenValidator(),
-		apiVersion(),
+		buildTag(),
 	)
 
 	return &Server{
-		config: cfg,
-		engine: engine,
+		config:    cfg,
+		engine:    engine,
+		routes:    make(map[string][]mc.RouteInfo),
+		transport: NewTransportConfig(cfg),
+		limits:    RequestLimits{MaxBodySize: cfg.RequestMaxBodySize(), Timeout: cfg.RequestTimeout()},
 	}
 }
 
@@ -82,22 +120,111 @@ func NewRESTServer(cfg *config.ServiceConfig) *Server {
 
 // region REST server fluent API configuration -------------------------------------------------------------------------
 
-// AddEndpoints add REST endpoints
+// WithTransport overrides the HTTP server transport configuration (TLS, HTTP/2, timeouts), must be called before Start
+func (s *Server) WithTransport(transport TransportConfig) *Server {
+	s.transport = transport
+	return s
+}
+
+// WithRequestLimits overrides the default request limits of the endpoints routes, must be called before adding endpoints
+func (s *Server) WithRequestLimits(limits RequestLimits) *Server {
+	s.limits = limits
+	return s
+}
+
+// WithIdempotency enables the Idempotency-Key support of the create and bulk routes, the responses are stored in the data
+// cache for the TTL period, must be called before adding endpoints
+func (s *Server) WithIdempotency(cache database.IDataCache, ttl time.Duration) *Server {
+	s.idempotency = IdempotencyConfig{Cache: cache, TTL: ttl}
+	return s
+}
+
+// WithRateLimits enables rate limiting of the API version routes (unversioned routes, e.g. health check, are not limited),
+// must be called before adding endpoints
+func (s *Server) WithRateLimits(limits RateLimitConfig) *Server {
+	s.limiter = newRateLimiter(limits)
+	return s
+}
+
+// AddEndpoints add unversioned REST endpoints (e.g. health check)
 func (s *Server) AddEndpoints(endpoints ...RestEndpoint) *Server {
+	s.addEndpoints(s.engine.Group("/"), "", endpoints...)
+	return s
+}
 
-	var group *gin.RouterGroup
-	for _, ep := range endpoints {
+// AddApiVersion add REST endpoints of API version, the endpoints are registered under the version prefix (e.g. /v2/users)
+// The same endpoints (sharing the same services) can be registered in multiple versions
+func (s *Server) AddApiVersion(version *ApiVersion, endpoints ...RestEndpoint) *Server {
+	if _, exists := s.routes[version.Name]; !exists {
+		s.versions = append(s.versions, version)
+	}
+	group := s.engine.Group(version.Prefix(), version.middleware())
+	s.addEndpoints(group, version.Name, endpoints...)
+	return s
+}
 
+// Register the endpoints routes in the router group and in the route table
+func (s *Server) addEndpoints(router *gin.RouterGroup, version string, endpoints ...RestEndpoint) {
+	for _, ep := range endpoints {
+		group := router
 		if len(ep.Path()) > 0 {
-			group = s.engine.Group(ep.Path())
-		} else {
-			group = s.engine.Group("/")
+			group = router.Group(ep.Path())
 		}
 
 		for _, entry := range ep.RestEntries() {
-			group.Handle(entry.Method, entry.Path, entry.Handler)
+			limits, overrideTimeout := RequestLimits{MaxBodySize: entry.MaxBodySize, Timeout: entry.Timeout}.withDefaults(s.limits)
+			var handlers []gin.HandlerFunc
+			if (len(version) > 0 || entry.RateLimit) && s.limiter != nil {
+				handlers = append(handlers, rateLimit(s.limiter, ep.Path()))
+			}
+			handlers = append(handlers, requestLimits(limits, overrideTimeout))
+			if entry.IdempotencyKey && s.idempotency.Cache != nil {
+				handlers = append(handlers, idempotency(s.idempotency))
+			}
+			handlers = append(handlers, decodeRequest(), entry.Handler)
+			group.Handle(entry.Method, entry.Path, handlers...)
+			s.routes[version] = append(s.routes[version], mc.RouteInfo{Method: entry.Method, Path: routePath(group.BasePath(), entry.Path)})
 		}
 	}
+}
+
+// Get the full path of the route (e.g. /v2/users/:id), trailing slash is removed
+func routePath(base, path string) string {
+	result := strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(path, "/")
+	if len(result) > 1 {
+		result = strings.TrimSuffix(result, "/")
+	}
+	return result
+}
+
+// RouteTable returns the registered routes grouped by API version (unversioned routes last)
+func (s *Server) RouteTable() []*mc.ApiVersionInfo {
+	result := make([]*mc.ApiVersionInfo, 0, len(s.versions)+1)
+	for _, v := range s.versions {
+		info := &mc.ApiVersionInfo{Version: v.Name, Link: v.Link, Routes: s.routes[v.Name]}
+		if !v.Deprecated.IsZero() {
+			info.Deprecated = v.Deprecated.UTC().Format(time.RFC3339)
+		}
+		if !v.Sunset.IsZero() {
+			info.Sunset = v.Sunset.UTC().Format(time.RFC3339)
+		}
+		result = append(result, info)
+	}
+	if routes, ok := s.routes[""]; ok {
+		result = append(result, &mc.ApiVersionInfo{Routes: routes})
+	}
+	return result
+}
+
+// AddMetricsEndpoint add the Prometheus metrics endpoint (e.g. /metrics), the endpoint does not require API key or
+// access token, instead it requires the metrics bearer token. The endpoint is not added when the token is empty
+func (s *Server) AddMetricsEndpoint(path, token string) *Server {
+	if len(token) == 0 {
+		logger.Warn("metrics endpoint %s is disabled: metrics token is not configured", path)
+		return s
+	}
+	whiteList[strings.ToLower(path)] = NoApiKey + NoToken
+	s.engine.GET(path, metricsHandler(token))
 	return s
 }
 
@@ -107,6 +234,12 @@ func (s *Server) AddStaticEndpoint(path, folder string) *Server {
 	return s
 }
 
+// AddStaticFileSystem add static files endpoint served from file system (e.g. embedded documentation files)
+func (s *Server) AddStaticFileSystem(path string, fs http.FileSystem) *Server {
+	s.engine.StaticFS(path, fs)
+	return s
+}
+
 // AddStaticFile registers a single route in order to serve a single file of the local filesystem.
 func (s *Server) AddStaticFile(path, relativePath string) *Server {
 	s.engine.StaticFile(path, relativePath)
@@ -117,7 +250,12 @@ func (s *Server) AddStaticFile(path, relativePath string) *Server {
 
 // region REST server builder and starter ------------------------------------------------------------------------------
 
-// Start web server
+// Handler returns the HTTP handler of the server (e.g. to run the server in-process with httptest)
+func (s *Server) Handler() http.Handler {
+	return s.engine
+}
+
+// Start web server, blocks until the server is stopped (returns nil after Shutdown)
 func (s *Server) Start(port int) error {
 
 	_ = s.engine.SetTrustedProxies(nil)
@@ -126,13 +264,71 @@ func (s *Server) Start(port int) error {
 		port = 8080
 	}
 
-	return s.engine.Run(fmt.Sprintf(":%d", port))
+	// h2c is supported by the engine handler (cleartext only)
+	s.engine.UseH2C = s.transport.H2c && !s.transport.TlsEnabled()
+	httpServer, err := s.transport.newHttpServer(fmt.Sprintf(":%d", port), s.engine.Handler())
+	if err != nil {
+		return err
+	}
+
+	// Shutdown may be called before the server is created (e.g. startup failure), the server is not started then
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.httpServer = httpServer
+	s.mu.Unlock()
+
+	if s.transport.TlsEnabled() {
+		// The certificate is provided by the TLS configuration (reloaded on change)
+		err = httpServer.ListenAndServeTLS("", "")
+	} else {
+		err = httpServer.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting new connections and waits for the in-flight requests to complete (until the context deadline)
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	httpServer := s.httpServer
+	s.mu.Unlock()
+
+	if httpServer == nil {
+		return nil
+	}
+	return httpServer.Shutdown(ctx)
 }
 
 // endregion
 
 // region REST server Middlewares --------------------------------------------------------------------------------------
 
+// Set the API key and access token headers from the query params of the streaming routes (if the headers are missing)
+func credentialsFromQuery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		restPath := stripVersion(strings.ToLower(c.Request.URL.Path))
+		for _, path := range queryCredentials {
+			if !strings.HasPrefix(restPath, path) {
+				continue
+			}
+			if apiKey := c.Query("apiKey"); len(apiKey) > 0 && len(c.GetHeader("X-API-KEY")) == 0 {
+				c.Request.Header.Set("X-API-KEY", apiKey)
+			}
+			if token := c.Query("accessToken"); len(token) > 0 && len(c.GetHeader("X-ACCESS-TOKEN")) == 0 {
+				c.Request.Header.Set("X-ACCESS-TOKEN", token)
+			}
+			break
+		}
+		c.Next()
+	}
+}
+
 // Fetch API key from the header and check it
 func apiKeyValidator() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -144,10 +340,7 @@ func apiKeyValidator() gin.HandlerFunc {
 		}
 
 		// Get path and strip version
-		restPath := strings.ToLower(c.Request.URL.Path)
-		if strings.HasPrefix(restPath, "/v1/") {
-			restPath = strings.Replace(restPath, "/v1/", "/", 1)
-		}
+		restPath := stripVersion(strings.ToLower(c.Request.URL.Path))
 
 		// Handle root
 		if restPath == "/" || len(restPath) == 0 {
@@ -185,10 +378,7 @@ func tokenValidator() gin.HandlerFunc {
 		}
 
 		// Get path and strip version
-		restPath := strings.ToLower(c.Request.URL.Path)
-		if strings.HasPrefix(restPath, "/v1/") {
-			restPath = strings.Replace(restPath, "/v1/", "/", 1)
-		}
+		restPath := stripVersion(strings.ToLower(c.Request.URL.Path))
 
 		// Handle root
 		if restPath == "/" || len(restPath) == 0 {
@@ -212,6 +402,8 @@ func tokenValidator() gin.HandlerFunc {
 			return
 		}
 
+		c.Set(SubjectIdKey, td.SubjectId)
+
 		// Set new token
 		if td.ExpiresIn > 0 {
 			td.ExpiresIn = int64(entity.Now() + 1000*60*30)
@@ -249,20 +441,19 @@ func disableCache() gin.HandlerFunc {
 	}
 }
 
-// Add custom recovery from any error
+// Recover from panic of the request handler: log the panic with the request ID and stack trace and respond with error
 func customRecovery(c *gin.Context, recovered any) {
-	if err, ok := recovered.(string); ok {
-		c.String(http.StatusInternalServerError, fmt.Sprintf("error: %s", err))
-	}
-	c.AbortWithStatus(http.StatusInternalServerError)
+	logger.Error("[%s] %s %s panic recovered: %v\n%s", GetRequestId(c), c.Request.Method, c.Request.URL.Path, recovered, debug.Stack())
+	_ = c.Error(fmt.Errorf("panic: %v", recovered))
+	c.AbortWithStatusJSON(http.StatusInternalServerError, rest.NewErrorResponse(errors.New("internal server error")))
 }
 
 // Enable CORS
 func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, X-API-KEY, X-ACCESS-TOKEN, X-TIMEZONE, accept, origin, Cache-Control, X-Requested-With, Content-Disposition, Content-Filename")
-		c.Writer.Header().Set("Access-Control-Exposed-Headers", "X-API-KEY, X-ACCESS-TOKEN, X-TIMEZONE, Content-Disposition, Content-Filename")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, X-API-KEY, X-ACCESS-TOKEN, X-TIMEZONE, X-Request-ID, Idempotency-Key, traceparent, tracestate, accept, origin, Cache-Control, X-Requested-With, Content-Disposition, Content-Filename")
+		c.Writer.Header().Set("Access-Control-Exposed-Headers", "X-API-KEY, X-ACCESS-TOKEN, X-TIMEZONE, X-Request-ID, X-API-VERSION, X-BUILD-TAG, Deprecation, Sunset, Link, Idempotent-Replayed, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After, traceparent, Content-Disposition, Content-Filename")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, HEAD")
 		c.Writer.Header().Set("Access-Control-Max-Age", "86400")
 
@@ -275,10 +466,10 @@ func corsMiddleware() gin.HandlerFunc {
 	}
 }
 
-// Add response header with API version
-func apiVersion() gin.HandlerFunc {
+// Add response header with the service build tag (the API version header is added by the version routes)
+func buildTag() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Header("X-API-VERSION", common.GetServiceHub().Version)
+		c.Header("X-BUILD-TAG", common.GetServiceHub().Version)
 	}
 }
 
@@ -0,0 +1,135 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/rest"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+	mc "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/utils"
+)
+
+// Test endpoint with a handler that panics
+type panicEndPoint struct {
+	BaseEndPoint
+}
+
+func (e *panicEndPoint) Path() string {
+	return "/panic"
+}
+
+func (e *panicEndPoint) RestEntries() []RestEntry {
+	return []RestEntry{{Method: http.MethodGet, Handler: e.panic, Path: ""}}
+}
+
+func (e *panicEndPoint) panic(c *gin.Context) {
+	panic("handler failed")
+}
+
+// Create request with valid API key and access token
+func newAuthorizedRequest(t *testing.T, method, target string) *http.Request {
+	t.Helper()
+	apiKey, err := utils.TokenUtils().CreateApiKey("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := utils.TokenUtils().CreateToken(&mc.TokenData{SubjectId: "tester"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("X-API-KEY", apiKey)
+	req.Header.Set("X-ACCESS-TOKEN", token)
+	return req
+}
+
+func TestRecoveryRespondsWithErrorBody(t *testing.T) {
+	// The handler panics inside the request limits middleware (the build tag middleware requires the service hub)
+	common.NewServiceHub()
+	server := NewRESTServer(config.GetConfig()).AddEndpoints(&panicEndPoint{})
+
+	req := newAuthorizedRequest(t, http.MethodGet, "/panic")
+	req.Header.Set(RequestIdHeader, "req-1")
+	w := httptest.NewRecorder()
+	server.Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500 but got %d", w.Code)
+	}
+	if id := w.Header().Get(RequestIdHeader); id != "req-1" {
+		t.Errorf("expected request ID req-1 but got %q", id)
+	}
+	body := rest.BaseRestResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("expected json error body: %v (%s)", err, w.Body.String())
+	}
+	if body.Error != "internal server error" {
+		t.Errorf("unexpected error body: %s", w.Body.String())
+	}
+}
+
+func TestMetricsEndpointAuth(t *testing.T) {
+	common.NewServiceHub()
+
+	// Without token the endpoint is not registered
+	server := NewRESTServer(config.GetConfig()).AddMetricsEndpoint("/metrics-disabled", "")
+	w := httptest.NewRecorder()
+	server.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics-disabled", nil))
+	if w.Code == http.StatusOK {
+		t.Fatalf("expected the metrics endpoint to be disabled but got status %d", w.Code)
+	}
+
+	server = NewRESTServer(config.GetConfig()).AddMetricsEndpoint("/metrics", "secret")
+	tests := []struct {
+		name   string
+		auth   string
+		status int
+	}{
+		{"missing token", "", http.StatusUnauthorized},
+		{"invalid token", "Bearer invalid", http.StatusUnauthorized},
+		{"valid token", "Bearer secret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if len(tt.auth) > 0 {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			w := httptest.NewRecorder()
+			server.Handler().ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Errorf("expected status %d but got %d", tt.status, w.Code)
+			}
+		})
+	}
+}
+
+func TestShutdownBeforeStart(t *testing.T) {
+	server := NewRESTServer(config.GetConfig())
+	if err := server.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	// The server is not started after shutdown (e.g. startup failure before the server goroutine runs)
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Start(0)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected nil error but got %v", err)
+		}
+	case <-time.After(time.Second):
+		_ = server.Shutdown(context.Background())
+		t.Fatal("expected the server not to start after shutdown")
+	}
+}
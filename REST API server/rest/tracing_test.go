@@ -0,0 +1,132 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rest"
+	usr "github.com/go-yaaf/yaaf-examples/rest-api/rest/user"
+	"github.com/go-yaaf/yaaf-examples/rest-api/utils"
+)
+
+// Trace context of the incoming request (the server span is child of this remote span)
+const (
+	parentTraceId   = "4bf92f3577b34da6a3ce929d0e0e4736"
+	parentSpanId    = "00f067aa0ba902b7"
+	parentTraceCtx  = "00-" + parentTraceId + "-" + parentSpanId + "-01"
+	tracedRequestId = "trace-req-1"
+)
+
+// Get the attribute value of the span (empty if not found)
+func spanAttribute(attrs []attribute.KeyValue, key string) string {
+	for _, attr := range attrs {
+		if string(attr.Key) == key {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestTracingSpanHierarchy(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	common.InitTracingWithProcessor(recorder)
+	t.Cleanup(func() { _ = common.ShutdownTracing(context.Background()) })
+
+	hub := common.NewServiceHub()
+	user := NewUser().(*User)
+	user.Id, user.Email, user.Name = "u1", "user@example.com", "User"
+	if _, err := hub.Database.Insert(user); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.GetConfig()
+	server := rest.NewRESTServer(cfg)
+	for _, version := range usr.NewUserApiVersions(cfg) {
+		server.AddApiVersion(version, usr.NewListOfUserRestEndPoints(hub)...)
+	}
+
+	apiKey, err := utils.TokenUtils().CreateApiKey("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := utils.TokenUtils().CreateToken(&TokenData{SubjectId: user.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/"+user.Id, nil)
+	req.Header.Set("X-API-KEY", apiKey)
+	req.Header.Set("X-ACCESS-TOKEN", token)
+	req.Header.Set("traceparent", parentTraceCtx)
+	req.Header.Set(rest.RequestIdHeader, tracedRequestId)
+	w := httptest.NewRecorder()
+	server.Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 but got %d: %s", w.Code, w.Body.String())
+	}
+	if id := w.Header().Get(rest.RequestIdHeader); id != tracedRequestId {
+		t.Errorf("expected response request ID %s but got %q", tracedRequestId, id)
+	}
+
+	spans := map[string]int{}
+	ended := recorder.Ended()
+	for i, span := range ended {
+		spans[span.Name()] = i
+	}
+	httpIdx, found := spans["GET /v1/users/:id"]
+	if !found {
+		t.Fatalf("http span not found in %v", spans)
+	}
+	serviceIdx, found := spans["UsersService.Get"]
+	if !found {
+		t.Fatalf("service span not found in %v", spans)
+	}
+	dbIdx, found := spans[common.MiddlewareDatabase+".Get"]
+	if !found {
+		t.Fatalf("database span not found in %v", spans)
+	}
+	httpSpan, serviceSpan, dbSpan := ended[httpIdx], ended[serviceIdx], ended[dbIdx]
+
+	// The server span continues the trace of the incoming request
+	if got := httpSpan.SpanContext().TraceID().String(); got != parentTraceId {
+		t.Errorf("expected trace ID %s but got %s", parentTraceId, got)
+	}
+	if got := httpSpan.Parent().SpanID().String(); got != parentSpanId || !httpSpan.Parent().IsRemote() {
+		t.Errorf("expected remote parent span %s but got %s", parentSpanId, got)
+	}
+	if httpSpan.SpanKind() != trace.SpanKindServer {
+		t.Errorf("expected server span but got %s", httpSpan.SpanKind())
+	}
+	if got := spanAttribute(httpSpan.Attributes(), "request.id"); got != tracedRequestId {
+		t.Errorf("expected request.id attribute %s but got %q", tracedRequestId, got)
+	}
+	if got := spanAttribute(httpSpan.Attributes(), "enduser.id"); got != user.Id {
+		t.Errorf("expected enduser.id attribute %s but got %q", user.Id, got)
+	}
+
+	// HTTP -> service -> database
+	if serviceSpan.Parent().SpanID() != httpSpan.SpanContext().SpanID() {
+		t.Errorf("expected service span to be child of the http span")
+	}
+	if dbSpan.Parent().SpanID() != serviceSpan.SpanContext().SpanID() {
+		t.Errorf("expected database span to be child of the service span")
+	}
+	if dbSpan.SpanKind() != trace.SpanKindClient {
+		t.Errorf("expected client span but got %s", dbSpan.SpanKind())
+	}
+
+	// The trace context is returned in the response
+	if tp := w.Header().Get("traceparent"); tp != "00-"+parentTraceId+"-"+httpSpan.SpanContext().SpanID().String()+"-01" {
+		t.Errorf("unexpected traceparent response header: %q", tp)
+	}
+}
@@ -0,0 +1,186 @@
+package rest
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-yaaf/yaaf-common/logger"
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+)
+
+// Minimal interval between checks of the certificate files modification (the check is done on TLS handshake)
+const certCheckInterval = 10 * time.Second
+
+// region Transport configuration --------------------------------------------------------------------------------------
+
+// TransportConfig is the HTTP server transport configuration: TLS (and mutual TLS), HTTP/2, h2c, timeouts and limits
+type TransportConfig struct {
+	CertFile          string        // TLS certificate file (PEM), TLS is enabled when both certificate and key are set
+	KeyFile           string        // TLS private key file (PEM)
+	ClientCaFile      string        // Client CA certificates file (PEM), requires and verifies client certificate
+	Http2             bool          // Enable HTTP/2 over TLS
+	H2c               bool          // Enable HTTP/2 over cleartext (ignored when TLS is enabled)
+	ReadTimeout       time.Duration // Timeout to read the entire request (zero for no timeout)
+	ReadHeaderTimeout time.Duration // Timeout to read the request headers (zero for no timeout)
+	WriteTimeout      time.Duration // Timeout to write the response (zero for no timeout)
+	IdleTimeout       time.Duration // Timeout of idle keep-alive connection (zero for no timeout)
+	MaxHeaderBytes    int           // Maximum size of the request headers
+}
+
+// NewTransportConfig creates the transport configuration from the service configuration
+func NewTransportConfig(cfg *config.ServiceConfig) TransportConfig {
+	return TransportConfig{
+		CertFile:          cfg.TlsCertFile(),
+		KeyFile:           cfg.TlsKeyFile(),
+		ClientCaFile:      cfg.TlsClientCaFile(),
+		Http2:             cfg.Http2Enabled(),
+		H2c:               cfg.H2cEnabled(),
+		ReadTimeout:       cfg.HttpReadTimeout(),
+		ReadHeaderTimeout: cfg.HttpReadHeaderTimeout(),
+		WriteTimeout:      cfg.HttpWriteTimeout(),
+		IdleTimeout:       cfg.HttpIdleTimeout(),
+		MaxHeaderBytes:    cfg.HttpMaxHeaderBytes(),
+	}
+}
+
+// TlsEnabled returns true if both TLS certificate and key are configured
+func (t TransportConfig) TlsEnabled() bool {
+	return len(t.CertFile) > 0 && len(t.KeyFile) > 0
+}
+
+// Create the HTTP server of the handler with the transport configuration
+func (t TransportConfig) newHttpServer(addr string, handler http.Handler) (*http.Server, error) {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadTimeout:       t.ReadTimeout,
+		ReadHeaderTimeout: t.ReadHeaderTimeout,
+		WriteTimeout:      t.WriteTimeout,
+		IdleTimeout:       t.IdleTimeout,
+		MaxHeaderBytes:    t.MaxHeaderBytes,
+	}
+
+	if !t.TlsEnabled() {
+		return srv, nil
+	}
+
+	reloader, err := newCertReloader(t.CertFile, t.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	srv.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	if len(t.ClientCaFile) > 0 {
+		pem, er := os.ReadFile(t.ClientCaFile)
+		if er != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", er)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificate in client CA file: %s", t.ClientCaFile)
+		}
+		srv.TLSConfig.ClientCAs = pool
+		srv.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	// HTTP/2 is negotiated by default (ALPN), non-nil empty map disables it
+	if !t.Http2 {
+		srv.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
+	}
+	return srv, nil
+}
+
+// endregion
+
+// region Certificate reloader -----------------------------------------------------------------------------------------
+
+// Serve the TLS certificate and reload it when the certificate or key files are modified (e.g. certificate renewal)
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time // Latest modification time of the certificate and key files
+	checked time.Time // Last check of the files modification time
+}
+
+// Create the certificate reloader, the certificate must be valid on start
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	modTime, err := r.modificationTime()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.load(modTime); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate returns the current certificate (tls.Config callback), the files are checked for modification at most
+// once in certCheckInterval, in case of failure to reload the previous certificate is kept
+func (r *certReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checked) < certCheckInterval {
+		return r.cert, nil
+	}
+	r.checked = time.Now()
+
+	modTime, err := r.modificationTime()
+	if err != nil {
+		logger.Warn("failed to check TLS certificate files: %s", err.Error())
+		return r.cert, nil
+	}
+	if modTime.Equal(r.modTime) {
+		return r.cert, nil
+	}
+	if err = r.load(modTime); err != nil {
+		logger.Warn("failed to reload TLS certificate, keeping the previous certificate: %s", err.Error())
+		return r.cert, nil
+	}
+	logger.Info("TLS certificate reloaded: %s", r.certFile)
+	return r.cert, nil
+}
+
+// Load the certificate and key pair (the caller holds the lock or owns the reloader)
+func (r *certReloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	r.cert = &cert
+	r.modTime = modTime
+	r.checked = time.Now()
+	return nil
+}
+
+// Get the latest modification time of the certificate and key files
+func (r *certReloader) modificationTime() (time.Time, error) {
+	var result time.Time
+	var errs []error
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if info.ModTime().After(result) {
+			result = info.ModTime()
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// endregion
@@ -9,6 +9,7 @@ import (
 	. "github.com/go-yaaf/yaaf-common/entity"
 	"github.com/go-yaaf/yaaf-common/rest"
 
+	meta "github.com/go-yaaf/yaaf-examples/rest-api/model"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/rest"
@@ -21,6 +22,7 @@ import (
 // @Service: AccountsService
 // @Path: /accounts
 // @Context: usr-accounts
+// @ApiVersion: v1, v2
 // @RequestHeader: X-API-KEY     | The key to identify the application (dashboard)
 // @RequestHeader: Authorization | The bearer token to identify the logged-in user
 // @ResourceGroup: Accounts Actions
@@ -35,13 +37,13 @@ func NewAccountsEndPoint(service *s.AccountsService) RestEndpoint {
 }
 
 func (h *AccountsEndPoint) Path() string {
-	return usrApiVersion + "/accounts"
+	return "/accounts"
 }
 
 func (h *AccountsEndPoint) RestEntries() (restEntries []RestEntry) {
 	restEntries = []RestEntry{
-		{Method: http.MethodPost, Handler: h.create, Path: ""},
-		{Method: http.MethodPost, Handler: h.create, Path: "/"},
+		{Method: http.MethodPost, Handler: h.create, Path: "", IdempotencyKey: true},
+		{Method: http.MethodPost, Handler: h.create, Path: "/", IdempotencyKey: true},
 		{Method: http.MethodPost, Handler: h.new, Path: "/new"},
 
 		{Method: http.MethodPut, Handler: h.update, Path: ""},
@@ -52,6 +54,7 @@ func (h *AccountsEndPoint) RestEntries() (restEntries []RestEntry) {
 
 		{Method: http.MethodGet, Handler: h.find, Path: ""},
 		{Method: http.MethodGet, Handler: h.find, Path: "/"},
+		{Method: http.MethodGet, Handler: h.export, Path: "/export", Timeout: ExportTimeout},
 	}
 
 	// Sort entries for best match
@@ -167,6 +170,7 @@ func (h *AccountsEndPoint) delete(c *gin.Context) {
 // Get a single account by id
 // @Http: GET /{id}
 // @PathParam: id | string | account ID to fetch
+// @QueryParam: fields | []string | list of fields (json paths) to include in the result (e.g. id,name,status)
 // @Return: EntityResponse<Account>
 func (h *AccountsEndPoint) get(c *gin.Context) {
 	// Get token data
@@ -177,20 +181,28 @@ func (h *AccountsEndPoint) get(c *gin.Context) {
 
 	id := c.Params.ByName("id")
 
+	fields, err := h.GetParamAsFields(c, "fields", NewAccount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
 	if entity, err := h.service.Get(td, id); err != nil {
 		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
 	} else {
-		c.JSON(http.StatusOK, rest.NewEntityResponse(entity))
+		c.JSON(http.StatusOK, rest.NewEntityResponse(meta.ProjectEntity(entity, fields)))
 	}
 }
 
 // Find accounts by query
 // @Http: GET /
 // @QueryParam: search | string              | filter accounts by free text search on account id, account name
+// @QueryParam: filter | string              | filter expression (e.g. status in (ACTIVE,BLOCKED) and name like 'acme*')
 // @QueryParam: status | []AccountStatusCode | filter accounts by status(s)
 // @QueryParam: sort   | string              | sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
 // @QueryParam: page   | int                 | page number (for pagination)
 // @QueryParam: size   | int                 | number of items per page (for pagination)
+// @QueryParam: fields | []string            | list of fields (json paths) to include in the results (e.g. id,name,status)
 // @Return: EntitiesResponse<Account>
 func (h *AccountsEndPoint) find(c *gin.Context) {
 	// Get token data
@@ -199,18 +211,73 @@ func (h *AccountsEndPoint) find(c *gin.Context) {
 		return
 	}
 
+	fields, err := h.GetParamAsFields(c, "fields", NewAccount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	criteria, err := h.GetParamAsFilter(c, "filter", NewAccount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
 	p := s.AccountsFindParams{
 		Search: h.GetParamAsString(c, "search", ""),
 		Status: h.GetParamAsEnumArray(c, "status", *AccountStatusCodes),
 		Sort:   h.GetParamAsString(c, "sort", "name"),
 		Page:   h.GetParamAsInt(c, "page", 1),
 		Size:   h.GetParamAsInt(c, "size", 100),
+		Fields: fields,
+		Filter: criteria,
 	}
-	if list, total, _, err := h.service.Find(p); err != nil {
+	if list, total, _, err := h.service.Find(td, p); err != nil {
 		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
 	} else {
 		c.JSON(http.StatusOK, rest.NewEntitiesResponse(list, p.Page, p.Size, int(total)))
 	}
 }
 
+// Export accounts by query to file (csv, ndjson or xlsx), all the matching accounts are exported (no pagination)
+// @Http: GET /export
+// @QueryParam: format | string              | export file format: csv | ndjson | xlsx (default: csv)
+// @QueryParam: search | string              | filter accounts by free text search on account id, account name
+// @QueryParam: filter | string              | filter expression (e.g. status in (ACTIVE,BLOCKED) and name like 'acme*')
+// @QueryParam: status | []AccountStatusCode | filter accounts by status(s)
+// @QueryParam: sort   | string              | sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+// @QueryParam: fields | []string            | list of fields (json paths) to export (default: all fields) (e.g. id,name,status)
+// @Return: file
+func (h *AccountsEndPoint) export(c *gin.Context) {
+	// Get token data
+	td := h.GetTokenData(c)
+	if td == nil {
+		return
+	}
+
+	fields, err := h.GetParamAsFields(c, "fields", NewAccount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	criteria, err := h.GetParamAsFilter(c, "filter", NewAccount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	p := s.AccountsFindParams{
+		Search: h.GetParamAsString(c, "search", ""),
+		Status: h.GetParamAsEnumArray(c, "status", *AccountStatusCodes),
+		Sort:   h.GetParamAsString(c, "sort", "name"),
+		Fields: fields,
+		Filter: criteria,
+	}
+
+	h.Export(c, "accounts", NewAccount, p.Fields, func(cb EntityCallback) error {
+		return h.service.Export(td, p, cb)
+	})
+}
+
 // endregion
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-yaaf/yaaf-common/rest"
 
+	meta "github.com/go-yaaf/yaaf-examples/rest-api/model"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/rest"
 	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
@@ -18,6 +19,7 @@ import (
 // @Service: AuditLogsService
 // @Path: /audit_logs
 // @Context: usr-auditLogs
+// @ApiVersion: v1, v2
 // @RequestHeader: X-API-KEY     | The key to identify the application (dashboard)
 // @RequestHeader: Authorization | The bearer token to identify the logged-in user
 // @ResourceGroup: AuditLogs Actions
@@ -32,18 +34,19 @@ func NewAuditLogsEndPoint(service *s.AuditLogsService) RestEndpoint {
 }
 
 func (h *AuditLogsEndPoint) Path() string {
-	return usrApiVersion + "/audit_logs"
+	return "/audit_logs"
 }
 
 func (h *AuditLogsEndPoint) RestEntries() (restEntries []RestEntry) {
 	restEntries = []RestEntry{
-		{Method: http.MethodPost, Handler: h.create, Path: ""},
-		{Method: http.MethodPost, Handler: h.create, Path: "/"},
+		{Method: http.MethodPost, Handler: h.create, Path: "", IdempotencyKey: true},
+		{Method: http.MethodPost, Handler: h.create, Path: "/", IdempotencyKey: true},
 
 		{Method: http.MethodGet, Handler: h.get, Path: "/:id"},
 
 		{Method: http.MethodGet, Handler: h.find, Path: ""},
 		{Method: http.MethodGet, Handler: h.find, Path: "/"},
+		{Method: http.MethodGet, Handler: h.export, Path: "/export", Timeout: ExportTimeout},
 		{Method: http.MethodGet, Handler: h.histogram, Path: "/histogram"},
 	}
 
@@ -87,6 +90,7 @@ func (h *AuditLogsEndPoint) create(c *gin.Context) {
 // Get a single auditLog by id
 // @Http: GET /{id}
 // @PathParam: id | string | auditLog ID to fetch
+// @QueryParam: fields | []string | list of fields (json paths) to include in the result (e.g. id,action,itemName)
 // @Return: EntityResponse<AuditLog>
 func (h *AuditLogsEndPoint) get(c *gin.Context) {
 	// Get token data
@@ -97,10 +101,16 @@ func (h *AuditLogsEndPoint) get(c *gin.Context) {
 
 	id := c.Params.ByName("id")
 
+	fields, err := h.GetParamAsFields(c, "fields", NewAuditLog)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
 	if entity, err := h.service.Get(td, id); err != nil {
 		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
 	} else {
-		c.JSON(http.StatusOK, rest.NewEntityResponse(entity))
+		c.JSON(http.StatusOK, rest.NewEntityResponse(meta.ProjectEntity(entity, fields)))
 	}
 }
 
@@ -114,9 +124,11 @@ func (h *AuditLogsEndPoint) get(c *gin.Context) {
 // @QueryParam: itemId   | string              | filter auditLogs by item id
 // @QueryParam: itemName | string              | filter auditLogs by item name
 // @QueryParam: search   | string              | filter auditLogs by free text search
+// @QueryParam: filter   | string              | filter expression (e.g. action = 'Delete' and not itemType in ('user','account'))
 // @QueryParam: sort     | string              | sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
 // @QueryParam: page     | int                 | page number (for pagination)
 // @QueryParam: size     | int                 | number of items per page (for pagination)
+// @QueryParam: fields   | []string            | list of fields (json paths) to include in the results (e.g. id,action,itemName)
 // @Return: EntitiesResponse<AuditLog>
 func (h *AuditLogsEndPoint) find(c *gin.Context) {
 	// Get token data
@@ -125,6 +137,18 @@ func (h *AuditLogsEndPoint) find(c *gin.Context) {
 		return
 	}
 
+	fields, err := h.GetParamAsFields(c, "fields", NewAuditLog)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	criteria, err := h.GetParamAsFilter(c, "filter", NewAuditLog)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
 	p := s.AuditLogsFindParams{
 		From:     h.GetParamAsTimestamp(c, "from", 0),
 		To:       h.GetParamAsTimestamp(c, "to", 0),
@@ -137,15 +161,70 @@ func (h *AuditLogsEndPoint) find(c *gin.Context) {
 		Sort:     h.GetParamAsString(c, "sort", "createdOn-"),
 		Page:     h.GetParamAsInt(c, "page", 1),
 		Size:     h.GetParamAsInt(c, "size", 100),
+		Fields:   fields,
+		Filter:   criteria,
 	}
 
-	if list, total, _, err := h.service.Find(p); err != nil {
+	if list, total, _, err := h.service.Find(td, p); err != nil {
 		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
 	} else {
 		c.JSON(http.StatusOK, rest.NewEntitiesResponse(list, p.Page, p.Size, int(total)))
 	}
 }
 
+// Export auditLogs by query to file (csv, ndjson or xlsx), all the matching auditLogs are exported (no pagination)
+// @Http: GET /export
+// @QueryParam: format   | string              | export file format: csv | ndjson | xlsx (default: csv)
+// @QueryParam: from     | Timestamp           | start of time range filter
+// @QueryParam: to       | Timestamp           | end of time range filter
+// @QueryParam: userId   | string              | filter auditLogs by user id
+// @QueryParam: action   | string              | filter auditLogs by action
+// @QueryParam: itemType | string              | filter auditLogs by item type
+// @QueryParam: itemId   | string              | filter auditLogs by item id
+// @QueryParam: itemName | string              | filter auditLogs by item name
+// @QueryParam: search   | string              | filter auditLogs by free text search
+// @QueryParam: filter   | string              | filter expression (e.g. action = 'Delete' and not itemType in ('user','account'))
+// @QueryParam: sort     | string              | sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+// @QueryParam: fields   | []string            | list of fields (json paths) to export (default: all fields) (e.g. id,action,itemName)
+// @Return: file
+func (h *AuditLogsEndPoint) export(c *gin.Context) {
+	// Get token data
+	td := h.GetTokenData(c)
+	if td == nil {
+		return
+	}
+
+	fields, err := h.GetParamAsFields(c, "fields", NewAuditLog)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	criteria, err := h.GetParamAsFilter(c, "filter", NewAuditLog)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	p := s.AuditLogsFindParams{
+		From:     h.GetParamAsTimestamp(c, "from", 0),
+		To:       h.GetParamAsTimestamp(c, "to", 0),
+		UserId:   h.GetParamAsString(c, "userId", ""),
+		Action:   h.GetParamAsString(c, "action", ""),
+		ItemType: h.GetParamAsString(c, "itemType", ""),
+		ItemId:   h.GetParamAsString(c, "itemId", ""),
+		ItemName: h.GetParamAsString(c, "itemName", ""),
+		Search:   h.GetParamAsString(c, "search", ""),
+		Sort:     h.GetParamAsString(c, "sort", "createdOn-"),
+		Fields:   fields,
+		Filter:   criteria,
+	}
+
+	h.Export(c, "audit-logs", NewAuditLog, p.Fields, func(cb EntityCallback) error {
+		return h.service.Export(td, p, cb)
+	})
+}
+
 // Find auditLogs count histogram over time
 // @Http: GET /histogram
 // @QueryParam: from     | Timestamp           | start of time range filter
@@ -156,6 +235,7 @@ func (h *AuditLogsEndPoint) find(c *gin.Context) {
 // @QueryParam: itemId   | string              | filter auditLogs by item id
 // @QueryParam: itemName | string              | filter auditLogs by item name
 // @QueryParam: search   | string              | filter auditLogs by free text search
+// @QueryParam: filter   | string              | filter expression (e.g. action = 'Delete' and not itemType in ('user','account'))
 // @QueryParam: sort     | string              | sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
 // @QueryParam: page     | int                 | page number (for pagination)
 // @QueryParam: size     | int                 | number of items per page (for pagination)
@@ -167,6 +247,12 @@ func (h *AuditLogsEndPoint) histogram(c *gin.Context) {
 		return
 	}
 
+	criteria, err := h.GetParamAsFilter(c, "filter", NewAuditLog)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
 	p := s.AuditLogsFindParams{
 		From:     h.GetParamAsTimestamp(c, "from", -1000*60*60*24*30),
 		To:       h.GetParamAsTimestamp(c, "to", -1),
@@ -179,9 +265,10 @@ func (h *AuditLogsEndPoint) histogram(c *gin.Context) {
 		Sort:     h.GetParamAsString(c, "sort", ""),
 		Page:     h.GetParamAsInt(c, "page", 1),
 		Size:     h.GetParamAsInt(c, "size", 100),
+		Filter:   criteria,
 	}
 
-	if result, err := h.service.Histogram(p); err != nil {
+	if result, err := h.service.Histogram(td, p); err != nil {
 		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
 	} else {
 		c.JSON(http.StatusOK, rest.NewEntityResponse(result))
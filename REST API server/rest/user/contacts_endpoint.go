@@ -1,26 +1,40 @@
 package rest
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	. "github.com/go-yaaf/yaaf-common/entity"
 	"github.com/go-yaaf/yaaf-common/rest"
 
+	meta "github.com/go-yaaf/yaaf-examples/rest-api/model"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/rest"
 	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
 )
 
+// Limits of the import route (file upload, larger than the default limits)
+const (
+	importMaxBodySize = 32 << 20
+	importTimeout     = 5 * time.Minute
+)
+
 // region Endpoint structure and factory method ------------------------------------------------------------------------
 
 // ContactsEndPoint Services for contacts actions
 // @Service: ContactsService
 // @Path: /contacts
 // @Context: usr-contacts
+// @ApiVersion: v1, v2
 // @RequestHeader: X-API-KEY     | The key to identify the application (dashboard)
 // @RequestHeader: Authorization | The bearer token to identify the logged-in user
 // @ResourceGroup: Contacts Actions
@@ -35,14 +49,15 @@ func NewContactsEndPoint(service *s.ContactsService) RestEndpoint {
 }
 
 func (h *ContactsEndPoint) Path() string {
-	return usrApiVersion + "/contacts"
+	return "/contacts"
 }
 
 func (h *ContactsEndPoint) RestEntries() (restEntries []RestEntry) {
 	restEntries = []RestEntry{
-		{Method: http.MethodPost, Handler: h.create, Path: ""},
-		{Method: http.MethodPost, Handler: h.create, Path: "/"},
+		{Method: http.MethodPost, Handler: h.create, Path: "", IdempotencyKey: true},
+		{Method: http.MethodPost, Handler: h.create, Path: "/", IdempotencyKey: true},
 		{Method: http.MethodPost, Handler: h.new, Path: "/new"},
+		{Method: http.MethodPost, Handler: h.importFile, Path: "/import", MaxBodySize: importMaxBodySize, Timeout: importTimeout, IdempotencyKey: true},
 
 		{Method: http.MethodPut, Handler: h.update, Path: ""},
 		{Method: http.MethodPut, Handler: h.update, Path: "/"},
@@ -52,6 +67,7 @@ func (h *ContactsEndPoint) RestEntries() (restEntries []RestEntry) {
 
 		{Method: http.MethodGet, Handler: h.find, Path: ""},
 		{Method: http.MethodGet, Handler: h.find, Path: "/"},
+		{Method: http.MethodGet, Handler: h.export, Path: "/export", Timeout: ExportTimeout},
 	}
 
 	// Sort entries for best match
@@ -111,6 +127,61 @@ func (h *ContactsEndPoint) create(c *gin.Context) {
 	}
 }
 
+// Import contacts from CSV or vCard file, run in dry-run mode first to get the validation report and then commit
+// @Http: POST /import
+// @QueryParam: format    | string | file format: csv | vcard (default: by the file extension or csv)
+// @QueryParam: mapping   | string | CSV column mapping json: contact field -> column header (e.g. {"name":"Full Name","address.city":"City"})
+// @QueryParam: accountId | string | related account of the imported contacts (rows of other accounts are invalid)
+// @QueryParam: dryRun    | bool   | validate the file without saving the contacts (default: true)
+// @BodyParam: file       | file   | CSV / vCard file (multipart form field or the raw request body)
+// @Return: EntityResponse<ImportReport>
+func (h *ContactsEndPoint) importFile(c *gin.Context) {
+
+	// Get token data
+	td := h.GetTokenData(c)
+	if td == nil {
+		return
+	}
+
+	p := s.ContactsImportParams{
+		Format:    h.GetParamAsString(c, "format", ""),
+		AccountId: h.GetParamAsString(c, "accountId", ""),
+		DryRun:    h.GetParamAsBool(c, "dryRun", true),
+	}
+
+	if mapping := h.GetParamAsString(c, "mapping", c.PostForm("mapping")); len(mapping) > 0 {
+		if err := json.Unmarshal([]byte(mapping), &p.Mapping); err != nil {
+			c.JSON(http.StatusBadRequest, rest.NewErrorResponse(fmt.Errorf("invalid mapping: %v", err)))
+			return
+		}
+	}
+
+	// Read the file from multipart form or from the request body
+	var reader io.Reader = c.Request.Body
+	if file, header, err := c.Request.FormFile("file"); err == nil {
+		defer func() { _ = file.Close() }()
+		reader = file
+		if len(p.Format) == 0 {
+			p.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+		}
+	}
+	if len(p.Format) == 0 && strings.Contains(c.ContentType(), "vcard") {
+		p.Format = "vcard"
+	}
+
+	// Invalid file is a bad request, the service errors include the status (e.g. database failure)
+	if report, err := h.service.Import(td, reader, p); err != nil {
+		status := http.StatusBadRequest
+		var serviceError Error
+		if errors.As(err, &serviceError) {
+			status = serviceError.Code()
+		}
+		c.JSON(status, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntityResponse(report))
+	}
+}
+
 // Update existing contact
 // @Http: PUT /
 // @BodyParam: body | Contact | contact data to update
@@ -165,6 +236,7 @@ func (h *ContactsEndPoint) delete(c *gin.Context) {
 // Get a single contact by id
 // @Http: GET /{id}
 // @PathParam: id | string | contact ID to fetch
+// @QueryParam: fields | []string | list of fields (json paths) to include in the result (e.g. id,name,address.city)
 // @Return: EntityResponse<Contact>
 func (h *ContactsEndPoint) get(c *gin.Context) {
 	// Get token data
@@ -175,20 +247,28 @@ func (h *ContactsEndPoint) get(c *gin.Context) {
 
 	id := c.Params.ByName("id")
 
+	fields, err := h.GetParamAsFields(c, "fields", NewContact)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
 	if entity, err := h.service.Get(td, id); err != nil {
 		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
 	} else {
-		c.JSON(http.StatusOK, rest.NewEntityResponse(entity))
+		c.JSON(http.StatusOK, rest.NewEntityResponse(meta.ProjectEntity(entity, fields)))
 	}
 }
 
 // Find contacts by query
 // @Http: GET /
 // @QueryParam: search | string              | filter contacts by free text search
+// @QueryParam: filter | string              | filter expression (e.g. address.city = 'London' or name like 'john*')
 // @QueryParam: status | []StatusCode        | filter contacts by status(es)
 // @QueryParam: sort   | string              | sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
 // @QueryParam: page   | int                 | page number (for pagination)
 // @QueryParam: size   | int                 | number of items per page (for pagination)
+// @QueryParam: fields | []string            | list of fields (json paths) to include in the results (e.g. id,name,address.city)
 // @Return: EntitiesResponse<Contact>
 func (h *ContactsEndPoint) find(c *gin.Context) {
 	// Get token data
@@ -197,18 +277,73 @@ func (h *ContactsEndPoint) find(c *gin.Context) {
 		return
 	}
 
+	fields, err := h.GetParamAsFields(c, "fields", NewContact)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	criteria, err := h.GetParamAsFilter(c, "filter", NewContact)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
 	p := s.ContactsFindParams{
 		Search: h.GetParamAsString(c, "search", ""),
 		Status: h.GetParamAsEnumArray(c, "status", *StatusCodes),
 		Sort:   h.GetParamAsString(c, "sort", "lastName"),
 		Page:   h.GetParamAsInt(c, "page", 1),
 		Size:   h.GetParamAsInt(c, "size", 100),
+		Fields: fields,
+		Filter: criteria,
 	}
-	if list, total, _, err := h.service.Find(p); err != nil {
+	if list, total, _, err := h.service.Find(td, p); err != nil {
 		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
 	} else {
 		c.JSON(http.StatusOK, rest.NewEntitiesResponse(list, p.Page, p.Size, int(total)))
 	}
 }
 
+// Export contacts by query to file (csv, ndjson or xlsx), all the matching contacts are exported (no pagination)
+// @Http: GET /export
+// @QueryParam: format | string              | export file format: csv | ndjson | xlsx (default: csv)
+// @QueryParam: search | string              | filter contacts by free text search
+// @QueryParam: filter | string              | filter expression (e.g. address.city = 'London' or name like 'john*')
+// @QueryParam: status | []StatusCode        | filter contacts by status(es)
+// @QueryParam: sort   | string              | sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+// @QueryParam: fields | []string            | list of fields (json paths) to export (default: all fields) (e.g. id,name,address.city)
+// @Return: file
+func (h *ContactsEndPoint) export(c *gin.Context) {
+	// Get token data
+	td := h.GetTokenData(c)
+	if td == nil {
+		return
+	}
+
+	fields, err := h.GetParamAsFields(c, "fields", NewContact)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	criteria, err := h.GetParamAsFilter(c, "filter", NewContact)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	p := s.ContactsFindParams{
+		Search: h.GetParamAsString(c, "search", ""),
+		Status: h.GetParamAsEnumArray(c, "status", *StatusCodes),
+		Sort:   h.GetParamAsString(c, "sort", "lastName"),
+		Fields: fields,
+		Filter: criteria,
+	}
+
+	h.Export(c, "contacts", NewContact, p.Fields, func(cb EntityCallback) error {
+		return h.service.Export(td, p, cb)
+	})
+}
+
 // endregion
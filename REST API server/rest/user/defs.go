@@ -2,23 +2,33 @@ package rest
 
 import (
 	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
 	"github.com/go-yaaf/yaaf-examples/rest-api/rest"
 	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
 )
 
-const (
-	usrApiVersion = "/v1"
-)
+// NewUserApiVersions is a factory method for the API versions of the user endpoints (see @ApiVersion annotations)
+// v1 is kept for backward compatibility, it is deprecated by configuration (API_V1_DEPRECATE, API_V1_SUNSET)
+func NewUserApiVersions(cfg *config.ServiceConfig) []*rest.ApiVersion {
+	return []*rest.ApiVersion{
+		rest.NewApiVersion("v1").Deprecate(cfg.ApiV1DeprecationDate(), cfg.ApiV1SunsetDate(), "/doc/"),
+		rest.NewApiVersion("v2"),
+	}
+}
 
 // NewListOfUserRestEndPoints is a factory method for user endpoints list
+// The list is registered in all the API versions (see @ApiVersion), the endpoints of the same service share the service
 func NewListOfUserRestEndPoints(facade *common.ServiceHub) []rest.RestEndpoint {
 	list := make([]rest.RestEndpoint, 0)
 	list = append(list, NewAccountsEndPoint(s.GetAccountsService(facade)))
 	list = append(list, NewAuditLogsEndPoint(s.GetAuditLogsService(facade)))
 	list = append(list, NewContactsEndPoint(s.GetContactsService(facade)))
+	list = append(list, NewEventsEndPoint(facade.Events, config.GetConfig().EventsHeartbeat()))
 	list = append(list, NewGroupsEndPoint(s.GetGroupsService(facade)))
+	list = append(list, NewOutboxEndPoint(s.GetOutboxService(facade)))
 	list = append(list, NewUserEndPoint(s.GetUsersService(facade)))
 	list = append(list, NewUsersEndPoint(s.GetUsersService(facade)))
+	list = append(list, NewWebhooksEndPoint(s.GetWebhooksService(facade)))
 
 	return list
 }
@@ -0,0 +1,266 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/rest"
+	"github.com/gorilla/websocket"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/rest"
+)
+
+// Time allowed to write a message to the WebSocket peer
+const wsWriteWait = 10 * time.Second
+
+// region Endpoint structure and factory method ------------------------------------------------------------------------
+
+// EventsEndPoint Stream of entity change events (create, update and delete) over Server-Sent Events or WebSocket
+// @Service: EventsService
+// @Path: /events
+// @Context: usr-events
+// @ApiVersion: v1, v2
+// @RequestHeader: X-API-KEY     | The key to identify the application (dashboard), or apiKey query param
+// @RequestHeader: Authorization | The bearer token to identify the logged-in user, or accessToken query param
+// @ResourceGroup: Events
+type EventsEndPoint struct {
+	BaseEndPoint
+	events    *common.EventBroker
+	heartbeat time.Duration
+	upgrader  websocket.Upgrader
+}
+
+// NewEventsEndPoint factory method
+func NewEventsEndPoint(events *common.EventBroker, heartbeat time.Duration) RestEndpoint {
+	if heartbeat <= 0 {
+		heartbeat = 30 * time.Second
+	}
+	return &EventsEndPoint{
+		events:    events,
+		heartbeat: heartbeat,
+		upgrader: websocket.Upgrader{
+			// Same origin policy as the CORS configuration (any origin, authenticated by API key and token)
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+func (h *EventsEndPoint) Path() string {
+	return "/events"
+}
+
+func (h *EventsEndPoint) RestEntries() (restEntries []RestEntry) {
+	restEntries = []RestEntry{
+		{Method: http.MethodGet, Handler: h.stream, Path: "", Timeout: -1},
+		{Method: http.MethodGet, Handler: h.stream, Path: "/", Timeout: -1},
+	}
+	return
+}
+
+// endregion
+
+// region Endpoint REST handlers ---------------------------------------------------------------------------------------
+
+// Stream the entity change events as Server-Sent Events, or as WebSocket json messages when the request is WebSocket
+// upgrade. The stream includes the events of the entities visible to the token subject, to resume after reconnect
+// provide the last received event ID (when the missed events are no longer available the stream starts with Reset
+// event and the client should reload its data)
+// @Http: GET /
+// @QueryParam: itemType    | []string | filter by item type: account | contact | user | users_group | audit_log (default: all)
+// @QueryParam: itemId      | []string | filter by item ID (default: all)
+// @QueryParam: lastEventId | int64    | resume after the last received event ID (or Last-Event-ID header)
+// @Return: stream<EntityEvent>
+func (h *EventsEndPoint) stream(c *gin.Context) {
+
+	// Get token data
+	td := h.GetTokenData(c)
+	if td == nil {
+		return
+	}
+
+	lastEventId := c.GetHeader("Last-Event-ID")
+	if len(lastEventId) == 0 {
+		lastEventId = h.GetParamAsString(c, "lastEventId", "")
+	}
+	lastId, err := strconv.ParseInt(lastEventId, 10, 64)
+	if len(lastEventId) > 0 && err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(fmt.Errorf("invalid last event ID: %s", lastEventId)))
+		return
+	}
+
+	filter := h.eventsFilter(td, h.GetParamAsStringArray(c, "itemType"), h.GetParamAsStringArray(c, "itemId"))
+
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		h.streamWebSocket(c, filter, lastId)
+	} else {
+		h.streamSSE(c, filter, lastId)
+	}
+}
+
+// endregion
+
+// region Endpoint streaming implementation ----------------------------------------------------------------------------
+
+// Stream the events as Server-Sent Events (event ID and json data), heartbeat comments keep the connection alive
+func (h *EventsEndPoint) streamSSE(c *gin.Context, filter func(event *EntityEvent) bool, lastEventId int64) {
+
+	sub, replay := h.events.Subscribe(filter, lastEventId)
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// Open the stream
+	if _, err := c.Writer.WriteString(": connected\n\n"); err != nil {
+		return
+	}
+	for _, event := range replay {
+		if writeSSE(c, event) != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			if writeSSE(c, event) != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := c.Writer.WriteString(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// Write the event as Server-Sent Event
+func writeSSE(c *gin.Context, event *EntityEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.Writer, "id: %d\ndata: %s\n\n", event.Id, data)
+	return err
+}
+
+// Stream the events as WebSocket json messages, ping messages keep the connection alive and detect dead peers
+func (h *EventsEndPoint) streamWebSocket(c *gin.Context, filter func(event *EntityEvent) bool, lastEventId int64) {
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader replied with error
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	sub, replay := h.events.Subscribe(filter, lastEventId)
+	defer sub.Close()
+
+	// Read the peer messages to process the control messages (pong and close), the client messages are ignored
+	closed := make(chan struct{})
+	_ = conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for _, event := range replay {
+		if writeWebSocket(conn, event) != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(wsWriteWait))
+				return
+			}
+			if writeWebSocket(conn, event) != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)) != nil {
+				return
+			}
+		}
+	}
+}
+
+// Write the event as WebSocket json message
+func writeWebSocket(conn *websocket.Conn, event *EntityEvent) error {
+	_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return conn.WriteJSON(event)
+}
+
+// Get the events filter by the requested item types and IDs and the token subject visibility: the system administrator
+// can see all the events, other subjects can't see the audit log, outbox and webhooks events and the events of other
+// users
+func (h *EventsEndPoint) eventsFilter(td *TokenData, itemTypes, itemIds []string) func(event *EntityEvent) bool {
+	types := make(map[string]bool, len(itemTypes))
+	for _, itemType := range itemTypes {
+		types[strings.ToLower(itemType)] = true
+	}
+	ids := make(map[string]bool, len(itemIds))
+	for _, itemId := range itemIds {
+		ids[itemId] = true
+	}
+	admin := td.SubjectType == UserTypeCodes.SYSADMIN
+	subject := td.SubjectId
+
+	return func(event *EntityEvent) bool {
+		if len(types) > 0 && !types[event.ItemType] {
+			return false
+		}
+		if len(ids) > 0 && !ids[event.ItemId] {
+			return false
+		}
+		if admin {
+			return true
+		}
+		switch event.ItemType {
+		case "audit_log", "outbox", "webhook", "webhook_delivery":
+			return false
+		case "user":
+			return event.ItemId == subject
+		default:
+			return true
+		}
+	}
+}
+
+// endregion
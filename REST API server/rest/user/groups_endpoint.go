@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-yaaf/yaaf-common/rest"
 
+	meta "github.com/go-yaaf/yaaf-examples/rest-api/model"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/rest"
@@ -21,6 +22,7 @@ import (
 // @Service: GroupsService
 // @Path: /groups
 // @Context: usr-groups
+// @ApiVersion: v1, v2
 // @RequestHeader: X-API-KEY     | The key to identify the application (dashboard)
 // @RequestHeader: Authorization | The bearer token to identify the logged-in user
 // @ResourceGroup: Groups Actions
@@ -35,13 +37,13 @@ func NewGroupsEndPoint(service *s.GroupsService) RestEndpoint {
 }
 
 func (h *GroupsEndPoint) Path() string {
-	return usrApiVersion + "/groups"
+	return "/groups"
 }
 
 func (h *GroupsEndPoint) RestEntries() (restEntries []RestEntry) {
 	restEntries = []RestEntry{
-		{Method: http.MethodPost, Handler: h.create, Path: ""},
-		{Method: http.MethodPost, Handler: h.create, Path: "/"},
+		{Method: http.MethodPost, Handler: h.create, Path: "", IdempotencyKey: true},
+		{Method: http.MethodPost, Handler: h.create, Path: "/", IdempotencyKey: true},
 		{Method: http.MethodPost, Handler: h.new, Path: "/new"},
 
 		{Method: http.MethodPut, Handler: h.update, Path: ""},
@@ -52,6 +54,7 @@ func (h *GroupsEndPoint) RestEntries() (restEntries []RestEntry) {
 
 		{Method: http.MethodGet, Handler: h.find, Path: ""},
 		{Method: http.MethodGet, Handler: h.find, Path: "/"},
+		{Method: http.MethodGet, Handler: h.export, Path: "/export", Timeout: ExportTimeout},
 	}
 
 	// Sort entries for best match
@@ -89,8 +92,8 @@ func (h *GroupsEndPoint) new(c *gin.Context) {
 
 // Create new group
 // @Http: POST /
-// @BodyParam: body | Group | group data to create
-// @Return: EntityResponse<Group>
+// @BodyParam: body | UsersGroup | group data to create
+// @Return: EntityResponse<UsersGroup>
 func (h *GroupsEndPoint) create(c *gin.Context) {
 
 	// Get token data
@@ -115,8 +118,8 @@ func (h *GroupsEndPoint) create(c *gin.Context) {
 
 // Update existing group
 // @Http: PUT /
-// @BodyParam: body | Group | group data to update
-// @Return: EntityResponse<Group>
+// @BodyParam: body | UsersGroup | group data to update
+// @Return: EntityResponse<UsersGroup>
 func (h *GroupsEndPoint) update(c *gin.Context) {
 	// Get token data
 	td := h.GetTokenData(c)
@@ -167,7 +170,8 @@ func (h *GroupsEndPoint) delete(c *gin.Context) {
 // Get a single group by id
 // @Http: GET /{id}
 // @PathParam: id | string | group ID to fetch
-// @Return: EntityResponse<Group>
+// @QueryParam: fields | []string | list of fields (json paths) to include in the result (e.g. id,name,members)
+// @Return: EntityResponse<UsersGroup>
 func (h *GroupsEndPoint) get(c *gin.Context) {
 	// Get token data
 	td := h.GetTokenData(c)
@@ -177,20 +181,28 @@ func (h *GroupsEndPoint) get(c *gin.Context) {
 
 	id := c.Params.ByName("id")
 
+	fields, err := h.GetParamAsFields(c, "fields", NewUsersGroup)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
 	if ent, err := h.service.Get(td, id); err != nil {
 		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
 	} else {
-		c.JSON(http.StatusOK, rest.NewEntityResponse(ent))
+		c.JSON(http.StatusOK, rest.NewEntityResponse(meta.ProjectEntity(ent, fields)))
 	}
 }
 
 // Find groups by query
 // @Http: GET /
 // @QueryParam: search | string              | filter groups by free text search
+// @QueryParam: filter | string              | filter expression (e.g. members = 'user@org.io')
 // @QueryParam: sort   | string              | sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
 // @QueryParam: page   | int                 | page number (for pagination)
 // @QueryParam: size   | int                 | number of items per page (for pagination)
-// @Return: EntitiesResponse<Group>
+// @QueryParam: fields | []string            | list of fields (json paths) to include in the results (e.g. id,name,members)
+// @Return: EntitiesResponse<UsersGroup>
 func (h *GroupsEndPoint) find(c *gin.Context) {
 	// Get token data
 	td := h.GetTokenData(c)
@@ -198,17 +210,70 @@ func (h *GroupsEndPoint) find(c *gin.Context) {
 		return
 	}
 
+	fields, err := h.GetParamAsFields(c, "fields", NewUsersGroup)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	criteria, err := h.GetParamAsFilter(c, "filter", NewUsersGroup)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
 	p := s.GroupsFindParams{
 		Search: h.GetParamAsString(c, "search", ""),
 		Sort:   h.GetParamAsString(c, "sort", ""),
 		Page:   h.GetParamAsInt(c, "page", 1),
 		Size:   h.GetParamAsInt(c, "size", 100),
+		Fields: fields,
+		Filter: criteria,
 	}
-	if list, total, _, err := h.service.Find(p); err != nil {
+	if list, total, _, err := h.service.Find(td, p); err != nil {
 		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
 	} else {
 		c.JSON(http.StatusOK, rest.NewEntitiesResponse(list, p.Page, p.Size, int(total)))
 	}
 }
 
+// Export groups by query to file (csv, ndjson or xlsx), all the matching groups are exported (no pagination)
+// @Http: GET /export
+// @QueryParam: format | string              | export file format: csv | ndjson | xlsx (default: csv)
+// @QueryParam: search | string              | filter groups by free text search
+// @QueryParam: filter | string              | filter expression (e.g. members = 'user@org.io')
+// @QueryParam: sort   | string              | sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+// @QueryParam: fields | []string            | list of fields (json paths) to export (default: all fields) (e.g. id,name,members)
+// @Return: file
+func (h *GroupsEndPoint) export(c *gin.Context) {
+	// Get token data
+	td := h.GetTokenData(c)
+	if td == nil {
+		return
+	}
+
+	fields, err := h.GetParamAsFields(c, "fields", NewUsersGroup)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	criteria, err := h.GetParamAsFilter(c, "filter", NewUsersGroup)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	p := s.GroupsFindParams{
+		Search: h.GetParamAsString(c, "search", ""),
+		Sort:   h.GetParamAsString(c, "sort", ""),
+		Fields: fields,
+		Filter: criteria,
+	}
+
+	h.Export(c, "groups", NewUsersGroup, p.Fields, func(cb EntityCallback) error {
+		return h.service.Export(td, p, cb)
+	})
+}
+
 // endregion
@@ -0,0 +1,166 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/rest"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/rest"
+	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// region Endpoint structure and factory method ------------------------------------------------------------------------
+
+// OutboxEndPoint Services to monitor the transactional outbox (events saved with the entity changes and relayed to the
+// message bus and webhooks) and retry failed entries, available to the system administrator only
+// @Service: OutboxService
+// @Path: /outbox
+// @Context: usr-outbox
+// @ApiVersion: v1, v2
+// @RequestHeader: X-API-KEY     | The key to identify the application (dashboard)
+// @RequestHeader: Authorization | The bearer token to identify the logged-in user
+// @ResourceGroup: Outbox Actions
+type OutboxEndPoint struct {
+	BaseEndPoint
+	service *s.OutboxService
+}
+
+// NewOutboxEndPoint factory method
+func NewOutboxEndPoint(service *s.OutboxService) RestEndpoint {
+	return &OutboxEndPoint{service: service}
+}
+
+func (h *OutboxEndPoint) Path() string {
+	return "/outbox"
+}
+
+func (h *OutboxEndPoint) RestEntries() (restEntries []RestEntry) {
+	restEntries = []RestEntry{
+		{Method: http.MethodGet, Handler: h.stats, Path: "/stats"},
+		{Method: http.MethodGet, Handler: h.get, Path: "/:id"},
+		{Method: http.MethodPost, Handler: h.retry, Path: "/:id/retry"},
+
+		{Method: http.MethodGet, Handler: h.find, Path: ""},
+		{Method: http.MethodGet, Handler: h.find, Path: "/"},
+	}
+
+	// Sort entries for best match
+	sort.Slice(restEntries, func(i, j int) bool {
+		return restEntries[i].Path > restEntries[j].Path
+	})
+	return
+}
+
+// endregion
+
+// region Endpoint REST handlers ---------------------------------------------------------------------------------------
+
+// Get a single outbox entry by id (including the event payload)
+// @Http: GET /{id}
+// @PathParam: id | string | outbox entry ID to fetch
+// @Return: EntityResponse<OutboxEntry>
+func (h *OutboxEndPoint) get(c *gin.Context) {
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	if entity, err := h.service.Get(td, c.Params.ByName("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntityResponse(entity))
+	}
+}
+
+// Find outbox entries by query
+// @Http: GET /
+// @QueryParam: topic  | string             | filter entries by topic (e.g. domain.UserCreated, entity.*)
+// @QueryParam: status | []OutboxStatusCode | filter entries by status(s)
+// @QueryParam: stuck  | bool               | filter stuck entries: waiting to be relayed longer than the stuck threshold
+// @QueryParam: sort   | string             | sort results by field and direction (default: createdOn = oldest first)
+// @QueryParam: page   | int                | page number (for pagination)
+// @QueryParam: size   | int                | number of items per page (for pagination)
+// @Return: EntitiesResponse<OutboxEntry>
+func (h *OutboxEndPoint) find(c *gin.Context) {
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	p := s.OutboxFindParams{
+		Topic:  h.GetParamAsString(c, "topic", ""),
+		Status: h.GetParamAsEnumArray(c, "status", *OutboxStatusCodes),
+		Stuck:  h.GetParamAsBool(c, "stuck", false),
+		Sort:   h.GetParamAsString(c, "sort", "createdOn"),
+		Page:   h.GetParamAsInt(c, "page", 1),
+		Size:   h.GetParamAsInt(c, "size", 100),
+	}
+	if list, total, _, err := h.service.Find(td, p); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntitiesResponse(list, p.Page, p.Size, int(total)))
+	}
+}
+
+// Retry the relay of outbox entry (e.g. failed entry after the message bus was fixed), the entry is relayed again and
+// the number of attempts is reset (sent entries can't be retried)
+// @Http: POST /{id}/retry
+// @PathParam: id | string | outbox entry ID to retry
+// @Return: EntityResponse<OutboxEntry>
+func (h *OutboxEndPoint) retry(c *gin.Context) {
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	if entity, err := h.service.Retry(td, c.Params.ByName("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntityResponse(entity))
+	}
+}
+
+// Get the state of the outbox: number of pending, stuck and failed entries and the oldest pending entry
+// @Http: GET /stats
+// @Return: EntityResponse<OutboxStats>
+func (h *OutboxEndPoint) stats(c *gin.Context) {
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	if entity, err := h.service.Stats(td); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntityResponse(entity))
+	}
+}
+
+// endregion
+
+// region Endpoint helpers ---------------------------------------------------------------------------------------------
+
+// Get token data of the system administrator (the outbox includes the events of all the entities), other subjects are
+// forbidden
+func (h *OutboxEndPoint) getAdminTokenData(c *gin.Context) *TokenData {
+	td := h.GetTokenData(c)
+	if td == nil {
+		return nil
+	}
+	if td.SubjectType != UserTypeCodes.SYSADMIN {
+		c.JSON(http.StatusForbidden, rest.NewErrorResponse(fmt.Errorf("outbox is forbidden")))
+		return nil
+	}
+	return td
+}
+
+// endregion
@@ -17,6 +17,7 @@ import (
 // @Service: UserService
 // @Path: /user
 // @Context: usr-user
+// @ApiVersion: v1, v2
 // @RequestHeader: X-API-KEY     | The key to identify the application (dashboard)
 // @RequestHeader: Authorization | The bearer token to identify the logged-in user
 // @ResourceGroup: User Actions
@@ -31,7 +32,7 @@ func NewUserEndPoint(service *s.UsersService) RestEndpoint {
 }
 
 func (h *UserEndPoint) Path() string {
-	return usrApiVersion + "/user"
+	return "/user"
 }
 
 func (h *UserEndPoint) RestEntries() (restEntries []RestEntry) {
@@ -65,7 +66,7 @@ func (h *UserEndPoint) authorize(c *gin.Context) {
 		return
 	}
 
-	if user, token, err := h.service.Authorize(login.Email); err != nil {
+	if user, token, err := h.service.Authorize(h.GetAnonymousTokenData(c), login.Email); err != nil {
 		c.JSON(http.StatusUnauthorized, rest.NewErrorResponse(errors.New("unauthorized")))
 		return
 	} else {
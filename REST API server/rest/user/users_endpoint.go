@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-yaaf/yaaf-common/rest"
 
+	meta "github.com/go-yaaf/yaaf-examples/rest-api/model"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/rest"
@@ -20,6 +21,7 @@ import (
 // @Service: UsersService
 // @Path: /users
 // @Context: usr-users
+// @ApiVersion: v1, v2
 // @RequestHeader: X-API-KEY     | The key to identify the application (dashboard)
 // @RequestHeader: Authorization | The bearer token to identify the logged-in user
 // @ResourceGroup: Users Actions
@@ -34,13 +36,13 @@ func NewUsersEndPoint(service *s.UsersService) RestEndpoint {
 }
 
 func (h *UsersEndPoint) Path() string {
-	return usrApiVersion + "/users"
+	return "/users"
 }
 
 func (h *UsersEndPoint) RestEntries() (restEntries []RestEntry) {
 	restEntries = []RestEntry{
-		{Method: http.MethodPost, Handler: h.create, Path: ""},
-		{Method: http.MethodPost, Handler: h.create, Path: "/"},
+		{Method: http.MethodPost, Handler: h.create, Path: "", IdempotencyKey: true},
+		{Method: http.MethodPost, Handler: h.create, Path: "/", IdempotencyKey: true},
 		{Method: http.MethodPost, Handler: h.new, Path: "/new"},
 
 		{Method: http.MethodPut, Handler: h.update, Path: ""},
@@ -51,6 +53,7 @@ func (h *UsersEndPoint) RestEntries() (restEntries []RestEntry) {
 
 		{Method: http.MethodGet, Handler: h.find, Path: ""},
 		{Method: http.MethodGet, Handler: h.find, Path: "/"},
+		{Method: http.MethodGet, Handler: h.export, Path: "/export", Timeout: ExportTimeout},
 	}
 
 	// Sort entries for best match
@@ -167,6 +170,7 @@ func (h *UsersEndPoint) delete(c *gin.Context) {
 // Get a single user by id
 // @Http: GET /{id}
 // @PathParam: id | string | user ID to fetch
+// @QueryParam: fields | []string | list of fields (json paths) to include in the result (e.g. id,name,status)
 // @Return: EntityResponse<User>
 func (h *UsersEndPoint) get(c *gin.Context) {
 	// Get token data
@@ -177,21 +181,29 @@ func (h *UsersEndPoint) get(c *gin.Context) {
 
 	id := c.Params.ByName("id")
 
+	fields, err := h.GetParamAsFields(c, "fields", NewUser)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
 	if entity, err := h.service.Get(td, id); err != nil {
 		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
 	} else {
-		c.JSON(http.StatusOK, rest.NewEntityResponse(entity))
+		c.JSON(http.StatusOK, rest.NewEntityResponse(meta.ProjectEntity(entity, fields)))
 	}
 }
 
 // Find users by query
 // @Http: GET /
 // @QueryParam: search | string              | filter users by free text search
+// @QueryParam: filter | string              | filter expression (e.g. type = USER and (status = PENDING or lastSignIn < '2024-01-01'))
 // @QueryParam: type   | []UserTypeCode      | filter users by type(s)
 // @QueryParam: status | []UserStatusCode    | filter users by status(es)
 // @QueryParam: sort   | string              | sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
 // @QueryParam: page   | int                 | page number (for pagination)
 // @QueryParam: size   | int                 | number of items per page (for pagination)
+// @QueryParam: fields | []string            | list of fields (json paths) to include in the results (e.g. id,name,status)
 // @Return: EntitiesResponse<User>
 func (h *UsersEndPoint) find(c *gin.Context) {
 	// Get token data
@@ -200,6 +212,18 @@ func (h *UsersEndPoint) find(c *gin.Context) {
 		return
 	}
 
+	fields, err := h.GetParamAsFields(c, "fields", NewUser)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	criteria, err := h.GetParamAsFilter(c, "filter", NewUser)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
 	p := s.UsersFindParams{
 		Search: h.GetParamAsString(c, "search", ""),
 		Type:   h.GetParamAsEnumArray(c, "type", *UserTypeCodes),
@@ -207,12 +231,57 @@ func (h *UsersEndPoint) find(c *gin.Context) {
 		Sort:   h.GetParamAsString(c, "sort", "name"),
 		Page:   h.GetParamAsInt(c, "page", 1),
 		Size:   h.GetParamAsInt(c, "size", 100),
+		Fields: fields,
+		Filter: criteria,
 	}
-	if list, total, _, err := h.service.Find(p); err != nil {
+	if list, total, _, err := h.service.Find(td, p); err != nil {
 		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
 	} else {
 		c.JSON(http.StatusOK, rest.NewEntitiesResponse(list, p.Page, p.Size, int(total)))
 	}
 }
 
+// Export users by query to file (csv, ndjson or xlsx), all the matching users are exported (no pagination)
+// @Http: GET /export
+// @QueryParam: format | string              | export file format: csv | ndjson | xlsx (default: csv)
+// @QueryParam: search | string              | filter users by free text search
+// @QueryParam: filter | string              | filter expression (e.g. type = USER and (status = PENDING or lastSignIn < '2024-01-01'))
+// @QueryParam: type   | []UserTypeCode      | filter users by type(s)
+// @QueryParam: status | []UserStatusCode    | filter users by status(es)
+// @QueryParam: sort   | string              | sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+// @QueryParam: fields | []string            | list of fields (json paths) to export (default: all fields) (e.g. id,name,status)
+// @Return: file
+func (h *UsersEndPoint) export(c *gin.Context) {
+	// Get token data
+	td := h.GetTokenData(c)
+	if td == nil {
+		return
+	}
+
+	fields, err := h.GetParamAsFields(c, "fields", NewUser)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	criteria, err := h.GetParamAsFilter(c, "filter", NewUser)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	p := s.UsersFindParams{
+		Search: h.GetParamAsString(c, "search", ""),
+		Type:   h.GetParamAsEnumArray(c, "type", *UserTypeCodes),
+		Status: h.GetParamAsEnumArray(c, "status", *UserStatusCodes),
+		Sort:   h.GetParamAsString(c, "sort", "name"),
+		Fields: fields,
+		Filter: criteria,
+	}
+
+	h.Export(c, "users", NewUser, p.Fields, func(cb EntityCallback) error {
+		return h.service.Export(td, p, cb)
+	})
+}
+
 // endregion
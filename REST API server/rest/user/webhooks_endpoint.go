@@ -0,0 +1,317 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"github.com/go-yaaf/yaaf-common/rest"
+
+	meta "github.com/go-yaaf/yaaf-examples/rest-api/model"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/rest"
+	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// region Endpoint structure and factory method ------------------------------------------------------------------------
+
+// WebhooksEndPoint Services to manage the webhooks (subscriptions of external receivers to the entity change events)
+// and their delivery history, available to the system administrator only
+// @Service: WebhooksService
+// @Path: /webhooks
+// @Context: usr-webhooks
+// @ApiVersion: v1, v2
+// @RequestHeader: X-API-KEY     | The key to identify the application (dashboard)
+// @RequestHeader: Authorization | The bearer token to identify the logged-in user
+// @ResourceGroup: Webhooks Actions
+type WebhooksEndPoint struct {
+	BaseEndPoint
+	service *s.WebhooksService
+}
+
+// NewWebhooksEndPoint factory method
+func NewWebhooksEndPoint(service *s.WebhooksService) RestEndpoint {
+	return &WebhooksEndPoint{service: service}
+}
+
+func (h *WebhooksEndPoint) Path() string {
+	return "/webhooks"
+}
+
+func (h *WebhooksEndPoint) RestEntries() (restEntries []RestEntry) {
+	restEntries = []RestEntry{
+		{Method: http.MethodPost, Handler: h.create, Path: "", IdempotencyKey: true},
+		{Method: http.MethodPost, Handler: h.create, Path: "/", IdempotencyKey: true},
+		{Method: http.MethodPost, Handler: h.new, Path: "/new"},
+
+		{Method: http.MethodPut, Handler: h.update, Path: ""},
+		{Method: http.MethodPut, Handler: h.update, Path: "/"},
+
+		{Method: http.MethodDelete, Handler: h.delete, Path: "/:id"},
+		{Method: http.MethodGet, Handler: h.get, Path: "/:id"},
+
+		{Method: http.MethodGet, Handler: h.find, Path: ""},
+		{Method: http.MethodGet, Handler: h.find, Path: "/"},
+
+		{Method: http.MethodGet, Handler: h.deliveries, Path: "/:id/deliveries"},
+		{Method: http.MethodGet, Handler: h.delivery, Path: "/deliveries/:id"},
+		{Method: http.MethodPost, Handler: h.redeliver, Path: "/deliveries/:id/redeliver"},
+	}
+
+	// Sort entries for best match
+	sort.Slice(restEntries, func(i, j int) bool {
+		return restEntries[i].Path > restEntries[j].Path
+	})
+	return
+}
+
+// endregion
+
+// region Endpoint REST handlers ---------------------------------------------------------------------------------------
+
+// Get new and empty webhook template
+// @Http: POST /new
+// @Return: EntityResponse<Webhook>
+func (h *WebhooksEndPoint) new(c *gin.Context) {
+
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	// Create empty entity
+	entity := NewWebhook()
+	entity.(*Webhook).Id = ""
+	entity.(*Webhook).CreatedOn = 0
+	entity.(*Webhook).UpdatedOn = 0
+	entity.(*Webhook).Props = make(Json)
+	entity.(*Webhook).Active = true
+
+	c.JSON(http.StatusOK, rest.NewEntityResponse(entity))
+}
+
+// Create new webhook, the secret is generated if not provided (the secret is returned only in the create response)
+// @Http: POST /
+// @BodyParam: body | Webhook | webhook data to create
+// @Return: EntityResponse<Webhook>
+func (h *WebhooksEndPoint) create(c *gin.Context) {
+
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	// Read entity from body
+	entity := NewWebhook()
+	if err := c.ShouldBindJSON(entity); err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	if result, err := h.service.Create(td, entity); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntityResponse(result))
+	}
+}
+
+// Update existing webhook, the secret is kept if not provided
+// @Http: PUT /
+// @BodyParam: body | Webhook | webhook data to update
+// @Return: EntityResponse<Webhook>
+func (h *WebhooksEndPoint) update(c *gin.Context) {
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	// Read entity from body
+	entity := NewWebhook()
+	if err := c.ShouldBindJSON(entity); err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	if result, err := h.service.Update(td, entity); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntityResponse(result))
+	}
+}
+
+// Delete webhook
+// @Http: DELETE /{id}
+// @PathParam: id | string | webhook ID to delete
+// @Return: ActionResponse
+func (h *WebhooksEndPoint) delete(c *gin.Context) {
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	id := c.Params.ByName("id")
+
+	if err := h.service.Delete(td, id); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewActionResponse(td.SubjectId, id))
+	}
+}
+
+// Get a single webhook by id (without the secret)
+// @Http: GET /{id}
+// @PathParam: id | string | webhook ID to fetch
+// @QueryParam: fields | []string | list of fields (json paths) to include in the result (e.g. id,name,url)
+// @Return: EntityResponse<Webhook>
+func (h *WebhooksEndPoint) get(c *gin.Context) {
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	id := c.Params.ByName("id")
+
+	fields, err := h.GetParamAsFields(c, "fields", NewWebhook)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	if entity, err := h.service.Get(td, id); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntityResponse(meta.ProjectEntity(entity, fields)))
+	}
+}
+
+// Find webhooks by query (without the secret)
+// @Http: GET /
+// @QueryParam: search    | string   | filter webhooks by free text search on webhook id, name and url
+// @QueryParam: accountId | string   | filter webhooks by account
+// @QueryParam: sort      | string   | sort results by field and direction: (e.g. name = sort by name asc, name- = sort by name desc)
+// @QueryParam: page      | int      | page number (for pagination)
+// @QueryParam: size      | int      | number of items per page (for pagination)
+// @QueryParam: fields    | []string | list of fields (json paths) to include in the results (e.g. id,name,url)
+// @Return: EntitiesResponse<Webhook>
+func (h *WebhooksEndPoint) find(c *gin.Context) {
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	fields, err := h.GetParamAsFields(c, "fields", NewWebhook)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rest.NewErrorResponse(err))
+		return
+	}
+
+	p := s.WebhooksFindParams{
+		Search:    h.GetParamAsString(c, "search", ""),
+		AccountId: h.GetParamAsString(c, "accountId", ""),
+		Sort:      h.GetParamAsString(c, "sort", "name"),
+		Page:      h.GetParamAsInt(c, "page", 1),
+		Size:      h.GetParamAsInt(c, "size", 100),
+		Fields:    fields,
+	}
+	if list, total, _, err := h.service.Find(td, p); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntitiesResponse(list, p.Page, p.Size, int(total)))
+	}
+}
+
+// Get the delivery history of the webhook
+// @Http: GET /{id}/deliveries
+// @PathParam: id     | string               | webhook ID
+// @QueryParam: status | []DeliveryStatusCode | filter deliveries by status(s)
+// @QueryParam: sort   | string               | sort results by field and direction (default: createdOn- = latest first)
+// @QueryParam: page   | int                  | page number (for pagination)
+// @QueryParam: size   | int                  | number of items per page (for pagination)
+// @Return: EntitiesResponse<WebhookDelivery>
+func (h *WebhooksEndPoint) deliveries(c *gin.Context) {
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	p := s.WebhookDeliveriesFindParams{
+		WebhookId: c.Params.ByName("id"),
+		Status:    h.GetParamAsEnumArray(c, "status", *DeliveryStatusCodes),
+		Sort:      h.GetParamAsString(c, "sort", "createdOn-"),
+		Page:      h.GetParamAsInt(c, "page", 1),
+		Size:      h.GetParamAsInt(c, "size", 100),
+	}
+	if list, total, _, err := h.service.FindDeliveries(td, p); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntitiesResponse(list, p.Page, p.Size, int(total)))
+	}
+}
+
+// Get a single webhook delivery by id (including the posted payload)
+// @Http: GET /deliveries/{id}
+// @PathParam: id | string | delivery ID to fetch
+// @Return: EntityResponse<WebhookDelivery>
+func (h *WebhooksEndPoint) delivery(c *gin.Context) {
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	if entity, err := h.service.GetDelivery(td, c.Params.ByName("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntityResponse(entity))
+	}
+}
+
+// Redeliver the webhook payload (e.g. failed delivery after the receiver was fixed), the delivery is attempted again
+// with the same delivery ID (X-Webhook-Id header) and the number of attempts is reset
+// @Http: POST /deliveries/{id}/redeliver
+// @PathParam: id | string | delivery ID to redeliver
+// @Return: EntityResponse<WebhookDelivery>
+func (h *WebhooksEndPoint) redeliver(c *gin.Context) {
+	// Get token data
+	td := h.getAdminTokenData(c)
+	if td == nil {
+		return
+	}
+
+	if entity, err := h.service.Redeliver(td, c.Params.ByName("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, rest.NewErrorResponse(err))
+	} else {
+		c.JSON(http.StatusOK, rest.NewEntityResponse(entity))
+	}
+}
+
+// endregion
+
+// region Endpoint helpers ---------------------------------------------------------------------------------------------
+
+// Get token data of the system administrator (the webhooks receive the changes of all the entities), other subjects
+// are forbidden
+func (h *WebhooksEndPoint) getAdminTokenData(c *gin.Context) *TokenData {
+	td := h.GetTokenData(c)
+	if td == nil {
+		return nil
+	}
+	if td.SubjectType != UserTypeCodes.SYSADMIN {
+		c.JSON(http.StatusForbidden, rest.NewErrorResponse(fmt.Errorf("webhooks are forbidden")))
+		return nil
+	}
+	return td
+}
+
+// endregion
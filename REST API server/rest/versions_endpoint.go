@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-yaaf/yaaf-common/rest"
+)
+
+// region Endpoint structure and factory method ------------------------------------------------------------------------
+
+// VersionsEndPoint for the API versions and route table (documentation)
+// @Service: VersionsService
+// @Path: /versions
+// @Context: versions
+// @ResourceGroup: Health
+type VersionsEndPoint struct {
+	BaseEndPoint
+	server *Server
+}
+
+// NewVersionsEndPoint factory method
+func NewVersionsEndPoint(server *Server) RestEndpoint {
+	return &VersionsEndPoint{server: server}
+}
+
+// endregion
+
+// region Endpoint methods implementation ------------------------------------------------------------------------------
+
+func (h *VersionsEndPoint) Path() string {
+	return "/versions"
+}
+
+func (h *VersionsEndPoint) RestEntries() (restEntries []RestEntry) {
+	restEntries = []RestEntry{
+		{Method: http.MethodGet, Handler: h.list, Path: ""},
+	}
+	return
+}
+
+// Get the API versions (including deprecation and sunset dates) and the routes of each version
+// @Http: GET /
+// @Return: EntitiesResponse<ApiVersionInfo>
+func (h *VersionsEndPoint) list(c *gin.Context) {
+	table := h.server.RouteTable()
+	c.JSON(http.StatusOK, rest.NewEntitiesResponse(table, 1, len(table), len(table)))
+}
+
+// endregion
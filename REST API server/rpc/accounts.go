@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"context"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rpc/pb"
+	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// accountsServer implements the gRPC AccountsService by the accounts service (same as the REST /accounts endpoints)
+type accountsServer struct {
+	pb.UnimplementedAccountsServiceServer
+	service *s.AccountsService
+}
+
+// Create new account
+func (h *accountsServer) Create(ctx context.Context, req *pb.Account) (*pb.Account, error) {
+	ent, err := toEntity(req, NewAccount)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Create(GetTokenData(ctx), ent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.Account{})
+}
+
+// Update existing account
+func (h *accountsServer) Update(ctx context.Context, req *pb.Account) (*pb.Account, error) {
+	ent, err := toEntity(req, NewAccount)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Update(GetTokenData(ctx), ent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.Account{})
+}
+
+// Delete account (only system administrator can delete accounts)
+func (h *accountsServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	td := GetTokenData(ctx)
+	if td.SubjectType != UserTypeCodes.SYSADMIN {
+		return nil, status.Error(codes.PermissionDenied, "delete is forbidden")
+	}
+	if err := h.service.Delete(td, req.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Get a single account by id
+func (h *accountsServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.Account, error) {
+	result, err := h.service.Get(GetTokenData(ctx), req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.Account{})
+}
+
+// Find accounts by query, all the matching accounts are streamed
+func (h *accountsServer) Find(req *pb.FindAccountsRequest, stream grpc.ServerStreamingServer[pb.Account]) error {
+	criteria, err := parseFilter(NewAccount, req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	p := s.AccountsFindParams{
+		Search: req.GetSearch(),
+		Status: enumCodes(req.GetStatus()),
+		Sort:   sortOrDefault(req.GetSort(), "name"),
+		Filter: criteria,
+	}
+	return statusError(h.service.Export(GetTokenData(stream.Context()), p, func(ent Entity) error {
+		msg, er := toMessage(ent, &pb.Account{})
+		if er != nil {
+			return er
+		}
+		return stream.Send(msg)
+	}))
+}
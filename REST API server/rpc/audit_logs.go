@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"context"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"google.golang.org/grpc"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rpc/pb"
+	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// auditLogsServer implements the gRPC AuditLogsService by the audit logs service (same as the REST /audit_logs endpoints)
+type auditLogsServer struct {
+	pb.UnimplementedAuditLogsServiceServer
+	service *s.AuditLogsService
+}
+
+// Create new audit log
+func (h *auditLogsServer) Create(ctx context.Context, req *pb.AuditLog) (*pb.AuditLog, error) {
+	ent, err := toEntity(req, NewAuditLog)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Create(GetTokenData(ctx), ent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.AuditLog{})
+}
+
+// Get a single audit log by id
+func (h *auditLogsServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.AuditLog, error) {
+	result, err := h.service.Get(GetTokenData(ctx), req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.AuditLog{})
+}
+
+// Find audit logs by query, all the matching audit logs are streamed (time range can be relative to now, negative value
+// in milliseconds)
+func (h *auditLogsServer) Find(req *pb.FindAuditLogsRequest, stream grpc.ServerStreamingServer[pb.AuditLog]) error {
+	criteria, err := parseFilter(NewAuditLog, req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	p := s.AuditLogsFindParams{
+		From:     absoluteTime(req.GetFrom()),
+		To:       absoluteTime(req.GetTo()),
+		UserId:   req.GetUserId(),
+		Action:   req.GetAction(),
+		ItemType: req.GetItemType(),
+		ItemId:   req.GetItemId(),
+		ItemName: req.GetItemName(),
+		Search:   req.GetSearch(),
+		Sort:     sortOrDefault(req.GetSort(), "createdOn-"),
+		Filter:   criteria,
+	}
+	return statusError(h.service.Export(GetTokenData(stream.Context()), p, func(ent Entity) error {
+		msg, er := toMessage(ent, &pb.AuditLog{})
+		if er != nil {
+			return er
+		}
+		return stream.Send(msg)
+	}))
+}
+
+// Convert relative time (negative number of milliseconds) to absolute time
+func absoluteTime(input int64) Timestamp {
+	if input < 0 {
+		return Now() + Timestamp(input)
+	}
+	return Timestamp(input)
+}
@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"context"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rpc/pb"
+	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// contactsServer implements the gRPC ContactsService by the contacts service (same as the REST /contacts endpoints)
+type contactsServer struct {
+	pb.UnimplementedContactsServiceServer
+	service *s.ContactsService
+}
+
+// Create new contact
+func (h *contactsServer) Create(ctx context.Context, req *pb.Contact) (*pb.Contact, error) {
+	ent, err := toEntity(req, NewContact)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Create(GetTokenData(ctx), ent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.Contact{})
+}
+
+// Update existing contact
+func (h *contactsServer) Update(ctx context.Context, req *pb.Contact) (*pb.Contact, error) {
+	ent, err := toEntity(req, NewContact)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Update(GetTokenData(ctx), ent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.Contact{})
+}
+
+// Delete contact (only system administrator can delete contacts)
+func (h *contactsServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	td := GetTokenData(ctx)
+	if td.SubjectType != UserTypeCodes.SYSADMIN {
+		return nil, status.Error(codes.PermissionDenied, "delete is forbidden")
+	}
+	if err := h.service.Delete(td, req.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Get a single contact by id
+func (h *contactsServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.Contact, error) {
+	result, err := h.service.Get(GetTokenData(ctx), req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.Contact{})
+}
+
+// Find contacts by query, all the matching contacts are streamed
+func (h *contactsServer) Find(req *pb.FindContactsRequest, stream grpc.ServerStreamingServer[pb.Contact]) error {
+	criteria, err := parseFilter(NewContact, req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	p := s.ContactsFindParams{
+		Search: req.GetSearch(),
+		Status: enumCodes(req.GetStatus()),
+		Sort:   sortOrDefault(req.GetSort(), "lastName"),
+		Filter: criteria,
+	}
+	return statusError(h.service.Export(GetTokenData(stream.Context()), p, func(ent Entity) error {
+		msg, er := toMessage(ent, &pb.Contact{})
+		if er != nil {
+			return er
+		}
+		return stream.Send(msg)
+	}))
+}
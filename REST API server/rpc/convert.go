@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/filter"
+)
+
+// Entity json documents are mapped to the messages by the field json names (fields which are not part of the messages
+// are ignored)
+var unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// region Entities and messages conversion -----------------------------------------------------------------------------
+
+// Convert entity to protobuf message (by the entity json document)
+func toMessage[M proto.Message](ent Entity, msg M) (M, error) {
+	content, err := json.Marshal(ent)
+	if err != nil {
+		return msg, status.Errorf(codes.Internal, "failed to convert %s: %v", ent.TABLE(), err)
+	}
+	if err = unmarshalOptions.Unmarshal(content, msg); err != nil {
+		return msg, status.Errorf(codes.Internal, "failed to convert %s: %v", ent.TABLE(), err)
+	}
+	return msg, nil
+}
+
+// Convert protobuf message to entity (by the message json document)
+func toEntity(msg proto.Message, ef EntityFactory) (Entity, error) {
+	ent := ef()
+	content, err := json.Marshal(messageDocument(msg.ProtoReflect()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid %s: %v", ent.TABLE(), err)
+	}
+	if err = json.Unmarshal(content, ent); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid %s: %v", ent.TABLE(), err)
+	}
+	return ent, nil
+}
+
+// Get the json document of the message populated fields (enums as numbers, int64 as json numbers)
+func messageDocument(m protoreflect.Message) map[string]any {
+	doc := make(map[string]any)
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsList() {
+			items := make([]any, v.List().Len())
+			for i := range items {
+				items[i] = fieldValue(fd, v.List().Get(i))
+			}
+			doc[fd.JSONName()] = items
+		} else {
+			doc[fd.JSONName()] = fieldValue(fd, v)
+		}
+		return true
+	})
+	return doc
+}
+
+// Get the json value of the message field
+func fieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		return int(v.Enum())
+	case protoreflect.MessageKind:
+		switch msg := v.Message().Interface().(type) {
+		case *structpb.Struct:
+			return msg.AsMap()
+		case *structpb.Value:
+			return msg.AsInterface()
+		default:
+			return messageDocument(v.Message())
+		}
+	default:
+		return v.Interface()
+	}
+}
+
+// Convert enum values of the find request to enum codes
+func enumCodes[E ~int32](values []E) []int {
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		result = append(result, int(v))
+	}
+	return result
+}
+
+// Parse the filter expression of the find request
+func parseFilter(ef EntityFactory, expression string) (*filter.Criteria, error) {
+	criteria, err := filter.Parse(ef, expression)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+	return criteria, nil
+}
+
+// Get the sort of the find request or the default sort
+func sortOrDefault(sort, defaultSort string) string {
+	if len(sort) == 0 {
+		return defaultSort
+	}
+	return sort
+}
+
+// endregion
+
+// region Errors conversion --------------------------------------------------------------------------------------------
+
+// HTTP status codes of the service errors mapped to the gRPC status codes
+var errorCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusUnprocessableEntity: codes.InvalidArgument,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+}
+
+// Convert service error to gRPC status error (service errors with no code are Internal, same as the REST API)
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+
+	var serviceError Error
+	if errors.As(err, &serviceError) {
+		if code, ok := errorCodes[serviceError.Code()]; ok {
+			return status.Error(code, err.Error())
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// endregion
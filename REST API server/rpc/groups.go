@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"context"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rpc/pb"
+	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// groupsServer implements the gRPC GroupsService by the groups service (same as the REST /groups endpoints)
+type groupsServer struct {
+	pb.UnimplementedGroupsServiceServer
+	service *s.GroupsService
+}
+
+// Create new users group
+func (h *groupsServer) Create(ctx context.Context, req *pb.UsersGroup) (*pb.UsersGroup, error) {
+	ent, err := toEntity(req, NewUsersGroup)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Create(GetTokenData(ctx), ent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.UsersGroup{})
+}
+
+// Update existing users group
+func (h *groupsServer) Update(ctx context.Context, req *pb.UsersGroup) (*pb.UsersGroup, error) {
+	ent, err := toEntity(req, NewUsersGroup)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Update(GetTokenData(ctx), ent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.UsersGroup{})
+}
+
+// Delete users group (only system administrator can delete users groups)
+func (h *groupsServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	td := GetTokenData(ctx)
+	if td.SubjectType != UserTypeCodes.SYSADMIN {
+		return nil, status.Error(codes.PermissionDenied, "delete is forbidden")
+	}
+	if err := h.service.Delete(td, req.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Get a single users group by id
+func (h *groupsServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.UsersGroup, error) {
+	result, err := h.service.Get(GetTokenData(ctx), req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.UsersGroup{})
+}
+
+// Find users groups by query, all the matching users groups are streamed
+func (h *groupsServer) Find(req *pb.FindGroupsRequest, stream grpc.ServerStreamingServer[pb.UsersGroup]) error {
+	criteria, err := parseFilter(NewUsersGroup, req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	p := s.GroupsFindParams{
+		Search: req.GetSearch(),
+		Sort:   req.GetSort(),
+		Filter: criteria,
+	}
+	return statusError(h.service.Export(GetTokenData(stream.Context()), p, func(ent Entity) error {
+		msg, er := toMessage(ent, &pb.UsersGroup{})
+		if er != nil {
+			return er
+		}
+		return stream.Send(msg)
+	}))
+}
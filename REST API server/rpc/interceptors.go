@@ -0,0 +1,259 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-yaaf/yaaf-common/entity"
+	"github.com/go-yaaf/yaaf-common/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	mc "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/utils"
+)
+
+// Metadata keys of the gRPC calls (same as the REST headers, lower case)
+const (
+	ApiKeyMetadata      = "x-api-key"      // The key to identify the application
+	AccessTokenMetadata = "x-access-token" // The token to identify the logged-in user (renewed token is sent in the response header)
+	RequestIdMetadata   = "x-request-id"   // Request correlation ID (request and response header)
+)
+
+// Methods that don't require API key or token validations (full method prefix)
+var publicMethods = []string{"/grpc.health.v1.Health/"}
+
+// Valid client provided request ID (otherwise a new ID is generated)
+var requestIdRegex = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+
+// Keys of the call values in the context
+type contextKey int
+
+const (
+	requestIdKey contextKey = iota
+	tokenDataKey
+	subjectKey // Subject ID reported by the auth interceptor to the access log
+)
+
+// GetRequestId returns the request correlation ID of the call
+func GetRequestId(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdKey).(string)
+	return id
+}
+
+// GetTokenData returns the security token data of the call (validated by the auth interceptor), the token data includes
+// the request correlation ID and the call context (cancellation, deadline)
+func GetTokenData(ctx context.Context) *mc.TokenData {
+	td, ok := ctx.Value(tokenDataKey).(*mc.TokenData)
+	if !ok {
+		return nil
+	}
+	return td.WithContext(ctx)
+}
+
+// region Unary interceptors -------------------------------------------------------------------------------------------
+
+// Convert handler panic to Internal error
+func unaryRecovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// Accept the client request ID (x-request-id metadata) or generate new one, and echo it in the response header
+func unaryRequestId() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id := requestId(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIdMetadata, id))
+		return handler(context.WithValue(ctx, requestIdKey, id), req)
+	}
+}
+
+// Write access log entry of each call
+func unaryAccessLog() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		var subject string
+		resp, err := handler(context.WithValue(ctx, subjectKey, &subject), req)
+		accessLog(ctx, info.FullMethod, subject, start, err)
+		return resp, err
+	}
+}
+
+// Validate the API key and the access token, the renewed token is sent in the response header
+func unaryAuth() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		td, token, err := authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(token) > 0 {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(AccessTokenMetadata, token))
+		}
+		return handler(context.WithValue(ctx, tokenDataKey, td), req)
+	}
+}
+
+// endregion
+
+// region Stream interceptors ------------------------------------------------------------------------------------------
+
+// Server stream with overridden context (values added by the interceptors)
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// Convert handler panic to Internal error
+func streamRecovery() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// Accept the client request ID (x-request-id metadata) or generate new one, and echo it in the response header
+func streamRequestId() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := requestId(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(RequestIdMetadata, id))
+		return handler(srv, &serverStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), requestIdKey, id)})
+	}
+}
+
+// Write access log entry of each call
+func streamAccessLog() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		var subject string
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), subjectKey, &subject)})
+		accessLog(ss.Context(), info.FullMethod, subject, start, err)
+		return err
+	}
+}
+
+// Validate the API key and the access token, the renewed token is sent in the response header
+func streamAuth() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		td, token, err := authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		if len(token) > 0 {
+			_ = ss.SetHeader(metadata.Pairs(AccessTokenMetadata, token))
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), tokenDataKey, td)})
+	}
+}
+
+// endregion
+
+// region Interceptors helpers -----------------------------------------------------------------------------------------
+
+// Check if the method doesn't require API key or token validations
+func isPublic(method string) bool {
+	for _, prefix := range publicMethods {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the first value of the incoming metadata key
+func metadataValue(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Get the client request ID or generate new one
+func requestId(ctx context.Context) string {
+	if id := metadataValue(ctx, RequestIdMetadata); requestIdRegex.MatchString(id) {
+		return id
+	}
+	return uuid.NewString()
+}
+
+// Validate the API key and the access token of the call (same validations as the REST API), returns the token data and
+// the renewed token
+func authenticate(ctx context.Context) (*mc.TokenData, string, error) {
+	if _, err := utils.TokenUtils().ParseApiKey(metadataValue(ctx, ApiKeyMetadata)); err != nil {
+		return nil, "", status.Error(codes.PermissionDenied, "invalid API key")
+	}
+
+	td, err := utils.TokenUtils().ParseToken(metadataValue(ctx, AccessTokenMetadata))
+	if err != nil {
+		return nil, "", status.Error(codes.Unauthenticated, "invalid auth token")
+	}
+	td.RequestId = GetRequestId(ctx)
+
+	// Report the subject to the access log
+	if subject, ok := ctx.Value(subjectKey).(*string); ok {
+		*subject = td.SubjectId
+	}
+
+	// Renew token
+	if td.ExpiresIn > 0 {
+		td.ExpiresIn = int64(entity.Now() + 1000*60*30)
+	}
+	token, _ := utils.TokenUtils().CreateToken(td)
+	return td, token, nil
+}
+
+// Log the panic and return Internal error (the panic value is not exposed to the client)
+func recovered(ctx context.Context, method string, r any) error {
+	logger.Error("[grpc] [%s] %s: panic: %v", GetRequestId(ctx), method, r)
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// Write access log entry of the call: method, status code, latency, subject and request ID. Server errors are logged as
+// error, client errors as warning and the rest as info
+func accessLog(ctx context.Context, method, subject string, start time.Time, err error) {
+	code := status.Code(err)
+	entry := fmt.Sprintf("access method=%s code=%s latency=%.3fms", method, code, float64(time.Since(start).Microseconds())/1000)
+	if len(subject) > 0 {
+		entry += " subject=" + subject
+	}
+	entry += " requestId=" + GetRequestId(ctx)
+	if err != nil {
+		entry += fmt.Sprintf(" error=%q", status.Convert(err).Message())
+	}
+
+	switch code {
+	case codes.OK:
+		logger.Info("%s", entry)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		logger.Error("%s", entry)
+	default:
+		logger.Warn("%s", entry)
+	}
+}
+
+// endregion
@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"context"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"google.golang.org/grpc"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/rpc/pb"
+	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// outboxServer implements the gRPC OutboxService by the outbox service (same as the REST /outbox endpoints)
+type outboxServer struct {
+	pb.UnimplementedOutboxServiceServer
+	service *s.OutboxService
+}
+
+// Get a single outbox entry by id
+func (h *outboxServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.OutboxEntry, error) {
+	td, err := adminTokenData(ctx, "outbox")
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Get(td, req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.OutboxEntry{})
+}
+
+// Find outbox entries by query, all the matching entries are streamed
+func (h *outboxServer) Find(req *pb.FindOutboxRequest, stream grpc.ServerStreamingServer[pb.OutboxEntry]) error {
+	td, err := adminTokenData(stream.Context(), "outbox")
+	if err != nil {
+		return err
+	}
+
+	p := s.OutboxFindParams{
+		Topic:  req.GetTopic(),
+		Status: enumCodes(req.GetStatus()),
+		Stuck:  req.GetStuck(),
+		Sort:   sortOrDefault(req.GetSort(), "createdOn"),
+	}
+	return statusError(h.service.Export(td, p, func(ent Entity) error {
+		msg, er := toMessage(ent, &pb.OutboxEntry{})
+		if er != nil {
+			return er
+		}
+		return stream.Send(msg)
+	}))
+}
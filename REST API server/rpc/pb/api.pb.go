@@ -0,0 +1,2486 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: api.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AccountStatusCode represents the account status: ACTIVE | INACTIVE | BLOCKED ...
+type AccountStatusCode int32
+
+const (
+	// Undefined [0]
+	AccountStatusCode_ACCOUNT_STATUS_CODE_UNDEFINED AccountStatusCode = 0
+	// Active account in the system [1]
+	AccountStatusCode_ACCOUNT_STATUS_CODE_ACTIVE AccountStatusCode = 1
+	// Inactive account in the system [2]
+	AccountStatusCode_ACCOUNT_STATUS_CODE_INACTIVE AccountStatusCode = 2
+	// Blocked account [3]
+	AccountStatusCode_ACCOUNT_STATUS_CODE_BLOCKED AccountStatusCode = 3
+	// Suspended account (about to be deleted) [4]
+	AccountStatusCode_ACCOUNT_STATUS_CODE_SUSPENDED AccountStatusCode = 4
+)
+
+// Enum value maps for AccountStatusCode.
+var (
+	AccountStatusCode_name = map[int32]string{
+		0: "ACCOUNT_STATUS_CODE_UNDEFINED",
+		1: "ACCOUNT_STATUS_CODE_ACTIVE",
+		2: "ACCOUNT_STATUS_CODE_INACTIVE",
+		3: "ACCOUNT_STATUS_CODE_BLOCKED",
+		4: "ACCOUNT_STATUS_CODE_SUSPENDED",
+	}
+	AccountStatusCode_value = map[string]int32{
+		"ACCOUNT_STATUS_CODE_UNDEFINED": 0,
+		"ACCOUNT_STATUS_CODE_ACTIVE":    1,
+		"ACCOUNT_STATUS_CODE_INACTIVE":  2,
+		"ACCOUNT_STATUS_CODE_BLOCKED":   3,
+		"ACCOUNT_STATUS_CODE_SUSPENDED": 4,
+	}
+)
+
+func (x AccountStatusCode) Enum() *AccountStatusCode {
+	p := new(AccountStatusCode)
+	*p = x
+	return p
+}
+
+func (x AccountStatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccountStatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[0].Descriptor()
+}
+
+func (AccountStatusCode) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[0]
+}
+
+func (x AccountStatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccountStatusCode.Descriptor instead.
+func (AccountStatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{0}
+}
+
+// AccountTypeCode represents the account type: DEMO | TRIAL | PARTNER | BUSINESS ...
+type AccountTypeCode int32
+
+const (
+	// Undefined [0]
+	AccountTypeCode_ACCOUNT_TYPE_CODE_UNDEFINED AccountTypeCode = 0
+	// Demo account [1]
+	AccountTypeCode_ACCOUNT_TYPE_CODE_DEMO AccountTypeCode = 1
+	// Trial account [2]
+	AccountTypeCode_ACCOUNT_TYPE_CODE_TRIAL AccountTypeCode = 2
+	// Partner account [3]
+	AccountTypeCode_ACCOUNT_TYPE_CODE_PARTNER AccountTypeCode = 3
+	// Business account [4]
+	AccountTypeCode_ACCOUNT_TYPE_CODE_BUSINESS AccountTypeCode = 4
+)
+
+// Enum value maps for AccountTypeCode.
+var (
+	AccountTypeCode_name = map[int32]string{
+		0: "ACCOUNT_TYPE_CODE_UNDEFINED",
+		1: "ACCOUNT_TYPE_CODE_DEMO",
+		2: "ACCOUNT_TYPE_CODE_TRIAL",
+		3: "ACCOUNT_TYPE_CODE_PARTNER",
+		4: "ACCOUNT_TYPE_CODE_BUSINESS",
+	}
+	AccountTypeCode_value = map[string]int32{
+		"ACCOUNT_TYPE_CODE_UNDEFINED": 0,
+		"ACCOUNT_TYPE_CODE_DEMO":      1,
+		"ACCOUNT_TYPE_CODE_TRIAL":     2,
+		"ACCOUNT_TYPE_CODE_PARTNER":   3,
+		"ACCOUNT_TYPE_CODE_BUSINESS":  4,
+	}
+)
+
+func (x AccountTypeCode) Enum() *AccountTypeCode {
+	p := new(AccountTypeCode)
+	*p = x
+	return p
+}
+
+func (x AccountTypeCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccountTypeCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[1].Descriptor()
+}
+
+func (AccountTypeCode) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[1]
+}
+
+func (x AccountTypeCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccountTypeCode.Descriptor instead.
+func (AccountTypeCode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{1}
+}
+
+// OutboxStatusCode represents the status of outbox entry: PENDING | RETRY | SENT | FAILED ...
+type OutboxStatusCode int32
+
+const (
+	// Undefined [0]
+	OutboxStatusCode_OUTBOX_STATUS_CODE_UNDEFINED OutboxStatusCode = 0
+	// Entry is waiting to be relayed [1]
+	OutboxStatusCode_OUTBOX_STATUS_CODE_PENDING OutboxStatusCode = 1
+	// Relay attempt failed, waiting for the next attempt [2]
+	OutboxStatusCode_OUTBOX_STATUS_CODE_RETRY OutboxStatusCode = 2
+	// Entry was relayed to the message bus or webhooks [3]
+	OutboxStatusCode_OUTBOX_STATUS_CODE_SENT OutboxStatusCode = 3
+	// All the relay attempts failed, can be retried manually [4]
+	OutboxStatusCode_OUTBOX_STATUS_CODE_FAILED OutboxStatusCode = 4
+)
+
+// Enum value maps for OutboxStatusCode.
+var (
+	OutboxStatusCode_name = map[int32]string{
+		0: "OUTBOX_STATUS_CODE_UNDEFINED",
+		1: "OUTBOX_STATUS_CODE_PENDING",
+		2: "OUTBOX_STATUS_CODE_RETRY",
+		3: "OUTBOX_STATUS_CODE_SENT",
+		4: "OUTBOX_STATUS_CODE_FAILED",
+	}
+	OutboxStatusCode_value = map[string]int32{
+		"OUTBOX_STATUS_CODE_UNDEFINED": 0,
+		"OUTBOX_STATUS_CODE_PENDING":   1,
+		"OUTBOX_STATUS_CODE_RETRY":     2,
+		"OUTBOX_STATUS_CODE_SENT":      3,
+		"OUTBOX_STATUS_CODE_FAILED":    4,
+	}
+)
+
+func (x OutboxStatusCode) Enum() *OutboxStatusCode {
+	p := new(OutboxStatusCode)
+	*p = x
+	return p
+}
+
+func (x OutboxStatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutboxStatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[2].Descriptor()
+}
+
+func (OutboxStatusCode) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[2]
+}
+
+func (x OutboxStatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutboxStatusCode.Descriptor instead.
+func (OutboxStatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{2}
+}
+
+// StatusCode represents a general workflow status: PENDING | IN_PROGRESS | DONE ...
+type StatusCode int32
+
+const (
+	// Undefined [0]
+	StatusCode_STATUS_CODE_UNDEFINED StatusCode = 0
+	// Flow not started yet [1]
+	StatusCode_STATUS_CODE_PENDING StatusCode = 1
+	// Flow in process [2]
+	StatusCode_STATUS_CODE_IN_PROCESS StatusCode = 2
+	// Flow completed [3]
+	StatusCode_STATUS_CODE_COMPLETED StatusCode = 3
+	// Flow cancelled by user [4]
+	StatusCode_STATUS_CODE_CANCELLED StatusCode = 4
+	// Flow automatically cancelled by the system [5]
+	StatusCode_STATUS_CODE_AUTO_CANCELLED StatusCode = 5
+)
+
+// Enum value maps for StatusCode.
+var (
+	StatusCode_name = map[int32]string{
+		0: "STATUS_CODE_UNDEFINED",
+		1: "STATUS_CODE_PENDING",
+		2: "STATUS_CODE_IN_PROCESS",
+		3: "STATUS_CODE_COMPLETED",
+		4: "STATUS_CODE_CANCELLED",
+		5: "STATUS_CODE_AUTO_CANCELLED",
+	}
+	StatusCode_value = map[string]int32{
+		"STATUS_CODE_UNDEFINED":      0,
+		"STATUS_CODE_PENDING":        1,
+		"STATUS_CODE_IN_PROCESS":     2,
+		"STATUS_CODE_COMPLETED":      3,
+		"STATUS_CODE_CANCELLED":      4,
+		"STATUS_CODE_AUTO_CANCELLED": 5,
+	}
+)
+
+func (x StatusCode) Enum() *StatusCode {
+	p := new(StatusCode)
+	*p = x
+	return p
+}
+
+func (x StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[3].Descriptor()
+}
+
+func (StatusCode) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[3]
+}
+
+func (x StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatusCode.Descriptor instead.
+func (StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{3}
+}
+
+// UserStatusCode represents the user status: PENDING | ACTIVE | BLOCKED ...
+type UserStatusCode int32
+
+const (
+	// Undefined [0]
+	UserStatusCode_USER_STATUS_CODE_UNDEFINED UserStatusCode = 0
+	// User is registered and pending verification [1]
+	UserStatusCode_USER_STATUS_CODE_PENDING UserStatusCode = 1
+	// Active user in the system [2]
+	UserStatusCode_USER_STATUS_CODE_ACTIVE UserStatusCode = 2
+	// Blocked user (only account system can unblock the user) [3]
+	UserStatusCode_USER_STATUS_CODE_BLOCKED UserStatusCode = 3
+	// Suspended user (about to be deleted) [4]
+	UserStatusCode_USER_STATUS_CODE_SUSPENDED UserStatusCode = 4
+)
+
+// Enum value maps for UserStatusCode.
+var (
+	UserStatusCode_name = map[int32]string{
+		0: "USER_STATUS_CODE_UNDEFINED",
+		1: "USER_STATUS_CODE_PENDING",
+		2: "USER_STATUS_CODE_ACTIVE",
+		3: "USER_STATUS_CODE_BLOCKED",
+		4: "USER_STATUS_CODE_SUSPENDED",
+	}
+	UserStatusCode_value = map[string]int32{
+		"USER_STATUS_CODE_UNDEFINED": 0,
+		"USER_STATUS_CODE_PENDING":   1,
+		"USER_STATUS_CODE_ACTIVE":    2,
+		"USER_STATUS_CODE_BLOCKED":   3,
+		"USER_STATUS_CODE_SUSPENDED": 4,
+	}
+)
+
+func (x UserStatusCode) Enum() *UserStatusCode {
+	p := new(UserStatusCode)
+	*p = x
+	return p
+}
+
+func (x UserStatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserStatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[4].Descriptor()
+}
+
+func (UserStatusCode) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[4]
+}
+
+func (x UserStatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserStatusCode.Descriptor instead.
+func (UserStatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{4}
+}
+
+// UserTypeCode represents the user type: SYSADMIN | SUPPORT | USER ...
+type UserTypeCode int32
+
+const (
+	// Undefined [0]
+	UserTypeCode_USER_TYPE_CODE_UNDEFINED UserTypeCode = 0
+	// System administrator has access to all accounts and permissions to perform all actions [1]
+	UserTypeCode_USER_TYPE_CODE_SYSADMIN UserTypeCode = 1
+	// Support user has view permissions only for all accounts that enabled option Enable Support [2]
+	UserTypeCode_USER_TYPE_CODE_SUPPORT UserTypeCode = 2
+	// Account user - has access to specific accounts with role based access control [3]
+	UserTypeCode_USER_TYPE_CODE_USER UserTypeCode = 3
+	// Service Account - to be used by other systems to perform actions using the API (can't login as a user to the portal) [4]
+	UserTypeCode_USER_TYPE_CODE_SERVICE UserTypeCode = 4
+)
+
+// Enum value maps for UserTypeCode.
+var (
+	UserTypeCode_name = map[int32]string{
+		0: "USER_TYPE_CODE_UNDEFINED",
+		1: "USER_TYPE_CODE_SYSADMIN",
+		2: "USER_TYPE_CODE_SUPPORT",
+		3: "USER_TYPE_CODE_USER",
+		4: "USER_TYPE_CODE_SERVICE",
+	}
+	UserTypeCode_value = map[string]int32{
+		"USER_TYPE_CODE_UNDEFINED": 0,
+		"USER_TYPE_CODE_SYSADMIN":  1,
+		"USER_TYPE_CODE_SUPPORT":   2,
+		"USER_TYPE_CODE_USER":      3,
+		"USER_TYPE_CODE_SERVICE":   4,
+	}
+)
+
+func (x UserTypeCode) Enum() *UserTypeCode {
+	p := new(UserTypeCode)
+	*p = x
+	return p
+}
+
+func (x UserTypeCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserTypeCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[5].Descriptor()
+}
+
+func (UserTypeCode) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[5]
+}
+
+func (x UserTypeCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserTypeCode.Descriptor instead.
+func (UserTypeCode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{5}
+}
+
+// Request of entity by ID
+type IdRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entity ID
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdRequest) Reset() {
+	*x = IdRequest{}
+	mi := &file_api_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdRequest) ProtoMessage() {}
+
+func (x *IdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdRequest.ProtoReflect.Descriptor instead.
+func (*IdRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IdRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Query of account entities
+type FindAccountsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter accounts by free text search on account id, account name
+	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	// filter expression (e.g. status in (ACTIVE,BLOCKED) and name like 'acme*')
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// filter accounts by status(s)
+	Status []AccountStatusCode `protobuf:"varint,3,rep,packed,name=status,proto3,enum=restapi.v1.AccountStatusCode" json:"status,omitempty"`
+	// sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+	Sort          string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindAccountsRequest) Reset() {
+	*x = FindAccountsRequest{}
+	mi := &file_api_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindAccountsRequest) ProtoMessage() {}
+
+func (x *FindAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindAccountsRequest.ProtoReflect.Descriptor instead.
+func (*FindAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FindAccountsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *FindAccountsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *FindAccountsRequest) GetStatus() []AccountStatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *FindAccountsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// Query of audit log entities
+type FindAuditLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// start of time range filter
+	From int64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	// end of time range filter
+	To int64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	// filter auditLogs by user id
+	UserId string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// filter auditLogs by action
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// filter auditLogs by item type
+	ItemType string `protobuf:"bytes,5,opt,name=item_type,json=itemType,proto3" json:"item_type,omitempty"`
+	// filter auditLogs by item id
+	ItemId string `protobuf:"bytes,6,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	// filter auditLogs by item name
+	ItemName string `protobuf:"bytes,7,opt,name=item_name,json=itemName,proto3" json:"item_name,omitempty"`
+	// filter auditLogs by free text search
+	Search string `protobuf:"bytes,8,opt,name=search,proto3" json:"search,omitempty"`
+	// filter expression (e.g. action = 'Delete' and not itemType in ('user','account'))
+	Filter string `protobuf:"bytes,9,opt,name=filter,proto3" json:"filter,omitempty"`
+	// sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+	Sort          string `protobuf:"bytes,10,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindAuditLogsRequest) Reset() {
+	*x = FindAuditLogsRequest{}
+	mi := &file_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindAuditLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindAuditLogsRequest) ProtoMessage() {}
+
+func (x *FindAuditLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindAuditLogsRequest.ProtoReflect.Descriptor instead.
+func (*FindAuditLogsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FindAuditLogsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *FindAuditLogsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *FindAuditLogsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FindAuditLogsRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *FindAuditLogsRequest) GetItemType() string {
+	if x != nil {
+		return x.ItemType
+	}
+	return ""
+}
+
+func (x *FindAuditLogsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *FindAuditLogsRequest) GetItemName() string {
+	if x != nil {
+		return x.ItemName
+	}
+	return ""
+}
+
+func (x *FindAuditLogsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *FindAuditLogsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *FindAuditLogsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// Query of contact entities
+type FindContactsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter contacts by free text search
+	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	// filter expression (e.g. address.city = 'London' or name like 'john*')
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// filter contacts by status(es)
+	Status []StatusCode `protobuf:"varint,3,rep,packed,name=status,proto3,enum=restapi.v1.StatusCode" json:"status,omitempty"`
+	// sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+	Sort          string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindContactsRequest) Reset() {
+	*x = FindContactsRequest{}
+	mi := &file_api_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindContactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindContactsRequest) ProtoMessage() {}
+
+func (x *FindContactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindContactsRequest.ProtoReflect.Descriptor instead.
+func (*FindContactsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FindContactsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *FindContactsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *FindContactsRequest) GetStatus() []StatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *FindContactsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// Query of users group entities
+type FindGroupsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter groups by free text search
+	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	// filter expression (e.g. members = 'user@org.io')
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+	Sort          string `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindGroupsRequest) Reset() {
+	*x = FindGroupsRequest{}
+	mi := &file_api_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindGroupsRequest) ProtoMessage() {}
+
+func (x *FindGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindGroupsRequest.ProtoReflect.Descriptor instead.
+func (*FindGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FindGroupsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *FindGroupsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *FindGroupsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// Query of outbox entry entities
+type FindOutboxRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter entries by topic (e.g. domain.UserCreated, entity.*)
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// filter entries by status(s)
+	Status []OutboxStatusCode `protobuf:"varint,2,rep,packed,name=status,proto3,enum=restapi.v1.OutboxStatusCode" json:"status,omitempty"`
+	// filter stuck entries: waiting to be relayed longer than the stuck threshold
+	Stuck bool `protobuf:"varint,3,opt,name=stuck,proto3" json:"stuck,omitempty"`
+	// sort results by field and direction (default: createdOn = oldest first)
+	Sort          string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindOutboxRequest) Reset() {
+	*x = FindOutboxRequest{}
+	mi := &file_api_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindOutboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindOutboxRequest) ProtoMessage() {}
+
+func (x *FindOutboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindOutboxRequest.ProtoReflect.Descriptor instead.
+func (*FindOutboxRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FindOutboxRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *FindOutboxRequest) GetStatus() []OutboxStatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *FindOutboxRequest) GetStuck() bool {
+	if x != nil {
+		return x.Stuck
+	}
+	return false
+}
+
+func (x *FindOutboxRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// Query of user entities
+type FindUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter users by free text search
+	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	// filter expression (e.g. type = USER and (status = PENDING or lastSignIn < '2024-01-01'))
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// filter users by type(s)
+	Type []UserTypeCode `protobuf:"varint,3,rep,packed,name=type,proto3,enum=restapi.v1.UserTypeCode" json:"type,omitempty"`
+	// filter users by status(es)
+	Status []UserStatusCode `protobuf:"varint,4,rep,packed,name=status,proto3,enum=restapi.v1.UserStatusCode" json:"status,omitempty"`
+	// sort results by field and direction: (e.g. time = sort by time asc, time- = sort by time desc)
+	Sort          string `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindUsersRequest) Reset() {
+	*x = FindUsersRequest{}
+	mi := &file_api_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindUsersRequest) ProtoMessage() {}
+
+func (x *FindUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindUsersRequest.ProtoReflect.Descriptor instead.
+func (*FindUsersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FindUsersRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *FindUsersRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *FindUsersRequest) GetType() []UserTypeCode {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *FindUsersRequest) GetStatus() []UserStatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *FindUsersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// Query of webhook entities
+type FindWebhooksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter webhooks by free text search on webhook id, name and url
+	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	// filter webhooks by account
+	AccountId string `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// sort results by field and direction: (e.g. name = sort by name asc, name- = sort by name desc)
+	Sort          string `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindWebhooksRequest) Reset() {
+	*x = FindWebhooksRequest{}
+	mi := &file_api_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindWebhooksRequest) ProtoMessage() {}
+
+func (x *FindWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*FindWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FindWebhooksRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *FindWebhooksRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *FindWebhooksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// Account entity is a billing account in the system
+type Account struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique object Id
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When the object was created [Epoch milliseconds Timestamp]
+	CreatedOn int64 `protobuf:"varint,2,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	// When the object was last updated [Epoch milliseconds Timestamp]
+	UpdatedOn int64 `protobuf:"varint,3,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	// Entity status flag (e.g. -1 = deleted)
+	Flag int64 `protobuf:"varint,4,opt,name=flag,proto3" json:"flag,omitempty"`
+	// List of custom properties
+	Props *structpb.Struct `protobuf:"bytes,5,opt,name=props,proto3" json:"props,omitempty"`
+	// Account name
+	Name string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	// Account description
+	Description string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	// Account type:  STUDENT | PRIVATE | BUSINESS ...
+	Type AccountTypeCode `protobuf:"varint,8,opt,name=type,proto3,enum=restapi.v1.AccountTypeCode" json:"type,omitempty"`
+	// Account status: UNDEFINED | ACTIVE | INACTIVE | BLOCKED | SUSPENDED
+	Status AccountStatusCode `protobuf:"varint,9,opt,name=status,proto3,enum=restapi.v1.AccountStatusCode" json:"status,omitempty"`
+	// Office / Landline phone
+	Phone string `protobuf:"bytes,10,opt,name=phone,proto3" json:"phone,omitempty"`
+	// Mobile phone
+	Mobile string `protobuf:"bytes,11,opt,name=mobile,proto3" json:"mobile,omitempty"`
+	// Email address
+	Email         string `protobuf:"bytes,12,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Account) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Account) GetCreatedOn() int64 {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return 0
+}
+
+func (x *Account) GetUpdatedOn() int64 {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return 0
+}
+
+func (x *Account) GetFlag() int64 {
+	if x != nil {
+		return x.Flag
+	}
+	return 0
+}
+
+func (x *Account) GetProps() *structpb.Struct {
+	if x != nil {
+		return x.Props
+	}
+	return nil
+}
+
+func (x *Account) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Account) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Account) GetType() AccountTypeCode {
+	if x != nil {
+		return x.Type
+	}
+	return AccountTypeCode_ACCOUNT_TYPE_CODE_UNDEFINED
+}
+
+func (x *Account) GetStatus() AccountStatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return AccountStatusCode_ACCOUNT_STATUS_CODE_UNDEFINED
+}
+
+func (x *Account) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Account) GetMobile() string {
+	if x != nil {
+		return x.Mobile
+	}
+	return ""
+}
+
+func (x *Account) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// AuditLog entity is a log entry in the audit log to track users / service account actions
+type AuditLog struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique object Id
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When the object was created [Epoch milliseconds Timestamp]
+	CreatedOn int64 `protobuf:"varint,2,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	// When the object was last updated [Epoch milliseconds Timestamp]
+	UpdatedOn int64 `protobuf:"varint,3,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	// Entity status flag (e.g. -1 = deleted)
+	Flag int64 `protobuf:"varint,4,opt,name=flag,proto3" json:"flag,omitempty"`
+	// List of custom properties
+	Props *structpb.Struct `protobuf:"bytes,5,opt,name=props,proto3" json:"props,omitempty"`
+	// User Id
+	UserId string `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// User type: UNDEFINED | SYSADMIN | USER | SERVICE_ACCOUNT
+	UserType UserTypeCode `protobuf:"varint,7,opt,name=user_type,json=userType,proto3,enum=restapi.v1.UserTypeCode" json:"user_type,omitempty"`
+	// Action that was performed
+	Action string `protobuf:"bytes,8,opt,name=action,proto3" json:"action,omitempty"`
+	// Item type
+	ItemType string `protobuf:"bytes,9,opt,name=item_type,json=itemType,proto3" json:"item_type,omitempty"`
+	// Item Id
+	ItemId string `protobuf:"bytes,10,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	// Item Name
+	ItemName string `protobuf:"bytes,11,opt,name=item_name,json=itemName,proto3" json:"item_name,omitempty"`
+	// Item value before change [Json]
+	BeforeChange string `protobuf:"bytes,12,opt,name=before_change,json=beforeChange,proto3" json:"before_change,omitempty"`
+	// Item delta after change [Json]
+	AfterChange string `protobuf:"bytes,13,opt,name=after_change,json=afterChange,proto3" json:"after_change,omitempty"`
+	// Correlation ID of the request that performed the action
+	RequestId     string `protobuf:"bytes,14,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLog) Reset() {
+	*x = AuditLog{}
+	mi := &file_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLog) ProtoMessage() {}
+
+func (x *AuditLog) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLog.ProtoReflect.Descriptor instead.
+func (*AuditLog) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AuditLog) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditLog) GetCreatedOn() int64 {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return 0
+}
+
+func (x *AuditLog) GetUpdatedOn() int64 {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return 0
+}
+
+func (x *AuditLog) GetFlag() int64 {
+	if x != nil {
+		return x.Flag
+	}
+	return 0
+}
+
+func (x *AuditLog) GetProps() *structpb.Struct {
+	if x != nil {
+		return x.Props
+	}
+	return nil
+}
+
+func (x *AuditLog) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditLog) GetUserType() UserTypeCode {
+	if x != nil {
+		return x.UserType
+	}
+	return UserTypeCode_USER_TYPE_CODE_UNDEFINED
+}
+
+func (x *AuditLog) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditLog) GetItemType() string {
+	if x != nil {
+		return x.ItemType
+	}
+	return ""
+}
+
+func (x *AuditLog) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *AuditLog) GetItemName() string {
+	if x != nil {
+		return x.ItemName
+	}
+	return ""
+}
+
+func (x *AuditLog) GetBeforeChange() string {
+	if x != nil {
+		return x.BeforeChange
+	}
+	return ""
+}
+
+func (x *AuditLog) GetAfterChange() string {
+	if x != nil {
+		return x.AfterChange
+	}
+	return ""
+}
+
+func (x *AuditLog) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Contact entity is a billing account in the system
+type Contact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique object Id
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When the object was created [Epoch milliseconds Timestamp]
+	CreatedOn int64 `protobuf:"varint,2,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	// When the object was last updated [Epoch milliseconds Timestamp]
+	UpdatedOn int64 `protobuf:"varint,3,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	// Entity status flag (e.g. -1 = deleted)
+	Flag int64 `protobuf:"varint,4,opt,name=flag,proto3" json:"flag,omitempty"`
+	// List of custom properties
+	Props *structpb.Struct `protobuf:"bytes,5,opt,name=props,proto3" json:"props,omitempty"`
+	// Related billing account ID
+	AccountId string `protobuf:"bytes,6,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Contact name
+	Name string `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	// Contact description
+	Description string `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	// Mobile phone
+	Mobile string `protobuf:"bytes,9,opt,name=mobile,proto3" json:"mobile,omitempty"`
+	// Email address
+	Email string `protobuf:"bytes,10,opt,name=email,proto3" json:"email,omitempty"`
+	// Contact address
+	Address *Address `protobuf:"bytes,11,opt,name=address,proto3" json:"address,omitempty"`
+	// Contact groups
+	Groups        []string `protobuf:"bytes,12,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contact) Reset() {
+	*x = Contact{}
+	mi := &file_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contact) ProtoMessage() {}
+
+func (x *Contact) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contact.ProtoReflect.Descriptor instead.
+func (*Contact) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Contact) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Contact) GetCreatedOn() int64 {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return 0
+}
+
+func (x *Contact) GetUpdatedOn() int64 {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return 0
+}
+
+func (x *Contact) GetFlag() int64 {
+	if x != nil {
+		return x.Flag
+	}
+	return 0
+}
+
+func (x *Contact) GetProps() *structpb.Struct {
+	if x != nil {
+		return x.Props
+	}
+	return nil
+}
+
+func (x *Contact) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Contact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Contact) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Contact) GetMobile() string {
+	if x != nil {
+		return x.Mobile
+	}
+	return ""
+}
+
+func (x *Contact) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Contact) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Contact) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// Address model represents an address
+type Address struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Street address
+	Street string `protobuf:"bytes,1,opt,name=street,proto3" json:"street,omitempty"`
+	// City
+	City string `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	// State (if applicable)
+	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// Local zip code (postal cod)
+	ZipCode string `protobuf:"bytes,4,opt,name=zip_code,json=zipCode,proto3" json:"zip_code,omitempty"`
+	// Country name
+	Country       string `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Address) GetZipCode() string {
+	if x != nil {
+		return x.ZipCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// OutboxEntry entity is an event saved in the same transaction as the entity change (transactional outbox), the entry
+// is relayed to the message bus or webhooks after the change was committed
+type OutboxEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique object Id
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When the object was created [Epoch milliseconds Timestamp]
+	CreatedOn int64 `protobuf:"varint,2,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	// When the object was last updated [Epoch milliseconds Timestamp]
+	UpdatedOn int64 `protobuf:"varint,3,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	// Entity status flag (e.g. -1 = deleted)
+	Flag int64 `protobuf:"varint,4,opt,name=flag,proto3" json:"flag,omitempty"`
+	// List of custom properties
+	Props *structpb.Struct `protobuf:"bytes,5,opt,name=props,proto3" json:"props,omitempty"`
+	// Event topic: entity.<item type> (entity change event) or domain.<event name> (domain event)
+	Topic string `protobuf:"bytes,6,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Event payload [Json]
+	Payload string `protobuf:"bytes,7,opt,name=payload,proto3" json:"payload,omitempty"`
+	// Relay status: UNDEFINED | PENDING | RETRY | SENT | FAILED
+	Status OutboxStatusCode `protobuf:"varint,8,opt,name=status,proto3,enum=restapi.v1.OutboxStatusCode" json:"status,omitempty"`
+	// Number of relay attempts
+	Attempts int64 `protobuf:"varint,9,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// When the next attempt is due [Epoch milliseconds Timestamp]
+	NextAttempt int64 `protobuf:"varint,10,opt,name=next_attempt,json=nextAttempt,proto3" json:"next_attempt,omitempty"`
+	// Error of the last attempt
+	Error string `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	// When the entry was relayed [Epoch milliseconds Timestamp]
+	SentOn int64 `protobuf:"varint,12,opt,name=sent_on,json=sentOn,proto3" json:"sent_on,omitempty"`
+	// Correlation ID of the request that performed the change
+	RequestId     string `protobuf:"bytes,13,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutboxEntry) Reset() {
+	*x = OutboxEntry{}
+	mi := &file_api_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboxEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboxEntry) ProtoMessage() {}
+
+func (x *OutboxEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboxEntry.ProtoReflect.Descriptor instead.
+func (*OutboxEntry) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OutboxEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OutboxEntry) GetCreatedOn() int64 {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return 0
+}
+
+func (x *OutboxEntry) GetUpdatedOn() int64 {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return 0
+}
+
+func (x *OutboxEntry) GetFlag() int64 {
+	if x != nil {
+		return x.Flag
+	}
+	return 0
+}
+
+func (x *OutboxEntry) GetProps() *structpb.Struct {
+	if x != nil {
+		return x.Props
+	}
+	return nil
+}
+
+func (x *OutboxEntry) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *OutboxEntry) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *OutboxEntry) GetStatus() OutboxStatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return OutboxStatusCode_OUTBOX_STATUS_CODE_UNDEFINED
+}
+
+func (x *OutboxEntry) GetAttempts() int64 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *OutboxEntry) GetNextAttempt() int64 {
+	if x != nil {
+		return x.NextAttempt
+	}
+	return 0
+}
+
+func (x *OutboxEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *OutboxEntry) GetSentOn() int64 {
+	if x != nil {
+		return x.SentOn
+	}
+	return 0
+}
+
+func (x *OutboxEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// User represents a human / system operator that has access to the system, and can perform operations
+// User authentication is done by an external identity provider
+type User struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique object Id
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When the object was created [Epoch milliseconds Timestamp]
+	CreatedOn int64 `protobuf:"varint,2,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	// When the object was last updated [Epoch milliseconds Timestamp]
+	UpdatedOn int64 `protobuf:"varint,3,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	// Entity status flag (e.g. -1 = deleted)
+	Flag int64 `protobuf:"varint,4,opt,name=flag,proto3" json:"flag,omitempty"`
+	// List of custom properties
+	Props *structpb.Struct `protobuf:"bytes,5,opt,name=props,proto3" json:"props,omitempty"`
+	// User name
+	Name string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	// User email
+	Email string `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	// User mobile phone number (for notification and validation)
+	Mobile string `protobuf:"bytes,8,opt,name=mobile,proto3" json:"mobile,omitempty"`
+	// User type: UNDEFINED | SYSADMIN | SUPPORT | USER
+	Type UserTypeCode `protobuf:"varint,9,opt,name=type,proto3,enum=restapi.v1.UserTypeCode" json:"type,omitempty"`
+	// User roles flags
+	Roles int64 `protobuf:"varint,10,opt,name=roles,proto3" json:"roles,omitempty"`
+	// User permissions groups
+	Groups []string `protobuf:"bytes,11,rep,name=groups,proto3" json:"groups,omitempty"`
+	// User status: UNDEFINED | PENDING | ACTIVE |  BLOCKED | SUSPENDED
+	Status UserStatusCode `protobuf:"varint,12,opt,name=status,proto3,enum=restapi.v1.UserStatusCode" json:"status,omitempty"`
+	// User last successful sign in timestamp [epoch time milliseconds]
+	LastSignIn    int64 `protobuf:"varint,13,opt,name=last_sign_in,json=lastSignIn,proto3" json:"last_sign_in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_api_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetCreatedOn() int64 {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return 0
+}
+
+func (x *User) GetUpdatedOn() int64 {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return 0
+}
+
+func (x *User) GetFlag() int64 {
+	if x != nil {
+		return x.Flag
+	}
+	return 0
+}
+
+func (x *User) GetProps() *structpb.Struct {
+	if x != nil {
+		return x.Props
+	}
+	return nil
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetMobile() string {
+	if x != nil {
+		return x.Mobile
+	}
+	return ""
+}
+
+func (x *User) GetType() UserTypeCode {
+	if x != nil {
+		return x.Type
+	}
+	return UserTypeCode_USER_TYPE_CODE_UNDEFINED
+}
+
+func (x *User) GetRoles() int64 {
+	if x != nil {
+		return x.Roles
+	}
+	return 0
+}
+
+func (x *User) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *User) GetStatus() UserStatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return UserStatusCode_USER_STATUS_CODE_UNDEFINED
+}
+
+func (x *User) GetLastSignIn() int64 {
+	if x != nil {
+		return x.LastSignIn
+	}
+	return 0
+}
+
+// UsersGroup represents a group of users to share permissions
+type UsersGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique object Id
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When the object was created [Epoch milliseconds Timestamp]
+	CreatedOn int64 `protobuf:"varint,2,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	// When the object was last updated [Epoch milliseconds Timestamp]
+	UpdatedOn int64 `protobuf:"varint,3,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	// Entity status flag (e.g. -1 = deleted)
+	Flag int64 `protobuf:"varint,4,opt,name=flag,proto3" json:"flag,omitempty"`
+	// List of custom properties
+	Props *structpb.Struct `protobuf:"bytes,5,opt,name=props,proto3" json:"props,omitempty"`
+	// Group name
+	Name string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	// Group email
+	Email string `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	// List of group members (user Ids)
+	Members       []string `protobuf:"bytes,8,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsersGroup) Reset() {
+	*x = UsersGroup{}
+	mi := &file_api_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsersGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsersGroup) ProtoMessage() {}
+
+func (x *UsersGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsersGroup.ProtoReflect.Descriptor instead.
+func (*UsersGroup) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UsersGroup) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UsersGroup) GetCreatedOn() int64 {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return 0
+}
+
+func (x *UsersGroup) GetUpdatedOn() int64 {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return 0
+}
+
+func (x *UsersGroup) GetFlag() int64 {
+	if x != nil {
+		return x.Flag
+	}
+	return 0
+}
+
+func (x *UsersGroup) GetProps() *structpb.Struct {
+	if x != nil {
+		return x.Props
+	}
+	return nil
+}
+
+func (x *UsersGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UsersGroup) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UsersGroup) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Webhook entity is a subscription of external receiver (URL) to the entity change events
+type Webhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique object Id
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When the object was created [Epoch milliseconds Timestamp]
+	CreatedOn int64 `protobuf:"varint,2,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	// When the object was last updated [Epoch milliseconds Timestamp]
+	UpdatedOn int64 `protobuf:"varint,3,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	// Entity status flag (e.g. -1 = deleted)
+	Flag int64 `protobuf:"varint,4,opt,name=flag,proto3" json:"flag,omitempty"`
+	// List of custom properties
+	Props *structpb.Struct `protobuf:"bytes,5,opt,name=props,proto3" json:"props,omitempty"`
+	// Related account ID: only the changes of the account and its contacts are sent (empty for all)
+	AccountId string `protobuf:"bytes,6,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Webhook name
+	Name string `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	// Receiver URL, the events are posted as json payload (WebhookPayload)
+	Url string `protobuf:"bytes,8,opt,name=url,proto3" json:"url,omitempty"`
+	// Subscribed events: item type or item type and action (e.g. contact, account.Update), empty for all
+	Events []string `protobuf:"bytes,9,rep,name=events,proto3" json:"events,omitempty"`
+	// Secret key of the payload HMAC signature (generated if empty, returned only on create)
+	Secret string `protobuf:"bytes,10,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Active flag, inactive webhook is not notified
+	Active        bool `protobuf:"varint,11,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetCreatedOn() int64 {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return 0
+}
+
+func (x *Webhook) GetUpdatedOn() int64 {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return 0
+}
+
+func (x *Webhook) GetFlag() int64 {
+	if x != nil {
+		return x.Flag
+	}
+	return 0
+}
+
+func (x *Webhook) GetProps() *structpb.Struct {
+	if x != nil {
+		return x.Props
+	}
+	return nil
+}
+
+func (x *Webhook) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Webhook) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+var File_api_proto protoreflect.FileDescriptor
+
+const file_api_proto_rawDesc = "" +
+	"\n" +
+	"\tapi.proto\x12\n" +
+	"restapi.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\"\x1b\n" +
+	"\tIdRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x90\x01\n" +
+	"\x13FindAccountsRequest\x12\x16\n" +
+	"\x06search\x18\x01 \x01(\tR\x06search\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\x125\n" +
+	"\x06status\x18\x03 \x03(\x0e2\x1d.restapi.v1.AccountStatusCodeR\x06status\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\"\x82\x02\n" +
+	"\x14FindAuditLogsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x03R\x02to\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1b\n" +
+	"\titem_type\x18\x05 \x01(\tR\bitemType\x12\x17\n" +
+	"\aitem_id\x18\x06 \x01(\tR\x06itemId\x12\x1b\n" +
+	"\titem_name\x18\a \x01(\tR\bitemName\x12\x16\n" +
+	"\x06search\x18\b \x01(\tR\x06search\x12\x16\n" +
+	"\x06filter\x18\t \x01(\tR\x06filter\x12\x12\n" +
+	"\x04sort\x18\n" +
+	" \x01(\tR\x04sort\"\x89\x01\n" +
+	"\x13FindContactsRequest\x12\x16\n" +
+	"\x06search\x18\x01 \x01(\tR\x06search\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\x12.\n" +
+	"\x06status\x18\x03 \x03(\x0e2\x16.restapi.v1.StatusCodeR\x06status\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\"W\n" +
+	"\x11FindGroupsRequest\x12\x16\n" +
+	"\x06search\x18\x01 \x01(\tR\x06search\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\"\x89\x01\n" +
+	"\x11FindOutboxRequest\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x124\n" +
+	"\x06status\x18\x02 \x03(\x0e2\x1c.restapi.v1.OutboxStatusCodeR\x06status\x12\x14\n" +
+	"\x05stuck\x18\x03 \x01(\bR\x05stuck\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\"\xb8\x01\n" +
+	"\x10FindUsersRequest\x12\x16\n" +
+	"\x06search\x18\x01 \x01(\tR\x06search\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\x12,\n" +
+	"\x04type\x18\x03 \x03(\x0e2\x18.restapi.v1.UserTypeCodeR\x04type\x122\n" +
+	"\x06status\x18\x04 \x03(\x0e2\x1a.restapi.v1.UserStatusCodeR\x06status\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\"`\n" +
+	"\x13FindWebhooksRequest\x12\x16\n" +
+	"\x06search\x18\x01 \x01(\tR\x06search\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\"\xfc\x02\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"created_on\x18\x02 \x01(\x03R\tcreatedOn\x12\x1d\n" +
+	"\n" +
+	"updated_on\x18\x03 \x01(\x03R\tupdatedOn\x12\x12\n" +
+	"\x04flag\x18\x04 \x01(\x03R\x04flag\x12-\n" +
+	"\x05props\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x05props\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12/\n" +
+	"\x04type\x18\b \x01(\x0e2\x1b.restapi.v1.AccountTypeCodeR\x04type\x125\n" +
+	"\x06status\x18\t \x01(\x0e2\x1d.restapi.v1.AccountStatusCodeR\x06status\x12\x14\n" +
+	"\x05phone\x18\n" +
+	" \x01(\tR\x05phone\x12\x16\n" +
+	"\x06mobile\x18\v \x01(\tR\x06mobile\x12\x14\n" +
+	"\x05email\x18\f \x01(\tR\x05email\"\xbd\x03\n" +
+	"\bAuditLog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"created_on\x18\x02 \x01(\x03R\tcreatedOn\x12\x1d\n" +
+	"\n" +
+	"updated_on\x18\x03 \x01(\x03R\tupdatedOn\x12\x12\n" +
+	"\x04flag\x18\x04 \x01(\x03R\x04flag\x12-\n" +
+	"\x05props\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x05props\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\tR\x06userId\x125\n" +
+	"\tuser_type\x18\a \x01(\x0e2\x18.restapi.v1.UserTypeCodeR\buserType\x12\x16\n" +
+	"\x06action\x18\b \x01(\tR\x06action\x12\x1b\n" +
+	"\titem_type\x18\t \x01(\tR\bitemType\x12\x17\n" +
+	"\aitem_id\x18\n" +
+	" \x01(\tR\x06itemId\x12\x1b\n" +
+	"\titem_name\x18\v \x01(\tR\bitemName\x12#\n" +
+	"\rbefore_change\x18\f \x01(\tR\fbeforeChange\x12!\n" +
+	"\fafter_change\x18\r \x01(\tR\vafterChange\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x0e \x01(\tR\trequestId\"\xe4\x02\n" +
+	"\aContact\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"created_on\x18\x02 \x01(\x03R\tcreatedOn\x12\x1d\n" +
+	"\n" +
+	"updated_on\x18\x03 \x01(\x03R\tupdatedOn\x12\x12\n" +
+	"\x04flag\x18\x04 \x01(\x03R\x04flag\x12-\n" +
+	"\x05props\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x05props\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x06 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04name\x18\a \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\x16\n" +
+	"\x06mobile\x18\t \x01(\tR\x06mobile\x12\x14\n" +
+	"\x05email\x18\n" +
+	" \x01(\tR\x05email\x12-\n" +
+	"\aaddress\x18\v \x01(\v2\x13.restapi.v1.AddressR\aaddress\x12\x16\n" +
+	"\x06groups\x18\f \x03(\tR\x06groups\"\x80\x01\n" +
+	"\aAddress\x12\x16\n" +
+	"\x06street\x18\x01 \x01(\tR\x06street\x12\x12\n" +
+	"\x04city\x18\x02 \x01(\tR\x04city\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x19\n" +
+	"\bzip_code\x18\x04 \x01(\tR\azipCode\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\"\x91\x03\n" +
+	"\vOutboxEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"created_on\x18\x02 \x01(\x03R\tcreatedOn\x12\x1d\n" +
+	"\n" +
+	"updated_on\x18\x03 \x01(\x03R\tupdatedOn\x12\x12\n" +
+	"\x04flag\x18\x04 \x01(\x03R\x04flag\x12-\n" +
+	"\x05props\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x05props\x12\x14\n" +
+	"\x05topic\x18\x06 \x01(\tR\x05topic\x12\x18\n" +
+	"\apayload\x18\a \x01(\tR\apayload\x124\n" +
+	"\x06status\x18\b \x01(\x0e2\x1c.restapi.v1.OutboxStatusCodeR\x06status\x12\x1a\n" +
+	"\battempts\x18\t \x01(\x03R\battempts\x12!\n" +
+	"\fnext_attempt\x18\n" +
+	" \x01(\x03R\vnextAttempt\x12\x14\n" +
+	"\x05error\x18\v \x01(\tR\x05error\x12\x17\n" +
+	"\asent_on\x18\f \x01(\x03R\x06sentOn\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\r \x01(\tR\trequestId\"\x8b\x03\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"created_on\x18\x02 \x01(\x03R\tcreatedOn\x12\x1d\n" +
+	"\n" +
+	"updated_on\x18\x03 \x01(\x03R\tupdatedOn\x12\x12\n" +
+	"\x04flag\x18\x04 \x01(\x03R\x04flag\x12-\n" +
+	"\x05props\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x05props\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\a \x01(\tR\x05email\x12\x16\n" +
+	"\x06mobile\x18\b \x01(\tR\x06mobile\x12,\n" +
+	"\x04type\x18\t \x01(\x0e2\x18.restapi.v1.UserTypeCodeR\x04type\x12\x14\n" +
+	"\x05roles\x18\n" +
+	" \x01(\x03R\x05roles\x12\x16\n" +
+	"\x06groups\x18\v \x03(\tR\x06groups\x122\n" +
+	"\x06status\x18\f \x01(\x0e2\x1a.restapi.v1.UserStatusCodeR\x06status\x12 \n" +
+	"\flast_sign_in\x18\r \x01(\x03R\n" +
+	"lastSignIn\"\xe1\x01\n" +
+	"\n" +
+	"UsersGroup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"created_on\x18\x02 \x01(\x03R\tcreatedOn\x12\x1d\n" +
+	"\n" +
+	"updated_on\x18\x03 \x01(\x03R\tupdatedOn\x12\x12\n" +
+	"\x04flag\x18\x04 \x01(\x03R\x04flag\x12-\n" +
+	"\x05props\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x05props\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\a \x01(\tR\x05email\x12\x18\n" +
+	"\amembers\x18\b \x03(\tR\amembers\"\xa7\x02\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"created_on\x18\x02 \x01(\x03R\tcreatedOn\x12\x1d\n" +
+	"\n" +
+	"updated_on\x18\x03 \x01(\x03R\tupdatedOn\x12\x12\n" +
+	"\x04flag\x18\x04 \x01(\x03R\x04flag\x12-\n" +
+	"\x05props\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x05props\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x06 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04name\x18\a \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\b \x01(\tR\x03url\x12\x16\n" +
+	"\x06events\x18\t \x03(\tR\x06events\x12\x16\n" +
+	"\x06secret\x18\n" +
+	" \x01(\tR\x06secret\x12\x16\n" +
+	"\x06active\x18\v \x01(\bR\x06active*\xbc\x01\n" +
+	"\x11AccountStatusCode\x12!\n" +
+	"\x1dACCOUNT_STATUS_CODE_UNDEFINED\x10\x00\x12\x1e\n" +
+	"\x1aACCOUNT_STATUS_CODE_ACTIVE\x10\x01\x12 \n" +
+	"\x1cACCOUNT_STATUS_CODE_INACTIVE\x10\x02\x12\x1f\n" +
+	"\x1bACCOUNT_STATUS_CODE_BLOCKED\x10\x03\x12!\n" +
+	"\x1dACCOUNT_STATUS_CODE_SUSPENDED\x10\x04*\xaa\x01\n" +
+	"\x0fAccountTypeCode\x12\x1f\n" +
+	"\x1bACCOUNT_TYPE_CODE_UNDEFINED\x10\x00\x12\x1a\n" +
+	"\x16ACCOUNT_TYPE_CODE_DEMO\x10\x01\x12\x1b\n" +
+	"\x17ACCOUNT_TYPE_CODE_TRIAL\x10\x02\x12\x1d\n" +
+	"\x19ACCOUNT_TYPE_CODE_PARTNER\x10\x03\x12\x1e\n" +
+	"\x1aACCOUNT_TYPE_CODE_BUSINESS\x10\x04*\xae\x01\n" +
+	"\x10OutboxStatusCode\x12 \n" +
+	"\x1cOUTBOX_STATUS_CODE_UNDEFINED\x10\x00\x12\x1e\n" +
+	"\x1aOUTBOX_STATUS_CODE_PENDING\x10\x01\x12\x1c\n" +
+	"\x18OUTBOX_STATUS_CODE_RETRY\x10\x02\x12\x1b\n" +
+	"\x17OUTBOX_STATUS_CODE_SENT\x10\x03\x12\x1d\n" +
+	"\x19OUTBOX_STATUS_CODE_FAILED\x10\x04*\xb2\x01\n" +
+	"\n" +
+	"StatusCode\x12\x19\n" +
+	"\x15STATUS_CODE_UNDEFINED\x10\x00\x12\x17\n" +
+	"\x13STATUS_CODE_PENDING\x10\x01\x12\x1a\n" +
+	"\x16STATUS_CODE_IN_PROCESS\x10\x02\x12\x19\n" +
+	"\x15STATUS_CODE_COMPLETED\x10\x03\x12\x19\n" +
+	"\x15STATUS_CODE_CANCELLED\x10\x04\x12\x1e\n" +
+	"\x1aSTATUS_CODE_AUTO_CANCELLED\x10\x05*\xa9\x01\n" +
+	"\x0eUserStatusCode\x12\x1e\n" +
+	"\x1aUSER_STATUS_CODE_UNDEFINED\x10\x00\x12\x1c\n" +
+	"\x18USER_STATUS_CODE_PENDING\x10\x01\x12\x1b\n" +
+	"\x17USER_STATUS_CODE_ACTIVE\x10\x02\x12\x1c\n" +
+	"\x18USER_STATUS_CODE_BLOCKED\x10\x03\x12\x1e\n" +
+	"\x1aUSER_STATUS_CODE_SUSPENDED\x10\x04*\x9a\x01\n" +
+	"\fUserTypeCode\x12\x1c\n" +
+	"\x18USER_TYPE_CODE_UNDEFINED\x10\x00\x12\x1b\n" +
+	"\x17USER_TYPE_CODE_SYSADMIN\x10\x01\x12\x1a\n" +
+	"\x16USER_TYPE_CODE_SUPPORT\x10\x02\x12\x17\n" +
+	"\x13USER_TYPE_CODE_USER\x10\x03\x12\x1a\n" +
+	"\x16USER_TYPE_CODE_SERVICE\x10\x042\xa5\x02\n" +
+	"\x0fAccountsService\x122\n" +
+	"\x06Create\x12\x13.restapi.v1.Account\x1a\x13.restapi.v1.Account\x122\n" +
+	"\x06Update\x12\x13.restapi.v1.Account\x1a\x13.restapi.v1.Account\x127\n" +
+	"\x06Delete\x12\x15.restapi.v1.IdRequest\x1a\x16.google.protobuf.Empty\x121\n" +
+	"\x03Get\x12\x15.restapi.v1.IdRequest\x1a\x13.restapi.v1.Account\x12>\n" +
+	"\x04Find\x12\x1f.restapi.v1.FindAccountsRequest\x1a\x13.restapi.v1.Account0\x012\xbe\x01\n" +
+	"\x10AuditLogsService\x124\n" +
+	"\x06Create\x12\x14.restapi.v1.AuditLog\x1a\x14.restapi.v1.AuditLog\x122\n" +
+	"\x03Get\x12\x15.restapi.v1.IdRequest\x1a\x14.restapi.v1.AuditLog\x12@\n" +
+	"\x04Find\x12 .restapi.v1.FindAuditLogsRequest\x1a\x14.restapi.v1.AuditLog0\x012\xa5\x02\n" +
+	"\x0fContactsService\x122\n" +
+	"\x06Create\x12\x13.restapi.v1.Contact\x1a\x13.restapi.v1.Contact\x122\n" +
+	"\x06Update\x12\x13.restapi.v1.Contact\x1a\x13.restapi.v1.Contact\x127\n" +
+	"\x06Delete\x12\x15.restapi.v1.IdRequest\x1a\x16.google.protobuf.Empty\x121\n" +
+	"\x03Get\x12\x15.restapi.v1.IdRequest\x1a\x13.restapi.v1.Contact\x12>\n" +
+	"\x04Find\x12\x1f.restapi.v1.FindContactsRequest\x1a\x13.restapi.v1.Contact0\x012\xb3\x02\n" +
+	"\rGroupsService\x128\n" +
+	"\x06Create\x12\x16.restapi.v1.UsersGroup\x1a\x16.restapi.v1.UsersGroup\x128\n" +
+	"\x06Update\x12\x16.restapi.v1.UsersGroup\x1a\x16.restapi.v1.UsersGroup\x127\n" +
+	"\x06Delete\x12\x15.restapi.v1.IdRequest\x1a\x16.google.protobuf.Empty\x124\n" +
+	"\x03Get\x12\x15.restapi.v1.IdRequest\x1a\x16.restapi.v1.UsersGroup\x12?\n" +
+	"\x04Find\x12\x1d.restapi.v1.FindGroupsRequest\x1a\x16.restapi.v1.UsersGroup0\x012\x88\x01\n" +
+	"\rOutboxService\x125\n" +
+	"\x03Get\x12\x15.restapi.v1.IdRequest\x1a\x17.restapi.v1.OutboxEntry\x12@\n" +
+	"\x04Find\x12\x1d.restapi.v1.FindOutboxRequest\x1a\x17.restapi.v1.OutboxEntry0\x012\x8d\x02\n" +
+	"\fUsersService\x12,\n" +
+	"\x06Create\x12\x10.restapi.v1.User\x1a\x10.restapi.v1.User\x12,\n" +
+	"\x06Update\x12\x10.restapi.v1.User\x1a\x10.restapi.v1.User\x127\n" +
+	"\x06Delete\x12\x15.restapi.v1.IdRequest\x1a\x16.google.protobuf.Empty\x12.\n" +
+	"\x03Get\x12\x15.restapi.v1.IdRequest\x1a\x10.restapi.v1.User\x128\n" +
+	"\x04Find\x12\x1c.restapi.v1.FindUsersRequest\x1a\x10.restapi.v1.User0\x012\xa5\x02\n" +
+	"\x0fWebhooksService\x122\n" +
+	"\x06Create\x12\x13.restapi.v1.Webhook\x1a\x13.restapi.v1.Webhook\x122\n" +
+	"\x06Update\x12\x13.restapi.v1.Webhook\x1a\x13.restapi.v1.Webhook\x127\n" +
+	"\x06Delete\x12\x15.restapi.v1.IdRequest\x1a\x16.google.protobuf.Empty\x121\n" +
+	"\x03Get\x12\x15.restapi.v1.IdRequest\x1a\x13.restapi.v1.Webhook\x12>\n" +
+	"\x04Find\x12\x1f.restapi.v1.FindWebhooksRequest\x1a\x13.restapi.v1.Webhook0\x01B5Z3github.com/go-yaaf/yaaf-examples/rest-api/rpc/pb;pbb\x06proto3"
+
+var (
+	file_api_proto_rawDescOnce sync.Once
+	file_api_proto_rawDescData []byte
+)
+
+func file_api_proto_rawDescGZIP() []byte {
+	file_api_proto_rawDescOnce.Do(func() {
+		file_api_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_rawDesc), len(file_api_proto_rawDesc)))
+	})
+	return file_api_proto_rawDescData
+}
+
+var file_api_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_api_proto_goTypes = []any{
+	(AccountStatusCode)(0),       // 0: restapi.v1.AccountStatusCode
+	(AccountTypeCode)(0),         // 1: restapi.v1.AccountTypeCode
+	(OutboxStatusCode)(0),        // 2: restapi.v1.OutboxStatusCode
+	(StatusCode)(0),              // 3: restapi.v1.StatusCode
+	(UserStatusCode)(0),          // 4: restapi.v1.UserStatusCode
+	(UserTypeCode)(0),            // 5: restapi.v1.UserTypeCode
+	(*IdRequest)(nil),            // 6: restapi.v1.IdRequest
+	(*FindAccountsRequest)(nil),  // 7: restapi.v1.FindAccountsRequest
+	(*FindAuditLogsRequest)(nil), // 8: restapi.v1.FindAuditLogsRequest
+	(*FindContactsRequest)(nil),  // 9: restapi.v1.FindContactsRequest
+	(*FindGroupsRequest)(nil),    // 10: restapi.v1.FindGroupsRequest
+	(*FindOutboxRequest)(nil),    // 11: restapi.v1.FindOutboxRequest
+	(*FindUsersRequest)(nil),     // 12: restapi.v1.FindUsersRequest
+	(*FindWebhooksRequest)(nil),  // 13: restapi.v1.FindWebhooksRequest
+	(*Account)(nil),              // 14: restapi.v1.Account
+	(*AuditLog)(nil),             // 15: restapi.v1.AuditLog
+	(*Contact)(nil),              // 16: restapi.v1.Contact
+	(*Address)(nil),              // 17: restapi.v1.Address
+	(*OutboxEntry)(nil),          // 18: restapi.v1.OutboxEntry
+	(*User)(nil),                 // 19: restapi.v1.User
+	(*UsersGroup)(nil),           // 20: restapi.v1.UsersGroup
+	(*Webhook)(nil),              // 21: restapi.v1.Webhook
+	(*structpb.Struct)(nil),      // 22: google.protobuf.Struct
+	(*emptypb.Empty)(nil),        // 23: google.protobuf.Empty
+}
+var file_api_proto_depIdxs = []int32{
+	0,  // 0: restapi.v1.FindAccountsRequest.status:type_name -> restapi.v1.AccountStatusCode
+	3,  // 1: restapi.v1.FindContactsRequest.status:type_name -> restapi.v1.StatusCode
+	2,  // 2: restapi.v1.FindOutboxRequest.status:type_name -> restapi.v1.OutboxStatusCode
+	5,  // 3: restapi.v1.FindUsersRequest.type:type_name -> restapi.v1.UserTypeCode
+	4,  // 4: restapi.v1.FindUsersRequest.status:type_name -> restapi.v1.UserStatusCode
+	22, // 5: restapi.v1.Account.props:type_name -> google.protobuf.Struct
+	1,  // 6: restapi.v1.Account.type:type_name -> restapi.v1.AccountTypeCode
+	0,  // 7: restapi.v1.Account.status:type_name -> restapi.v1.AccountStatusCode
+	22, // 8: restapi.v1.AuditLog.props:type_name -> google.protobuf.Struct
+	5,  // 9: restapi.v1.AuditLog.user_type:type_name -> restapi.v1.UserTypeCode
+	22, // 10: restapi.v1.Contact.props:type_name -> google.protobuf.Struct
+	17, // 11: restapi.v1.Contact.address:type_name -> restapi.v1.Address
+	22, // 12: restapi.v1.OutboxEntry.props:type_name -> google.protobuf.Struct
+	2,  // 13: restapi.v1.OutboxEntry.status:type_name -> restapi.v1.OutboxStatusCode
+	22, // 14: restapi.v1.User.props:type_name -> google.protobuf.Struct
+	5,  // 15: restapi.v1.User.type:type_name -> restapi.v1.UserTypeCode
+	4,  // 16: restapi.v1.User.status:type_name -> restapi.v1.UserStatusCode
+	22, // 17: restapi.v1.UsersGroup.props:type_name -> google.protobuf.Struct
+	22, // 18: restapi.v1.Webhook.props:type_name -> google.protobuf.Struct
+	14, // 19: restapi.v1.AccountsService.Create:input_type -> restapi.v1.Account
+	14, // 20: restapi.v1.AccountsService.Update:input_type -> restapi.v1.Account
+	6,  // 21: restapi.v1.AccountsService.Delete:input_type -> restapi.v1.IdRequest
+	6,  // 22: restapi.v1.AccountsService.Get:input_type -> restapi.v1.IdRequest
+	7,  // 23: restapi.v1.AccountsService.Find:input_type -> restapi.v1.FindAccountsRequest
+	15, // 24: restapi.v1.AuditLogsService.Create:input_type -> restapi.v1.AuditLog
+	6,  // 25: restapi.v1.AuditLogsService.Get:input_type -> restapi.v1.IdRequest
+	8,  // 26: restapi.v1.AuditLogsService.Find:input_type -> restapi.v1.FindAuditLogsRequest
+	16, // 27: restapi.v1.ContactsService.Create:input_type -> restapi.v1.Contact
+	16, // 28: restapi.v1.ContactsService.Update:input_type -> restapi.v1.Contact
+	6,  // 29: restapi.v1.ContactsService.Delete:input_type -> restapi.v1.IdRequest
+	6,  // 30: restapi.v1.ContactsService.Get:input_type -> restapi.v1.IdRequest
+	9,  // 31: restapi.v1.ContactsService.Find:input_type -> restapi.v1.FindContactsRequest
+	20, // 32: restapi.v1.GroupsService.Create:input_type -> restapi.v1.UsersGroup
+	20, // 33: restapi.v1.GroupsService.Update:input_type -> restapi.v1.UsersGroup
+	6,  // 34: restapi.v1.GroupsService.Delete:input_type -> restapi.v1.IdRequest
+	6,  // 35: restapi.v1.GroupsService.Get:input_type -> restapi.v1.IdRequest
+	10, // 36: restapi.v1.GroupsService.Find:input_type -> restapi.v1.FindGroupsRequest
+	6,  // 37: restapi.v1.OutboxService.Get:input_type -> restapi.v1.IdRequest
+	11, // 38: restapi.v1.OutboxService.Find:input_type -> restapi.v1.FindOutboxRequest
+	19, // 39: restapi.v1.UsersService.Create:input_type -> restapi.v1.User
+	19, // 40: restapi.v1.UsersService.Update:input_type -> restapi.v1.User
+	6,  // 41: restapi.v1.UsersService.Delete:input_type -> restapi.v1.IdRequest
+	6,  // 42: restapi.v1.UsersService.Get:input_type -> restapi.v1.IdRequest
+	12, // 43: restapi.v1.UsersService.Find:input_type -> restapi.v1.FindUsersRequest
+	21, // 44: restapi.v1.WebhooksService.Create:input_type -> restapi.v1.Webhook
+	21, // 45: restapi.v1.WebhooksService.Update:input_type -> restapi.v1.Webhook
+	6,  // 46: restapi.v1.WebhooksService.Delete:input_type -> restapi.v1.IdRequest
+	6,  // 47: restapi.v1.WebhooksService.Get:input_type -> restapi.v1.IdRequest
+	13, // 48: restapi.v1.WebhooksService.Find:input_type -> restapi.v1.FindWebhooksRequest
+	14, // 49: restapi.v1.AccountsService.Create:output_type -> restapi.v1.Account
+	14, // 50: restapi.v1.AccountsService.Update:output_type -> restapi.v1.Account
+	23, // 51: restapi.v1.AccountsService.Delete:output_type -> google.protobuf.Empty
+	14, // 52: restapi.v1.AccountsService.Get:output_type -> restapi.v1.Account
+	14, // 53: restapi.v1.AccountsService.Find:output_type -> restapi.v1.Account
+	15, // 54: restapi.v1.AuditLogsService.Create:output_type -> restapi.v1.AuditLog
+	15, // 55: restapi.v1.AuditLogsService.Get:output_type -> restapi.v1.AuditLog
+	15, // 56: restapi.v1.AuditLogsService.Find:output_type -> restapi.v1.AuditLog
+	16, // 57: restapi.v1.ContactsService.Create:output_type -> restapi.v1.Contact
+	16, // 58: restapi.v1.ContactsService.Update:output_type -> restapi.v1.Contact
+	23, // 59: restapi.v1.ContactsService.Delete:output_type -> google.protobuf.Empty
+	16, // 60: restapi.v1.ContactsService.Get:output_type -> restapi.v1.Contact
+	16, // 61: restapi.v1.ContactsService.Find:output_type -> restapi.v1.Contact
+	20, // 62: restapi.v1.GroupsService.Create:output_type -> restapi.v1.UsersGroup
+	20, // 63: restapi.v1.GroupsService.Update:output_type -> restapi.v1.UsersGroup
+	23, // 64: restapi.v1.GroupsService.Delete:output_type -> google.protobuf.Empty
+	20, // 65: restapi.v1.GroupsService.Get:output_type -> restapi.v1.UsersGroup
+	20, // 66: restapi.v1.GroupsService.Find:output_type -> restapi.v1.UsersGroup
+	18, // 67: restapi.v1.OutboxService.Get:output_type -> restapi.v1.OutboxEntry
+	18, // 68: restapi.v1.OutboxService.Find:output_type -> restapi.v1.OutboxEntry
+	19, // 69: restapi.v1.UsersService.Create:output_type -> restapi.v1.User
+	19, // 70: restapi.v1.UsersService.Update:output_type -> restapi.v1.User
+	23, // 71: restapi.v1.UsersService.Delete:output_type -> google.protobuf.Empty
+	19, // 72: restapi.v1.UsersService.Get:output_type -> restapi.v1.User
+	19, // 73: restapi.v1.UsersService.Find:output_type -> restapi.v1.User
+	21, // 74: restapi.v1.WebhooksService.Create:output_type -> restapi.v1.Webhook
+	21, // 75: restapi.v1.WebhooksService.Update:output_type -> restapi.v1.Webhook
+	23, // 76: restapi.v1.WebhooksService.Delete:output_type -> google.protobuf.Empty
+	21, // 77: restapi.v1.WebhooksService.Get:output_type -> restapi.v1.Webhook
+	21, // 78: restapi.v1.WebhooksService.Find:output_type -> restapi.v1.Webhook
+	49, // [49:79] is the sub-list for method output_type
+	19, // [19:49] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_init() }
+func file_api_proto_init() {
+	if File_api_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_rawDesc), len(file_api_proto_rawDesc)),
+			NumEnums:      6,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   7,
+		},
+		GoTypes:           file_api_proto_goTypes,
+		DependencyIndexes: file_api_proto_depIdxs,
+		EnumInfos:         file_api_proto_enumTypes,
+		MessageInfos:      file_api_proto_msgTypes,
+	}.Build()
+	File_api_proto = out.File
+	file_api_proto_goTypes = nil
+	file_api_proto_depIdxs = nil
+}
@@ -0,0 +1,1724 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AccountsService_Create_FullMethodName = "/restapi.v1.AccountsService/Create"
+	AccountsService_Update_FullMethodName = "/restapi.v1.AccountsService/Update"
+	AccountsService_Delete_FullMethodName = "/restapi.v1.AccountsService/Delete"
+	AccountsService_Get_FullMethodName    = "/restapi.v1.AccountsService/Get"
+	AccountsService_Find_FullMethodName   = "/restapi.v1.AccountsService/Find"
+)
+
+// AccountsServiceClient is the client API for AccountsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AccountsService manages the account entities (same service as the REST /accounts endpoints)
+type AccountsServiceClient interface {
+	// Create a new account
+	Create(ctx context.Context, in *Account, opts ...grpc.CallOption) (*Account, error)
+	// Update existing account
+	Update(ctx context.Context, in *Account, opts ...grpc.CallOption) (*Account, error)
+	// Delete account by ID
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Get account by ID
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Account, error)
+	// Find account entities by query, all the matching entities are streamed (no pagination)
+	Find(ctx context.Context, in *FindAccountsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Account], error)
+}
+
+type accountsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccountsServiceClient(cc grpc.ClientConnInterface) AccountsServiceClient {
+	return &accountsServiceClient{cc}
+}
+
+func (c *accountsServiceClient) Create(ctx context.Context, in *Account, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, AccountsService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsServiceClient) Update(ctx context.Context, in *Account, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, AccountsService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AccountsService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, AccountsService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsServiceClient) Find(ctx context.Context, in *FindAccountsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Account], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AccountsService_ServiceDesc.Streams[0], AccountsService_Find_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindAccountsRequest, Account]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AccountsService_FindClient = grpc.ServerStreamingClient[Account]
+
+// AccountsServiceServer is the server API for AccountsService service.
+// All implementations must embed UnimplementedAccountsServiceServer
+// for forward compatibility.
+//
+// AccountsService manages the account entities (same service as the REST /accounts endpoints)
+type AccountsServiceServer interface {
+	// Create a new account
+	Create(context.Context, *Account) (*Account, error)
+	// Update existing account
+	Update(context.Context, *Account) (*Account, error)
+	// Delete account by ID
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	// Get account by ID
+	Get(context.Context, *IdRequest) (*Account, error)
+	// Find account entities by query, all the matching entities are streamed (no pagination)
+	Find(*FindAccountsRequest, grpc.ServerStreamingServer[Account]) error
+	mustEmbedUnimplementedAccountsServiceServer()
+}
+
+// UnimplementedAccountsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAccountsServiceServer struct{}
+
+func (UnimplementedAccountsServiceServer) Create(context.Context, *Account) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedAccountsServiceServer) Update(context.Context, *Account) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedAccountsServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedAccountsServiceServer) Get(context.Context, *IdRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedAccountsServiceServer) Find(*FindAccountsRequest, grpc.ServerStreamingServer[Account]) error {
+	return status.Errorf(codes.Unimplemented, "method Find not implemented")
+}
+func (UnimplementedAccountsServiceServer) mustEmbedUnimplementedAccountsServiceServer() {}
+func (UnimplementedAccountsServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeAccountsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AccountsServiceServer will
+// result in compilation errors.
+type UnsafeAccountsServiceServer interface {
+	mustEmbedUnimplementedAccountsServiceServer()
+}
+
+func RegisterAccountsServiceServer(s grpc.ServiceRegistrar, srv AccountsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAccountsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AccountsService_ServiceDesc, srv)
+}
+
+func _AccountsService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Account)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).Create(ctx, req.(*Account))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Account)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).Update(ctx, req.(*Account))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsService_Find_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindAccountsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccountsServiceServer).Find(m, &grpc.GenericServerStream[FindAccountsRequest, Account]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AccountsService_FindServer = grpc.ServerStreamingServer[Account]
+
+// AccountsService_ServiceDesc is the grpc.ServiceDesc for AccountsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AccountsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restapi.v1.AccountsService",
+	HandlerType: (*AccountsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _AccountsService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _AccountsService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _AccountsService_Delete_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _AccountsService_Get_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Find",
+			Handler:       _AccountsService_Find_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
+
+const (
+	AuditLogsService_Create_FullMethodName = "/restapi.v1.AuditLogsService/Create"
+	AuditLogsService_Get_FullMethodName    = "/restapi.v1.AuditLogsService/Get"
+	AuditLogsService_Find_FullMethodName   = "/restapi.v1.AuditLogsService/Find"
+)
+
+// AuditLogsServiceClient is the client API for AuditLogsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AuditLogsService manages the audit log entities (same service as the REST /audit_logs endpoints)
+type AuditLogsServiceClient interface {
+	// Create a new audit log
+	Create(ctx context.Context, in *AuditLog, opts ...grpc.CallOption) (*AuditLog, error)
+	// Get audit log by ID
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*AuditLog, error)
+	// Find audit log entities by query, all the matching entities are streamed (no pagination)
+	Find(ctx context.Context, in *FindAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditLog], error)
+}
+
+type auditLogsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuditLogsServiceClient(cc grpc.ClientConnInterface) AuditLogsServiceClient {
+	return &auditLogsServiceClient{cc}
+}
+
+func (c *auditLogsServiceClient) Create(ctx context.Context, in *AuditLog, opts ...grpc.CallOption) (*AuditLog, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditLog)
+	err := c.cc.Invoke(ctx, AuditLogsService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auditLogsServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*AuditLog, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditLog)
+	err := c.cc.Invoke(ctx, AuditLogsService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auditLogsServiceClient) Find(ctx context.Context, in *FindAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditLog], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AuditLogsService_ServiceDesc.Streams[0], AuditLogsService_Find_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindAuditLogsRequest, AuditLog]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuditLogsService_FindClient = grpc.ServerStreamingClient[AuditLog]
+
+// AuditLogsServiceServer is the server API for AuditLogsService service.
+// All implementations must embed UnimplementedAuditLogsServiceServer
+// for forward compatibility.
+//
+// AuditLogsService manages the audit log entities (same service as the REST /audit_logs endpoints)
+type AuditLogsServiceServer interface {
+	// Create a new audit log
+	Create(context.Context, *AuditLog) (*AuditLog, error)
+	// Get audit log by ID
+	Get(context.Context, *IdRequest) (*AuditLog, error)
+	// Find audit log entities by query, all the matching entities are streamed (no pagination)
+	Find(*FindAuditLogsRequest, grpc.ServerStreamingServer[AuditLog]) error
+	mustEmbedUnimplementedAuditLogsServiceServer()
+}
+
+// UnimplementedAuditLogsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuditLogsServiceServer struct{}
+
+func (UnimplementedAuditLogsServiceServer) Create(context.Context, *AuditLog) (*AuditLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedAuditLogsServiceServer) Get(context.Context, *IdRequest) (*AuditLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedAuditLogsServiceServer) Find(*FindAuditLogsRequest, grpc.ServerStreamingServer[AuditLog]) error {
+	return status.Errorf(codes.Unimplemented, "method Find not implemented")
+}
+func (UnimplementedAuditLogsServiceServer) mustEmbedUnimplementedAuditLogsServiceServer() {}
+func (UnimplementedAuditLogsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAuditLogsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuditLogsServiceServer will
+// result in compilation errors.
+type UnsafeAuditLogsServiceServer interface {
+	mustEmbedUnimplementedAuditLogsServiceServer()
+}
+
+func RegisterAuditLogsServiceServer(s grpc.ServiceRegistrar, srv AuditLogsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuditLogsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuditLogsService_ServiceDesc, srv)
+}
+
+func _AuditLogsService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditLog)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditLogsServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuditLogsService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditLogsServiceServer).Create(ctx, req.(*AuditLog))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuditLogsService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditLogsServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuditLogsService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditLogsServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuditLogsService_Find_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindAuditLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuditLogsServiceServer).Find(m, &grpc.GenericServerStream[FindAuditLogsRequest, AuditLog]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuditLogsService_FindServer = grpc.ServerStreamingServer[AuditLog]
+
+// AuditLogsService_ServiceDesc is the grpc.ServiceDesc for AuditLogsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuditLogsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restapi.v1.AuditLogsService",
+	HandlerType: (*AuditLogsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _AuditLogsService_Create_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _AuditLogsService_Get_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Find",
+			Handler:       _AuditLogsService_Find_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
+
+const (
+	ContactsService_Create_FullMethodName = "/restapi.v1.ContactsService/Create"
+	ContactsService_Update_FullMethodName = "/restapi.v1.ContactsService/Update"
+	ContactsService_Delete_FullMethodName = "/restapi.v1.ContactsService/Delete"
+	ContactsService_Get_FullMethodName    = "/restapi.v1.ContactsService/Get"
+	ContactsService_Find_FullMethodName   = "/restapi.v1.ContactsService/Find"
+)
+
+// ContactsServiceClient is the client API for ContactsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ContactsService manages the contact entities (same service as the REST /contacts endpoints)
+type ContactsServiceClient interface {
+	// Create a new contact
+	Create(ctx context.Context, in *Contact, opts ...grpc.CallOption) (*Contact, error)
+	// Update existing contact
+	Update(ctx context.Context, in *Contact, opts ...grpc.CallOption) (*Contact, error)
+	// Delete contact by ID
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Get contact by ID
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Contact, error)
+	// Find contact entities by query, all the matching entities are streamed (no pagination)
+	Find(ctx context.Context, in *FindContactsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Contact], error)
+}
+
+type contactsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewContactsServiceClient(cc grpc.ClientConnInterface) ContactsServiceClient {
+	return &contactsServiceClient{cc}
+}
+
+func (c *contactsServiceClient) Create(ctx context.Context, in *Contact, opts ...grpc.CallOption) (*Contact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Contact)
+	err := c.cc.Invoke(ctx, ContactsService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsServiceClient) Update(ctx context.Context, in *Contact, opts ...grpc.CallOption) (*Contact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Contact)
+	err := c.cc.Invoke(ctx, ContactsService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ContactsService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Contact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Contact)
+	err := c.cc.Invoke(ctx, ContactsService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsServiceClient) Find(ctx context.Context, in *FindContactsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Contact], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ContactsService_ServiceDesc.Streams[0], ContactsService_Find_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindContactsRequest, Contact]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContactsService_FindClient = grpc.ServerStreamingClient[Contact]
+
+// ContactsServiceServer is the server API for ContactsService service.
+// All implementations must embed UnimplementedContactsServiceServer
+// for forward compatibility.
+//
+// ContactsService manages the contact entities (same service as the REST /contacts endpoints)
+type ContactsServiceServer interface {
+	// Create a new contact
+	Create(context.Context, *Contact) (*Contact, error)
+	// Update existing contact
+	Update(context.Context, *Contact) (*Contact, error)
+	// Delete contact by ID
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	// Get contact by ID
+	Get(context.Context, *IdRequest) (*Contact, error)
+	// Find contact entities by query, all the matching entities are streamed (no pagination)
+	Find(*FindContactsRequest, grpc.ServerStreamingServer[Contact]) error
+	mustEmbedUnimplementedContactsServiceServer()
+}
+
+// UnimplementedContactsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedContactsServiceServer struct{}
+
+func (UnimplementedContactsServiceServer) Create(context.Context, *Contact) (*Contact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedContactsServiceServer) Update(context.Context, *Contact) (*Contact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedContactsServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedContactsServiceServer) Get(context.Context, *IdRequest) (*Contact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedContactsServiceServer) Find(*FindContactsRequest, grpc.ServerStreamingServer[Contact]) error {
+	return status.Errorf(codes.Unimplemented, "method Find not implemented")
+}
+func (UnimplementedContactsServiceServer) mustEmbedUnimplementedContactsServiceServer() {}
+func (UnimplementedContactsServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeContactsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ContactsServiceServer will
+// result in compilation errors.
+type UnsafeContactsServiceServer interface {
+	mustEmbedUnimplementedContactsServiceServer()
+}
+
+func RegisterContactsServiceServer(s grpc.ServiceRegistrar, srv ContactsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedContactsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ContactsService_ServiceDesc, srv)
+}
+
+func _ContactsService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Contact)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContactsService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServiceServer).Create(ctx, req.(*Contact))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContactsService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Contact)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContactsService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServiceServer).Update(ctx, req.(*Contact))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContactsService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContactsService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContactsService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContactsService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContactsService_Find_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindContactsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContactsServiceServer).Find(m, &grpc.GenericServerStream[FindContactsRequest, Contact]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContactsService_FindServer = grpc.ServerStreamingServer[Contact]
+
+// ContactsService_ServiceDesc is the grpc.ServiceDesc for ContactsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ContactsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restapi.v1.ContactsService",
+	HandlerType: (*ContactsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _ContactsService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ContactsService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ContactsService_Delete_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _ContactsService_Get_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Find",
+			Handler:       _ContactsService_Find_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
+
+const (
+	GroupsService_Create_FullMethodName = "/restapi.v1.GroupsService/Create"
+	GroupsService_Update_FullMethodName = "/restapi.v1.GroupsService/Update"
+	GroupsService_Delete_FullMethodName = "/restapi.v1.GroupsService/Delete"
+	GroupsService_Get_FullMethodName    = "/restapi.v1.GroupsService/Get"
+	GroupsService_Find_FullMethodName   = "/restapi.v1.GroupsService/Find"
+)
+
+// GroupsServiceClient is the client API for GroupsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GroupsService manages the users group entities (same service as the REST /groups endpoints)
+type GroupsServiceClient interface {
+	// Create a new users group
+	Create(ctx context.Context, in *UsersGroup, opts ...grpc.CallOption) (*UsersGroup, error)
+	// Update existing users group
+	Update(ctx context.Context, in *UsersGroup, opts ...grpc.CallOption) (*UsersGroup, error)
+	// Delete users group by ID
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Get users group by ID
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*UsersGroup, error)
+	// Find users group entities by query, all the matching entities are streamed (no pagination)
+	Find(ctx context.Context, in *FindGroupsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UsersGroup], error)
+}
+
+type groupsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGroupsServiceClient(cc grpc.ClientConnInterface) GroupsServiceClient {
+	return &groupsServiceClient{cc}
+}
+
+func (c *groupsServiceClient) Create(ctx context.Context, in *UsersGroup, opts ...grpc.CallOption) (*UsersGroup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsersGroup)
+	err := c.cc.Invoke(ctx, GroupsService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupsServiceClient) Update(ctx context.Context, in *UsersGroup, opts ...grpc.CallOption) (*UsersGroup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsersGroup)
+	err := c.cc.Invoke(ctx, GroupsService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupsServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, GroupsService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupsServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*UsersGroup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsersGroup)
+	err := c.cc.Invoke(ctx, GroupsService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupsServiceClient) Find(ctx context.Context, in *FindGroupsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UsersGroup], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GroupsService_ServiceDesc.Streams[0], GroupsService_Find_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindGroupsRequest, UsersGroup]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GroupsService_FindClient = grpc.ServerStreamingClient[UsersGroup]
+
+// GroupsServiceServer is the server API for GroupsService service.
+// All implementations must embed UnimplementedGroupsServiceServer
+// for forward compatibility.
+//
+// GroupsService manages the users group entities (same service as the REST /groups endpoints)
+type GroupsServiceServer interface {
+	// Create a new users group
+	Create(context.Context, *UsersGroup) (*UsersGroup, error)
+	// Update existing users group
+	Update(context.Context, *UsersGroup) (*UsersGroup, error)
+	// Delete users group by ID
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	// Get users group by ID
+	Get(context.Context, *IdRequest) (*UsersGroup, error)
+	// Find users group entities by query, all the matching entities are streamed (no pagination)
+	Find(*FindGroupsRequest, grpc.ServerStreamingServer[UsersGroup]) error
+	mustEmbedUnimplementedGroupsServiceServer()
+}
+
+// UnimplementedGroupsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGroupsServiceServer struct{}
+
+func (UnimplementedGroupsServiceServer) Create(context.Context, *UsersGroup) (*UsersGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedGroupsServiceServer) Update(context.Context, *UsersGroup) (*UsersGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedGroupsServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedGroupsServiceServer) Get(context.Context, *IdRequest) (*UsersGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedGroupsServiceServer) Find(*FindGroupsRequest, grpc.ServerStreamingServer[UsersGroup]) error {
+	return status.Errorf(codes.Unimplemented, "method Find not implemented")
+}
+func (UnimplementedGroupsServiceServer) mustEmbedUnimplementedGroupsServiceServer() {}
+func (UnimplementedGroupsServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeGroupsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GroupsServiceServer will
+// result in compilation errors.
+type UnsafeGroupsServiceServer interface {
+	mustEmbedUnimplementedGroupsServiceServer()
+}
+
+func RegisterGroupsServiceServer(s grpc.ServiceRegistrar, srv GroupsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGroupsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GroupsService_ServiceDesc, srv)
+}
+
+func _GroupsService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsersGroup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupsServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupsService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupsServiceServer).Create(ctx, req.(*UsersGroup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupsService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsersGroup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupsServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupsService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupsServiceServer).Update(ctx, req.(*UsersGroup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupsService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupsServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupsService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupsServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupsService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupsServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupsService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupsServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupsService_Find_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindGroupsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GroupsServiceServer).Find(m, &grpc.GenericServerStream[FindGroupsRequest, UsersGroup]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GroupsService_FindServer = grpc.ServerStreamingServer[UsersGroup]
+
+// GroupsService_ServiceDesc is the grpc.ServiceDesc for GroupsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GroupsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restapi.v1.GroupsService",
+	HandlerType: (*GroupsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _GroupsService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _GroupsService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _GroupsService_Delete_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _GroupsService_Get_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Find",
+			Handler:       _GroupsService_Find_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
+
+const (
+	OutboxService_Get_FullMethodName  = "/restapi.v1.OutboxService/Get"
+	OutboxService_Find_FullMethodName = "/restapi.v1.OutboxService/Find"
+)
+
+// OutboxServiceClient is the client API for OutboxService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OutboxService manages the outbox entry entities (same service as the REST /outbox endpoints)
+type OutboxServiceClient interface {
+	// Get outbox entry by ID
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*OutboxEntry, error)
+	// Find outbox entry entities by query, all the matching entities are streamed (no pagination)
+	Find(ctx context.Context, in *FindOutboxRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OutboxEntry], error)
+}
+
+type outboxServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOutboxServiceClient(cc grpc.ClientConnInterface) OutboxServiceClient {
+	return &outboxServiceClient{cc}
+}
+
+func (c *outboxServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*OutboxEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OutboxEntry)
+	err := c.cc.Invoke(ctx, OutboxService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxServiceClient) Find(ctx context.Context, in *FindOutboxRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OutboxEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OutboxService_ServiceDesc.Streams[0], OutboxService_Find_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindOutboxRequest, OutboxEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OutboxService_FindClient = grpc.ServerStreamingClient[OutboxEntry]
+
+// OutboxServiceServer is the server API for OutboxService service.
+// All implementations must embed UnimplementedOutboxServiceServer
+// for forward compatibility.
+//
+// OutboxService manages the outbox entry entities (same service as the REST /outbox endpoints)
+type OutboxServiceServer interface {
+	// Get outbox entry by ID
+	Get(context.Context, *IdRequest) (*OutboxEntry, error)
+	// Find outbox entry entities by query, all the matching entities are streamed (no pagination)
+	Find(*FindOutboxRequest, grpc.ServerStreamingServer[OutboxEntry]) error
+	mustEmbedUnimplementedOutboxServiceServer()
+}
+
+// UnimplementedOutboxServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOutboxServiceServer struct{}
+
+func (UnimplementedOutboxServiceServer) Get(context.Context, *IdRequest) (*OutboxEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedOutboxServiceServer) Find(*FindOutboxRequest, grpc.ServerStreamingServer[OutboxEntry]) error {
+	return status.Errorf(codes.Unimplemented, "method Find not implemented")
+}
+func (UnimplementedOutboxServiceServer) mustEmbedUnimplementedOutboxServiceServer() {}
+func (UnimplementedOutboxServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeOutboxServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OutboxServiceServer will
+// result in compilation errors.
+type UnsafeOutboxServiceServer interface {
+	mustEmbedUnimplementedOutboxServiceServer()
+}
+
+func RegisterOutboxServiceServer(s grpc.ServiceRegistrar, srv OutboxServiceServer) {
+	// If the following call pancis, it indicates UnimplementedOutboxServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OutboxService_ServiceDesc, srv)
+}
+
+func _OutboxService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxService_Find_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindOutboxRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OutboxServiceServer).Find(m, &grpc.GenericServerStream[FindOutboxRequest, OutboxEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OutboxService_FindServer = grpc.ServerStreamingServer[OutboxEntry]
+
+// OutboxService_ServiceDesc is the grpc.ServiceDesc for OutboxService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OutboxService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restapi.v1.OutboxService",
+	HandlerType: (*OutboxServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _OutboxService_Get_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Find",
+			Handler:       _OutboxService_Find_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
+
+const (
+	UsersService_Create_FullMethodName = "/restapi.v1.UsersService/Create"
+	UsersService_Update_FullMethodName = "/restapi.v1.UsersService/Update"
+	UsersService_Delete_FullMethodName = "/restapi.v1.UsersService/Delete"
+	UsersService_Get_FullMethodName    = "/restapi.v1.UsersService/Get"
+	UsersService_Find_FullMethodName   = "/restapi.v1.UsersService/Find"
+)
+
+// UsersServiceClient is the client API for UsersService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// UsersService manages the user entities (same service as the REST /users endpoints)
+type UsersServiceClient interface {
+	// Create a new user
+	Create(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
+	// Update existing user
+	Update(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
+	// Delete user by ID
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Get user by ID
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*User, error)
+	// Find user entities by query, all the matching entities are streamed (no pagination)
+	Find(ctx context.Context, in *FindUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[User], error)
+}
+
+type usersServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUsersServiceClient(cc grpc.ClientConnInterface) UsersServiceClient {
+	return &usersServiceClient{cc}
+}
+
+func (c *usersServiceClient) Create(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UsersService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) Update(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UsersService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UsersService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UsersService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) Find(ctx context.Context, in *FindUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[User], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UsersService_ServiceDesc.Streams[0], UsersService_Find_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindUsersRequest, User]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UsersService_FindClient = grpc.ServerStreamingClient[User]
+
+// UsersServiceServer is the server API for UsersService service.
+// All implementations must embed UnimplementedUsersServiceServer
+// for forward compatibility.
+//
+// UsersService manages the user entities (same service as the REST /users endpoints)
+type UsersServiceServer interface {
+	// Create a new user
+	Create(context.Context, *User) (*User, error)
+	// Update existing user
+	Update(context.Context, *User) (*User, error)
+	// Delete user by ID
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	// Get user by ID
+	Get(context.Context, *IdRequest) (*User, error)
+	// Find user entities by query, all the matching entities are streamed (no pagination)
+	Find(*FindUsersRequest, grpc.ServerStreamingServer[User]) error
+	mustEmbedUnimplementedUsersServiceServer()
+}
+
+// UnimplementedUsersServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUsersServiceServer struct{}
+
+func (UnimplementedUsersServiceServer) Create(context.Context, *User) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedUsersServiceServer) Update(context.Context, *User) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedUsersServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedUsersServiceServer) Get(context.Context, *IdRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedUsersServiceServer) Find(*FindUsersRequest, grpc.ServerStreamingServer[User]) error {
+	return status.Errorf(codes.Unimplemented, "method Find not implemented")
+}
+func (UnimplementedUsersServiceServer) mustEmbedUnimplementedUsersServiceServer() {}
+func (UnimplementedUsersServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeUsersServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UsersServiceServer will
+// result in compilation errors.
+type UnsafeUsersServiceServer interface {
+	mustEmbedUnimplementedUsersServiceServer()
+}
+
+func RegisterUsersServiceServer(s grpc.ServiceRegistrar, srv UsersServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUsersServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UsersService_ServiceDesc, srv)
+}
+
+func _UsersService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).Create(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).Update(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_Find_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UsersServiceServer).Find(m, &grpc.GenericServerStream[FindUsersRequest, User]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UsersService_FindServer = grpc.ServerStreamingServer[User]
+
+// UsersService_ServiceDesc is the grpc.ServiceDesc for UsersService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UsersService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restapi.v1.UsersService",
+	HandlerType: (*UsersServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _UsersService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _UsersService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _UsersService_Delete_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _UsersService_Get_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Find",
+			Handler:       _UsersService_Find_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
+
+const (
+	WebhooksService_Create_FullMethodName = "/restapi.v1.WebhooksService/Create"
+	WebhooksService_Update_FullMethodName = "/restapi.v1.WebhooksService/Update"
+	WebhooksService_Delete_FullMethodName = "/restapi.v1.WebhooksService/Delete"
+	WebhooksService_Get_FullMethodName    = "/restapi.v1.WebhooksService/Get"
+	WebhooksService_Find_FullMethodName   = "/restapi.v1.WebhooksService/Find"
+)
+
+// WebhooksServiceClient is the client API for WebhooksService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// WebhooksService manages the webhook entities (same service as the REST /webhooks endpoints)
+type WebhooksServiceClient interface {
+	// Create a new webhook
+	Create(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*Webhook, error)
+	// Update existing webhook
+	Update(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*Webhook, error)
+	// Delete webhook by ID
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Get webhook by ID
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Webhook, error)
+	// Find webhook entities by query, all the matching entities are streamed (no pagination)
+	Find(ctx context.Context, in *FindWebhooksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Webhook], error)
+}
+
+type webhooksServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhooksServiceClient(cc grpc.ClientConnInterface) WebhooksServiceClient {
+	return &webhooksServiceClient{cc}
+}
+
+func (c *webhooksServiceClient) Create(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, WebhooksService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhooksServiceClient) Update(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, WebhooksService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhooksServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, WebhooksService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhooksServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, WebhooksService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhooksServiceClient) Find(ctx context.Context, in *FindWebhooksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Webhook], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WebhooksService_ServiceDesc.Streams[0], WebhooksService_Find_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindWebhooksRequest, Webhook]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WebhooksService_FindClient = grpc.ServerStreamingClient[Webhook]
+
+// WebhooksServiceServer is the server API for WebhooksService service.
+// All implementations must embed UnimplementedWebhooksServiceServer
+// for forward compatibility.
+//
+// WebhooksService manages the webhook entities (same service as the REST /webhooks endpoints)
+type WebhooksServiceServer interface {
+	// Create a new webhook
+	Create(context.Context, *Webhook) (*Webhook, error)
+	// Update existing webhook
+	Update(context.Context, *Webhook) (*Webhook, error)
+	// Delete webhook by ID
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	// Get webhook by ID
+	Get(context.Context, *IdRequest) (*Webhook, error)
+	// Find webhook entities by query, all the matching entities are streamed (no pagination)
+	Find(*FindWebhooksRequest, grpc.ServerStreamingServer[Webhook]) error
+	mustEmbedUnimplementedWebhooksServiceServer()
+}
+
+// UnimplementedWebhooksServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebhooksServiceServer struct{}
+
+func (UnimplementedWebhooksServiceServer) Create(context.Context, *Webhook) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedWebhooksServiceServer) Update(context.Context, *Webhook) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedWebhooksServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedWebhooksServiceServer) Get(context.Context, *IdRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedWebhooksServiceServer) Find(*FindWebhooksRequest, grpc.ServerStreamingServer[Webhook]) error {
+	return status.Errorf(codes.Unimplemented, "method Find not implemented")
+}
+func (UnimplementedWebhooksServiceServer) mustEmbedUnimplementedWebhooksServiceServer() {}
+func (UnimplementedWebhooksServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeWebhooksServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhooksServiceServer will
+// result in compilation errors.
+type UnsafeWebhooksServiceServer interface {
+	mustEmbedUnimplementedWebhooksServiceServer()
+}
+
+func RegisterWebhooksServiceServer(s grpc.ServiceRegistrar, srv WebhooksServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWebhooksServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WebhooksService_ServiceDesc, srv)
+}
+
+func _WebhooksService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Webhook)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhooksServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhooksService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhooksServiceServer).Create(ctx, req.(*Webhook))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhooksService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Webhook)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhooksServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhooksService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhooksServiceServer).Update(ctx, req.(*Webhook))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhooksService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhooksServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhooksService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhooksServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhooksService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhooksServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhooksService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhooksServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhooksService_Find_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindWebhooksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WebhooksServiceServer).Find(m, &grpc.GenericServerStream[FindWebhooksRequest, Webhook]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WebhooksService_FindServer = grpc.ServerStreamingServer[Webhook]
+
+// WebhooksService_ServiceDesc is the grpc.ServiceDesc for WebhooksService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhooksService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restapi.v1.WebhooksService",
+	HandlerType: (*WebhooksServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _WebhooksService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _WebhooksService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _WebhooksService_Delete_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _WebhooksService_Get_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Find",
+			Handler:       _WebhooksService_Find_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
@@ -0,0 +1,120 @@
+// Package rpc The gRPC API server exposes the same services as the REST API (protobuf definitions are generated by
+// cmd/protogen to the pb package)
+package rpc
+
+//go:generate go run ../cmd/protogen -root ..
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rpc/pb"
+	"github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// region gRPC server structure and factory method ---------------------------------------------------------------------
+
+// Server is the gRPC API server
+type Server struct {
+	config *config.ServiceConfig
+	server *grpc.Server
+	health *health.Server // Standard gRPC health service (reports NOT_SERVING while draining)
+
+	mu       sync.Mutex   // Guards the listener (started and stopped by different goroutines)
+	listener net.Listener // Listener of the server (set on start)
+}
+
+// NewGRPCServer Factory method, registers the API services (sharing the services singletons of the REST endpoints) and
+// the health service
+func NewGRPCServer(cfg *config.ServiceConfig, facade *common.ServiceHub) *Server {
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryRecovery(), unaryRequestId(), unaryAccessLog(), unaryAuth()),
+		grpc.ChainStreamInterceptor(streamRecovery(), streamRequestId(), streamAccessLog(), streamAuth()),
+	)
+
+	pb.RegisterAccountsServiceServer(server, &accountsServer{service: services.GetAccountsService(facade)})
+	pb.RegisterAuditLogsServiceServer(server, &auditLogsServer{service: services.GetAuditLogsService(facade)})
+	pb.RegisterContactsServiceServer(server, &contactsServer{service: services.GetContactsService(facade)})
+	pb.RegisterGroupsServiceServer(server, &groupsServer{service: services.GetGroupsService(facade)})
+	pb.RegisterOutboxServiceServer(server, &outboxServer{service: services.GetOutboxService(facade)})
+	pb.RegisterUsersServiceServer(server, &usersServer{service: services.GetUsersService(facade)})
+	pb.RegisterWebhooksServiceServer(server, &webhooksServer{service: services.GetWebhooksService(facade)})
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	return &Server{
+		config: cfg,
+		server: server,
+		health: healthServer,
+	}
+}
+
+// endregion
+
+// region gRPC server starter ------------------------------------------------------------------------------------------
+
+// Start the gRPC server on the port, blocks until the server is stopped (returns nil after Shutdown)
+func (s *Server) Start(port int) error {
+	if port == 0 {
+		port = 9090
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve the gRPC API on the listener (e.g. in-process bufconn listener), blocks until the server is stopped
+func (s *Server) Serve(listener net.Listener) error {
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting new connections and waits for the in-flight calls to complete (until the context deadline),
+// the remaining calls (e.g. long streams) are cancelled when the deadline is exceeded
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	s.mu.Lock()
+	listener := s.listener
+	s.mu.Unlock()
+	if listener == nil {
+		// Not serving yet (e.g. startup failure), stopping the server makes the pending Serve return immediately
+		s.server.Stop()
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+// endregion
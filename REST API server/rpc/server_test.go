@@ -0,0 +1,202 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+	mc "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rpc/pb"
+	"github.com/go-yaaf/yaaf-examples/rest-api/utils"
+)
+
+// Start the gRPC server in-process on bufconn listener with the in-memory database, returns the client connection
+func newTestConnection(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	server := NewGRPCServer(config.GetConfig(), common.NewServiceHub())
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(func() { _ = server.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// Create outgoing context with the API key and the access token of the system administrator
+func authorizedContext(t *testing.T) context.Context {
+	t.Helper()
+	apiKey, err := utils.TokenUtils().CreateApiKey("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := utils.TokenUtils().CreateToken(&mc.TokenData{SubjectId: "admin@example.com", SubjectType: UserTypeCodes.SYSADMIN})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), ApiKeyMetadata, apiKey, AccessTokenMetadata, token)
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	users := pb.NewUsersServiceClient(newTestConnection(t))
+
+	apiKey, err := utils.TokenUtils().CreateApiKey("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		md   []string
+		code codes.Code
+	}{
+		{"missing api key", nil, codes.PermissionDenied},
+		{"invalid api key", []string{ApiKeyMetadata, "invalid"}, codes.PermissionDenied},
+		{"missing token", []string{ApiKeyMetadata, apiKey}, codes.Unauthenticated},
+		{"invalid token", []string{ApiKeyMetadata, apiKey, AccessTokenMetadata, "invalid"}, codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.md...)
+
+			// Unary call
+			if _, err := users.Get(ctx, &pb.IdRequest{Id: "u1"}); status.Code(err) != tt.code {
+				t.Errorf("get: expected code %s but got %v", tt.code, err)
+			}
+
+			// Streaming call (the error is returned on the first receive)
+			stream, err := users.Find(ctx, &pb.FindUsersRequest{})
+			if err == nil {
+				_, err = stream.Recv()
+			}
+			if status.Code(err) != tt.code {
+				t.Errorf("find: expected code %s but got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestCreateAndGet(t *testing.T) {
+	users := pb.NewUsersServiceClient(newTestConnection(t))
+	ctx := authorizedContext(t)
+
+	var header metadata.MD
+	created, err := users.Create(ctx, &pb.User{
+		Name:   "John Smith",
+		Email:  "john@example.com",
+		Type:   pb.UserTypeCode_USER_TYPE_CODE_USER,
+		Status: pb.UserStatusCode_USER_STATUS_CODE_ACTIVE,
+	}, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.GetId() != "john@example.com" || created.GetCreatedOn() == 0 {
+		t.Errorf("unexpected created user: id %q created on %d", created.GetId(), created.GetCreatedOn())
+	}
+	if len(header.Get(RequestIdMetadata)) == 0 || len(header.Get(AccessTokenMetadata)) == 0 {
+		t.Errorf("expected request ID and renewed token headers, got %v", header)
+	}
+
+	fetched, err := users.Get(ctx, &pb.IdRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if fetched.GetName() != "John Smith" || fetched.GetType() != pb.UserTypeCode_USER_TYPE_CODE_USER || fetched.GetStatus() != pb.UserStatusCode_USER_STATUS_CODE_ACTIVE {
+		t.Errorf("unexpected user: %s %s %s", fetched.GetName(), fetched.GetType(), fetched.GetStatus())
+	}
+
+	if _, err = users.Create(ctx, &pb.User{Name: "Invalid", Email: "invalid"}); err == nil {
+		t.Error("expected error for invalid email")
+	}
+}
+
+func TestFindStream(t *testing.T) {
+	users := pb.NewUsersServiceClient(newTestConnection(t))
+	ctx := authorizedContext(t)
+
+	for _, name := range []string{"alice", "bob", "carol", "dave"} {
+		userType := pb.UserTypeCode_USER_TYPE_CODE_USER
+		if name == "dave" {
+			userType = pb.UserTypeCode_USER_TYPE_CODE_SUPPORT
+		}
+		if _, err := users.Create(ctx, &pb.User{Name: name, Email: name + "@stream.example.com", Type: userType}); err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		request  *pb.FindUsersRequest
+		expected string
+	}{
+		{"search", &pb.FindUsersRequest{Search: "*@stream.example.com"}, "alice,bob,carol,dave"},
+		{"type", &pb.FindUsersRequest{Search: "*@stream.example.com", Type: []pb.UserTypeCode{pb.UserTypeCode_USER_TYPE_CODE_SUPPORT}}, "dave"},
+		{"filter", &pb.FindUsersRequest{Filter: "name like 'a*' or name = 'carol'"}, "alice,carol"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := users.Find(ctx, tt.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for {
+				user, er := stream.Recv()
+				if errors.Is(er, io.EOF) {
+					break
+				}
+				if er != nil {
+					t.Fatalf("recv: %v", er)
+				}
+				names = append(names, user.GetName())
+			}
+			sort.Strings(names)
+			if got := strings.Join(names, ","); got != tt.expected {
+				t.Errorf("expected users [%s] but got [%s]", tt.expected, got)
+			}
+		})
+	}
+
+	// Invalid filter expression
+	stream, err := users.Find(ctx, &pb.FindUsersRequest{Filter: "unknown = 1"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected invalid argument but got %v", err)
+	}
+}
+
+func TestRecoveryHidesPanic(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/api.ContactsService/Get"}
+	_, err := unaryRecovery()(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		panic("secret connection string")
+	})
+	if status.Code(err) != codes.Internal || status.Convert(err).Message() != "internal server error" {
+		t.Errorf("expected generic Internal error but got %v", err)
+	}
+}
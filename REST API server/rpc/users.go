@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rpc/pb"
+	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// usersServer implements the gRPC UsersService by the users service (same as the REST /users endpoints)
+type usersServer struct {
+	pb.UnimplementedUsersServiceServer
+	service *s.UsersService
+}
+
+// Create new user
+func (h *usersServer) Create(ctx context.Context, req *pb.User) (*pb.User, error) {
+	ent, err := toEntity(req, NewUser)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Create(GetTokenData(ctx), ent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.User{})
+}
+
+// Update existing user
+func (h *usersServer) Update(ctx context.Context, req *pb.User) (*pb.User, error) {
+	ent, err := toEntity(req, NewUser)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Update(GetTokenData(ctx), ent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.User{})
+}
+
+// Delete user (only system administrator can delete users)
+func (h *usersServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	td := GetTokenData(ctx)
+	if td.SubjectType != UserTypeCodes.SYSADMIN {
+		return nil, status.Error(codes.PermissionDenied, "delete is forbidden")
+	}
+	if err := h.service.Delete(td, req.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Get a single user by id
+func (h *usersServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.User, error) {
+	result, err := h.service.Get(GetTokenData(ctx), req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.User{})
+}
+
+// Find users by query, all the matching users are streamed
+func (h *usersServer) Find(req *pb.FindUsersRequest, stream grpc.ServerStreamingServer[pb.User]) error {
+	criteria, err := parseFilter(NewUser, req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	p := s.UsersFindParams{
+		Search: req.GetSearch(),
+		Type:   enumCodes(req.GetType()),
+		Status: enumCodes(req.GetStatus()),
+		Sort:   sortOrDefault(req.GetSort(), "name"),
+		Filter: criteria,
+	}
+	return statusError(h.service.Export(GetTokenData(stream.Context()), p, func(ent Entity) error {
+		msg, er := toMessage(ent, &pb.User{})
+		if er != nil {
+			return er
+		}
+		return stream.Send(msg)
+	}))
+}
@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"context"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	mc "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	"github.com/go-yaaf/yaaf-examples/rest-api/rpc/pb"
+	s "github.com/go-yaaf/yaaf-examples/rest-api/services"
+)
+
+// webhooksServer implements the gRPC WebhooksService by the webhooks service (same as the REST /webhooks endpoints)
+type webhooksServer struct {
+	pb.UnimplementedWebhooksServiceServer
+	service *s.WebhooksService
+}
+
+// Create new webhook (the secret is returned only on create)
+func (h *webhooksServer) Create(ctx context.Context, req *pb.Webhook) (*pb.Webhook, error) {
+	td, err := adminTokenData(ctx, "webhooks")
+	if err != nil {
+		return nil, err
+	}
+	ent, err := toEntity(req, NewWebhook)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Create(td, ent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.Webhook{})
+}
+
+// Update existing webhook
+func (h *webhooksServer) Update(ctx context.Context, req *pb.Webhook) (*pb.Webhook, error) {
+	td, err := adminTokenData(ctx, "webhooks")
+	if err != nil {
+		return nil, err
+	}
+	ent, err := toEntity(req, NewWebhook)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Update(td, ent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.Webhook{})
+}
+
+// Delete webhook
+func (h *webhooksServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	td, err := adminTokenData(ctx, "webhooks")
+	if err != nil {
+		return nil, err
+	}
+	if err = h.service.Delete(td, req.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Get a single webhook by id
+func (h *webhooksServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.Webhook, error) {
+	td, err := adminTokenData(ctx, "webhooks")
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.Get(td, req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMessage(result, &pb.Webhook{})
+}
+
+// Find webhooks by query, all the matching webhooks are streamed
+func (h *webhooksServer) Find(req *pb.FindWebhooksRequest, stream grpc.ServerStreamingServer[pb.Webhook]) error {
+	td, err := adminTokenData(stream.Context(), "webhooks")
+	if err != nil {
+		return err
+	}
+
+	p := s.WebhooksFindParams{
+		Search:    req.GetSearch(),
+		AccountId: req.GetAccountId(),
+		Sort:      sortOrDefault(req.GetSort(), "name"),
+	}
+	return statusError(h.service.Export(td, p, func(ent Entity) error {
+		msg, er := toMessage(ent, &pb.Webhook{})
+		if er != nil {
+			return er
+		}
+		return stream.Send(msg)
+	}))
+}
+
+// Get token data of the system administrator, the resource (e.g. webhooks) is forbidden to other subjects
+func adminTokenData(ctx context.Context, resource string) (*mc.TokenData, error) {
+	td := GetTokenData(ctx)
+	if td.SubjectType != UserTypeCodes.SYSADMIN {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is forbidden", resource)
+	}
+	return td, nil
+}
@@ -8,9 +8,11 @@ import (
 	. "github.com/go-yaaf/yaaf-common/entity"
 
 	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/filter"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/events"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/utils"
 )
 
@@ -34,6 +36,8 @@ func GetAccountsService(sh *ServiceHub) *AccountsService {
 
 // Create a new account in the system
 func (s *AccountsService) Create(td *TokenData, entity Entity) (Entity, error) {
+	td, end := s.observe(td, "Create")
+	defer end()
 
 	ent := entity.(*Account)
 
@@ -47,23 +51,25 @@ func (s *AccountsService) Create(td *TokenData, entity Entity) (Entity, error) {
 	ent.Mobile = s.stripPhone(ent.Mobile)
 	ent.Phone = s.stripPhone(ent.Phone)
 
-	if updated, er := s.sh.Database.Insert(ent); er != nil {
-		return nil, s.serviceError("Create", er)
+	tx := s.sh.Transaction(td.Context()).Insert(ent)
+	if er := s.commitChange(td, tx, ent, actionCreate, nil, ent, &AccountCreated{AccountId: ent.Id, Name: ent.Name, Type: ent.Type}); er != nil {
+		return nil, s.serviceError(td, "Create", er)
 	} else {
-		s.auditLog(td, ent, actionCreate, nil, updated)
-		return updated, nil
+		return ent, nil
 	}
 }
 
 // Update existing account in the system
 func (s *AccountsService) Update(td *TokenData, entity Entity) (Entity, error) {
+	td, end := s.observe(td, "Update")
+	defer end()
 
 	ent := entity.(*Account)
 
 	// Get existing account
-	existing, err := s.sh.Database.Get(NewAccount, ent.Id)
+	existing, err := s.sh.DatabaseContext(td.Context()).Get(NewAccount, ent.Id)
 	if err != nil {
-		return nil, s.serviceError("Update", err)
+		return nil, s.serviceError(td, "Update", err)
 	}
 
 	// Override system fields,
@@ -75,38 +81,49 @@ func (s *AccountsService) Update(td *TokenData, entity Entity) (Entity, error) {
 	ent.Mobile = s.stripPhone(ent.Mobile)
 	ent.Phone = s.stripPhone(ent.Phone)
 
-	if updated, er := s.sh.Database.Update(ent); er != nil {
-		return nil, s.serviceError("Update", er)
+	var events []DomainEvent
+	if ent.Status == AccountStatusCodes.SUSPENDED && existing.(*Account).Status != AccountStatusCodes.SUSPENDED {
+		events = append(events, &AccountSuspended{AccountId: ent.Id, Name: ent.Name})
+	}
+
+	tx := s.sh.Transaction(td.Context()).Update(ent)
+	if er := s.commitChange(td, tx, ent, actionUpdate, existing, ent, events...); er != nil {
+		return nil, s.serviceError(td, "Update", er)
 	} else {
-		s.auditLog(td, ent, actionUpdate, existing, updated)
-		return updated, nil
+		return ent, nil
 	}
 }
 
 // Delete account
 func (s *AccountsService) Delete(td *TokenData, id string) (err error) {
+	td, end := s.observe(td, "Delete")
+	defer end()
 
 	// Get existing member
 	var existing Entity
-	if existing, err = s.sh.Database.Get(NewAccount, id); err != nil {
-		return s.serviceError("Delete", err)
+	if existing, err = s.sh.DatabaseContext(td.Context()).Get(NewAccount, id); err != nil {
+		return s.serviceError(td, "Delete", err)
 	}
 
 	if existing.(*Account).Flag < 0 {
-		if err = s.sh.Database.Delete(NewAccount, id); err != nil {
-			return s.serviceError("Delete", err)
+		tx := s.sh.Transaction(td.Context()).Delete(existing)
+		if err = s.commitChange(td, tx, existing, actionDelete, existing, nil, &AccountDeleted{AccountId: id, Name: existing.(*Account).Name}); err != nil {
+			return s.serviceError(td, "Delete", err)
 		} else {
-			s.auditLog(td, existing, actionDelete, existing, nil)
 			return nil
 		}
 	} else {
+		var events []DomainEvent
+		if existing.(*Account).Status != AccountStatusCodes.SUSPENDED {
+			events = append(events, &AccountSuspended{AccountId: id, Name: existing.(*Account).Name})
+		}
 		existing.(*Account).Flag = -1
 		existing.(*Account).Status = AccountStatusCodes.SUSPENDED
 
-		if _, err = s.sh.Database.Update(existing); err != nil {
-			return s.serviceError("Delete", err)
+		tx := s.sh.Transaction(td.Context()).Update(existing)
+		if err = s.commitChange(td, tx, existing, actionDelete, existing, nil, events...); err != nil {
+			return s.serviceError(td, "Delete", err)
 		} else {
-			s.auditLog(td, existing, actionDelete, existing, nil)
 			return nil
 		}
 	}
@@ -114,14 +131,47 @@ func (s *AccountsService) Delete(td *TokenData, id string) (err error) {
 
 // Get single account by id
 func (s *AccountsService) Get(td *TokenData, id string) (Entity, error) {
+	td, end := s.observe(td, "Get")
+	defer end()
 
-	if ent, err := s.sh.Database.Get(NewAccount, id); err != nil {
+	if ent, err := s.sh.DatabaseContext(td.Context()).Get(NewAccount, id); err != nil {
 		return nil, fmt.Errorf("[%s]::Get: %v", s.ServiceName, err)
 	} else {
 		return ent, nil
 	}
 }
 
+// List gets the accounts by IDs (missing accounts are ignored), used to batch load related entities
+func (s *AccountsService) List(td *TokenData, ids []string) ([]Entity, error) {
+	td, end := s.observe(td, "List")
+	defer end()
+	if list, err := s.sh.DatabaseContext(td.Context()).List(NewAccount, ids); err != nil {
+		return nil, fmt.Errorf("[%s]::List: %v", s.ServiceName, err)
+	} else {
+		return list, nil
+	}
+}
+
+// GetSubjectAccountType gets the type of the account the subject (user) belongs to, referenced by the accountId property of the user
+func (s *AccountsService) GetSubjectAccountType(td *TokenData) (AccountTypeCode, error) {
+	td, end := s.observe(td, "GetSubjectAccountType")
+	defer end()
+
+	user, err := s.sh.DatabaseContext(td.Context()).Get(NewUser, td.SubjectId)
+	if err != nil {
+		return AccountTypeCodes.UNDEFINED, fmt.Errorf("[%s]::GetSubjectAccountType: %v", s.ServiceName, err)
+	}
+	accountId, _ := user.(*User).Props["accountId"].(string)
+	if len(accountId) == 0 {
+		return AccountTypeCodes.UNDEFINED, fmt.Errorf("[%s]::GetSubjectAccountType: user %s has no account", s.ServiceName, td.SubjectId)
+	}
+	if ent, er := s.sh.DatabaseContext(td.Context()).Get(NewAccount, accountId); er != nil {
+		return AccountTypeCodes.UNDEFINED, fmt.Errorf("[%s]::GetSubjectAccountType: %v", s.ServiceName, er)
+	} else {
+		return ent.(*Account).Type, nil
+	}
+}
+
 // AccountsFindParams Query params aggregator for find commands service
 type AccountsFindParams struct {
 	Search string              // Filter by free text search (using * wildcard)
@@ -129,6 +179,8 @@ type AccountsFindParams struct {
 	Sort   string              // Sort descriptor (field name with suffix +/- for sort order)
 	Page   int                 // Page number for pagination
 	Size   int                 // Page size: number of items per page
+	Fields []string            // Sparse fieldset: list of fields (json paths) to include in the results
+	Filter *filter.Criteria    // Filter expression (parsed filter query language)
 }
 
 func (f *AccountsFindParams) Statuses() (result []any) {
@@ -139,11 +191,13 @@ func (f *AccountsFindParams) Statuses() (result []any) {
 }
 
 // Find list of accounts by filter
-func (s *AccountsService) Find(p AccountsFindParams) (entities []Entity, total int64, pages int, error error) {
-	if entities, total, error = s.sh.Database.Query(NewAccount).
+func (s *AccountsService) Find(td *TokenData, p AccountsFindParams) (entities []Entity, total int64, pages int, error error) {
+	td, end := s.observe(td, "Find")
+	defer end()
+	query := p.Filter.Apply(s.sh.DatabaseContext(td.Context()).Query(NewAccount)).
 		MatchAny(
+			F("id").Eq(p.Search),
 			F("name").Like(p.Search),
-			F("enName").Like(p.Search),
 			F("email").Like(p.Search),
 		).
 		MatchAll(
@@ -152,11 +206,23 @@ func (s *AccountsService) Find(p AccountsFindParams) (entities []Entity, total i
 		).
 		Page(p.Page).
 		Limit(p.Size).
-		Sort(p.Sort).
-		Find(); error == nil {
+		Sort(p.Sort)
+
+	if entities, total, error = s.find(query, p.Fields); error == nil {
 		pages = s.calcPages(total, p.Size)
 	} else {
-		error = s.serviceError("Find", error)
+		error = s.serviceError(td, "Find", error)
 	}
 	return
 }
+
+// Export iterates over all the accounts matching the query (regardless of the pagination) and passes them to the callback
+func (s *AccountsService) Export(td *TokenData, p AccountsFindParams, cb func(Entity) error) error {
+	td, end := s.observe(td, "Export")
+	defer end()
+	return s.iterate(func(page, size int) ([]Entity, error) {
+		p.Page, p.Size = page, size
+		list, _, _, err := s.Find(td, p)
+		return list, err
+	}, cb)
+}
@@ -12,6 +12,7 @@ import (
 	. "github.com/go-yaaf/yaaf-common/utils"
 
 	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/filter"
 	"github.com/go-yaaf/yaaf-examples/rest-api/utils"
 
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
@@ -41,6 +42,8 @@ func GetAuditLogsService(sh *common.ServiceHub) *AuditLogsService {
 
 // Create new audit log entry in the system
 func (s *AuditLogsService) Create(td *TokenData, entity Entity) (Entity, error) {
+	td, end := s.observe(td, "Create")
+	defer end()
 
 	ent := entity.(*AuditLog)
 
@@ -50,17 +53,19 @@ func (s *AuditLogsService) Create(td *TokenData, entity Entity) (Entity, error)
 	ent.UpdatedOn = Now()
 	ent.Props = nil
 
-	if updated, er := s.sh.Database.Insert(ent); er != nil {
+	tx := s.sh.Transaction(td.Context()).Insert(ent)
+	if er := s.commitChange(td, tx, ent, actionCreate, nil, ent); er != nil {
 		return nil, er
 	} else {
-		s.auditLog(td, ent, actionCreate, nil, updated)
-		return updated, nil
+		return ent, nil
 	}
 }
 
 // Get single audit log entry by id
 func (s *AuditLogsService) Get(td *TokenData, id string) (Entity, error) {
-	entry, err := s.sh.Database.Get(NewAuditLog, id)
+	td, end := s.observe(td, "Get")
+	defer end()
+	entry, err := s.sh.DatabaseContext(td.Context()).Get(NewAuditLog, id)
 	if err != nil {
 		return nil, fmt.Errorf("[%s]::Get: %v", s.ServiceName, err)
 	}
@@ -78,26 +83,30 @@ func (s *AuditLogsService) Get(td *TokenData, id string) (Entity, error) {
 
 // AuditLogsFindParams Query params aggregator for find commands service
 type AuditLogsFindParams struct {
-	From     Timestamp // From timestamp
-	To       Timestamp // To timestamp
-	UserId   string    // Filter by User ID
-	Action   string    // Filter by action
-	ItemType string    // Filter by item type
-	ItemId   string    // Filter by item ID
-	ItemName string    // Filter by item name
-	Search   string    // Filter by free search text
-	Sort     string    // Sort descriptor (field name with suffix +/- for sort order)
-	Page     int       // Page number for pagination
-	Size     int       // Page size: number of items per page
+	From     Timestamp        // From timestamp
+	To       Timestamp        // To timestamp
+	UserId   string           // Filter by User ID
+	Action   string           // Filter by action
+	ItemType string           // Filter by item type
+	ItemId   string           // Filter by item ID
+	ItemName string           // Filter by item name
+	Search   string           // Filter by free search text
+	Sort     string           // Sort descriptor (field name with suffix +/- for sort order)
+	Page     int              // Page number for pagination
+	Size     int              // Page size: number of items per page
+	Fields   []string         // Sparse fieldset: list of fields (json paths) to include in the results
+	Filter   *filter.Criteria // Filter expression (parsed filter query language)
 }
 
 // Find list of audit log entries by filter
-func (s *AuditLogsService) Find(p AuditLogsFindParams) (entities []Entity, total int64, pages int, error error) {
+func (s *AuditLogsService) Find(td *TokenData, p AuditLogsFindParams) (entities []Entity, total int64, pages int, error error) {
+	td, end := s.observe(td, "Find")
+	defer end()
 	cb := func(in Entity) (out Entity) {
 		in.(*AuditLog).Props = Json{}
 		return in
 	}
-	if entities, total, error = s.sh.Database.Query(NewAuditLog).
+	query := p.Filter.Apply(s.sh.DatabaseContext(td.Context()).Query(NewAuditLog)).
 		Range("createdOn", p.From, p.To).
 		MatchAny(
 			F("itemType").Like(p.Search),
@@ -116,18 +125,32 @@ func (s *AuditLogsService) Find(p AuditLogsFindParams) (entities []Entity, total
 		Page(p.Page).
 		Limit(p.Size).
 		Sort(p.Sort).
-		Apply(cb).
-		Find(); error == nil {
+		Apply(cb)
+
+	if entities, total, error = s.find(query, p.Fields, "props"); error == nil {
 		pages = s.calcPages(total, p.Size)
 	}
 	return
 }
 
+// Export iterates over all the audit log entries matching the query (regardless of the pagination) and passes them to the callback
+func (s *AuditLogsService) Export(td *TokenData, p AuditLogsFindParams, cb func(Entity) error) error {
+	td, end := s.observe(td, "Export")
+	defer end()
+	return s.iterate(func(page, size int) ([]Entity, error) {
+		p.Page, p.Size = page, size
+		list, _, _, err := s.Find(td, p)
+		return list, err
+	}, cb)
+}
+
 // Histogram creates audit log actions count over time: TimeSeries[float64]
-func (s *AuditLogsService) Histogram(p AuditLogsFindParams) (Entity, error) {
+func (s *AuditLogsService) Histogram(td *TokenData, p AuditLogsFindParams) (Entity, error) {
+	td, end := s.observe(td, "Histogram")
+	defer end()
 
 	interval := 24 * time.Hour
-	out, _, err := s.sh.Database.Query(NewAuditLog).
+	out, _, err := p.Filter.Apply(s.sh.DatabaseContext(td.Context()).Query(NewAuditLog)).
 		MatchAny(
 			F("itemType").Like(p.Search),
 			F("itemId").Like(p.Search),
@@ -145,7 +168,7 @@ func (s *AuditLogsService) Histogram(p AuditLogsFindParams) (Entity, error) {
 		Histogram("", COUNT, "createdOn", interval)
 
 	if err != nil {
-		return nil, s.serviceError("Histogram", err)
+		return nil, s.serviceError(td, "Histogram", err)
 	}
 
 	timeSeries := HistogramTimeSeries("audit-log", p.From, p.To, interval, out)
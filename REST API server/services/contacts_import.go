@@ -0,0 +1,479 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strings"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	meta "github.com/go-yaaf/yaaf-examples/rest-api/model"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/utils"
+)
+
+// Maximum number of rows in a single import file
+const maxImportRows = 10000
+
+// Maximum number of values in a single database IN filter (duplicates lookup)
+const importLookupChunk = 500
+
+// Valid phone number after normalization: optional + followed by 7-15 digits
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+
+// ContactsImportParams Params aggregator for contacts import
+type ContactsImportParams struct {
+	Format    string            // File format: csv | vcard
+	Mapping   map[string]string // CSV column mapping: contact field (json path) -> column header, other fields are mapped by name
+	AccountId string            // Related billing account of the imported contacts (the accountId column should match it)
+	DryRun    bool              // Validate the data and return the report without saving it
+}
+
+// importRow is a single contact parsed from the import file
+type importRow struct {
+	contact  *Contact
+	messages []string
+}
+
+// Import contacts from CSV or vCard file, the rows are validated, phone numbers are normalized and duplicates are detected
+// by email or mobile (both in the file and in the database account). Rows of other accounts are invalid. In dry-run mode
+// only the validation report is returned, otherwise the valid rows are saved and all the audit log entries include the
+// import ID (props.importId). File errors are returned as is and database errors with status 500 (Error)
+func (s *ContactsService) Import(td *TokenData, r io.Reader, p ContactsImportParams) (*ImportReport, error) {
+	td, end := s.observe(td, "Import")
+	defer end()
+
+	var rows []*importRow
+	var err error
+	switch strings.ToLower(p.Format) {
+	case "vcard", "vcf":
+		rows, err = s.parseVCard(r)
+	case "csv", "":
+		rows, err = s.parseCsv(r, p.Mapping)
+	default:
+		err = fmt.Errorf("unsupported import format: %s (use csv or vcard)", p.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{ImportId: TokenUtils().GUID(), DryRun: p.DryRun, Total: len(rows), Rows: make([]ImportRowResult, 0, len(rows))}
+
+	emails, mobiles, err := s.findExisting(td, rows, p.AccountId)
+	if err != nil {
+		return nil, s.serviceErrorEx(td, "Import", http.StatusInternalServerError, err.Error())
+	}
+
+	for i, row := range rows {
+		ent := row.contact
+		if len(ent.AccountId) == 0 {
+			ent.AccountId = p.AccountId
+		} else if ent.AccountId != p.AccountId {
+			row.messages = append(row.messages, fmt.Sprintf("account %s does not match the import account", ent.AccountId))
+		}
+		result := ImportRowResult{Row: i + 1, Name: ent.Name, Messages: append(row.messages, s.validateContact(ent)...)}
+
+		email, mobile := strings.ToLower(ent.Email), ent.Mobile
+		switch {
+		case len(result.Messages) > 0:
+			result.Status = ImportStatusCodes.INVALID
+			report.Invalid++
+		case s.isDuplicate(emails, email):
+			result.Status, result.ItemId = ImportStatusCodes.DUPLICATE, emails[email]
+			result.Messages = append(result.Messages, fmt.Sprintf("duplicate email: %s", ent.Email))
+			report.Duplicates++
+		case s.isDuplicate(mobiles, mobile):
+			result.Status, result.ItemId = ImportStatusCodes.DUPLICATE, mobiles[mobile]
+			result.Messages = append(result.Messages, fmt.Sprintf("duplicate mobile: %s", ent.Mobile))
+			report.Duplicates++
+		default:
+			result.Status = ImportStatusCodes.VALID
+			report.Valid++
+		}
+
+		if result.Status == ImportStatusCodes.VALID {
+			if !p.DryRun {
+				s.importContact(td, ent, report, &result)
+			}
+			// Valid rows are registered to detect duplicates in the next rows of the file (the id is known only after import)
+			if len(email) > 0 {
+				emails[email] = result.ItemId
+			}
+			if len(mobile) > 0 {
+				mobiles[mobile] = result.ItemId
+			}
+		}
+		report.Rows = append(report.Rows, result)
+	}
+	return report, nil
+}
+
+// Save single imported contact and update the row result
+func (s *ContactsService) importContact(td *TokenData, ent *Contact, report *ImportReport, result *ImportRowResult) {
+	if updated, err := s.create(td, ent, Json{"importId": report.ImportId}); err != nil {
+		result.Status = ImportStatusCodes.FAILED
+		result.Messages = append(result.Messages, err.Error())
+		report.Failed++
+	} else {
+		result.Status, result.ItemId = ImportStatusCodes.IMPORTED, updated.ID()
+		report.Imported++
+	}
+}
+
+// Check if the key (email or mobile) was already registered
+func (s *ContactsService) isDuplicate(keys map[string]string, key string) bool {
+	if len(key) == 0 {
+		return false
+	}
+	_, exists := keys[key]
+	return exists
+}
+
+// Validate imported contact, returns the list of validation errors
+func (s *ContactsService) validateContact(ent *Contact) (messages []string) {
+	messages = make([]string, 0)
+	if len(ent.Name) == 0 {
+		messages = append(messages, "name is required")
+	}
+	if len(ent.Email) == 0 && len(ent.Mobile) == 0 {
+		messages = append(messages, "email or mobile is required")
+	}
+	if len(ent.Email) > 0 {
+		if addr, err := mail.ParseAddress(ent.Email); err != nil || addr.Address != ent.Email {
+			messages = append(messages, fmt.Sprintf("invalid email: %s", ent.Email))
+		}
+	}
+	if len(ent.Mobile) > 0 {
+		if ent.Mobile = s.normalizePhone(ent.Mobile); !phonePattern.MatchString(ent.Mobile) {
+			messages = append(messages, fmt.Sprintf("invalid mobile: %s", ent.Mobile))
+		}
+	}
+	return messages
+}
+
+// Find existing contacts with the same emails or mobiles of the imported rows, returns maps of email -> id and mobile -> id
+func (s *ContactsService) findExisting(td *TokenData, rows []*importRow, accountId string) (emails, mobiles map[string]string, err error) {
+
+	emailList, mobileList := make([]string, 0), make([]string, 0)
+	for _, row := range rows {
+		if len(row.contact.Email) > 0 {
+			emailList = append(emailList, row.contact.Email, strings.ToLower(row.contact.Email))
+		}
+		if len(row.contact.Mobile) > 0 {
+			mobileList = append(mobileList, s.normalizePhone(row.contact.Mobile))
+		}
+	}
+
+	emails, mobiles = make(map[string]string), make(map[string]string)
+	if err = s.lookupContacts(td, "email", emailList, accountId, func(c *Contact) { emails[strings.ToLower(c.Email)] = c.Id }); err != nil {
+		return
+	}
+	err = s.lookupContacts(td, "mobile", mobileList, accountId, func(c *Contact) { mobiles[c.Mobile] = c.Id })
+	return
+}
+
+// Query active contacts by the values of the field (in chunks) and pass each contact to the callback
+func (s *ContactsService) lookupContacts(td *TokenData, field string, values []string, accountId string, cb func(*Contact)) error {
+	for start := 0; start < len(values); start += importLookupChunk {
+		end := min(start+importLookupChunk, len(values))
+		list, _, err := s.sh.DatabaseContext(td.Context()).Query(NewContact).
+			MatchAll(
+				F(field).In(ToAnyVariadic(values[start:end])...),
+				F("accountId").Eq(accountId),
+				F("flag").Gte(0),
+			).
+			Limit(maxImportRows).
+			Find()
+		if err != nil {
+			return err
+		}
+		for _, ent := range list {
+			cb(ent.(*Contact))
+		}
+	}
+	return nil
+}
+
+// region CSV parser ---------------------------------------------------------------------------------------------------
+
+// Parse CSV file with header row, the columns are mapped to the contact fields by the mapping (field -> column header)
+// and the unmapped fields are mapped to the columns with the same name (case-insensitive)
+func (s *ContactsService) parseCsv(r io.Reader, mapping map[string]string) ([]*importRow, error) {
+
+	reader := csv.NewReader(bufio.NewReader(r))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv header: %v", err)
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	columns, err := s.mapColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*importRow, 0)
+	for {
+		record, er := reader.Read()
+		if er == io.EOF {
+			break
+		}
+		if er != nil {
+			return nil, fmt.Errorf("invalid csv: %v", er)
+		}
+		if len(rows) == maxImportRows {
+			return nil, fmt.Errorf("too many rows, the maximum is %d", maxImportRows)
+		}
+
+		doc := meta.Projection{}
+		for field, idx := range columns {
+			if idx < len(record) && len(strings.TrimSpace(record[idx])) > 0 {
+				doc.Set(field, s.fieldValue(field, strings.TrimSpace(record[idx])))
+			}
+		}
+		rows = append(rows, s.newImportRow(doc))
+	}
+	return rows, nil
+}
+
+// Map the contact fields to the CSV column indexes
+func (s *ContactsService) mapColumns(header []string, mapping map[string]string) (map[string]int, error) {
+
+	importable := contactImportFields()
+	index := func(name string) int {
+		for i, col := range header {
+			if strings.EqualFold(strings.TrimSpace(col), strings.TrimSpace(name)) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	columns := make(map[string]int)
+	for field, column := range mapping {
+		if _, ok := importable[field]; !ok {
+			return nil, fmt.Errorf("invalid mapping: field %s can't be imported", field)
+		}
+		if idx := index(column); idx < 0 {
+			return nil, fmt.Errorf("invalid mapping: column %s of field %s not found", column, field)
+		} else {
+			columns[field] = idx
+		}
+	}
+
+	for field := range importable {
+		if _, mapped := mapping[field]; !mapped {
+			if idx := index(field); idx >= 0 {
+				columns[field] = idx
+			}
+		}
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("none of the columns is mapped to contact fields")
+	}
+	return columns, nil
+}
+
+// Get the field value: list fields (e.g. groups) are separated by ; or |
+func (s *ContactsService) fieldValue(field, value string) any {
+	if typ, _ := meta.GetFieldType(NewContact, field); typ != nil && typ.Kind() == reflect.Slice {
+		list := make([]string, 0)
+		for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == '|' }) {
+			if item = strings.TrimSpace(item); len(item) > 0 {
+				list = append(list, item)
+			}
+		}
+		return list
+	}
+	return value
+}
+
+// Get the contact fields (json paths) that can be imported: all the text fields except the id
+func contactImportFields() map[string]bool {
+	result := make(map[string]bool)
+	for _, field := range meta.GetEntityFields(NewContact) {
+		typ, _ := meta.GetFieldType(NewContact, field)
+		if field == "id" || typ == nil {
+			continue
+		}
+		if typ.Kind() == reflect.String || (typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.String) {
+			result[field] = true
+		}
+	}
+	return result
+}
+
+// Create import row from the json document of the contact fields
+func (s *ContactsService) newImportRow(doc meta.Projection) *importRow {
+	ent := NewContact().(*Contact)
+	row := &importRow{contact: ent, messages: make([]string, 0)}
+	if data, err := json.Marshal(doc); err != nil {
+		row.messages = append(row.messages, err.Error())
+	} else if err = json.Unmarshal(data, ent); err != nil {
+		row.messages = append(row.messages, err.Error())
+	}
+	return row
+}
+
+// endregion
+
+// region vCard parser -------------------------------------------------------------------------------------------------
+
+// Parse vCard file (versions 2.1, 3.0 and 4.0) with one or more cards, the supported properties are:
+// FN / N (name), EMAIL, TEL (mobile: the CELL type or the first phone), ADR (address), NOTE (description) and CATEGORIES (groups)
+func (s *ContactsService) parseVCard(r io.Reader) ([]*importRow, error) {
+
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vcard: %v", err)
+	}
+
+	rows := make([]*importRow, 0)
+	var doc meta.Projection
+	var mobile, phone, altName string
+
+	for _, line := range lines {
+		name, params, value, ok := parseVCardLine(line)
+		if !ok {
+			continue
+		}
+
+		switch name {
+		case "BEGIN":
+			doc, mobile, phone, altName = meta.Projection{}, "", "", ""
+		case "END":
+			if doc == nil {
+				continue
+			}
+			if len(rows) == maxImportRows {
+				return nil, fmt.Errorf("too many cards, the maximum is %d", maxImportRows)
+			}
+			if _, hasName := doc["name"]; !hasName && len(altName) > 0 {
+				doc.Set("name", altName)
+			}
+			if len(mobile) == 0 {
+				mobile = phone
+			}
+			if len(mobile) > 0 {
+				doc.Set("mobile", mobile)
+			}
+			rows = append(rows, s.newImportRow(doc))
+			doc = nil
+		}
+
+		if doc == nil {
+			continue
+		}
+
+		switch name {
+		case "FN":
+			doc.Set("name", unescapeVCard(value))
+		case "N":
+			parts := splitVCard(value, ';')
+			if len(parts) > 1 {
+				altName = strings.TrimSpace(parts[1] + " " + parts[0])
+			} else {
+				altName = parts[0]
+			}
+		case "EMAIL":
+			if _, exists := doc["email"]; !exists {
+				doc.Set("email", unescapeVCard(value))
+			}
+		case "TEL":
+			value = strings.TrimPrefix(unescapeVCard(value), "tel:")
+			if strings.Contains(params, "CELL") && len(mobile) == 0 {
+				mobile = value
+			} else if len(phone) == 0 {
+				phone = value
+			}
+		case "ADR":
+			// post office box; extended address; street; locality; region; postal code; country
+			parts := splitVCard(value, ';')
+			for i, field := range []string{"", "", "address.street", "address.city", "address.state", "address.zipCode", "address.country"} {
+				if len(field) > 0 && i < len(parts) && len(parts[i]) > 0 {
+					doc.Set(field, parts[i])
+				}
+			}
+		case "NOTE":
+			doc.Set("description", unescapeVCard(value))
+		case "CATEGORIES":
+			doc.Set("groups", splitVCard(value, ','))
+		}
+	}
+	return rows, nil
+}
+
+// Read the lines and join folded lines (continuation lines start with space or tab)
+func unfoldLines(r io.Reader) ([]string, error) {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+		} else if len(strings.TrimSpace(line)) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// Parse vCard content line: [group.]NAME[;PARAM=VALUE...]:VALUE, returns the property name and params in upper case
+func parseVCardLine(line string) (name, params, value string, ok bool) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return "", "", "", false
+	}
+	name, value = strings.ToUpper(line[:idx]), line[idx+1:]
+	if sep := strings.Index(name, ";"); sep >= 0 {
+		name, params = name[:sep], name[sep+1:]
+	}
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+	return name, params, value, true
+}
+
+// Split structured vCard value by the (unescaped) separator and unescape the parts
+func splitVCard(value string, sep rune) []string {
+	parts := make([]string, 0)
+	current := strings.Builder{}
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			current.WriteRune('\\')
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == sep:
+			parts = append(parts, strings.TrimSpace(unescapeVCard(current.String())))
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(parts, strings.TrimSpace(unescapeVCard(current.String())))
+}
+
+// Unescape vCard text value
+func unescapeVCard(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(strings.TrimSpace(value))
+}
+
+// endregion
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// Get the status of each row of the import report
+func rowStatuses(report *ImportReport) []ImportStatusCode {
+	result := make([]ImportStatusCode, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		result = append(result, row.Status)
+	}
+	return result
+}
+
+// Count the contacts of the account
+func countContacts(t *testing.T, hub *ServiceHub, accountId string) int64 {
+	t.Helper()
+	count, err := hub.Database.Query(NewContact).MatchAll(F("accountId").Eq(accountId)).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestImportCsvDryRun(t *testing.T) {
+	hub := newServicesTestHub(t)
+	service := GetContactsService(hub)
+
+	// Existing contacts of the import account and of other account
+	for _, c := range []struct{ id, account, email string }{{"e1", "csv1", "existing@example.com"}, {"e2", "csv2", "other@example.com"}} {
+		contact := NewContact().(*Contact)
+		contact.Id, contact.AccountId, contact.Name, contact.Email = c.id, c.account, c.id, c.email
+		if _, err := hub.Database.Insert(contact); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file := strings.Join([]string{
+		"\ufeffFull Name,email,mobile,groups,accountId",
+		"Alice,alice@example.com,+1 (555) 010-0001,vip;friends,",
+		"Bob,not-an-email,,,",
+		"Alice Again,ALICE@example.com,,,",
+		"Existing,existing@example.com,,,",
+		"Other,other@example.com,,,",
+		"Intruder,intruder@example.com,,,csv2",
+		",nameless@example.com,,,",
+	}, "\n")
+
+	report, err := service.Import(&TokenData{}, strings.NewReader(file), ContactsImportParams{
+		Format:    "csv",
+		Mapping:   map[string]string{"name": "Full Name"},
+		AccountId: "csv1",
+		DryRun:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ImportStatusCode{
+		ImportStatusCodes.VALID,
+		ImportStatusCodes.INVALID,
+		ImportStatusCodes.DUPLICATE,
+		ImportStatusCodes.DUPLICATE,
+		ImportStatusCodes.VALID,
+		ImportStatusCodes.INVALID,
+		ImportStatusCodes.INVALID,
+	}
+	if got := rowStatuses(report); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected statuses %v but got %v", expected, got)
+	}
+	if report.Total != 7 || report.Valid != 2 || report.Invalid != 3 || report.Duplicates != 2 || report.Imported != 0 || !report.DryRun {
+		t.Errorf("unexpected report counts: %+v", report)
+	}
+	if report.Rows[3].ItemId != "e1" {
+		t.Errorf("expected duplicate of e1 but got %q", report.Rows[3].ItemId)
+	}
+	if messages := strings.Join(report.Rows[5].Messages, ";"); !strings.Contains(messages, "account csv2") {
+		t.Errorf("expected account mismatch message but got %q", messages)
+	}
+
+	// Dry-run does not save the valid rows
+	if count := countContacts(t, hub, "csv1"); count != 1 {
+		t.Errorf("expected 1 contact after dry-run but got %d", count)
+	}
+}
+
+func TestImportVCard(t *testing.T) {
+	hub := newServicesTestHub(t)
+	service := GetContactsService(hub)
+
+	file := strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"N:Smith;John;;;",
+		"EMAIL;TYPE=work:john@example.com",
+		"TEL;TYPE=home:+44 20 7946 0000",
+		"TEL;TYPE=CELL:+44 7700 900123",
+		"ADR;TYPE=work:;;221B Baker Street;London;;NW1 6XE;UK",
+		"NOTE:Met at the conference\\, 2024",
+		"CATEGORIES:vip,partners",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"FN:Jane Doe",
+		"TEL:tel:+1-555-010-0002",
+		"END:VCARD",
+	}, "\r\n")
+
+	report, err := service.Import(&TokenData{}, strings.NewReader(file), ContactsImportParams{Format: "vcard", AccountId: "vcf1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []ImportStatusCode{ImportStatusCodes.IMPORTED, ImportStatusCodes.IMPORTED}
+	if got := rowStatuses(report); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected statuses %v but got %v: %+v", expected, got, report.Rows)
+	}
+
+	ent, err := hub.Database.Get(NewContact, report.Rows[0].ItemId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	john := ent.(*Contact)
+	if john.Name != "John Smith" || john.Email != "john@example.com" || john.Mobile != "+447700900123" || john.AccountId != "vcf1" {
+		t.Errorf("unexpected contact: %s %s %s %s", john.Name, john.Email, john.Mobile, john.AccountId)
+	}
+	if john.Address.Street != "221B Baker Street" || john.Address.City != "London" || john.Address.Country != "UK" {
+		t.Errorf("unexpected address: %+v", john.Address)
+	}
+	if john.Description != "Met at the conference, 2024" || !reflect.DeepEqual(john.Groups, []string{"vip", "partners"}) {
+		t.Errorf("unexpected description %q or groups %v", john.Description, john.Groups)
+	}
+
+	ent, err = hub.Database.Get(NewContact, report.Rows[1].ItemId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jane := ent.(*Contact); jane.Name != "Jane Doe" || jane.Mobile != "+15550100002" {
+		t.Errorf("unexpected contact: %s %s", jane.Name, jane.Mobile)
+	}
+
+	// Import of the same file again detects the duplicates
+	report, err = service.Import(&TokenData{}, strings.NewReader(file), ContactsImportParams{Format: "vcard", AccountId: "vcf1", DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Duplicates != 2 {
+		t.Errorf("expected 2 duplicates but got %+v", report.Rows)
+	}
+}
+
+func TestImportInvalidFile(t *testing.T) {
+	service := GetContactsService(newServicesTestHub(t))
+	tests := []struct {
+		format string
+		file   string
+	}{
+		{"xml", "<contacts/>"},
+		{"csv", ""},
+		{"csv", "unknown,columns\n1,2"},
+	}
+	for _, tt := range tests {
+		if _, err := service.Import(&TokenData{}, strings.NewReader(tt.file), ContactsImportParams{Format: tt.format, AccountId: "a1"}); err == nil {
+			t.Errorf("%s %q: expected error", tt.format, tt.file)
+		}
+	}
+	if _, err := service.Import(&TokenData{}, strings.NewReader("name\nx"), ContactsImportParams{Format: "csv", Mapping: map[string]string{"id": "name"}}); err == nil {
+		t.Error("expected error for mapping of the id")
+	}
+}
@@ -2,12 +2,14 @@ package services
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	. "github.com/go-yaaf/yaaf-common/database"
 	. "github.com/go-yaaf/yaaf-common/entity"
 
 	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/filter"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
@@ -34,6 +36,13 @@ func GetContactsService(sh *ServiceHub) *ContactsService {
 
 // Create new contact in the system
 func (s *ContactsService) Create(td *TokenData, entity Entity) (Entity, error) {
+	td, end := s.observe(td, "Create")
+	defer end()
+	return s.create(td, entity, nil)
+}
+
+// Create new contact, the audit log entry includes the provided properties (e.g. importId)
+func (s *ContactsService) create(td *TokenData, entity Entity, auditProps Json) (Entity, error) {
 
 	ent := entity.(*Contact)
 
@@ -43,26 +52,28 @@ func (s *ContactsService) Create(td *TokenData, entity Entity) (Entity, error) {
 	ent.UpdatedOn = Now()
 	ent.Props = nil
 
-	// Strip phone numbers
-	ent.Mobile = s.stripPhone(ent.Mobile)
+	// Normalize phone numbers
+	ent.Mobile = s.normalizePhone(ent.Mobile)
 
-	if updated, er := s.sh.Database.Insert(ent); er != nil {
-		return nil, s.serviceError("Create", er)
+	tx := s.sh.Transaction(td.Context()).Insert(ent)
+	if er := s.commitChangeWithProps(td, tx, ent, actionCreate, nil, ent, auditProps); er != nil {
+		return nil, s.serviceError(td, "Create", er)
 	} else {
-		s.auditLog(td, ent, actionCreate, nil, updated)
-		return updated, nil
+		return ent, nil
 	}
 }
 
 // Update existing contact in the system
 func (s *ContactsService) Update(td *TokenData, entity Entity) (Entity, error) {
+	td, end := s.observe(td, "Update")
+	defer end()
 
 	ent := entity.(*Contact)
 
 	// Get existing contact
-	existing, err := s.sh.Database.Get(NewContact, ent.Id)
+	existing, err := s.sh.DatabaseContext(td.Context()).Get(NewContact, ent.Id)
 	if err != nil {
-		return nil, s.serviceError("Update", err)
+		return nil, s.serviceError(td, "Update", err)
 	}
 
 	// Override system fields,
@@ -70,40 +81,42 @@ func (s *ContactsService) Update(td *TokenData, entity Entity) (Entity, error) {
 	ent.UpdatedOn = Now()
 	ent.Props = nil
 
-	// Strip phone numbers
-	ent.Mobile = s.stripPhone(ent.Mobile)
+	// Normalize phone numbers
+	ent.Mobile = s.normalizePhone(ent.Mobile)
 
-	if updated, er := s.sh.Database.Update(ent); er != nil {
-		return nil, s.serviceError("Update", er)
+	tx := s.sh.Transaction(td.Context()).Update(ent)
+	if er := s.commitChange(td, tx, ent, actionUpdate, existing, ent); er != nil {
+		return nil, s.serviceError(td, "Update", er)
 	} else {
-		s.auditLog(td, ent, actionUpdate, existing, updated)
-		return updated, nil
+		return ent, nil
 	}
 }
 
 // Delete contact
 func (s *ContactsService) Delete(td *TokenData, id string) (err error) {
+	td, end := s.observe(td, "Delete")
+	defer end()
 
 	// Get existing contact
 	var existing Entity
-	if existing, err = s.sh.Database.Get(NewContact, id); err != nil {
-		return s.serviceError("Delete", err)
+	if existing, err = s.sh.DatabaseContext(td.Context()).Get(NewContact, id); err != nil {
+		return s.serviceError(td, "Delete", err)
 	}
 
 	if existing.(*Contact).Flag < 0 {
-		if err = s.sh.Database.Delete(NewContact, id); err != nil {
-			return s.serviceError("Delete", err)
+		tx := s.sh.Transaction(td.Context()).Delete(existing)
+		if err = s.commitChange(td, tx, existing, actionDelete, existing, nil); err != nil {
+			return s.serviceError(td, "Delete", err)
 		} else {
-			s.auditLog(td, existing, actionDelete, existing, nil)
 			return nil
 		}
 	} else {
 		existing.(*Contact).Flag = -1
 
-		if _, err = s.sh.Database.Update(existing); err != nil {
-			return s.serviceError("Delete", err)
+		tx := s.sh.Transaction(td.Context()).Update(existing)
+		if err = s.commitChange(td, tx, existing, actionDelete, existing, nil); err != nil {
+			return s.serviceError(td, "Delete", err)
 		} else {
-			s.auditLog(td, existing, actionDelete, existing, nil)
 			return nil
 		}
 	}
@@ -111,7 +124,9 @@ func (s *ContactsService) Delete(td *TokenData, id string) (err error) {
 
 // Get single contact by id
 func (s *ContactsService) Get(td *TokenData, id string) (Entity, error) {
-	if ent, err := s.sh.Database.Get(NewContact, id); err != nil {
+	td, end := s.observe(td, "Get")
+	defer end()
+	if ent, err := s.sh.DatabaseContext(td.Context()).Get(NewContact, id); err != nil {
 		return nil, fmt.Errorf("[%s]::Get: %v", s.ServiceName, err)
 	} else {
 		ent.(*Contact).Props = Json{}
@@ -121,25 +136,29 @@ func (s *ContactsService) Get(td *TokenData, id string) (Entity, error) {
 
 // ContactsFindParams Query params aggregator for find commands service
 type ContactsFindParams struct {
-	Search string       // Filter by free text search (using * wildcard)
-	Status []StatusCode // Filter by status(es)
-	Sort   string       // Sort descriptor (field name with suffix +/- for sort order)
-	Page   int          // Page number for pagination
-	Size   int          // Page size: number of items per page
+	Search string           // Filter by free text search (using * wildcard)
+	Status []StatusCode     // Filter by status(es)
+	Sort   string           // Sort descriptor (field name with suffix +/- for sort order)
+	Page   int              // Page number for pagination
+	Size   int              // Page size: number of items per page
+	Fields []string         // Sparse fieldset: list of fields (json paths) to include in the results
+	Filter *filter.Criteria // Filter expression (parsed filter query language)
 }
 
 // Find list of contacts by filter
-func (s *ContactsService) Find(p ContactsFindParams) (entities []Entity, total int64, pages int, error error) {
+func (s *ContactsService) Find(td *TokenData, p ContactsFindParams) (entities []Entity, total int64, pages int, error error) {
+	td, end := s.observe(td, "Find")
+	defer end()
 	cb := func(in Entity) (out Entity) {
 		in.(*Contact).Props = Json{}
 		return in
 	}
 
-	if entities, total, error = s.sh.Database.Query(NewContact).
+	query := p.Filter.Apply(s.sh.DatabaseContext(td.Context()).Query(NewContact)).
 		MatchAny(
 			F("id").Eq(p.Search),
 			F("name").Like(p.Search),
-			F("enName").Like(p.Search),
+			F("email").Like(p.Search),
 		).
 		MatchAll(
 			F("flag").Gte(0),
@@ -148,11 +167,29 @@ func (s *ContactsService) Find(p ContactsFindParams) (entities []Entity, total i
 		Page(p.Page).
 		Limit(p.Size).
 		Sort(p.Sort).
-		Apply(cb).
-		Find(); error == nil {
+		Apply(cb)
+
+	if entities, total, error = s.find(query, p.Fields, "props"); error == nil {
 		pages = s.calcPages(total, p.Size)
 	} else {
-		error = s.serviceError("Find", error)
+		error = s.serviceError(td, "Find", error)
 	}
 	return
 }
+
+// Export iterates over all the contacts matching the query (regardless of the pagination) and passes them to the callback
+func (s *ContactsService) Export(td *TokenData, p ContactsFindParams, cb func(Entity) error) error {
+	td, end := s.observe(td, "Export")
+	defer end()
+	return s.iterate(func(page, size int) ([]Entity, error) {
+		p.Page, p.Size = page, size
+		list, _, _, err := s.Find(td, p)
+		return list, err
+	}, cb)
+}
+
+// Normalize phone number: strip spaces, dashes, dots and parentheses
+func (s *ContactsService) normalizePhone(phone string) string {
+	phone = s.stripPhone(strings.TrimSpace(phone))
+	return strings.NewReplacer(".", "", "(", "", ")", "").Replace(phone)
+}
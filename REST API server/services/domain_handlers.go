@@ -0,0 +1,37 @@
+package services
+
+import (
+	"github.com/go-yaaf/yaaf-common/logger"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/events"
+)
+
+// Subscription name of the in-process domain events handlers
+const domainHandlersSubscription = "rest-api"
+
+// RegisterDomainEventHandlers subscribes the in-process handlers of the domain events (called once on startup)
+func RegisterDomainEventHandlers(sh *ServiceHub) error {
+	if _, err := SubscribeDomainEvent(sh, domainHandlersSubscription, func(event *AccountSuspended) {
+		deactivateAccountWebhooks(sh, event.Meta(), event.AccountId)
+	}); err != nil {
+		return err
+	}
+	if _, err := SubscribeDomainEvent(sh, domainHandlersSubscription, func(event *AccountDeleted) {
+		deactivateAccountWebhooks(sh, event.Meta(), event.AccountId)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Stop notifying the webhooks of suspended or deleted account, the handler acts on behalf of the event subject
+func deactivateAccountWebhooks(sh *ServiceHub, meta *EventMeta, accountId string) {
+	td := &TokenData{SubjectId: meta.SubjectId, RequestId: meta.RequestId}
+	if count, err := GetWebhooksService(sh).DeactivateAccountWebhooks(td, accountId); err != nil {
+		logger.Warn("[DomainEvents]: failed to deactivate webhooks of account %s: %s", accountId, err.Error())
+	} else if count > 0 {
+		logger.Info("[DomainEvents]: deactivated %d webhooks of account %s", count, accountId)
+	}
+}
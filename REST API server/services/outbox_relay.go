@@ -0,0 +1,396 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"github.com/go-yaaf/yaaf-common/logger"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// Subscription name of the entity change events of the event broker
+const entityEventsSubscription = "rest-api-events"
+
+// Number of due entries relayed in each relay round
+const outboxBatchSize = 100
+
+// Interval of purging the sent entries older than the retention
+const outboxPurgeInterval = time.Hour
+
+// Entities of the entity change events (the item type is the entity table)
+var entityEventFactories = []EntityFactory{NewAccount, NewAuditLog, NewContact, NewUser, NewUsersGroup, NewWebhook}
+
+// Outbox relay results (label of the relay metrics)
+const (
+	outboxResultSent   = "sent"
+	outboxResultRetry  = "retry"
+	outboxResultFailed = "failed"
+)
+
+// region Relay configuration ------------------------------------------------------------------------------------------
+
+// OutboxConfig is the outbox relay configuration
+type OutboxConfig struct {
+	MaxAttempts  int           // Number of relay attempts before the entry fails (FAILED)
+	Backoff      time.Duration // Delay before the first retry, doubled on each retry
+	MaxBackoff   time.Duration // Maximal delay between retries
+	PollInterval time.Duration // Interval of checking the due entries and updating the outbox metrics
+	StuckAfter   time.Duration // Time after which a waiting entry is reported as stuck
+	Retention    time.Duration // Time to keep the sent entries (0 to keep them)
+}
+
+// NewOutboxConfig creates the relay configuration from the service configuration
+func NewOutboxConfig(cfg *config.ServiceConfig) OutboxConfig {
+	return OutboxConfig{
+		MaxAttempts:  cfg.OutboxMaxAttempts(),
+		Backoff:      cfg.OutboxBackoff(),
+		MaxBackoff:   cfg.OutboxBackoffMax(),
+		PollInterval: cfg.OutboxPollInterval(),
+		StuckAfter:   cfg.OutboxStuckAfter(),
+		Retention:    cfg.OutboxRetention(),
+	}
+}
+
+// endregion
+
+// region Relay structure and factory method ---------------------------------------------------------------------------
+
+var outboxRelayOnce sync.Once
+var outboxRelayInst *OutboxRelay = nil
+
+// OutboxRelay publishes the outbox entries saved by the services in the same transaction as the entity changes: the
+// entity change events are enqueued to the webhooks and published to the message bus (streamed by the event broker of
+// every instance), the domain events are published to the message bus. The entries are not claimed, so the relay must run on a single service instance
+// (OUTBOX_RELAY). The entries are relayed at least once (an entry may be relayed again if its status update fails, the
+// webhook deliveries are enqueued once per entry), failed attempts are retried with exponential backoff
+type OutboxRelay struct {
+	BaseService
+	sh     *ServiceHub
+	config OutboxConfig
+	wake   chan struct{} // Signal the loop to relay the due entries (new entries were committed)
+
+	mu          sync.Mutex
+	cancel      context.CancelFunc // Stops the loop (nil when not started)
+	done        chan struct{}      // Closed when the loop is stopped
+	lastPurge   time.Time          // When the sent entries were last purged
+	lastEventId int64              // ID of the last published entity change event
+}
+
+// GetOutboxRelay factory function (configured by the service configuration)
+func GetOutboxRelay(sh *ServiceHub) *OutboxRelay {
+	outboxRelayOnce.Do(func() {
+		if outboxRelayInst == nil {
+			outboxRelayInst = NewOutboxRelay(sh, NewOutboxConfig(config.GetConfig()))
+		}
+	})
+	return outboxRelayInst
+}
+
+// NewOutboxRelay creates new relay (e.g. with short backoff for testing), the services wake the relay of GetOutboxRelay
+func NewOutboxRelay(sh *ServiceHub, cfg OutboxConfig) *OutboxRelay {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	return &OutboxRelay{
+		BaseService: BaseService{ServiceName: "OutboxRelay"},
+		sh:          sh,
+		config:      cfg,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// endregion
+
+// region Relay loop ---------------------------------------------------------------------------------------------------
+
+// Start the relay loop, the due entries are relayed on every poll interval and when new entries are committed
+func (r *OutboxRelay) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel, r.done = cancel, make(chan struct{})
+	go r.run(ctx, r.done)
+}
+
+// Stop the relay loop and wait for the current round to end (up to the context deadline), the entries that were not
+// relayed are relayed after restart
+func (r *OutboxRelay) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel = nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *OutboxRelay) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		poll := false
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			poll = true
+		case <-r.wake:
+		}
+		if _, err := r.ProcessDue(ctx); err != nil {
+			logger.Warn("[%s:ProcessDue]: %s", r.ServiceName, err.Error())
+		}
+		if !poll {
+			continue
+		}
+		if _, err := r.Stats(ctx); err != nil {
+			logger.Warn("[%s:Stats]: %s", r.ServiceName, err.Error())
+		}
+		if err := r.purge(ctx); err != nil {
+			logger.Warn("[%s:Purge]: %s", r.ServiceName, err.Error())
+		}
+	}
+}
+
+// Signal the loop to relay the due entries
+func (r *OutboxRelay) notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// endregion
+
+// region Relay methods ------------------------------------------------------------------------------------------------
+
+// ProcessDue relays the due entries (pending and retries) in their creation order, returns the number of attempts. An
+// entry that fails to update is logged and the batch continues (the entry is relayed again on the next round)
+func (r *OutboxRelay) ProcessDue(ctx context.Context) (int, error) {
+	list, _, err := r.sh.DatabaseContext(ctx).Query(NewOutboxEntry).
+		MatchAll(
+			F("status").In(OutboxStatusCodes.PENDING, OutboxStatusCodes.RETRY),
+			F("nextAttempt").Lte(Now()),
+		).
+		Sort("createdOn").
+		Page(1).
+		Limit(outboxBatchSize).
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	attempts := 0
+	for _, ent := range list {
+		if ctx.Err() != nil {
+			break
+		}
+		attempts++
+		if _, er := r.Relay(ctx, ent.(*OutboxEntry)); er != nil {
+			logger.Warn("[%s:ProcessDue]: %s", r.ServiceName, er.Error())
+		}
+	}
+	return attempts, nil
+}
+
+// Relay publishes the entry and updates it: SENT when published, otherwise RETRY with exponential backoff or FAILED
+// after the maximal number of attempts
+func (r *OutboxRelay) Relay(ctx context.Context, entry *OutboxEntry) (*OutboxEntry, error) {
+	err := r.publish(ctx, entry)
+	if err != nil && ctx.Err() != nil {
+		// Interrupted by shutdown, relay again after restart
+		return entry, nil
+	}
+
+	entry.UpdatedOn = Now()
+	entry.Attempts++
+	if err == nil {
+		entry.Status = OutboxStatusCodes.SENT
+		entry.SentOn = Now()
+		entry.Error = ""
+		Metrics().CountOutboxRelay(outboxResultSent)
+	} else {
+		entry.Error = err.Error()
+		if entry.Attempts >= r.config.MaxAttempts {
+			entry.Status = OutboxStatusCodes.FAILED
+			Metrics().CountOutboxRelay(outboxResultFailed)
+			logger.Error("%s: entry %s of %s failed after %d attempts: %s", r.logPrefix(&TokenData{RequestId: entry.RequestId}, "Relay"), entry.Id, entry.Topic, entry.Attempts, entry.Error)
+		} else {
+			entry.Status = OutboxStatusCodes.RETRY
+			entry.NextAttempt = Timestamp(time.Now().Add(r.backoff(entry.Attempts)).UnixMilli())
+			Metrics().CountOutboxRelay(outboxResultRetry)
+		}
+	}
+
+	if _, er := r.sh.DatabaseContext(ctx).Update(entry); er != nil {
+		return entry, fmt.Errorf("failed to update outbox entry %s: %v", entry.Id, er)
+	}
+	return entry, nil
+}
+
+// Publish the entry by its topic: entity change event to the webhooks and the message bus, domain event to the message
+// bus
+func (r *OutboxRelay) publish(ctx context.Context, entry *OutboxEntry) error {
+	if r.sh.MessageBus == nil {
+		return fmt.Errorf("message bus is not configured")
+	}
+
+	switch {
+	case strings.HasPrefix(entry.Topic, EntityEventsTopic):
+		event := &EntityEvent{}
+		if err := json.Unmarshal([]byte(entry.Payload), event); err != nil {
+			return fmt.Errorf("invalid entity event: %v", err)
+		}
+		// The webhook deliveries are enqueued first, both are retried if publish fails (the deliveries are enqueued once)
+		if err := GetWebhookDispatcher(r.sh).Enqueue(ctx, entry.Id, event); err != nil {
+			return err
+		}
+		event.Id = r.eventId(entry)
+		return r.sh.PublishEntityEvent(ctx, event)
+	case strings.HasPrefix(entry.Topic, DomainEventsTopic):
+		return r.sh.PublishDomainEventPayload(ctx, entry.Topic, []byte(entry.Payload))
+	default:
+		return fmt.Errorf("unknown topic: %s", entry.Topic)
+	}
+}
+
+// ID of the entity change event of the entry: the entry creation time in microseconds, increased if not after the last
+// event ID. The IDs are ordered as the events are published (the relay runs on a single instance), so the subscribers
+// resume by the same ID on any instance
+func (r *OutboxRelay) eventId(entry *OutboxEntry) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastEventId = max(int64(entry.CreatedOn)*1000, r.lastEventId+1)
+	return r.lastEventId
+}
+
+// Stats returns the state of the outbox (pending, stuck and failed entries) and records it in the outbox metrics
+func (r *OutboxRelay) Stats(ctx context.Context) (*OutboxStats, error) {
+	db := r.sh.DatabaseContext(ctx)
+	waiting := F("status").In(OutboxStatusCodes.PENDING, OutboxStatusCodes.RETRY)
+	stats := &OutboxStats{StuckAfter: r.config.StuckAfter.Milliseconds()}
+
+	var err error
+	if stats.Pending, err = db.Query(NewOutboxEntry).MatchAll(waiting).Count(); err != nil {
+		return nil, err
+	}
+	if stats.Stuck, err = db.Query(NewOutboxEntry).MatchAll(waiting, F("createdOn").Lte(r.stuckBefore())).Count(); err != nil {
+		return nil, err
+	}
+	if stats.Failed, err = db.Query(NewOutboxEntry).MatchAll(F("status").Eq(OutboxStatusCodes.FAILED)).Count(); err != nil {
+		return nil, err
+	}
+
+	oldest := time.Duration(0)
+	if stats.Pending > 0 {
+		list, _, er := db.Query(NewOutboxEntry).MatchAll(waiting).Sort("createdOn").Page(1).Limit(1).Find()
+		if er != nil {
+			return nil, er
+		}
+		if len(list) > 0 {
+			stats.OldestPending = list[0].(*OutboxEntry).CreatedOn
+			oldest = time.Since(time.UnixMilli(int64(stats.OldestPending)))
+		}
+	}
+
+	Metrics().SetOutboxStats(stats.Pending, stats.Stuck, stats.Failed, oldest)
+	return stats, nil
+}
+
+// Creation time before which a waiting entry is stuck
+func (r *OutboxRelay) stuckBefore() Timestamp {
+	return Timestamp(time.Now().Add(-r.config.StuckAfter).UnixMilli())
+}
+
+// Delete the sent entries older than the retention (at most once per purge interval)
+func (r *OutboxRelay) purge(ctx context.Context) error {
+	if r.config.Retention <= 0 || time.Since(r.lastPurge) < outboxPurgeInterval {
+		return nil
+	}
+	r.lastPurge = time.Now()
+
+	before := Timestamp(time.Now().Add(-r.config.Retention).UnixMilli())
+	deleted, err := r.sh.DatabaseContext(ctx).Query(NewOutboxEntry).
+		MatchAll(
+			F("status").Eq(OutboxStatusCodes.SENT),
+			F("sentOn").Lte(before),
+		).
+		Delete()
+	if err == nil && deleted > 0 {
+		logger.Info("[%s:Purge]: %d sent entries deleted", r.ServiceName, deleted)
+	}
+	return err
+}
+
+// Delay before the next attempt: the backoff is doubled on each failed attempt up to the maximal backoff
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	delay := r.config.Backoff
+	for i := 1; i < attempts && (r.config.MaxBackoff <= 0 || delay < r.config.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if r.config.MaxBackoff > 0 && delay > r.config.MaxBackoff {
+		delay = r.config.MaxBackoff
+	}
+	return delay
+}
+
+// endregion
+
+// region Helpers ------------------------------------------------------------------------------------------------------
+
+// SubscribeEntityEvents feeds the event broker of this service instance with the entity change events published by the
+// outbox relay (called once on startup on every instance)
+func SubscribeEntityEvents(sh *ServiceHub) error {
+	itemTypes := make([]string, 0, len(entityEventFactories))
+	for _, factory := range entityEventFactories {
+		itemTypes = append(itemTypes, factory().TABLE())
+	}
+	_, err := sh.SubscribeEntityEvents(entityEventsSubscription, itemTypes...)
+	return err
+}
+
+// Create the outbox entry of the event (to insert in the transaction of the change)
+func newOutboxEntry(td *TokenData, topic string, event any) (Entity, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize %s event: %v", topic, err)
+	}
+
+	entry := NewOutboxEntry().(*OutboxEntry)
+	entry.Topic = topic
+	entry.Payload = string(payload)
+	entry.Status = OutboxStatusCodes.PENDING
+	entry.NextAttempt = Now()
+	entry.RequestId = td.RequestId
+	return entry, nil
+}
+
+// endregion
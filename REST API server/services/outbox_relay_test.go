@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// Insert outbox entry of the topic and event
+func insertOutboxEntry(t *testing.T, hub *ServiceHub, topic string, event any) *OutboxEntry {
+	t.Helper()
+	ent, err := newOutboxEntry(&TokenData{RequestId: "r1"}, topic, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = hub.Database.Insert(ent); err != nil {
+		t.Fatal(err)
+	}
+	return ent.(*OutboxEntry)
+}
+
+func TestOutboxRelayRetry(t *testing.T) {
+	hub := newServicesTestHub(t)
+	relay := NewOutboxRelay(hub, OutboxConfig{MaxAttempts: 2, Backoff: time.Minute})
+	entry := insertOutboxEntry(t, hub, "unknown.event", &EntityEvent{})
+
+	// The failed attempt is retried after the backoff
+	before := time.Now().Truncate(time.Millisecond)
+	if _, err := relay.Relay(context.Background(), entry); err != nil {
+		t.Fatal(err)
+	}
+	ent, err := hub.Database.Get(NewOutboxEntry, entry.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := ent.(*OutboxEntry)
+	if saved.Status != OutboxStatusCodes.RETRY || saved.Attempts != 1 || !strings.Contains(saved.Error, "unknown topic") {
+		t.Errorf("expected RETRY after 1 attempt but got %v after %d: %s", saved.Status, saved.Attempts, saved.Error)
+	}
+	if next := time.UnixMilli(int64(saved.NextAttempt)); next.Before(before.Add(time.Minute)) || next.After(time.Now().Add(time.Minute)) {
+		t.Errorf("expected next attempt after the backoff but got %s", next)
+	}
+
+	// The entry fails after the maximal number of attempts
+	if _, err = relay.Relay(context.Background(), saved); err != nil {
+		t.Fatal(err)
+	}
+	if ent, err = hub.Database.Get(NewOutboxEntry, entry.Id); err != nil {
+		t.Fatal(err)
+	}
+	if saved = ent.(*OutboxEntry); saved.Status != OutboxStatusCodes.FAILED || saved.Attempts != 2 {
+		t.Errorf("expected FAILED after 2 attempts but got %v after %d", saved.Status, saved.Attempts)
+	}
+}
+
+func TestOutboxRelayBackoff(t *testing.T) {
+	tests := []struct {
+		maxBackoff time.Duration
+		attempts   int
+		expected   time.Duration
+	}{
+		{5 * time.Second, 1, time.Second},
+		{5 * time.Second, 2, 2 * time.Second},
+		{5 * time.Second, 3, 4 * time.Second},
+		{5 * time.Second, 4, 5 * time.Second},
+		{5 * time.Second, 30, 5 * time.Second},
+		{0, 4, 8 * time.Second},
+	}
+	for _, tt := range tests {
+		relay := NewOutboxRelay(nil, OutboxConfig{Backoff: time.Second, MaxBackoff: tt.maxBackoff})
+		if got := relay.backoff(tt.attempts); got != tt.expected {
+			t.Errorf("max %s, %d attempts: expected %s but got %s", tt.maxBackoff, tt.attempts, tt.expected, got)
+		}
+	}
+}
+
+func TestOutboxRelayEntityEvents(t *testing.T) {
+	hub := newServicesTestHub(t)
+	if err := SubscribeEntityEvents(hub); err != nil {
+		t.Fatal(err)
+	}
+	sub, _ := hub.Events.Subscribe(func(event *EntityEvent) bool { return event.ItemType == "audit_log" }, 0)
+	defer sub.Close()
+
+	// The events of entries created at the same time get ordered IDs derived from the creation time
+	relay := NewOutboxRelay(hub, OutboxConfig{})
+	createdOn := Now()
+	for i, itemId := range []string{"l1", "l2"} {
+		entry := insertOutboxEntry(t, hub, EntityEventTopic("audit_log"), &EntityEvent{Action: "Create", ItemType: "audit_log", ItemId: itemId})
+		entry.CreatedOn = createdOn
+		if saved, err := relay.Relay(context.Background(), entry); err != nil || saved.Status != OutboxStatusCodes.SENT {
+			t.Fatalf("expected SENT entry but got %v: %v", saved.Status, err)
+		}
+
+		// The event is received from the message bus by the event broker
+		select {
+		case event := <-sub.C:
+			if expected := int64(createdOn)*1000 + int64(i); event.Id != expected || event.ItemId != itemId {
+				t.Errorf("expected event %d of %s but got %d of %s", expected, itemId, event.Id, event.ItemId)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event of %s was not received", itemId)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+var outboxServiceOnce sync.Once
+var outboxServiceInst *OutboxService = nil
+
+type OutboxService struct {
+	BaseService
+	sh *ServiceHub // Service hub
+}
+
+// GetOutboxService factory function
+func GetOutboxService(sh *ServiceHub) *OutboxService {
+	outboxServiceOnce.Do(func() {
+		if outboxServiceInst == nil {
+			outboxServiceInst = &OutboxService{BaseService: BaseService{ServiceName: "OutboxService"}, sh: sh}
+		}
+	})
+	return outboxServiceInst
+}
+
+// Get single outbox entry by id
+func (s *OutboxService) Get(td *TokenData, id string) (Entity, error) {
+	td, end := s.observe(td, "Get")
+	defer end()
+
+	if ent, err := s.sh.DatabaseContext(td.Context()).Get(NewOutboxEntry, id); err != nil {
+		return nil, fmt.Errorf("[%s]::Get: %v", s.ServiceName, err)
+	} else {
+		return ent, nil
+	}
+}
+
+// OutboxFindParams Query params aggregator for find commands service
+type OutboxFindParams struct {
+	Topic  string             // Filter by topic (using * wildcard)
+	Status []OutboxStatusCode // Filter by status(s)
+	Stuck  bool               // Filter stuck entries: waiting longer than the stuck threshold
+	Sort   string             // Sort descriptor (field name with suffix +/- for sort order)
+	Page   int                // Page number for pagination
+	Size   int                // Page size: number of items per page
+}
+
+func (f *OutboxFindParams) Statuses() (result []any) {
+	for _, t := range f.Status {
+		result = append(result, t)
+	}
+	return result
+}
+
+// Find list of outbox entries by filter
+func (s *OutboxService) Find(td *TokenData, p OutboxFindParams) (entities []Entity, total int64, pages int, error error) {
+	td, end := s.observe(td, "Find")
+	defer end()
+
+	filters := []QueryFilter{
+		F("topic").Like(p.Topic),
+		F("status").In(p.Statuses()...),
+	}
+	if p.Stuck {
+		relay := GetOutboxRelay(s.sh)
+		filters = append(filters,
+			F("status").In(OutboxStatusCodes.PENDING, OutboxStatusCodes.RETRY),
+			F("createdOn").Lte(relay.stuckBefore()),
+		)
+	}
+
+	query := s.sh.DatabaseContext(td.Context()).Query(NewOutboxEntry).
+		MatchAll(filters...).
+		Page(p.Page).
+		Limit(p.Size).
+		Sort(p.Sort)
+
+	if entities, total, error = query.Find(); error == nil {
+		pages = s.calcPages(total, p.Size)
+	} else {
+		error = s.serviceError(td, "Find", error)
+	}
+	return
+}
+
+// Export iterates over all the outbox entries matching the query (regardless of the pagination) and passes them to the
+// callback
+func (s *OutboxService) Export(td *TokenData, p OutboxFindParams, cb func(Entity) error) error {
+	td, end := s.observe(td, "Export")
+	defer end()
+	return s.iterate(func(page, size int) ([]Entity, error) {
+		p.Page, p.Size = page, size
+		list, _, _, err := s.Find(td, p)
+		return list, err
+	}, cb)
+}
+
+// Retry schedules the entry for immediate relay (e.g. failed entry after the message bus was fixed), the attempts are
+// counted from the start
+func (s *OutboxService) Retry(td *TokenData, id string) (Entity, error) {
+	td, end := s.observe(td, "Retry")
+	defer end()
+
+	existing, err := s.sh.DatabaseContext(td.Context()).Get(NewOutboxEntry, id)
+	if err != nil {
+		return nil, s.serviceError(td, "Retry", err)
+	}
+	if existing.(*OutboxEntry).Status == OutboxStatusCodes.SENT {
+		return nil, s.serviceErrorf(td, "Retry", "outbox entry %s was already sent", id)
+	}
+
+	entry := *existing.(*OutboxEntry)
+	entry.UpdatedOn = Now()
+	entry.Status = OutboxStatusCodes.PENDING
+	entry.Attempts = 0
+	entry.NextAttempt = Now()
+	entry.Error = ""
+
+	// Operational row, updated with no audit log and entity change event
+	if er := s.sh.Transaction(td.Context()).Update(&entry).Commit(); er != nil {
+		return nil, s.serviceError(td, "Retry", er)
+	} else {
+		GetOutboxRelay(s.sh).notify()
+		return &entry, nil
+	}
+}
+
+// Stats gets the state of the outbox: number of pending, stuck and failed entries and the oldest pending entry
+func (s *OutboxService) Stats(td *TokenData) (Entity, error) {
+	td, end := s.observe(td, "Stats")
+	defer end()
+
+	if stats, err := GetOutboxRelay(s.sh).Stats(td.Context()); err != nil {
+		return nil, s.serviceError(td, "Stats", err)
+	} else {
+		return stats, nil
+	}
+}
@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
+	. "github.com/go-yaaf/yaaf-common/database"
 	. "github.com/go-yaaf/yaaf-common/entity"
 	"github.com/go-yaaf/yaaf-common/logger"
 	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	meta "github.com/go-yaaf/yaaf-examples/rest-api/model"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/events"
 )
 
 const (
@@ -19,30 +23,63 @@ const (
 	actionDelete = "Delete"
 )
 
+// Number of entities to fetch in each database round trip when iterating over all the query results (e.g. export)
+const iteratePageSize = 1000
+
 type BaseService struct {
 	ServiceName string
 }
 
 // Return formatted service error
-func (s *BaseService) serviceError(method string, err error) error {
+func (s *BaseService) serviceError(td *TokenData, method string, err error) error {
 	if err == nil {
 		return nil
 	} else {
-		logger.Error("[%s:%s]: %s", s.ServiceName, method, err.Error())
+		common.Metrics().CountServiceError(s.ServiceName, method)
+		common.SpanError(td.Context(), err)
+		logger.Error("%s: %s", s.logPrefix(td, method), err.Error())
 		return err //fmt.Errorf("%s:%s error: %s", s.ServiceName, method, err.Error())
 	}
 }
 
-func (s *BaseService) serviceErrorEx(method string, code int, errText string) Error {
-	logger.Error("[%s:%s]: %s", s.ServiceName, method, errText)
-	return NewError(code, fmt.Sprintf("%s:%s error: %s", s.ServiceName, method, errText))
+func (s *BaseService) serviceErrorEx(td *TokenData, method string, code int, errText string) Error {
+	err := NewError(code, fmt.Sprintf("%s:%s error: %s", s.ServiceName, method, errText))
+	common.Metrics().CountServiceError(s.ServiceName, method)
+	common.SpanError(td.Context(), err)
+	logger.Error("%s: %s", s.logPrefix(td, method), errText)
+	return err
 }
 
 // Return custom formatted service error
-func (s *BaseService) serviceErrorf(method string, message string, args ...any) error {
+func (s *BaseService) serviceErrorf(td *TokenData, method string, message string, args ...any) error {
 	errMsg := fmt.Sprintf(message, args...)
-	logger.Error("[%s:%s]: %s", s.ServiceName, method, errMsg)
-	return fmt.Errorf("%s:%s error: %s", s.ServiceName, method, errMsg)
+	err := fmt.Errorf("%s:%s error: %s", s.ServiceName, method, errMsg)
+	common.Metrics().CountServiceError(s.ServiceName, method)
+	common.SpanError(td.Context(), err)
+	logger.Error("%s: %s", s.logPrefix(td, method), errMsg)
+	return err
+}
+
+// Log line prefix of the service method including the request correlation ID (if available): [Service:Method] [requestId]
+func (s *BaseService) logPrefix(td *TokenData, method string) string {
+	if td == nil || len(td.RequestId) == 0 {
+		return fmt.Sprintf("[%s:%s]", s.ServiceName, method)
+	}
+	return fmt.Sprintf("[%s:%s] [%s]", s.ServiceName, method, td.RequestId)
+}
+
+// Start the service method span (child of the request span) and timer, returns the token data bound to the method span
+// context and a function to record the method latency metric and end the span, usage:
+//
+//	td, end := s.observe(td, "Method")
+//	defer end()
+func (s *BaseService) observe(td *TokenData, method string) (*TokenData, func()) {
+	start := time.Now()
+	ctx, span := common.StartSpan(td.Context(), s.ServiceName+"."+method)
+	return td.WithContext(ctx), func() {
+		common.Metrics().ObserveServiceMethod(s.ServiceName, method, time.Since(start))
+		span.End()
+	}
 }
 
 // Calculate number of pages in the query based on total items and page size
@@ -55,13 +92,143 @@ func (s *BaseService) calcPages(total int64, size int) int {
 	return int(math.Round(rem)) + last
 }
 
-// Save user action to audit log
-func (s *BaseService) auditLog(td *TokenData, entity Entity, action string, before, after any) {
+// Execute the find query, if fields are provided the results are projected to the sparse fieldset (list of json paths)
+// The projection is pushed down to the database when supported, otherwise it is applied on the query results. The hidden
+// fields (e.g. cleared by the query callback, which is not applied on push-down) are removed from the fieldset
+func (s *BaseService) find(query IQuery, fields []string, hidden ...string) (entities []Entity, total int64, err error) {
 
-	if td == nil || entity == nil {
+	if len(fields) == 0 {
+		return query.Find()
+	}
+	if fields = visibleFields(fields, hidden); len(fields) == 0 {
+		fields = []string{"id"}
+	}
+
+	if expressions, ok := common.SelectExpressions(fields); ok {
+		return s.selectFields(query, fields, expressions)
+	}
+
+	if entities, total, err = query.Find(); err == nil {
+		entities = meta.ProjectEntities(entities, fields)
+	}
+	return
+}
+
+// Select only the provided fields from the database and convert the rows to entity projections
+func (s *BaseService) selectFields(query IQuery, fields, expressions []string) (entities []Entity, total int64, err error) {
+
+	if total, err = query.Count(); err != nil {
 		return
 	}
 
+	var rows []Json
+	if rows, err = query.Select(append([]string{`id as "id"`}, expressions...)...); err != nil {
+		return
+	}
+
+	entities = make([]Entity, 0, len(rows))
+	for _, row := range rows {
+		projection := meta.NewProjection(rawString(row["id"]))
+		for _, field := range fields {
+			var value any
+			if raw := rawString(row[field]); len(raw) > 0 {
+				_ = json.Unmarshal([]byte(raw), &value)
+			}
+			projection.Set(field, value)
+		}
+		entities = append(entities, projection)
+	}
+	return
+}
+
+// Remove the hidden fields and their nested fields from the fieldset
+func visibleFields(fields, hidden []string) []string {
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		visible := true
+		for _, h := range hidden {
+			if field == h || strings.HasPrefix(field, h+".") {
+				visible = false
+				break
+			}
+		}
+		if visible {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// Get the string value of a selected database column
+func rawString(value any) string {
+	switch v := value.(type) {
+	case *string:
+		if v != nil {
+			return *v
+		}
+	case string:
+		return v
+	}
+	return ""
+}
+
+// Iterate over all the query results page by page and pass each entity to the callback, stops on the first error
+// The find function should return the requested page of the query results
+func (s *BaseService) iterate(find func(page, size int) ([]Entity, error), cb func(Entity) error) error {
+	for page := 1; ; page++ {
+		list, err := find(page, iteratePageSize)
+		if err != nil {
+			return err
+		}
+		for _, ent := range list {
+			if err = cb(ent); err != nil {
+				return err
+			}
+		}
+		if len(list) < iteratePageSize {
+			return nil
+		}
+	}
+}
+
+// Commit the entity change transaction with the audit log entry of the user action and the outbox entries of the entity
+// change event and the domain events (transactional outbox), the events are relayed after commit
+func (s *BaseService) commitChange(td *TokenData, tx common.Transaction, entity Entity, action string, before, after any, events ...DomainEvent) error {
+	return s.commitChangeWithProps(td, tx, entity, action, before, after, nil, events...)
+}
+
+// Commit the entity change transaction with the audit log entry including additional properties (e.g. importId to group
+// the entries of a bulk action) and the outbox entries of the entity change event and the domain events
+func (s *BaseService) commitChangeWithProps(td *TokenData, tx common.Transaction, entity Entity, action string, before, after any, props Json, events ...DomainEvent) error {
+
+	if td == nil || entity == nil {
+		return tx.Commit()
+	}
+
+	tx.Insert(s.newAuditLog(td, entity, action, before, after, props))
+
+	entry, err := s.newEntityEventEntry(td, entity, action, after)
+	if err != nil {
+		return err
+	}
+	tx.Insert(entry)
+
+	for _, event := range events {
+		if entry, err = s.newDomainEventEntry(td, event); err != nil {
+			return err
+		}
+		tx.Insert(entry)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	GetOutboxRelay(common.GetServiceHub()).notify()
+	return nil
+}
+
+// Create the audit log entry of the user action
+func (s *BaseService) newAuditLog(td *TokenData, entity Entity, action string, before, after any, props Json) Entity {
 	log := NewAuditLog()
 	log.(*AuditLog).Id = IDN()
 	log.(*AuditLog).UserId = td.SubjectId
@@ -70,12 +237,61 @@ func (s *BaseService) auditLog(td *TokenData, entity Entity, action string, befo
 	log.(*AuditLog).ItemType = entity.TABLE()
 	log.(*AuditLog).ItemId = entity.ID()
 	log.(*AuditLog).ItemName = entity.NAME()
+	log.(*AuditLog).RequestId = td.RequestId
 
 	log.(*AuditLog).BeforeChange = s.serializeChanges(before)
 	log.(*AuditLog).AfterChange = s.serializeChanges(after)
+	for key, value := range props {
+		log.(*AuditLog).Props[key] = value
+	}
+	return log
+}
+
+// Create the outbox entry of the entity change event (streamed by the events endpoint and posted to the webhooks)
+func (s *BaseService) newEntityEventEntry(td *TokenData, entity Entity, action string, after any) (Entity, error) {
+	event := &EntityEvent{
+		Action:    action,
+		ItemType:  entity.TABLE(),
+		ItemId:    entity.ID(),
+		ItemName:  entity.NAME(),
+		AccountId: entityAccountId(entity),
+		UserId:    td.SubjectId,
+		RequestId: td.RequestId,
+		Timestamp: Now(),
+	}
+	if after != nil {
+		item := Json{}
+		if err := json.Unmarshal([]byte(s.serializeChanges(after)), &item); err == nil {
+			event.Item = item
+		}
+	}
+	return newOutboxEntry(td, common.EntityEventTopic(event.ItemType), event)
+}
+
+// Get the account of account scoped entity: the account itself, the account of contact or webhook (empty for others)
+func entityAccountId(entity Entity) string {
+	switch ent := entity.(type) {
+	case *Account:
+		return ent.Id
+	case *Contact:
+		return ent.AccountId
+	case *Webhook:
+		return ent.AccountId
+	default:
+		return ""
+	}
+}
+
+// Create the outbox entry of the domain event (published to the message bus), the event metadata is set from the token
+// data
+func (s *BaseService) newDomainEventEntry(td *TokenData, event DomainEvent) (Entity, error) {
+	m := event.Meta()
+	m.EventId = NanoID()
+	m.SubjectId = td.SubjectId
+	m.RequestId = td.RequestId
+	m.Timestamp = Now()
 
-	// Save log entry to the database
-	_, _ = common.GetServiceHub().Database.Insert(log)
+	return newOutboxEntry(td, common.DomainEventTopic(event), event)
 }
 
 func (s *BaseService) serializeChanges(changes interface{}) (changesJson string) {
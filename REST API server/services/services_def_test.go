@@ -0,0 +1,80 @@
+package services
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+)
+
+var servicesTestHubOnce sync.Once
+var servicesTestHub *ServiceHub
+
+// Get the service hub of the services singletons (bound to the first hub) with the database schema, the tables are
+// emptied for each test
+func newServicesTestHub(t *testing.T) *ServiceHub {
+	t.Helper()
+	servicesTestHubOnce.Do(func() {
+		servicesTestHub = NewServiceHub()
+		ddl := map[string][]string{
+			"audit_log": {"createdOn", "userId", "action", "itemType", "itemId"},
+			"contact":   {"name", "status", "flag"},
+			"outbox":    {"topic", "status", "nextAttempt", "createdOn"},
+		}
+		if err := servicesTestHub.Database.ExecuteDDL(ddl); err != nil {
+			t.Fatal(err)
+		}
+	})
+	for _, factory := range []EntityFactory{NewAuditLog, NewContact, NewOutboxEntry} {
+		if _, err := servicesTestHub.Database.Query(factory).Delete(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return servicesTestHub
+}
+
+func TestVisibleFields(t *testing.T) {
+	tests := []struct {
+		fields   []string
+		expected []string
+	}{
+		{[]string{"name", "props"}, []string{"name"}},
+		{[]string{"name", "props.key", "propsCount"}, []string{"name", "propsCount"}},
+		{[]string{"props"}, []string{}},
+	}
+	for _, tt := range tests {
+		if got := visibleFields(tt.fields, []string{"props"}); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%v: expected %v but got %v", tt.fields, tt.expected, got)
+		}
+	}
+}
+
+func TestFindHiddenFields(t *testing.T) {
+	hub := newServicesTestHub(t)
+	contact := NewContact().(*Contact)
+	contact.Id, contact.AccountId, contact.Name, contact.Props = "hidden-c1", "hidden", "John", Json{"internal": "value"}
+	if _, err := hub.Database.Insert(contact); err != nil {
+		t.Fatal(err)
+	}
+
+	list, total, _, err := GetContactsService(hub).Find(&TokenData{}, ContactsFindParams{Search: "hidden-c1", Page: 1, Size: 10, Fields: []string{"name", "props"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(list) != 1 {
+		t.Fatalf("expected 1 contact but got %d", total)
+	}
+	projection, ok := list[0].(Projection)
+	if !ok {
+		t.Fatalf("expected projection but got %T", list[0])
+	}
+	if _, found := projection["props"]; found || projection["name"] != "John" || projection.ID() != "hidden-c1" {
+		t.Errorf("unexpected projection: %v", projection)
+	}
+}
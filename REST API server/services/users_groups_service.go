@@ -8,6 +8,7 @@ import (
 	. "github.com/go-yaaf/yaaf-common/entity"
 
 	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/filter"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/utils"
@@ -33,6 +34,8 @@ func GetGroupsService(sh *ServiceHub) *GroupsService {
 
 // Create new group in the system
 func (s *GroupsService) Create(td *TokenData, entity Entity) (Entity, error) {
+	td, end := s.observe(td, "Create")
+	defer end()
 
 	ent := entity.(*UsersGroup)
 
@@ -46,23 +49,25 @@ func (s *GroupsService) Create(td *TokenData, entity Entity) (Entity, error) {
 
 	ent.Members = nil
 
-	if updated, er := s.sh.Database.Insert(ent); er != nil {
-		return nil, s.serviceError("Create", er)
+	tx := s.sh.Transaction(td.Context()).Insert(ent)
+	if er := s.commitChange(td, tx, ent, actionCreate, nil, ent); er != nil {
+		return nil, s.serviceError(td, "Create", er)
 	} else {
-		s.auditLog(td, ent, actionCreate, nil, updated)
-		return updated, nil
+		return ent, nil
 	}
 }
 
 // Update existing group in the system
 func (s *GroupsService) Update(td *TokenData, entity Entity) (Entity, error) {
+	td, end := s.observe(td, "Update")
+	defer end()
 
 	ent := entity.(*UsersGroup)
 
 	// Get existing group
-	existing, err := s.sh.Database.Get(NewUsersGroup, ent.Id)
+	existing, err := s.sh.DatabaseContext(td.Context()).Get(NewUsersGroup, ent.Id)
 	if err != nil {
-		return nil, s.serviceError("Update", err)
+		return nil, s.serviceError(td, "Update", err)
 	}
 
 	// Override system fields,
@@ -70,62 +75,93 @@ func (s *GroupsService) Update(td *TokenData, entity Entity) (Entity, error) {
 	ent.UpdatedOn = Now()
 	ent.Props = nil
 
-	if updated, er := s.sh.Database.Update(ent); er != nil {
-		return nil, s.serviceError("Update", er)
+	tx := s.sh.Transaction(td.Context()).Update(ent)
+	if er := s.commitChange(td, tx, ent, actionUpdate, existing, ent); er != nil {
+		return nil, s.serviceError(td, "Update", er)
 	} else {
-		s.auditLog(td, ent, actionUpdate, existing, updated)
-		return updated, nil
+		return ent, nil
 	}
 }
 
 // Delete group
 func (s *GroupsService) Delete(td *TokenData, id string) (err error) {
+	td, end := s.observe(td, "Delete")
+	defer end()
 
 	// Get existing group
 	var existing Entity
-	if existing, err = s.sh.Database.Get(NewUsersGroup, id); err != nil {
-		return s.serviceError("Delete", err)
+	if existing, err = s.sh.DatabaseContext(td.Context()).Get(NewUsersGroup, id); err != nil {
+		return s.serviceError(td, "Delete", err)
 	}
 
-	if err = s.sh.Database.Delete(NewUsersGroup, id); err != nil {
-		return s.serviceError("Delete", err)
+	tx := s.sh.Transaction(td.Context()).Delete(existing)
+	if err = s.commitChange(td, tx, existing, actionDelete, existing, nil); err != nil {
+		return s.serviceError(td, "Delete", err)
 	} else {
-		s.auditLog(td, existing, actionDelete, existing, nil)
 		return nil
 	}
 }
 
 // Get single group by id
 func (s *GroupsService) Get(td *TokenData, id string) (Entity, error) {
-	if ent, err := s.sh.Database.Get(NewUsersGroup, id); err != nil {
+	td, end := s.observe(td, "Get")
+	defer end()
+	if ent, err := s.sh.DatabaseContext(td.Context()).Get(NewUsersGroup, id); err != nil {
 		return nil, fmt.Errorf("[%s]::Get: %v", s.ServiceName, err)
 	} else {
 		return ent, nil
 	}
 }
 
+// List gets the groups by IDs (missing groups are ignored), used to batch load related entities
+func (s *GroupsService) List(td *TokenData, ids []string) ([]Entity, error) {
+	td, end := s.observe(td, "List")
+	defer end()
+	if list, err := s.sh.DatabaseContext(td.Context()).List(NewUsersGroup, ids); err != nil {
+		return nil, fmt.Errorf("[%s]::List: %v", s.ServiceName, err)
+	} else {
+		return list, nil
+	}
+}
+
 // GroupsFindParams Query params aggregator for find commands service
 type GroupsFindParams struct {
-	Search string // Filter by free text search (using * wildcard)
-	Sort   string // Sort descriptor (field name with suffix +/- for sort order)
-	Page   int    // Page number for pagination
-	Size   int    // Page size: number of items per page
+	Search string           // Filter by free text search (using * wildcard)
+	Sort   string           // Sort descriptor (field name with suffix +/- for sort order)
+	Page   int              // Page number for pagination
+	Size   int              // Page size: number of items per page
+	Fields []string         // Sparse fieldset: list of fields (json paths) to include in the results
+	Filter *filter.Criteria // Filter expression (parsed filter query language)
 }
 
 // Find list of groups by filter
-func (s *GroupsService) Find(p GroupsFindParams) (entities []Entity, total int64, pages int, error error) {
-	if entities, total, error = s.sh.Database.Query(NewUsersGroup).
+func (s *GroupsService) Find(td *TokenData, p GroupsFindParams) (entities []Entity, total int64, pages int, error error) {
+	td, end := s.observe(td, "Find")
+	defer end()
+	query := p.Filter.Apply(s.sh.DatabaseContext(td.Context()).Query(NewUsersGroup)).
 		MatchAny(
 			F("id").Like(p.Search),
 			F("name").Like(p.Search),
 		).
 		Page(p.Page).
 		Limit(p.Size).
-		Sort(p.Sort).
-		Find(); error == nil {
+		Sort(p.Sort)
+
+	if entities, total, error = s.find(query, p.Fields); error == nil {
 		pages = s.calcPages(total, p.Size)
 	} else {
-		error = s.serviceError("Find", error)
+		error = s.serviceError(td, "Find", error)
 	}
 	return
 }
+
+// Export iterates over all the groups matching the query (regardless of the pagination) and passes them to the callback
+func (s *GroupsService) Export(td *TokenData, p GroupsFindParams, cb func(Entity) error) error {
+	td, end := s.observe(td, "Export")
+	defer end()
+	return s.iterate(func(page, size int) ([]Entity, error) {
+		p.Page, p.Size = page, size
+		list, _, _, err := s.Find(td, p)
+		return list, err
+	}, cb)
+}
@@ -9,9 +9,11 @@ import (
 	. "github.com/go-yaaf/yaaf-common/entity"
 
 	"github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/filter"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/events"
 	. "github.com/go-yaaf/yaaf-examples/rest-api/utils"
 )
 
@@ -38,13 +40,15 @@ func GetUsersService(sh *common.ServiceHub) *UsersService {
 
 // Create new user in the system
 func (s *UsersService) Create(td *TokenData, entity Entity) (Entity, error) {
+	td, end := s.observe(td, "Create")
+	defer end()
 
 	ent := entity.(*User)
 
 	if StringUtils().IsValidEmail(ent.Email) {
 		ent.Id = ent.Email
 	} else {
-		return nil, s.serviceError("Create", errors.New("not a valid email"))
+		return nil, s.serviceError(td, "Create", errors.New("not a valid email"))
 	}
 
 	// Override system fields,
@@ -55,23 +59,25 @@ func (s *UsersService) Create(td *TokenData, entity Entity) (Entity, error) {
 	ent.UpdatedOn = Now()
 	ent.Props = nil
 
-	if updated, er := s.sh.Database.Insert(ent); er != nil {
-		return nil, s.serviceError("Create", er)
+	tx := s.sh.Transaction(td.Context()).Insert(ent)
+	if er := s.commitChange(td, tx, ent, actionCreate, nil, ent, &UserCreated{UserId: ent.Id, Email: ent.Email, Name: ent.Name, Type: ent.Type}); er != nil {
+		return nil, s.serviceError(td, "Create", er)
 	} else {
-		s.auditLog(td, ent, actionCreate, nil, updated)
-		return updated, nil
+		return ent, nil
 	}
 }
 
 // Update existing user in the system
 func (s *UsersService) Update(td *TokenData, entity Entity) (Entity, error) {
+	td, end := s.observe(td, "Update")
+	defer end()
 
 	ent := entity.(*User)
 
 	// Get existing account
-	existing, err := s.sh.Database.Get(NewUser, ent.Id)
+	existing, err := s.sh.DatabaseContext(td.Context()).Get(NewUser, ent.Id)
 	if err != nil {
-		return nil, s.serviceError("Update", err)
+		return nil, s.serviceError(td, "Update", err)
 	}
 
 	// Override system fields,
@@ -79,37 +85,44 @@ func (s *UsersService) Update(td *TokenData, entity Entity) (Entity, error) {
 	ent.UpdatedOn = Now()
 	ent.Props = nil
 
-	if updated, er := s.sh.Database.Update(ent); er != nil {
-		return nil, s.serviceError("Update", er)
+	var events []DomainEvent
+	if from := existing.(*User).Status; from != ent.Status {
+		events = append(events, &UserStatusChanged{UserId: ent.Id, From: from, To: ent.Status})
+	}
+
+	tx := s.sh.Transaction(td.Context()).Update(ent)
+	if er := s.commitChange(td, tx, ent, actionUpdate, existing, ent, events...); er != nil {
+		return nil, s.serviceError(td, "Update", er)
 	} else {
-		s.auditLog(td, ent, actionUpdate, existing, updated)
-		return updated, nil
+		return ent, nil
 	}
 }
 
 // Delete user
 func (s *UsersService) Delete(td *TokenData, id string) (err error) {
+	td, end := s.observe(td, "Delete")
+	defer end()
 
 	// Get existing member
 	var existing Entity
-	if existing, err = s.sh.Database.Get(NewUser, id); err != nil {
-		return s.serviceError("Delete", err)
+	if existing, err = s.sh.DatabaseContext(td.Context()).Get(NewUser, id); err != nil {
+		return s.serviceError(td, "Delete", err)
 	}
 
 	if existing.(*User).Flag < 0 {
-		if err = s.sh.Database.Delete(NewUser, id); err != nil {
-			return s.serviceError("Delete", err)
+		tx := s.sh.Transaction(td.Context()).Delete(existing)
+		if err = s.commitChange(td, tx, existing, actionDelete, existing, nil); err != nil {
+			return s.serviceError(td, "Delete", err)
 		} else {
-			s.auditLog(td, existing, actionDelete, existing, nil)
 			return nil
 		}
 	} else {
 		existing.(*User).Flag = -1
 
-		if _, err = s.sh.Database.Update(existing); err != nil {
-			return s.serviceError("Delete", err)
+		tx := s.sh.Transaction(td.Context()).Update(existing)
+		if err = s.commitChange(td, tx, existing, actionDelete, existing, nil, &UserDeleted{UserId: id}); err != nil {
+			return s.serviceError(td, "Delete", err)
 		} else {
-			s.auditLog(td, existing, actionDelete, existing, nil)
 			return nil
 		}
 	}
@@ -117,46 +130,63 @@ func (s *UsersService) Delete(td *TokenData, id string) (err error) {
 
 // Get a single user by id
 func (s *UsersService) Get(td *TokenData, id string) (Entity, error) {
-	if ent, err := s.sh.Database.Get(NewUser, id); err != nil {
+	td, end := s.observe(td, "Get")
+	defer end()
+	if ent, err := s.sh.DatabaseContext(td.Context()).Get(NewUser, id); err != nil {
 		return nil, fmt.Errorf("[%s]::Get: %v", s.ServiceName, err)
 	} else {
 		return ent, nil
 	}
 }
 
+// List gets the users by IDs (missing users are ignored), used to batch load related entities
+func (s *UsersService) List(td *TokenData, ids []string) ([]Entity, error) {
+	td, end := s.observe(td, "List")
+	defer end()
+	if list, err := s.sh.DatabaseContext(td.Context()).List(NewUser, ids); err != nil {
+		return nil, fmt.Errorf("[%s]::List: %v", s.ServiceName, err)
+	} else {
+		return list, nil
+	}
+}
+
 // GetBtEmail get single user by email
-func (s *UsersService) GetBtEmail(email string) (Entity, error) {
-	if ent, err := s.sh.Database.Query(NewUser).
+func (s *UsersService) GetBtEmail(td *TokenData, email string) (Entity, error) {
+	td, end := s.observe(td, "GetBtEmail")
+	defer end()
+	if ent, err := s.sh.DatabaseContext(td.Context()).Query(NewUser).
 		Filter(F("email").Eq(email)).
 		FindSingle(); err != nil {
-		return nil, s.serviceError("GetBtEmail", err)
+		return nil, s.serviceError(td, "GetBtEmail", err)
 	} else {
 		return ent, err
 	}
 }
 
 // Authorize get a single user by email, get the member of account and create JWT token
-func (s *UsersService) Authorize(email string) (user Entity, token string, error error) {
+func (s *UsersService) Authorize(td *TokenData, email string) (user Entity, token string, error error) {
+	td, end := s.observe(td, "Authorize")
+	defer end()
 	// Get user by email
-	user, error = s.sh.Database.Query(NewUser).Filter(F("email").Eq(email)).FindSingle()
+	user, error = s.sh.DatabaseContext(td.Context()).Query(NewUser).Filter(F("email").Eq(email)).FindSingle()
 	if error != nil {
-		return nil, "", s.serviceError("Authorize", error)
+		return nil, "", s.serviceError(td, "Authorize", error)
 	}
 
 	if user.(*User).Status != UserStatusCodes.ACTIVE {
-		return nil, "", s.serviceError("Authorize", fmt.Errorf("not authorized"))
+		return nil, "", s.serviceError(td, "Authorize", fmt.Errorf("not authorized"))
 	}
 
 	// Update last sign-in
 	user.(*User).LastSignIn = Now()
-	_, _ = s.sh.Database.Update(user)
+	_, _ = s.sh.DatabaseContext(td.Context()).Update(user)
 
 	// if user is a sysadmin, return default account
 	if user.(*User).Type == UserTypeCodes.SYSADMIN {
-		token, error = s.createTokenForSysAdmin(user)
+		token, error = s.createTokenForSysAdmin(td, user)
 		user.(*User).Roles = UserRoleFlags.ALL
 	} else {
-		token, error = s.createTokenForUser(user)
+		token, error = s.createTokenForUser(td, user)
 	}
 	return
 }
@@ -169,11 +199,15 @@ type UsersFindParams struct {
 	Sort   string           // Sort descriptor (field name with suffix +/- for sort order)
 	Page   int              // Page number for pagination
 	Size   int              // Page size: number of items per page
+	Fields []string         // Sparse fieldset: list of fields (json paths) to include in the results
+	Filter *filter.Criteria // Filter expression (parsed filter query language)
 }
 
 // Find a list of members by filter
-func (s *UsersService) Find(p UsersFindParams) (entities []Entity, total int64, pages int, error error) {
-	if entities, total, error = s.sh.Database.Query(NewUser).
+func (s *UsersService) Find(td *TokenData, p UsersFindParams) (entities []Entity, total int64, pages int, error error) {
+	td, end := s.observe(td, "Find")
+	defer end()
+	query := p.Filter.Apply(s.sh.DatabaseContext(td.Context()).Query(NewUser)).
 		MatchAny(
 			F("id").Eq(p.Search),
 			F("name").Like(p.Search),
@@ -186,34 +220,46 @@ func (s *UsersService) Find(p UsersFindParams) (entities []Entity, total int64,
 		).
 		Page(p.Page).
 		Limit(p.Size).
-		Sort(p.Sort).
-		Find(); error == nil {
+		Sort(p.Sort)
+
+	if entities, total, error = s.find(query, p.Fields); error == nil {
 		pages = s.calcPages(total, p.Size)
 	} else {
-		error = s.serviceError("Find", error)
+		error = s.serviceError(td, "Find", error)
 	}
 	return
 }
 
+// Export iterates over all the users matching the query (regardless of the pagination) and passes them to the callback
+func (s *UsersService) Export(td *TokenData, p UsersFindParams, cb func(Entity) error) error {
+	td, end := s.observe(td, "Export")
+	defer end()
+	return s.iterate(func(page, size int) ([]Entity, error) {
+		p.Page, p.Size = page, size
+		list, _, _, err := s.Find(td, p)
+		return list, err
+	}, cb)
+}
+
 // Create token for sys admin
-func (s *UsersService) createTokenForSysAdmin(user Entity) (string, error) {
-	td := &TokenData{
+func (s *UsersService) createTokenForSysAdmin(td *TokenData, user Entity) (string, error) {
+	claims := &TokenData{
 		SubjectId:   user.ID(),
 		SubjectType: UserTypeCodes.SYSADMIN,
 		Status:      user.(*User).Status,
 		ExpiresIn:   int64(Now() + 1000*60*30),
 	}
 	// Update default account
-	if token, err := TokenUtils().CreateToken(td); err != nil {
-		return "", s.serviceError("createTokenForSysAdmin", err)
+	if token, err := TokenUtils().CreateToken(claims); err != nil {
+		return "", s.serviceError(td, "createTokenForSysAdmin", err)
 	} else {
 		return token, nil
 	}
 }
 
 // Create token for user
-func (s *UsersService) createTokenForUser(user Entity) (string, error) {
-	td := &TokenData{
+func (s *UsersService) createTokenForUser(td *TokenData, user Entity) (string, error) {
+	claims := &TokenData{
 		SubjectId:   user.ID(),
 		SubjectType: UserTypeCodes.SYSADMIN,
 		Status:      user.(*User).Status,
@@ -221,8 +267,8 @@ func (s *UsersService) createTokenForUser(user Entity) (string, error) {
 	}
 
 	// Update default account
-	if token, err := TokenUtils().CreateToken(td); err != nil {
-		return "", s.serviceError("createTokenForUser", err)
+	if token, err := TokenUtils().CreateToken(claims); err != nil {
+		return "", s.serviceError(td, "createTokenForUser", err)
 	} else {
 		return token, nil
 	}
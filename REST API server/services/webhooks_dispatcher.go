@@ -0,0 +1,449 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+	"github.com/go-yaaf/yaaf-common/logger"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+// Webhook request headers
+const (
+	WebhookIdHeader        = "X-Webhook-Id"        // Delivery ID (the same in all the attempts)
+	WebhookEventHeader     = "X-Webhook-Event"     // Event name (e.g. contact.Create)
+	WebhookTimestampHeader = "X-Webhook-Timestamp" // Attempt time (epoch seconds), part of the signed content
+	WebhookSignatureHeader = "X-Webhook-Signature" // Payload signature: sha256=<hex HMAC-SHA256 of "timestamp.payload">
+)
+
+// Item types of the entity change events sent to the webhooks
+var webhookItemTypes = map[string]bool{"account": true, "contact": true, "user": true, "users_group": true}
+
+// Number of due deliveries attempted in each dispatcher round
+const webhookBatchSize = 100
+
+// Maximal size of the receiver response body that is read (the body is ignored)
+const webhookMaxResponse = 64 * 1024
+
+// region Dispatcher configuration -------------------------------------------------------------------------------------
+
+// WebhookConfig is the webhooks dispatcher configuration
+type WebhookConfig struct {
+	MaxAttempts  int           // Number of delivery attempts before the delivery is dead-lettered (FAILED)
+	Backoff      time.Duration // Delay before the first retry, doubled on each retry
+	MaxBackoff   time.Duration // Maximal delay between retries
+	Timeout      time.Duration // Timeout of the delivery request
+	PollInterval time.Duration // Interval of checking the due deliveries
+	AllowPrivate bool          // Allow delivery to loopback, link-local and private addresses (otherwise rejected on dial)
+}
+
+// NewWebhookConfig creates the dispatcher configuration from the service configuration
+func NewWebhookConfig(cfg *config.ServiceConfig) WebhookConfig {
+	return WebhookConfig{
+		MaxAttempts:  cfg.WebhookMaxAttempts(),
+		Backoff:      cfg.WebhookBackoff(),
+		MaxBackoff:   cfg.WebhookBackoffMax(),
+		Timeout:      cfg.WebhookTimeout(),
+		PollInterval: cfg.WebhookPollInterval(),
+		AllowPrivate: cfg.WebhookAllowPrivate(),
+	}
+}
+
+// endregion
+
+// region Dispatcher structure and factory method ----------------------------------------------------------------------
+
+var webhookDispatcherOnce sync.Once
+var webhookDispatcherInst *WebhookDispatcher = nil
+
+// WebhookDispatcher posts the entity change events to the subscribed webhooks: the outbox relay enqueues a delivery
+// for each matching webhook (saved in the database) and the dispatcher loop attempts the due deliveries, failed
+// attempts are retried with exponential backoff until the delivery is dead-lettered. The deliveries are not claimed, so
+// the dispatcher runs with the relay on a single service instance (OUTBOX_RELAY)
+type WebhookDispatcher struct {
+	BaseService
+	sh     *ServiceHub
+	config WebhookConfig
+	client *http.Client
+	wake   chan struct{} // Signal the loop to process the due deliveries (new delivery)
+
+	mu     sync.Mutex
+	cancel context.CancelFunc // Stops the loop (nil when not started)
+	done   chan struct{}      // Closed when the loop is stopped
+}
+
+// GetWebhookDispatcher factory function (configured by the service configuration)
+func GetWebhookDispatcher(sh *ServiceHub) *WebhookDispatcher {
+	webhookDispatcherOnce.Do(func() {
+		if webhookDispatcherInst == nil {
+			webhookDispatcherInst = NewWebhookDispatcher(sh, NewWebhookConfig(config.GetConfig()))
+		}
+	})
+	return webhookDispatcherInst
+}
+
+// NewWebhookDispatcher creates new dispatcher (e.g. with short backoff to test against local receiver), the outbox
+// relay enqueues the deliveries by the dispatcher of GetWebhookDispatcher
+func NewWebhookDispatcher(sh *ServiceHub, cfg WebhookConfig) *WebhookDispatcher {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	return &WebhookDispatcher{
+		BaseService: BaseService{ServiceName: "WebhookDispatcher"},
+		sh:          sh,
+		config:      cfg,
+		client:      newWebhookClient(cfg),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Create the HTTP client of the delivery requests, unless private addresses are allowed the dialer rejects connection to
+// not public address (checked on every connection, including redirects) and the environment proxy is not used
+func newWebhookClient(cfg WebhookConfig) *http.Client {
+	if cfg.AllowPrivate {
+		return &http.Client{Timeout: cfg.Timeout}
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: webhookDialControl}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: cfg.Timeout, Transport: transport}
+}
+
+// WithClient sets the HTTP client of the delivery requests (e.g. the client of httptest server)
+func (d *WebhookDispatcher) WithClient(client *http.Client) *WebhookDispatcher {
+	d.client = client
+	return d
+}
+
+// endregion
+
+// region Dispatcher loop ----------------------------------------------------------------------------------------------
+
+// Start the dispatcher loop, the due deliveries are processed on every poll interval and when a delivery is enqueued
+func (d *WebhookDispatcher) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel, d.done = cancel, make(chan struct{})
+	go d.run(ctx, d.done)
+}
+
+// Stop the dispatcher loop and wait for the current round to end (up to the context deadline), the interrupted
+// deliveries are attempted again after restart
+func (d *WebhookDispatcher) Stop(ctx context.Context) error {
+	d.mu.Lock()
+	cancel, done := d.cancel, d.done
+	d.cancel = nil
+	d.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *WebhookDispatcher) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+		if _, err := d.ProcessDue(ctx); err != nil {
+			logger.Warn("[%s:ProcessDue]: %s", d.ServiceName, err.Error())
+		}
+	}
+}
+
+// Signal the loop to process the due deliveries
+func (d *WebhookDispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// endregion
+
+// region Dispatcher methods -------------------------------------------------------------------------------------------
+
+// Enqueue creates the deliveries of the entity change event to the matching active webhooks (read page by page), the
+// deliveries are attempted by the dispatcher loop (called by the outbox relay for the entity change events). The
+// delivery ID is derived from the source ID (outbox entry ID) and the webhook ID, so enqueue of the same event again
+// (also concurrently) skips the existing deliveries
+func (d *WebhookDispatcher) Enqueue(ctx context.Context, sourceId string, event *EntityEvent) error {
+	if !webhookItemTypes[event.ItemType] {
+		return nil
+	}
+
+	db := d.sh.DatabaseContext(ctx)
+	activeWebhooks := func(page, size int) ([]Entity, error) {
+		list, _, err := db.Query(NewWebhook).
+			MatchAll(F("active").IsTrue(), F("flag").Gte(0)).
+			Sort("id").
+			Page(page).
+			Limit(size).
+			Find()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get webhooks: %v", err)
+		}
+		return list, nil
+	}
+
+	name := event.ItemType + "." + event.Action
+	enqueued := 0
+	err := d.iterate(activeWebhooks, func(ent Entity) error {
+		webhook := ent.(*Webhook)
+		if !webhookMatches(webhook, event) {
+			return nil
+		}
+
+		delivery := NewWebhookDelivery().(*WebhookDelivery)
+		delivery.Id = sourceId + "-" + webhook.Id
+		if exists, er := db.Exists(NewWebhookDelivery, delivery.Id); er != nil {
+			return fmt.Errorf("failed to check delivery %s: %v", delivery.Id, er)
+		} else if exists {
+			return nil
+		}
+
+		payload, er := json.Marshal(&WebhookPayload{Id: delivery.Id, WebhookId: webhook.Id, Event: name, Timestamp: event.Timestamp, Data: *event})
+		if er != nil {
+			return fmt.Errorf("failed to serialize payload: %v", er)
+		}
+		delivery.WebhookId = webhook.Id
+		delivery.Event = name
+		delivery.ItemId = event.ItemId
+		delivery.Url = webhook.Url
+		delivery.Payload = string(payload)
+		delivery.Status = DeliveryStatusCodes.PENDING
+		delivery.NextAttempt = Now()
+
+		if _, er = db.Insert(delivery); er != nil {
+			// Inserted by concurrent enqueue of the same event
+			if exists, _ := db.Exists(NewWebhookDelivery, delivery.Id); exists {
+				return nil
+			}
+			return fmt.Errorf("failed to save delivery %s: %v", delivery.Id, er)
+		}
+		enqueued++
+		return nil
+	})
+	if enqueued > 0 {
+		d.notify()
+	}
+	return err
+}
+
+// ProcessDue attempts the due deliveries (pending and retries), returns the number of attempts. A delivery that fails to
+// update is logged and the batch continues (the delivery is attempted again on the next round)
+func (d *WebhookDispatcher) ProcessDue(ctx context.Context) (int, error) {
+	list, _, err := d.sh.DatabaseContext(ctx).Query(NewWebhookDelivery).
+		MatchAll(
+			F("status").In(DeliveryStatusCodes.PENDING, DeliveryStatusCodes.RETRY),
+			F("nextAttempt").Lte(Now()),
+		).
+		Sort("nextAttempt").
+		Page(1).
+		Limit(webhookBatchSize).
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	attempts := 0
+	for _, ent := range list {
+		if ctx.Err() != nil {
+			break
+		}
+		attempts++
+		if _, er := d.Attempt(ctx, ent.(*WebhookDelivery)); er != nil {
+			logger.Warn("[%s:ProcessDue]: %s", d.ServiceName, er.Error())
+		}
+	}
+	return attempts, nil
+}
+
+// Attempt posts the delivery payload to the webhook and updates the delivery: DELIVERED on 2xx response, otherwise RETRY
+// with exponential backoff or FAILED (dead letter) after the maximal number of attempts. Delivery of deleted or inactive
+// webhook fails with no attempt
+func (d *WebhookDispatcher) Attempt(ctx context.Context, delivery *WebhookDelivery) (*WebhookDelivery, error) {
+	db := d.sh.DatabaseContext(ctx)
+
+	var webhook *Webhook
+	if ent, err := db.Get(NewWebhook, delivery.WebhookId); err == nil && ent.(*Webhook).Active && ent.(*Webhook).Flag >= 0 {
+		webhook = ent.(*Webhook)
+	}
+
+	statusCode, err := 0, fmt.Errorf("webhook is deleted or inactive")
+	if webhook != nil {
+		statusCode, err = d.post(ctx, webhook, delivery)
+		if err != nil && ctx.Err() != nil {
+			// Interrupted by shutdown, attempt again after restart
+			return delivery, nil
+		}
+	}
+
+	delivery.UpdatedOn = Now()
+	delivery.StatusCode = statusCode
+	if err == nil {
+		delivery.Attempts++
+		delivery.Status = DeliveryStatusCodes.DELIVERED
+		delivery.DeliveredOn = Now()
+		delivery.Error = ""
+	} else {
+		if webhook != nil {
+			delivery.Attempts++
+		}
+		delivery.Error = err.Error()
+		if webhook == nil || delivery.Attempts >= d.config.MaxAttempts {
+			delivery.Status = DeliveryStatusCodes.FAILED
+			logger.Warn("[%s:Attempt]: delivery %s of %s to webhook %s failed after %d attempts: %s", d.ServiceName, delivery.Id, delivery.Event, delivery.WebhookId, delivery.Attempts, delivery.Error)
+		} else {
+			delivery.Status = DeliveryStatusCodes.RETRY
+			delivery.NextAttempt = Timestamp(time.Now().Add(d.backoff(delivery.Attempts)).UnixMilli())
+		}
+	}
+
+	if _, er := db.Update(delivery); er != nil {
+		return delivery, fmt.Errorf("failed to update delivery %s: %v", delivery.Id, er)
+	}
+	return delivery, nil
+}
+
+// Post the signed payload to the webhook URL, returns the response status
+func (d *WebhookDispatcher) post(ctx context.Context, webhook *Webhook, delivery *WebhookDelivery) (int, error) {
+	if d.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.config.Timeout)
+		defer cancel()
+	}
+
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "rest-api-webhooks/"+d.sh.Version)
+	req.Header.Set(WebhookIdHeader, delivery.Id)
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, WebhookSignature(webhook.Secret, timestamp, payload))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = res.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, webhookMaxResponse))
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return res.StatusCode, fmt.Errorf("receiver responded with status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// Delay before the next attempt: the backoff is doubled on each failed attempt up to the maximal backoff
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	delay := d.config.Backoff
+	for i := 1; i < attempts && (d.config.MaxBackoff <= 0 || delay < d.config.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if d.config.MaxBackoff > 0 && delay > d.config.MaxBackoff {
+		delay = d.config.MaxBackoff
+	}
+	return delay
+}
+
+// endregion
+
+// region Helpers ------------------------------------------------------------------------------------------------------
+
+// WebhookSignature returns the payload signature (X-Webhook-Signature header): sha256=<hex HMAC-SHA256 of the timestamp
+// and the payload separated by dot, signed by the webhook secret>, the receiver verifies it by the same function
+func WebhookSignature(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Check if the webhook is subscribed to the event: by the events list (item type or item type and action, empty for
+// all) and by the account (the account itself and its contacts)
+func webhookMatches(webhook *Webhook, event *EntityEvent) bool {
+	if len(webhook.AccountId) > 0 {
+		if event.ItemType != "account" && event.ItemType != "contact" {
+			return false
+		}
+		if eventAccountId(event) != webhook.AccountId {
+			return false
+		}
+	}
+
+	if len(webhook.Events) == 0 {
+		return true
+	}
+	for _, name := range webhook.Events {
+		itemType, action, _ := strings.Cut(name, ".")
+		if strings.EqualFold(itemType, event.ItemType) && (len(action) == 0 || strings.EqualFold(action, event.Action)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the account of the event item, the events committed before the account ID was added to the event are matched by
+// the item (not available for delete)
+func eventAccountId(event *EntityEvent) string {
+	if len(event.AccountId) > 0 {
+		return event.AccountId
+	}
+	if event.ItemType == "account" {
+		return event.ItemId
+	}
+	accountId, _ := event.Item["accountId"].(string)
+	return accountId
+}
+
+// endregion
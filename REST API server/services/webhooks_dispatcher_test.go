@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+)
+
+const testWebhookBackoff = 50 * time.Millisecond
+
+// Received webhook request
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// Webhook receiver that responds with the configured statuses (in order, 200 when exhausted) and records the requests
+type testReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+func (r *testReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body})
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+// Create the service hub with webhook of the receiver URL (subscribed to the contacts of account a1)
+func newWebhookTestHub(t *testing.T, receiverUrl string) (*ServiceHub, *Webhook) {
+	t.Helper()
+	hub := NewServiceHub()
+	ddl := map[string][]string{"webhook": {"accountId", "active"}, "webhook_delivery": {"webhookId", "status", "nextAttempt"}}
+	if err := hub.Database.ExecuteDDL(ddl); err != nil {
+		t.Fatal(err)
+	}
+	webhook := NewWebhook().(*Webhook)
+	webhook.Id, webhook.Name, webhook.Url, webhook.Secret, webhook.Active = "w1", "test", receiverUrl, "s3cret", true
+	webhook.AccountId, webhook.Events = "a1", []string{"contact"}
+	if _, err := hub.Database.Insert(webhook); err != nil {
+		t.Fatal(err)
+	}
+	return hub, webhook
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	receiver := &testReceiver{statuses: []int{http.StatusServiceUnavailable}}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+
+	hub, webhook := newWebhookTestHub(t, ts.URL)
+	dispatcher := NewWebhookDispatcher(hub, WebhookConfig{MaxAttempts: 3, Backoff: testWebhookBackoff, Timeout: time.Second, AllowPrivate: true})
+	ctx := context.Background()
+
+	event := &EntityEvent{Action: "Create", ItemType: "contact", ItemId: "c1", AccountId: "a1", Timestamp: Now()}
+	if err := dispatcher.Enqueue(ctx, "src1", event); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	deliveryId := "src1-" + webhook.Id
+
+	// First attempt fails with 5xx and the delivery is retried after the backoff
+	if attempts, err := dispatcher.ProcessDue(ctx); err != nil || attempts != 1 {
+		t.Fatalf("expected 1 attempt but got %d: %v", attempts, err)
+	}
+	ent, err := hub.Database.Get(NewWebhookDelivery, deliveryId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delivery := ent.(*WebhookDelivery)
+	if delivery.Status != DeliveryStatusCodes.RETRY || delivery.Attempts != 1 || delivery.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("unexpected delivery after failure: status %v attempts %d code %d", delivery.Status, delivery.Attempts, delivery.StatusCode)
+	}
+	if backoff := time.Duration(delivery.NextAttempt-delivery.UpdatedOn) * time.Millisecond; backoff < testWebhookBackoff-time.Millisecond {
+		t.Errorf("expected backoff of %s but got %s", testWebhookBackoff, backoff)
+	}
+
+	// Not due before the backoff
+	if attempts, _ := dispatcher.ProcessDue(ctx); attempts != 0 {
+		t.Fatalf("expected no attempt before the backoff but got %d", attempts)
+	}
+
+	time.Sleep(testWebhookBackoff + 10*time.Millisecond)
+	if attempts, err := dispatcher.ProcessDue(ctx); err != nil || attempts != 1 {
+		t.Fatalf("expected 1 attempt but got %d: %v", attempts, err)
+	}
+
+	// Delivery history
+	list, total, err := hub.Database.Query(NewWebhookDelivery).MatchAll(F("webhookId").Eq(webhook.Id)).Find()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(list) != 1 {
+		t.Fatalf("expected 1 delivery but got %d", total)
+	}
+	delivery = list[0].(*WebhookDelivery)
+	if delivery.Status != DeliveryStatusCodes.DELIVERED || delivery.Attempts != 2 || delivery.StatusCode != http.StatusOK || delivery.DeliveredOn == 0 || len(delivery.Error) > 0 {
+		t.Errorf("unexpected delivered delivery: status %v attempts %d code %d error %q", delivery.Status, delivery.Attempts, delivery.StatusCode, delivery.Error)
+	}
+
+	// Both attempts are signed by the webhook secret with the same delivery ID
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	if len(receiver.requests) != 2 {
+		t.Fatalf("expected 2 requests but got %d", len(receiver.requests))
+	}
+	for i, req := range receiver.requests {
+		timestamp, err := strconv.ParseInt(req.header.Get(WebhookTimestampHeader), 10, 64)
+		if err != nil {
+			t.Fatalf("request %d: invalid timestamp: %v", i+1, err)
+		}
+		expected := WebhookSignature(webhook.Secret, timestamp, req.body)
+		if signature := req.header.Get(WebhookSignatureHeader); !hmac.Equal([]byte(signature), []byte(expected)) {
+			t.Errorf("request %d: invalid signature %q", i+1, signature)
+		}
+		if WebhookSignature("other", timestamp, req.body) == expected {
+			t.Errorf("request %d: signature does not depend on the secret", i+1)
+		}
+		if id := req.header.Get(WebhookIdHeader); id != deliveryId {
+			t.Errorf("request %d: expected delivery ID %s but got %s", i+1, deliveryId, id)
+		}
+		if name := req.header.Get(WebhookEventHeader); name != "contact.Create" {
+			t.Errorf("request %d: expected event contact.Create but got %s", i+1, name)
+		}
+
+		payload := WebhookPayload{}
+		if err = json.Unmarshal(req.body, &payload); err != nil {
+			t.Fatalf("request %d: invalid payload: %v", i+1, err)
+		}
+		if payload.Id != deliveryId || payload.Data.ItemId != "c1" {
+			t.Errorf("request %d: unexpected payload: %s", i+1, req.body)
+		}
+	}
+}
+
+func TestWebhookMatchesAccount(t *testing.T) {
+	webhook := &Webhook{AccountId: "a1", Events: []string{"contact"}}
+	tests := []struct {
+		name     string
+		event    *EntityEvent
+		expected bool
+	}{
+		{"create of account contact", &EntityEvent{Action: "Create", ItemType: "contact", AccountId: "a1", Item: Json{"accountId": "a1"}}, true},
+		{"delete of account contact", &EntityEvent{Action: "Delete", ItemType: "contact", AccountId: "a1"}, true},
+		{"contact of other account", &EntityEvent{Action: "Delete", ItemType: "contact", AccountId: "a2"}, false},
+		{"event without account", &EntityEvent{Action: "Update", ItemType: "contact", Item: Json{"accountId": "a1"}}, true},
+		{"not subscribed item type", &EntityEvent{Action: "Create", ItemType: "user", AccountId: "a1"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := webhookMatches(webhook, tt.event); got != tt.expected {
+				t.Errorf("expected %v but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWebhookUrlValidation(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"ftp://example.com/hook", false},
+		{"/relative", false},
+		{"http://127.0.0.1:8080/hook", false},
+		{"http://localhost/hook", false},
+		{"http://[::1]/hook", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://10.1.2.3/hook", false},
+		{"http://192.168.1.1/hook", false},
+		{"http://0.0.0.0/hook", false},
+		{"http://0.1.2.3/hook", false},
+		{"http://100.64.0.1/hook", false},
+		{"http://100.127.255.254/hook", false},
+		{"http://[::ffff:100.64.0.1]/hook", false},
+		{"http://100.128.0.1/hook", true},
+		{"https://8.8.8.8/hook", true},
+	}
+	for _, tt := range tests {
+		if err := validateWebhookUrl(tt.url, false); (err == nil) != tt.valid {
+			t.Errorf("%s: expected valid %v but got %v", tt.url, tt.valid, err)
+		}
+	}
+	if err := validateWebhookUrl("http://127.0.0.1:8080/hook", true); err != nil {
+		t.Errorf("expected private address to be allowed: %v", err)
+	}
+}
+
+func TestWebhookDialRejectsPrivateAddress(t *testing.T) {
+	receiver := &testReceiver{}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+
+	// The webhook was registered with public host that resolves now to loopback address
+	hub, _ := newWebhookTestHub(t, ts.URL)
+	dispatcher := NewWebhookDispatcher(hub, WebhookConfig{MaxAttempts: 1, Timeout: time.Second})
+	if err := dispatcher.Enqueue(context.Background(), "src2", &EntityEvent{Action: "Update", ItemType: "contact", AccountId: "a1"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if _, err := dispatcher.ProcessDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ent, err := hub.Database.Get(NewWebhookDelivery, "src2-w1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery := ent.(*WebhookDelivery); delivery.Status != DeliveryStatusCodes.FAILED || len(delivery.Error) == 0 {
+		t.Errorf("expected failed delivery but got status %v error %q", delivery.Status, delivery.Error)
+	}
+	if len(receiver.requests) != 0 {
+		t.Errorf("expected no request to the receiver but got %d", len(receiver.requests))
+	}
+}
@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+
+	. "github.com/go-yaaf/yaaf-common/database"
+	. "github.com/go-yaaf/yaaf-common/entity"
+
+	. "github.com/go-yaaf/yaaf-examples/rest-api/common"
+	"github.com/go-yaaf/yaaf-examples/rest-api/config"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/common"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/entities"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/model/enums"
+	. "github.com/go-yaaf/yaaf-examples/rest-api/utils"
+)
+
+// Timeout of resolving the webhook host on registration
+const webhookResolveTimeout = 5 * time.Second
+
+var webhooksServiceOnce sync.Once
+var webhooksServiceInst *WebhooksService = nil
+
+type WebhooksService struct {
+	BaseService
+	sh *ServiceHub // Service hub
+}
+
+// GetWebhooksService factory function
+func GetWebhooksService(sh *ServiceHub) *WebhooksService {
+	webhooksServiceOnce.Do(func() {
+		if webhooksServiceInst == nil {
+			webhooksServiceInst = &WebhooksService{BaseService: BaseService{ServiceName: "WebhooksService"}, sh: sh}
+		}
+	})
+	return webhooksServiceInst
+}
+
+// Create a new webhook, the secret is generated if not provided (returned only on create)
+func (s *WebhooksService) Create(td *TokenData, entity Entity) (Entity, error) {
+	td, end := s.observe(td, "Create")
+	defer end()
+
+	ent := entity.(*Webhook)
+	if err := validateWebhookUrl(ent.Url, config.GetConfig().WebhookAllowPrivate()); err != nil {
+		return nil, s.serviceError(td, "Create", err)
+	}
+
+	// Override system fields,
+	ent.Id = TokenUtils().GUID()
+	ent.CreatedOn = Now()
+	ent.UpdatedOn = Now()
+	ent.Props = nil
+	if len(ent.Secret) == 0 {
+		secret, err := TokenUtils().Secret()
+		if err != nil {
+			return nil, s.serviceError(td, "Create", err)
+		}
+		ent.Secret = secret
+	}
+
+	tx := s.sh.Transaction(td.Context()).Insert(ent)
+	if er := s.commitChange(td, tx, ent, actionCreate, nil, maskWebhook(ent)); er != nil {
+		return nil, s.serviceError(td, "Create", er)
+	} else {
+		return ent, nil
+	}
+}
+
+// Update existing webhook, the secret is kept if not provided
+func (s *WebhooksService) Update(td *TokenData, entity Entity) (Entity, error) {
+	td, end := s.observe(td, "Update")
+	defer end()
+
+	ent := entity.(*Webhook)
+	if err := validateWebhookUrl(ent.Url, config.GetConfig().WebhookAllowPrivate()); err != nil {
+		return nil, s.serviceError(td, "Update", err)
+	}
+
+	// Get existing webhook
+	existing, err := s.sh.DatabaseContext(td.Context()).Get(NewWebhook, ent.Id)
+	if err != nil {
+		return nil, s.serviceError(td, "Update", err)
+	}
+
+	// Override system fields,
+	ent.CreatedOn = existing.(*Webhook).CreatedOn
+	ent.UpdatedOn = Now()
+	ent.Props = nil
+	if len(ent.Secret) == 0 {
+		ent.Secret = existing.(*Webhook).Secret
+	}
+
+	tx := s.sh.Transaction(td.Context()).Update(ent)
+	if er := s.commitChange(td, tx, ent, actionUpdate, maskWebhook(existing), maskWebhook(ent)); er != nil {
+		return nil, s.serviceError(td, "Update", er)
+	} else {
+		return maskWebhook(ent), nil
+	}
+}
+
+// Delete webhook (the pending deliveries of deleted webhook fail)
+func (s *WebhooksService) Delete(td *TokenData, id string) (err error) {
+	td, end := s.observe(td, "Delete")
+	defer end()
+
+	// Get existing webhook
+	var existing Entity
+	if existing, err = s.sh.DatabaseContext(td.Context()).Get(NewWebhook, id); err != nil {
+		return s.serviceError(td, "Delete", err)
+	}
+
+	if existing.(*Webhook).Flag < 0 {
+		tx := s.sh.Transaction(td.Context()).Delete(existing)
+		if err = s.commitChange(td, tx, existing, actionDelete, maskWebhook(existing), nil); err != nil {
+			return s.serviceError(td, "Delete", err)
+		} else {
+			return nil
+		}
+	} else {
+		existing.(*Webhook).Flag = -1
+		existing.(*Webhook).Active = false
+
+		tx := s.sh.Transaction(td.Context()).Update(existing)
+		if err = s.commitChange(td, tx, existing, actionDelete, maskWebhook(existing), nil); err != nil {
+			return s.serviceError(td, "Delete", err)
+		} else {
+			return nil
+		}
+	}
+}
+
+// Get single webhook by id (without the secret)
+func (s *WebhooksService) Get(td *TokenData, id string) (Entity, error) {
+	td, end := s.observe(td, "Get")
+	defer end()
+
+	if ent, err := s.sh.DatabaseContext(td.Context()).Get(NewWebhook, id); err != nil {
+		return nil, fmt.Errorf("[%s]::Get: %v", s.ServiceName, err)
+	} else {
+		return maskWebhook(ent), nil
+	}
+}
+
+// WebhooksFindParams Query params aggregator for find commands service
+type WebhooksFindParams struct {
+	Search    string   // Filter by free text search (using * wildcard)
+	AccountId string   // Filter by account
+	Sort      string   // Sort descriptor (field name with suffix +/- for sort order)
+	Page      int      // Page number for pagination
+	Size      int      // Page size: number of items per page
+	Fields    []string // Sparse fieldset: list of fields (json paths) to include in the results
+}
+
+// Find list of webhooks by filter (without the secret)
+func (s *WebhooksService) Find(td *TokenData, p WebhooksFindParams) (entities []Entity, total int64, pages int, error error) {
+	td, end := s.observe(td, "Find")
+	defer end()
+	query := s.sh.DatabaseContext(td.Context()).Query(NewWebhook).
+		MatchAny(
+			F("id").Eq(p.Search),
+			F("name").Like(p.Search),
+			F("url").Like(p.Search),
+		).
+		MatchAll(
+			F("flag").Gte(0),
+			F("accountId").Eq(p.AccountId),
+		).
+		Page(p.Page).
+		Limit(p.Size).
+		Sort(p.Sort)
+
+	// The secret can't be selected
+	if entities, total, error = s.find(query, p.Fields, "secret"); error == nil {
+		for i, ent := range entities {
+			entities[i] = maskWebhook(ent)
+		}
+		pages = s.calcPages(total, p.Size)
+	} else {
+		error = s.serviceError(td, "Find", error)
+	}
+	return
+}
+
+// Export iterates over all the webhooks matching the query (regardless of the pagination) and passes them to the callback
+func (s *WebhooksService) Export(td *TokenData, p WebhooksFindParams, cb func(Entity) error) error {
+	td, end := s.observe(td, "Export")
+	defer end()
+	return s.iterate(func(page, size int) ([]Entity, error) {
+		p.Page, p.Size = page, size
+		list, _, _, err := s.Find(td, p)
+		return list, err
+	}, cb)
+}
+
+// DeactivateAccountWebhooks deactivates the active webhooks of the account (e.g. suspended account), returns the number
+// of deactivated webhooks
+func (s *WebhooksService) DeactivateAccountWebhooks(td *TokenData, accountId string) (int, error) {
+	td, end := s.observe(td, "DeactivateAccountWebhooks")
+	defer end()
+
+	list, _, err := s.sh.DatabaseContext(td.Context()).Query(NewWebhook).
+		MatchAll(
+			F("accountId").Eq(accountId),
+			F("active").IsTrue(),
+			F("flag").Gte(0),
+		).
+		Find()
+	if err != nil {
+		return 0, s.serviceError(td, "DeactivateAccountWebhooks", err)
+	}
+
+	for i, ent := range list {
+		existing := *ent.(*Webhook)
+		ent.(*Webhook).Active = false
+		ent.(*Webhook).UpdatedOn = Now()
+		tx := s.sh.Transaction(td.Context()).Update(ent)
+		if err = s.commitChange(td, tx, ent, actionUpdate, maskWebhook(&existing), maskWebhook(ent)); err != nil {
+			return i, s.serviceError(td, "DeactivateAccountWebhooks", err)
+		}
+	}
+	return len(list), nil
+}
+
+// WebhookDeliveriesFindParams Query params aggregator for find deliveries commands service
+type WebhookDeliveriesFindParams struct {
+	WebhookId string               // Filter by webhook
+	Status    []DeliveryStatusCode // by status(s)
+	Sort      string               // Sort descriptor (field name with suffix +/- for sort order)
+	Page      int                  // Page number for pagination
+	Size      int                  // Page size: number of items per page
+}
+
+func (f *WebhookDeliveriesFindParams) Statuses() (result []any) {
+	for _, t := range f.Status {
+		result = append(result, t)
+	}
+	return result
+}
+
+// FindDeliveries gets the delivery history of the webhook
+func (s *WebhooksService) FindDeliveries(td *TokenData, p WebhookDeliveriesFindParams) (entities []Entity, total int64, pages int, error error) {
+	td, end := s.observe(td, "FindDeliveries")
+	defer end()
+	query := s.sh.DatabaseContext(td.Context()).Query(NewWebhookDelivery).
+		MatchAll(
+			F("webhookId").Eq(p.WebhookId),
+			F("status").In(p.Statuses()...),
+		).
+		Page(p.Page).
+		Limit(p.Size).
+		Sort(p.Sort)
+
+	if entities, total, error = query.Find(); error == nil {
+		pages = s.calcPages(total, p.Size)
+	} else {
+		error = s.serviceError(td, "FindDeliveries", error)
+	}
+	return
+}
+
+// GetDelivery gets single delivery by id
+func (s *WebhooksService) GetDelivery(td *TokenData, id string) (Entity, error) {
+	td, end := s.observe(td, "GetDelivery")
+	defer end()
+
+	if ent, err := s.sh.DatabaseContext(td.Context()).Get(NewWebhookDelivery, id); err != nil {
+		return nil, fmt.Errorf("[%s]::GetDelivery: %v", s.ServiceName, err)
+	} else {
+		return ent, nil
+	}
+}
+
+// Redeliver schedules the delivery for immediate attempt (e.g. dead-lettered delivery after the receiver was fixed), the
+// payload is posted with the same delivery ID and the attempts are counted from the start
+func (s *WebhooksService) Redeliver(td *TokenData, id string) (Entity, error) {
+	td, end := s.observe(td, "Redeliver")
+	defer end()
+
+	existing, err := s.sh.DatabaseContext(td.Context()).Get(NewWebhookDelivery, id)
+	if err != nil {
+		return nil, s.serviceError(td, "Redeliver", err)
+	}
+
+	delivery := *existing.(*WebhookDelivery)
+	delivery.UpdatedOn = Now()
+	delivery.Status = DeliveryStatusCodes.PENDING
+	delivery.Attempts = 0
+	delivery.NextAttempt = Now()
+	delivery.Error = ""
+
+	// Operational row, updated with no audit log and entity change event
+	if er := s.sh.Transaction(td.Context()).Update(&delivery).Commit(); er != nil {
+		return nil, s.serviceError(td, "Redeliver", er)
+	} else {
+		GetWebhookDispatcher(s.sh).notify()
+		return &delivery, nil
+	}
+}
+
+// Get copy of the webhook without the secret
+func maskWebhook(entity Entity) Entity {
+	if webhook, ok := entity.(*Webhook); ok {
+		masked := *webhook
+		masked.Secret = ""
+		return &masked
+	}
+	return entity
+}
+
+// The webhook URL must be absolute http(s) URL, the host must resolve to public addresses only (unless private addresses
+// are allowed) to prevent requests to internal services (SSRF), the addresses are checked again on dial
+func validateWebhookUrl(webhookUrl string, allowPrivate bool) error {
+	u, err := url.Parse(webhookUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Hostname()) == 0 {
+		return fmt.Errorf("invalid webhook url: %s", webhookUrl)
+	}
+	if allowPrivate {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookResolveTimeout)
+	defer cancel()
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %s: failed to resolve host: %v", webhookUrl, err)
+	}
+	for _, address := range addresses {
+		if !isPublicAddress(address.IP) {
+			return fmt.Errorf("invalid webhook url: %s: host address %s is not public", webhookUrl, address.IP)
+		}
+	}
+	return nil
+}
+
+// Check if the IP address is public: not loopback, link-local, private, unspecified, multicast, shared address space
+// (CGNAT) or "this network"
+func isPublicAddress(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() ||
+		ip.IsUnspecified() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Not public address ranges that are not covered by the net.IP checks
+var nonPublicNetworks = []*net.IPNet{
+	{IP: net.IPv4(100, 64, 0, 0).To4(), Mask: net.CIDRMask(10, 32)}, // Shared address space (CGNAT)
+	{IP: net.IPv4(0, 0, 0, 0).To4(), Mask: net.CIDRMask(8, 32)},     // "This network"
+}
+
+// Dialer control of the webhook deliveries, rejects connection to not public address (the host may resolve to another
+// address after the webhook was registered, or the receiver may redirect to internal service)
+func webhookDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublicAddress(ip) {
+		return fmt.Errorf("webhook address %s is not public", host)
+	}
+	return nil
+}
@@ -67,6 +67,15 @@ func (t *TokenUtilsStruct) NanoID() string {
 	}
 }
 
+// Secret return a random secret key (32 bytes, hex encoded), e.g. for payload signature
+func (t *TokenUtilsStruct) Secret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return hex.EncodeToString(key), nil
+}
+
 // endregion
 
 // region Access Token parsing helpers ---------------------------------------------------------------------------------